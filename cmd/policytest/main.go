@@ -0,0 +1,149 @@
+// Command policytest decides on a table of requests with the authorization model and policy, and
+// fails when a decision is not the expected one, so that policy changes can be tested in CI.
+//
+// The model and policy are the ones in the database the application is configured with, once it
+// is migrated, or the ones of the -model and -policy files when both are given. Every line of the cases file is
+//
+//	sub, obj, act, expected[, dom[, owner]]
+//
+// where expected is allow or deny, and cases without a domain are made in the -domain one.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	_casbin "github.com/casbin/casbin/v2"
+
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/author"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run decides on the cases and returns the exit code, which is 1 when a case fails and 2 when the
+// cases cannot run, so that the deferred closes happen before main exits
+func run() int {
+	casesPath := flag.String("cases", "", "path of the cases file")
+	domain := flag.String("domain", models.TenantDomain(models.DefaultTenantID), "domain of the cases that do not name one")
+	modelPath := flag.String("model", "", "path of a model file, instead of the model in the database")
+	policyPath := flag.String("policy", "", "path of a policy csv file, instead of the policy in the database")
+	verbose := flag.Bool("v", false, "print the cases that pass too")
+	flag.Parse()
+
+	if *casesPath == "" || (*modelPath == "") != (*policyPath == "") {
+		flag.Usage()
+		return 2
+	}
+
+	file, err := os.Open(*casesPath)
+	if err != nil {
+		return fail(err)
+	}
+	defer file.Close()
+
+	cases, err := author.ReadPolicyCases(file, *domain)
+	if err != nil {
+		return fail(fmt.Errorf("%s: %w", *casesPath, err))
+	}
+
+	enforcer, err := newEnforcer(*modelPath, *policyPath)
+	if err != nil {
+		return fail(err)
+	}
+
+	failed := 0
+	for _, result := range author.RunPolicyCases(enforcer, cases) {
+		if result.Passed() {
+			if *verbose {
+				fmt.Printf("ok   %s\n", describe(&result))
+			}
+			continue
+		}
+
+		failed++
+		fmt.Printf("FAIL %s\n", describe(&result))
+	}
+
+	fmt.Printf("%d cases, %d failed\n", len(cases), failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// newEnforcer creates an enforcer with the model and policy of the files, or else of the database
+func newEnforcer(modelPath, policyPath string) (*_casbin.SyncedEnforcer, error) {
+	if modelPath != "" {
+		return _casbin.NewSyncedEnforcer(modelPath, policyPath)
+	}
+
+	container, err := configs.NewContainer()
+	if err != nil {
+		return nil, err
+	}
+
+	// the database is migrated first, so that the cases run against the model and policy this
+	// tree seeds
+	db, err := postgres.NewConnection(context.Background(), container.DB)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	schema, err := postgres.MigrateSchema(db)
+	if err != nil {
+		return nil, err
+	}
+
+	casbin, err := author.NewCasbinConfig(container.DB, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return casbin.Enforcer, nil
+}
+
+// describe formats the request of a case with the decision made on it and what explains it
+func describe(result *author.PolicyCaseResult) string {
+	request := result.Case.Request
+	line := fmt.Sprintf("line %d: %s, %s, %s, %s", result.Case.Line, request.Subject, request.Domain, request.Object, request.Action)
+	if request.Owner != "" {
+		line += ", owner " + request.Owner
+	}
+
+	if result.Err != nil {
+		return fmt.Sprintf("%s: %v", line, result.Err)
+	}
+
+	line += fmt.Sprintf(": expected %s, got %s", decision(result.Case.Expected), decision(result.Decision.Allowed))
+	for _, rule := range result.Decision.Rules {
+		line += fmt.Sprintf(" by p, %s", strings.Join(rule.Values(), ", "))
+	}
+	if !result.Decision.Allowed {
+		line += fmt.Sprintf(" with roles [%s]", strings.Join(result.Decision.Roles, ", "))
+	}
+
+	return line
+}
+
+// decision names a decision as the cases file does
+func decision(allowed bool) string {
+	if allowed {
+		return "allow"
+	}
+	return "deny"
+}
+
+// fail prints an error that keeps the cases from running and returns the exit code for it
+func fail(err error) int {
+	fmt.Fprintln(os.Stderr, err)
+	return 2
+}
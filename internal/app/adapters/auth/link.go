@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"go.uber.org/fx"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+)
+
+// defaultLinkDuration is how long a link stays valid when LINK_DURATION is not set
+const defaultLinkDuration = 72 * time.Hour
+
+// tenantClaim is the claim of the tenant a link was created in
+const tenantClaim = "tenant_id"
+
+/**
+ * LinkHandler implements ports.LinkService interface
+ * and signs links with a paseto key shared by every replica
+ */
+type LinkHandler struct {
+	key      *paseto.V4SymmetricKey
+	parser   *paseto.Parser
+	baseURL  string
+	duration time.Duration
+}
+
+// NewLinkHandler creates a new paseto link instance
+func NewLinkHandler(config *configs.Link) (ports.LinkService, error) {
+	duration := defaultLinkDuration
+	if config.Duration != "" {
+		var err error
+		duration, err = time.ParseDuration(config.Duration)
+		if err != nil {
+			return nil, models.ErrTokenDuration
+		}
+	}
+
+	key := paseto.NewV4SymmetricKey()
+	if config.Secret != "" {
+		var err error
+		key, err = paseto.V4SymmetricKeyFromHex(config.Secret)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		slog.Warn("LINK_SECRET is not set, links will not survive a restart")
+	}
+
+	parser := paseto.NewParser()
+
+	return &LinkHandler{
+		&key,
+		&parser,
+		strings.TrimRight(config.BaseURL, "/"),
+		duration,
+	}, nil
+}
+
+// CreateLink signs the subject and the tenant of the request into a token bound to the purpose and builds the link.
+// Whoever follows the link is not signed in, so the tenant is taken from the link instead
+func (lh *LinkHandler) CreateLink(ctx context.Context, purpose models.LinkPurpose, subject string) (string, time.Time, error) {
+	tenantID, ok := _constant.TenantID(ctx)
+	if !ok || tenantID == 0 {
+		return "", time.Time{}, models.ErrInvalidTenant
+	}
+
+	token := paseto.NewToken()
+
+	issuedAt := time.Now()
+	expiredAt := issuedAt.Add(lh.duration)
+
+	token.SetSubject(subject)
+	token.SetIssuedAt(issuedAt)
+	token.SetNotBefore(issuedAt)
+	token.SetExpiration(expiredAt)
+	token.SetString(tenantClaim, strconv.FormatUint(tenantID, 10))
+
+	// the purpose is an implicit assertion, so a token signed for one purpose fails for another
+	signed := token.V4Encrypt(*lh.key, []byte(purpose))
+
+	link := fmt.Sprintf("%s/%s?token=%s", lh.baseURL, purpose, url.QueryEscape(signed))
+
+	return link, expiredAt, nil
+}
+
+// VerifyLink verifies the token against the purpose and returns its subject and tenant.
+// Links created before tenants were introduced belong to the default tenant
+func (lh *LinkHandler) VerifyLink(purpose models.LinkPurpose, token string) (string, uint64, error) {
+	parsedToken, err := lh.parser.ParseV4Local(*lh.key, token, []byte(purpose))
+	if err != nil {
+		if err.Error() == "this token has expired" {
+			return "", 0, models.ErrExpiredLink
+		}
+		return "", 0, models.ErrInvalidLink
+	}
+
+	subject, err := parsedToken.GetSubject()
+	if err != nil {
+		return "", 0, models.ErrInvalidLink
+	}
+
+	tenantID := models.DefaultTenantID
+	if claim, err := parsedToken.GetString(tenantClaim); err == nil {
+		tenantID, err = strconv.ParseUint(claim, 10, 64)
+		if err != nil || tenantID == 0 {
+			return "", 0, models.ErrInvalidLink
+		}
+	}
+
+	return subject, tenantID, nil
+}
+
+var LinkModule = fx.Module(
+	"link-handler-module",
+	fx.Provide(
+		fx.Annotate(NewLinkHandler, fx.As(new(ports.LinkService))),
+	),
+)
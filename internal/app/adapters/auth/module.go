@@ -7,4 +7,5 @@ import (
 var Module = fx.Module(
 	"auth-handler-module",
 	TokenModule,
+	LinkModule,
 )
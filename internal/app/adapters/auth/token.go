@@ -50,9 +50,13 @@ func (pt *TokenHandler) CreateToken(user *models.User) (string, error) {
 	}
 
 	payload := &models.TokenPayload{
-		ID:     id,
-		UserID: user.ID,
-		Role:   user.Role,
+		ID:       id,
+		UserID:   user.ID,
+		Role:     user.Role,
+		TenantID: user.TenantID,
+	}
+	if user.StoreID != nil {
+		payload.StoreID = *user.StoreID
 	}
 
 	err = pt.token.Set("payload", payload)
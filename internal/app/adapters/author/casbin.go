@@ -3,6 +3,7 @@ package author
 import (
 	"database/sql"
 	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
 	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
 	_casbin "github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
@@ -16,16 +17,24 @@ import (
 type CasbinConfig struct {
 	DSN        string
 	DriverName string
-	Enforcer   *_casbin.Enforcer
+	Enforcer   *_casbin.SyncedEnforcer
 }
 
-func NewCasbinConfig(db *configs.DB) *CasbinConfig {
+// NewCasbinConfig creates the enforcer with the model and policy of the database, which is
+// migrated first as the model is read only once
+func NewCasbinConfig(db *configs.DB, _ *postgres.Schema) (*CasbinConfig, error) {
 	casbinConfig := &CasbinConfig{
 		DSN:        db.DSN,
 		DriverName: db.DriverName,
 	}
-	casbinConfig.Enforcer = casbinConfig.NewEnforcer()
-	return casbinConfig
+
+	enforcer, err := casbinConfig.NewEnforcer()
+	if err != nil {
+		return nil, err
+	}
+	casbinConfig.Enforcer = enforcer
+
+	return casbinConfig, nil
 }
 
 func (casbinConfig *CasbinConfig) LoadModelFromDB() (string, error) {
@@ -46,7 +55,7 @@ func (casbinConfig *CasbinConfig) LoadModelFromDB() (string, error) {
 
 }
 
-func (casbin *CasbinConfig) NewEnforcer() *_casbin.Enforcer {
+func (casbin *CasbinConfig) NewEnforcer() (*_casbin.SyncedEnforcer, error) {
 
 	driverName := casbin.DriverName
 	dsn := casbin.DSN
@@ -55,32 +64,33 @@ func (casbin *CasbinConfig) NewEnforcer() *_casbin.Enforcer {
 	// dbSpecified = "true" is for automatically creating the 'casbin_rule' table
 	adapter, err := xormadapter.NewAdapter(driverName, dsn, true)
 	if err != nil {
-		log.Fatalf("Failed to create adapter: %v\n", err)
+		return nil, fmt.Errorf("failed to create adapter: %w", err)
 	}
 	// Assume loadModelFromDB pulls your model configuration from the DB
 	modelText, err := casbin.LoadModelFromDB()
 	if err != nil {
-		log.Fatalf("Failed to load model: %v\n", err)
+		return nil, fmt.Errorf("failed to load model: %w", err)
 	}
 	// Load Casbin model from the text
 	m, err := model.NewModelFromString(modelText)
 	if err != nil {
-		log.Fatalf("Failed to create model from string: %v\n", err)
+		return nil, fmt.Errorf("failed to create model from string: %w", err)
 	}
-	// Create a Casbin enforcer with the adapter and model
-	e, err := _casbin.NewEnforcer(m, adapter)
+	// Create a Casbin enforcer with the adapter and model, synced as the policy
+	// watcher changes it while requests are being enforced
+	e, err := _casbin.NewSyncedEnforcer(m, adapter)
 	if err != nil {
-		log.Fatalf("Failed to create enforcer: %v\n", err)
+		return nil, fmt.Errorf("failed to create enforcer: %w", err)
 	}
 
-	return e
+	return e, nil
 
 }
 
 func (casbinConfig *CasbinConfig) LoadPolicy() {
 	err := casbinConfig.Enforcer.LoadPolicy()
 	if err != nil {
-		fmt.Errorf("failed to load policy: %w", err)
+		log.Printf("Failed to load policy: %v\n", err)
 	}
 }
 
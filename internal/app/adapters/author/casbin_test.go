@@ -0,0 +1,79 @@
+package author
+
+import (
+	"context"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+// emptyDatabase creates a database without any migration next to the one of the environment,
+// the test is skipped without one
+func emptyDatabase(t *testing.T) *configs.DB {
+	t.Helper()
+
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST is not set")
+	}
+
+	config := &configs.DB{
+		Connection: os.Getenv("DB_CONNECTION"),
+		Host:       os.Getenv("DB_HOST"),
+		Port:       os.Getenv("DB_PORT"),
+		User:       os.Getenv("DB_USER"),
+		Password:   os.Getenv("DB_PASSWORD"),
+		Name:       os.Getenv("DB_NAME"),
+		DriverName: "postgres",
+	}
+	url := func(name string) string {
+		return fmt.Sprintf("%s://%s:%s@%s:%s/%s?sslmode=disable", config.Connection, config.User, config.Password, config.Host, config.Port, name)
+	}
+
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, url(config.Name))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close(ctx) })
+
+	name := "boot_" + uuid.NewString()[:8]
+	_, err = conn.Exec(ctx, fmt.Sprintf(`CREATE DATABASE %q`, name))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := conn.Exec(ctx, fmt.Sprintf(`DROP DATABASE %q WITH (FORCE)`, name))
+		assert.NoError(t, err)
+	})
+
+	config.Name = name
+	config.DSN = url(name)
+
+	return config
+}
+
+func TestNewCasbinConfig_UnmigratedDatabase(t *testing.T) {
+	config := emptyDatabase(t)
+
+	var casbin *CasbinConfig
+	app := fxtest.New(t,
+		fx.Supply(config),
+		postgres.Module,
+		CasbinModule,
+		fx.Populate(&casbin),
+	)
+	app.RequireStart()
+	defer app.RequireStop()
+
+	// the enforcer is built once the migrations installed the model the middlewares enforce with
+	request := casbin.Enforcer.GetModel()["r"]["r"].Tokens
+	assert.Len(t, request, 5, "Request definition mismatch")
+
+	_, err := casbin.Enforcer.Enforce("user:1", "tenant:1", "/v1/users/1", "GET", "owner:1")
+	assert.NoError(t, err, "Enforce error")
+}
@@ -0,0 +1,107 @@
+package author
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"io"
+	"strings"
+
+	_casbin "github.com/casbin/casbin/v2"
+)
+
+// PolicyCase is a request and the decision the policy is expected to make on it, read from
+// the line of a cases file
+type PolicyCase struct {
+	Line     int
+	Request  models.AuthzRequest
+	Expected bool
+}
+
+// PolicyCaseResult is the decision the policy made on a case
+type PolicyCaseResult struct {
+	Case     PolicyCase
+	Decision *models.AuthzDecision
+	Err      error
+}
+
+// Passed reports whether the policy made the expected decision
+func (r *PolicyCaseResult) Passed() bool {
+	return r.Err == nil && r.Decision.Allowed == r.Case.Expected
+}
+
+// ReadPolicyCases reads cases of "sub, obj, act, expected" lines, optionally followed by the domain
+// and the owner of the resource. The expected decision is "allow" or "deny", cases without a domain
+// are made in the given one. Blank lines and lines starting with "#" are skipped
+func ReadPolicyCases(r io.Reader, domain string) ([]PolicyCase, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var cases []PolicyCase
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		if len(record) < 4 || len(record) > 6 {
+			return nil, fmt.Errorf("line %d: expected sub, obj, act, expected[, dom[, owner]], got %d values", line, len(record))
+		}
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+
+		var expected bool
+		switch strings.ToLower(record[3]) {
+		case "allow":
+			expected = true
+		case "deny":
+			expected = false
+		default:
+			return nil, fmt.Errorf("line %d: expected decision %q is not allow or deny", line, record[3])
+		}
+
+		request := models.AuthzRequest{
+			Subject: record[0],
+			Domain:  domain,
+			Object:  record[1],
+			Action:  record[2],
+		}
+		if len(record) > 4 && record[4] != "" {
+			request.Domain = record[4]
+		}
+		if len(record) > 5 {
+			request.Owner = record[5]
+		}
+
+		cases = append(cases, PolicyCase{
+			Line:     line,
+			Request:  request,
+			Expected: expected,
+		})
+	}
+
+	return cases, nil
+}
+
+// RunPolicyCases decides on every case with the enforcer, as the router would
+func RunPolicyCases(enforcer *_casbin.SyncedEnforcer, cases []PolicyCase) []PolicyCaseResult {
+	results := make([]PolicyCaseResult, 0, len(cases))
+	for _, c := range cases {
+		request := c.Request
+		decision, err := Explain(enforcer, &request)
+		results = append(results, PolicyCaseResult{
+			Case:     c,
+			Decision: decision,
+			Err:      err,
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,113 @@
+package author
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"strings"
+	"testing"
+
+	_casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDomainModel = `
+[request_definition]
+r = sub, dom, obj, act, owner
+
+[policy_definition]
+p = sub, dom, obj, act
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = (g(r.sub, p.sub, r.dom) || (p.sub == "owner" && r.sub == r.owner)) && (p.dom == r.dom || p.dom == "*") && keyMatch2(r.obj, p.obj) && regexMatch(r.act, "^(" + p.act + ")$")
+`
+
+func TestReadPolicyCases(t *testing.T) {
+	input := `# sub, obj, act, expected[, dom[, owner]]
+user:1, /v1/users/42, GET, allow
+
+user:2, /v1/users/42, DELETE, Deny, tenant:2
+user:42, /v1/users/42, PATCH, allow, , user:42
+`
+
+	cases, err := ReadPolicyCases(strings.NewReader(input), "tenant:1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []PolicyCase{
+		{
+			Line:     2,
+			Request:  models.AuthzRequest{Subject: "user:1", Domain: "tenant:1", Object: "/v1/users/42", Action: "GET"},
+			Expected: true,
+		},
+		{
+			Line:     4,
+			Request:  models.AuthzRequest{Subject: "user:2", Domain: "tenant:2", Object: "/v1/users/42", Action: "DELETE"},
+			Expected: false,
+		},
+		{
+			Line:     5,
+			Request:  models.AuthzRequest{Subject: "user:42", Domain: "tenant:1", Object: "/v1/users/42", Action: "PATCH", Owner: "user:42"},
+			Expected: true,
+		},
+	}, cases)
+}
+
+func TestReadPolicyCases_Invalid(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		input string
+	}{
+		{desc: "TooFewValues", input: "user:1, /v1/users/42, GET\n"},
+		{desc: "TooManyValues", input: "user:1, /v1/users/42, GET, allow, tenant:1, user:1, extra\n"},
+		{desc: "UnknownDecision", input: "user:1, /v1/users/42, GET, yes\n"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := ReadPolicyCases(strings.NewReader(tc.input), "tenant:1")
+			assert.Error(t, err, "Error mismatch")
+		})
+	}
+}
+
+func TestRunPolicyCases(t *testing.T) {
+	m, err := model.NewModelFromString(testDomainModel)
+	require.NoError(t, err)
+	enforcer, err := _casbin.NewSyncedEnforcer(m)
+	require.NoError(t, err)
+
+	_, err = enforcer.AddPolicy("admin", models.AnyDomain, "/v1/users/:id", "GET|DELETE")
+	require.NoError(t, err)
+	_, err = enforcer.AddGroupingPolicy("user:1", "admin", "tenant:1")
+	require.NoError(t, err)
+
+	cases := []PolicyCase{
+		{Line: 1, Request: models.AuthzRequest{Subject: "user:1", Domain: "tenant:1", Object: "/v1/users/42", Action: "DELETE"}, Expected: true},
+		{Line: 2, Request: models.AuthzRequest{Subject: "user:1", Domain: "tenant:1", Object: "/v1/users/42", Action: "PUT"}, Expected: true},
+		{Line: 3, Request: models.AuthzRequest{Subject: "user:1", Domain: "tenant:2", Object: "/v1/users/42", Action: "GET"}, Expected: false},
+	}
+
+	results := RunPolicyCases(enforcer, cases)
+	require.Len(t, results, len(cases))
+
+	// an allowed request is explained by the rule that allowed it
+	assert.True(t, results[0].Passed(), "Decision mismatch")
+	assert.Equal(t, []models.PolicyRule{
+		{Type: models.PolicyPermission, Subject: "admin", Domain: models.AnyDomain, Object: "/v1/users/:id", Action: "GET|DELETE"},
+	}, results[0].Decision.Rules)
+
+	// a denied one by the roles the subject has, none of which allow it
+	assert.False(t, results[1].Passed(), "Decision mismatch")
+	assert.Empty(t, results[1].Decision.Rules)
+	assert.Equal(t, []string{"admin"}, results[1].Decision.Roles)
+
+	assert.True(t, results[2].Passed(), "Decision mismatch")
+	assert.Empty(t, results[2].Decision.Roles)
+}
@@ -7,4 +7,6 @@ import (
 var Module = fx.Module(
 	"auth-handler-module",
 	CasbinModule,
+	PolicyModule,
+	WatcherModule,
 )
@@ -0,0 +1,257 @@
+package author
+
+import (
+	"context"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	_casbin "github.com/casbin/casbin/v2"
+	"go.uber.org/fx"
+)
+
+/**
+ * PolicyService implements ports.PolicyService interface
+ * and keeps the casbin grouping rules in sync with role assignments,
+ * in the domain of the tenant they are made in
+ */
+type PolicyService struct {
+	enforcer *_casbin.SyncedEnforcer
+}
+
+// NewPolicyService creates a new casbin policy services instance
+func NewPolicyService(casbin *CasbinConfig) *PolicyService {
+	return &PolicyService{
+		casbin.Enforcer,
+	}
+}
+
+// AddUserRole adds a "g, user:<id>, <role>, tenant:<id>" grouping rule
+func (ps *PolicyService) AddUserRole(ctx context.Context, userID uint64, role models.UserRole) error {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ps.enforcer.AddGroupingPolicy(models.UserSubject(userID), string(role), dom)
+	return err
+}
+
+// UpdateUserRole replaces the "g, user:<id>, <from>, tenant:<id>" grouping rule with "g, user:<id>, <to>, tenant:<id>"
+func (ps *PolicyService) UpdateUserRole(ctx context.Context, userID uint64, from, to models.UserRole) error {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return err
+	}
+
+	sub := models.UserSubject(userID)
+
+	_, err = ps.enforcer.RemoveGroupingPolicy(sub, string(from), dom)
+	if err != nil {
+		return err
+	}
+
+	_, err = ps.enforcer.AddGroupingPolicy(sub, string(to), dom)
+	return err
+}
+
+// DeleteUser removes every grouping rule where the user is the subject
+func (ps *PolicyService) DeleteUser(ctx context.Context, userID uint64) error {
+	_, err := ps.enforcer.DeleteUser(models.UserSubject(userID))
+	return err
+}
+
+// DeleteRole removes the role from every policy and grouping rule, of every tenant as roles are shared
+func (ps *PolicyService) DeleteRole(ctx context.Context, role models.UserRole) error {
+	_, err := ps.enforcer.DeleteRole(string(role))
+	return err
+}
+
+// AddGroupMember adds a "g, user:<id>, group:<id>, tenant:<id>" grouping rule
+func (ps *PolicyService) AddGroupMember(ctx context.Context, groupID, userID uint64) error {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ps.enforcer.AddGroupingPolicy(models.UserSubject(userID), models.GroupSubject(groupID), dom)
+	return err
+}
+
+// DeleteGroupMember removes the "g, user:<id>, group:<id>, tenant:<id>" grouping rule
+func (ps *PolicyService) DeleteGroupMember(ctx context.Context, groupID, userID uint64) error {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ps.enforcer.RemoveGroupingPolicy(models.UserSubject(userID), models.GroupSubject(groupID), dom)
+	return err
+}
+
+// UpdateGroupParent replaces the "g, group:<id>, group:<parent>, tenant:<id>" grouping rule
+func (ps *PolicyService) UpdateGroupParent(ctx context.Context, groupID uint64, from, to *uint64) error {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return err
+	}
+
+	sub := models.GroupSubject(groupID)
+
+	if from != nil {
+		_, err := ps.enforcer.RemoveGroupingPolicy(sub, models.GroupSubject(*from), dom)
+		if err != nil {
+			return err
+		}
+	}
+
+	if to != nil {
+		_, err := ps.enforcer.AddGroupingPolicy(sub, models.GroupSubject(*to), dom)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddGroupRole adds a "g, group:<id>, <role>, tenant:<id>" grouping rule
+func (ps *PolicyService) AddGroupRole(ctx context.Context, groupID uint64, role models.UserRole) error {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ps.enforcer.AddGroupingPolicy(models.GroupSubject(groupID), string(role), dom)
+	return err
+}
+
+// DeleteGroupRole removes the "g, group:<id>, <role>, tenant:<id>" grouping rule
+func (ps *PolicyService) DeleteGroupRole(ctx context.Context, groupID uint64, role models.UserRole) error {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = ps.enforcer.RemoveGroupingPolicy(models.GroupSubject(groupID), string(role), dom)
+	return err
+}
+
+// DeleteGroup removes every grouping rule where the group is either side
+func (ps *PolicyService) DeleteGroup(ctx context.Context, groupID uint64) error {
+	_, err := ps.enforcer.DeleteRole(models.GroupSubject(groupID))
+	return err
+}
+
+// ListRules lists the "p" or "g" rules whose values match the non-empty values of the filter
+func (ps *PolicyService) ListRules(filter *models.PolicyRule) ([]models.PolicyRule, error) {
+	var values [][]string
+	var err error
+
+	if filter.Type == models.PolicyGrouping {
+		values, err = ps.enforcer.GetFilteredGroupingPolicy(0, filter.Values()...)
+	} else {
+		values, err = ps.enforcer.GetFilteredPolicy(0, filter.Values()...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]models.PolicyRule, 0, len(values))
+	for _, value := range values {
+		rule := models.PolicyRule{Type: filter.Type}
+		if filter.Type == models.PolicyGrouping {
+			if len(value) < 3 {
+				continue
+			}
+			rule.Subject, rule.Role, rule.Domain = value[0], value[1], value[2]
+		} else {
+			if len(value) < 4 {
+				continue
+			}
+			rule.Subject, rule.Domain, rule.Object, rule.Action = value[0], value[1], value[2], value[3]
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// AddRule adds a "p" or "g" rule and saves it through the adapter
+func (ps *PolicyService) AddRule(rule *models.PolicyRule) (bool, error) {
+	if rule.Type == models.PolicyGrouping {
+		return ps.enforcer.AddGroupingPolicy(rule.Values())
+	}
+	return ps.enforcer.AddPolicy(rule.Values())
+}
+
+// RemoveRule removes a "p" or "g" rule and deletes it through the adapter
+func (ps *PolicyService) RemoveRule(rule *models.PolicyRule) (bool, error) {
+	if rule.Type == models.PolicyGrouping {
+		return ps.enforcer.RemoveGroupingPolicy(rule.Values())
+	}
+	return ps.enforcer.RemovePolicy(rule.Values())
+}
+
+// GetSubjectRoles resolves the roles of a subject in a domain through the role manager
+func (ps *PolicyService) GetSubjectRoles(subject, domain string) (*models.SubjectRoles, error) {
+	roles, err := ps.enforcer.GetRolesForUser(subject, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	implicitRoles, err := ps.enforcer.GetImplicitRolesForUser(subject, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SubjectRoles{
+		Subject:       subject,
+		Domain:        domain,
+		Roles:         roles,
+		ImplicitRoles: implicitRoles,
+	}, nil
+}
+
+// Explain decides on a request with the enforcer the router uses
+func (ps *PolicyService) Explain(request *models.AuthzRequest) (*models.AuthzDecision, error) {
+	return Explain(ps.enforcer, request)
+}
+
+// Explain decides on a request as the router does, and explains the decision with the rule that allowed it
+// and the roles of the subject in the domain. A denied request has no rule, the roles tell what it lacked
+func Explain(enforcer *_casbin.SyncedEnforcer, request *models.AuthzRequest) (*models.AuthzDecision, error) {
+	allowed, explain, err := enforcer.EnforceEx(request.Subject, request.Domain, request.Object, request.Action, request.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := enforcer.GetImplicitRolesForUser(request.Subject, request.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := &models.AuthzDecision{
+		Request: *request,
+		Allowed: allowed,
+		Rules:   []models.PolicyRule{},
+		Roles:   roles,
+	}
+	if allowed && len(explain) >= 4 {
+		decision.Rules = append(decision.Rules, models.PolicyRule{
+			Type:    models.PolicyPermission,
+			Subject: explain[0],
+			Domain:  explain[1],
+			Object:  explain[2],
+			Action:  explain[3],
+		})
+	}
+
+	return decision, nil
+}
+
+var PolicyModule = fx.Module(
+	"policy-module",
+	fx.Provide(
+		fx.Annotate(NewPolicyService, fx.As(new(ports.PolicyService))),
+	),
+)
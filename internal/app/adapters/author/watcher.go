@@ -0,0 +1,302 @@
+package author
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/redis"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	_casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"log/slog"
+	"time"
+)
+
+// policyChannel is the redis channel every replica publishes its policy changes on
+const policyChannel = "casbin:policy"
+
+// publishTimeout bounds how long a policy change waits on redis, the enforcer is locked meanwhile
+const publishTimeout = 5 * time.Second
+
+// defaultPolicyReloadInterval is how often the whole policy is reloaded when POLICY_RELOAD_INTERVAL is not set
+const defaultPolicyReloadInterval = 5 * time.Minute
+
+// policyOp is the kind of change a policy update carries
+type policyOp string
+
+// policyOp enum values
+const (
+	policyAdd            policyOp = "add"
+	policyRemove         policyOp = "remove"
+	policyRemoveFiltered policyOp = "remove_filtered"
+	policyReload         policyOp = "reload"
+)
+
+// policyUpdate is a policy change published by a replica, origin tells replicas their own changes apart
+type policyUpdate struct {
+	Origin      string     `json:"origin"`
+	Op          policyOp   `json:"op"`
+	Sec         string     `json:"sec,omitempty"`
+	Ptype       string     `json:"ptype,omitempty"`
+	Rules       [][]string `json:"rules,omitempty"`
+	FieldIndex  int        `json:"field_index,omitempty"`
+	FieldValues []string   `json:"field_values,omitempty"`
+}
+
+// policyBroker publishes and receives the policy changes of every replica
+type policyBroker interface {
+	Publish(ctx context.Context, channel string, message []byte) error
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
+}
+
+/**
+ * PolicyWatcher implements persist.WatcherEx interface
+ * and keeps the casbin policy of every replica in sync over redis pub/sub
+ */
+type PolicyWatcher struct {
+	enforcer *_casbin.SyncedEnforcer
+	broker   policyBroker
+	origin   string
+	callback func(string)
+	cancel   context.CancelFunc
+}
+
+// NewPolicyWatcher creates a new redis policy watcher instance
+func NewPolicyWatcher(casbin *CasbinConfig, cache *redis.Redis) *PolicyWatcher {
+	return newPolicyWatcher(casbin.Enforcer, cache)
+}
+
+// newPolicyWatcher creates a policy watcher that reloads the whole policy when an update cannot be applied
+func newPolicyWatcher(enforcer *_casbin.SyncedEnforcer, broker policyBroker) *PolicyWatcher {
+	return &PolicyWatcher{
+		enforcer: enforcer,
+		broker:   broker,
+		origin:   uuid.NewString(),
+		callback: func(string) {
+			err := enforcer.LoadPolicy()
+			if err != nil {
+				slog.Error("Failed to reload the policy", "error", err)
+			}
+		},
+	}
+}
+
+// Start subscribes to the policy changes of the other replicas until the watcher is closed
+func (pw *PolicyWatcher) Start(ctx context.Context) error {
+	ctx, pw.cancel = context.WithCancel(ctx)
+
+	messages, err := pw.broker.Subscribe(ctx, policyChannel)
+	if err != nil {
+		pw.cancel()
+		return err
+	}
+
+	go func() {
+		for message := range messages {
+			pw.handle(message)
+		}
+	}()
+
+	return nil
+}
+
+// SetUpdateCallback sets the function that reloads the whole policy
+func (pw *PolicyWatcher) SetUpdateCallback(callback func(string)) error {
+	pw.callback = callback
+	return nil
+}
+
+// Update asks the other replicas to reload the whole policy
+func (pw *PolicyWatcher) Update() error {
+	return pw.publish(&policyUpdate{Op: policyReload})
+}
+
+// Close stops receiving the policy changes of the other replicas
+func (pw *PolicyWatcher) Close() {
+	if pw.cancel != nil {
+		pw.cancel()
+	}
+}
+
+// UpdateForAddPolicy asks the other replicas to add a rule
+func (pw *PolicyWatcher) UpdateForAddPolicy(sec, ptype string, params ...string) error {
+	return pw.UpdateForAddPolicies(sec, ptype, params)
+}
+
+// UpdateForRemovePolicy asks the other replicas to remove a rule
+func (pw *PolicyWatcher) UpdateForRemovePolicy(sec, ptype string, params ...string) error {
+	return pw.UpdateForRemovePolicies(sec, ptype, params)
+}
+
+// UpdateForRemoveFilteredPolicy asks the other replicas to remove the rules matching a filter
+func (pw *PolicyWatcher) UpdateForRemoveFilteredPolicy(sec, ptype string, fieldIndex int, fieldValues ...string) error {
+	return pw.publish(&policyUpdate{
+		Op:          policyRemoveFiltered,
+		Sec:         sec,
+		Ptype:       ptype,
+		FieldIndex:  fieldIndex,
+		FieldValues: fieldValues,
+	})
+}
+
+// UpdateForSavePolicy asks the other replicas to reload the whole policy
+func (pw *PolicyWatcher) UpdateForSavePolicy(model model.Model) error {
+	return pw.Update()
+}
+
+// UpdateForAddPolicies asks the other replicas to add rules
+func (pw *PolicyWatcher) UpdateForAddPolicies(sec string, ptype string, rules ...[]string) error {
+	return pw.publish(&policyUpdate{
+		Op:    policyAdd,
+		Sec:   sec,
+		Ptype: ptype,
+		Rules: rules,
+	})
+}
+
+// UpdateForRemovePolicies asks the other replicas to remove rules
+func (pw *PolicyWatcher) UpdateForRemovePolicies(sec string, ptype string, rules ...[]string) error {
+	return pw.publish(&policyUpdate{
+		Op:    policyRemove,
+		Sec:   sec,
+		Ptype: ptype,
+		Rules: rules,
+	})
+}
+
+// publish sends a policy change to the other replicas. The change is already saved, so a
+// failure is only logged and the replicas catch up on their next full reload
+func (pw *PolicyWatcher) publish(update *policyUpdate) error {
+	update.Origin = pw.origin
+
+	message, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	err = pw.broker.Publish(ctx, policyChannel, message)
+	if err != nil {
+		slog.Warn("Failed to publish a policy change", "op", update.Op, "error", err)
+	}
+
+	return nil
+}
+
+// handle applies a policy change of another replica, falling back on a full reload
+func (pw *PolicyWatcher) handle(message []byte) {
+	var update policyUpdate
+
+	err := json.Unmarshal(message, &update)
+	if err != nil {
+		slog.Warn("Failed to decode a policy change, reloading the policy", "error", err)
+		pw.callback(string(message))
+		return
+	}
+
+	if update.Origin == pw.origin {
+		return
+	}
+
+	if update.Op == policyReload {
+		pw.callback(string(message))
+		return
+	}
+
+	err = pw.apply(&update)
+	if err != nil {
+		slog.Warn("Failed to apply a policy change, reloading the policy", "op", update.Op, "error", err)
+		pw.callback(string(message))
+	}
+}
+
+// apply changes the policy in memory only, the replica that published the change has saved it already
+func (pw *PolicyWatcher) apply(update *policyUpdate) error {
+	lock := pw.enforcer.GetLock()
+	lock.Lock()
+	defer lock.Unlock()
+
+	enforcer := pw.enforcer.Enforcer
+	enforcer.EnableAutoSave(false)
+	defer enforcer.EnableAutoSave(true)
+
+	// rules are applied one by one, so that one this replica already has does not hold back the others
+	switch update.Op {
+	case policyAdd:
+		for _, rule := range update.Rules {
+			_, err := enforcer.SelfAddPolicy(update.Sec, update.Ptype, rule)
+			if err != nil {
+				return err
+			}
+		}
+	case policyRemove:
+		for _, rule := range update.Rules {
+			_, err := enforcer.SelfRemovePolicy(update.Sec, update.Ptype, rule)
+			if err != nil {
+				return err
+			}
+		}
+	case policyRemoveFiltered:
+		_, err := enforcer.SelfRemoveFilteredPolicy(update.Sec, update.Ptype, update.FieldIndex, update.FieldValues...)
+		if err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown policy change %q", update.Op)
+	}
+
+	return nil
+}
+
+// WatchPolicy keeps the policy of this replica in sync with the changes made on the others, and
+// reloads the whole policy on an interval in case a change was missed while redis was unreachable.
+// An interval of 0 turns the periodic reload off. Both start and stop with the application
+func WatchPolicy(lc fx.Lifecycle, casbin *CasbinConfig, watcher *PolicyWatcher, config *configs.Policy) error {
+	interval := defaultPolicyReloadInterval
+	if config.ReloadInterval != "" {
+		var err error
+		interval, err = time.ParseDuration(config.ReloadInterval)
+		if err != nil {
+			return fmt.Errorf("policy reload interval %q is invalid: %w", config.ReloadInterval, err)
+		}
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// the callback is set before the subscription starts, which reads it from its own goroutine
+			err := casbin.Enforcer.SetWatcher(watcher)
+			if err != nil {
+				return err
+			}
+
+			// the start context ends once the application started, the subscription lasts until it stops
+			err = watcher.Start(context.WithoutCancel(ctx))
+			if err != nil {
+				return err
+			}
+
+			if interval > 0 {
+				casbin.Enforcer.StartAutoLoadPolicy(interval)
+			}
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			casbin.Enforcer.StopAutoLoadPolicy()
+			watcher.Close()
+			return nil
+		},
+	})
+
+	return nil
+}
+
+var WatcherModule = fx.Module(
+	"policy-watcher-module",
+	fx.Provide(NewPolicyWatcher),
+	fx.Invoke(WatchPolicy),
+)
@@ -0,0 +1,183 @@
+package author
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"sync"
+	"testing"
+	"time"
+
+	_casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+)
+
+const testModel = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+`
+
+// memoryBroker keeps the published messages so that tests deliver them by hand
+type memoryBroker struct {
+	mu         sync.Mutex
+	published  [][]byte
+	err        error
+	subscribed context.Context
+}
+
+func (b *memoryBroker) Publish(ctx context.Context, channel string, message []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, message)
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subscribed = ctx
+	return make(chan []byte), nil
+}
+
+// deliver hands every message published so far to each watcher, as every replica is subscribed
+func (b *memoryBroker) deliver(watchers ...*PolicyWatcher) {
+	b.mu.Lock()
+	messages := b.published
+	b.published = nil
+	b.mu.Unlock()
+
+	for _, message := range messages {
+		for _, watcher := range watchers {
+			watcher.handle(message)
+		}
+	}
+}
+
+func newTestReplica(t *testing.T, broker *memoryBroker) (*_casbin.SyncedEnforcer, *PolicyWatcher, *int) {
+	t.Helper()
+
+	m, err := model.NewModelFromString(testModel)
+	require.NoError(t, err)
+
+	enforcer, err := _casbin.NewSyncedEnforcer(m)
+	require.NoError(t, err)
+
+	reloads := 0
+	watcher := newPolicyWatcher(enforcer, broker)
+	require.NoError(t, watcher.SetUpdateCallback(func(string) { reloads++ }))
+	require.NoError(t, enforcer.SetWatcher(watcher))
+
+	return enforcer, watcher, &reloads
+}
+
+func TestPolicyWatcher_Incremental(t *testing.T) {
+	broker := &memoryBroker{}
+	local, localWatcher, localReloads := newTestReplica(t, broker)
+	remote, remoteWatcher, remoteReloads := newTestReplica(t, broker)
+
+	_, err := local.AddPolicy("manager", "/v1/products/", "POST")
+	require.NoError(t, err)
+	_, err = local.AddGroupingPolicy("user:1", "manager")
+	require.NoError(t, err)
+
+	// a replica ignores its own changes, it applied them already
+	broker.deliver(localWatcher, remoteWatcher)
+
+	rules, err := local.GetPolicy()
+	require.NoError(t, err)
+	assert.Len(t, rules, 1, "Local rules mismatch")
+
+	allowed, err := remote.Enforce("user:1", "/v1/products/", "POST")
+	require.NoError(t, err)
+	assert.True(t, allowed, "Added rules mismatch")
+
+	_, err = local.RemoveGroupingPolicy("user:1", "manager")
+	require.NoError(t, err)
+	broker.deliver(remoteWatcher)
+
+	allowed, err = remote.Enforce("user:1", "/v1/products/", "POST")
+	require.NoError(t, err)
+	assert.False(t, allowed, "Removed rules mismatch")
+
+	_, err = local.DeleteRole("manager")
+	require.NoError(t, err)
+	broker.deliver(remoteWatcher)
+
+	rules, err = remote.GetPolicy()
+	require.NoError(t, err)
+	assert.Empty(t, rules, "Filtered rules mismatch")
+
+	assert.Zero(t, *localReloads, "Local reloads mismatch")
+	assert.Zero(t, *remoteReloads, "Remote reloads mismatch")
+}
+
+func TestPolicyWatcher_Reload(t *testing.T) {
+	broker := &memoryBroker{}
+	_, localWatcher, _ := newTestReplica(t, broker)
+	_, remoteWatcher, remoteReloads := newTestReplica(t, broker)
+
+	require.NoError(t, localWatcher.Update())
+	broker.deliver(remoteWatcher)
+	assert.Equal(t, 1, *remoteReloads, "Reload mismatch")
+
+	// a change that cannot be understood falls back on a full reload
+	remoteWatcher.handle([]byte("not json"))
+	remoteWatcher.handle([]byte(`{"origin":"other","op":"rename"}`))
+	assert.Equal(t, 3, *remoteReloads, "Fallback reload mismatch")
+}
+
+func TestPolicyWatcher_PublishFailure(t *testing.T) {
+	broker := &memoryBroker{err: errors.New("connection refused")}
+	local, _, _ := newTestReplica(t, broker)
+
+	// the change is kept, the other replicas catch up on their next full reload
+	added, err := local.AddPolicy("manager", "/v1/products/", "POST")
+	assert.NoError(t, err, "Error mismatch")
+	assert.True(t, added, "Added mismatch")
+}
+
+func TestWatchPolicy_Lifecycle(t *testing.T) {
+	m, err := model.NewModelFromString(testModel)
+	require.NoError(t, err)
+
+	enforcer, err := _casbin.NewSyncedEnforcer(m)
+	require.NoError(t, err)
+
+	broker := &memoryBroker{}
+	watcher := newPolicyWatcher(enforcer, broker)
+
+	lc := fxtest.NewLifecycle(t)
+	err = WatchPolicy(lc, &CasbinConfig{Enforcer: enforcer}, watcher, &configs.Policy{ReloadInterval: "1h"})
+	require.NoError(t, err)
+	assert.Nil(t, broker.subscribed, "Subscribed before start")
+
+	lc.RequireStart()
+	require.NotNil(t, broker.subscribed, "Subscription mismatch")
+	assert.NoError(t, broker.subscribed.Err(), "Subscription ended with the start")
+	assert.True(t, enforcer.IsAutoLoadingRunning(), "Auto load mismatch")
+
+	lc.RequireStop()
+	assert.Error(t, broker.subscribed.Err(), "Subscription outlived the stop")
+	// the auto load ends once its goroutine receives the stop
+	assert.Eventually(t, func() bool { return !enforcer.IsAutoLoadingRunning() }, time.Second, 10*time.Millisecond, "Auto load outlived the stop")
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// AuditHandler represents the HTTP handlers for audit-related requests
+type AuditHandler struct {
+	svc ports.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler instance
+func NewAuditHandler(svc ports.AuditService) *AuditHandler {
+	return &AuditHandler{
+		svc,
+	}
+}
+
+// listUserHistoryRequest represents the request body for listing a user's change history
+type listUserHistoryRequest struct {
+	Skip    uint64             `form:"skip" binding:"required,min=0" example:"0"`
+	Limit   uint64             `form:"limit" binding:"required,min=5" example:"5"`
+	ActorID uint64             `form:"actor_id" binding:"omitempty,min=1" example:"1"`
+	Action  models.AuditAction `form:"action" binding:"omitempty,oneof=user.create user.update user.delete user.email_request user.email_confirm user.email_revert" example:"user.update"`
+	From    time.Time          `form:"from" time_format:"2006-01-02T15:04:05Z07:00" example:"1970-01-01T00:00:00Z"`
+	To      time.Time          `form:"to" time_format:"2006-01-02T15:04:05Z07:00" example:"1970-01-01T00:00:00Z"`
+}
+
+// ListUserHistory godoc
+//
+//	@Summary		List a user's change history
+//	@Description	List the audit records of a user with filtering and pagination, newest first
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		uint64			true	"User ID"
+//	@Param			skip		query		uint64			true	"Skip"
+//	@Param			limit		query		uint64			true	"Limit"
+//	@Param			actor_id	query		uint64			false	"Actor ID"
+//	@Param			action		query		string			false	"Action"
+//	@Param			from		query		string			false	"From (RFC 3339, inclusive)"
+//	@Param			to			query		string			false	"To (RFC 3339, exclusive)"
+//	@Success		200			{object}	meta			"History displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/users/{id}/history [get]
+//	@Security		BearerAuth
+func (ah *AuditHandler) ListUserHistory(ctx *gin.Context) {
+	var uri getUserRequest
+	var req listUserHistoryRequest
+	var recordsList []utils.AuditRecordResponse
+
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	filter := models.AuditFilter{
+		TargetID: uri.ID,
+		ActorID:  req.ActorID,
+		Action:   req.Action,
+		From:     req.From,
+		To:       req.To,
+	}
+
+	records, err := ah.svc.ListUserHistory(ctx, &filter, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, record := range records {
+		recordsList = append(recordsList, utils.NewAuditRecordResponse(&record))
+	}
+
+	total := uint64(len(recordsList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, recordsList, "history")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var AuditModule = fx.Module(
+	"audit-handler-module",
+	fx.Provide(NewAuditHandler),
+)
@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// AuthzHandler represents the HTTP handlers for administering the authorization policy
+type AuthzHandler struct {
+	svc ports.AuthzService
+}
+
+// NewAuthzHandler creates a new AuthzHandler instance
+func NewAuthzHandler(svc ports.AuthzService) *AuthzHandler {
+	return &AuthzHandler{
+		svc,
+	}
+}
+
+// listPoliciesRequest represents the request body for listing permission rules
+type listPoliciesRequest struct {
+	Skip    uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit   uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	Subject string `form:"subject" binding:"omitempty,max=100" example:"manager"`
+	Object  string `form:"object" binding:"omitempty,max=100" example:"/v1/products/"`
+	Action  string `form:"action" binding:"omitempty,max=100" example:"POST"`
+}
+
+// ListPolicies godoc
+//
+//	@Summary		List permission rules
+//	@Description	List the "p" rules of every tenant and then of the tenant of the request with pagination, filtered by subject, object and action
+//	@Tags			Authz
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Param			subject	query		string			false	"Subject"
+//	@Param			object	query		string			false	"Object"
+//	@Param			action	query		string			false	"Action"
+//	@Success		200		{object}	meta			"Permission rules displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/authz/policies [get]
+//	@Security		BearerAuth
+func (ah *AuthzHandler) ListPolicies(ctx *gin.Context) {
+	var req listPoliciesRequest
+	var policiesList []utils.PolicyResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	filter := models.PolicyRule{
+		Type:    models.PolicyPermission,
+		Subject: req.Subject,
+		Object:  req.Object,
+		Action:  req.Action,
+	}
+
+	rules, err := ah.svc.ListRules(ctx, &filter, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, rule := range rules {
+		policiesList = append(policiesList, utils.NewPolicyResponse(&rule))
+	}
+
+	total := uint64(len(policiesList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, policiesList, "policies")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// policyRequest represents the request body for adding or removing a permission rule
+type policyRequest struct {
+	Subject string `json:"subject" binding:"required,max=100" example:"manager"`
+	Object  string `json:"object" binding:"required,max=100" example:"/v1/products/"`
+	Action  string `json:"action" binding:"required,max=100" example:"POST"`
+}
+
+// AddPolicy godoc
+//
+//	@Summary		Add a permission rule
+//	@Description	Add a "p" rule allowing a subject to act on an object in the tenant of the request, it applies from the next request. The object may be a route such as /v1/users/:id or /v1/groups/*, and the action several methods such as GET|PUT, or .* for all of them. Only the admins of the default tenant can add rules on /v1/tenants or /v1/roles, which every tenant shares
+//	@Tags			Authz
+//	@Accept			json
+//	@Produce		json
+//	@Param			policyRequest	body		policyRequest	true	"Add permission rule request"
+//	@Success		200				{object}	policyResponse	"Permission rule added"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		409				{object}	errorResponse	"Data conflict error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/authz/policies [post]
+//	@Security		BearerAuth
+func (ah *AuthzHandler) AddPolicy(ctx *gin.Context) {
+	var req policyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	rule := models.PolicyRule{
+		Type:    models.PolicyPermission,
+		Subject: req.Subject,
+		Object:  req.Object,
+		Action:  req.Action,
+	}
+
+	_, err := ah.svc.AddRule(ctx, &rule)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewPolicyResponse(&rule)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// RemovePolicy godoc
+//
+//	@Summary		Remove a permission rule
+//	@Description	Remove a "p" rule of the tenant of the request, the admin role's rules on the authz API cannot be removed
+//	@Tags			Authz
+//	@Accept			json
+//	@Produce		json
+//	@Param			policyRequest	body		policyRequest	true	"Remove permission rule request"
+//	@Success		200				{object}	response		"Permission rule removed"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		404				{object}	errorResponse	"Data not found error"
+//	@Failure		409				{object}	errorResponse	"Protected rule error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/authz/policies [delete]
+//	@Security		BearerAuth
+func (ah *AuthzHandler) RemovePolicy(ctx *gin.Context) {
+	var req policyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	rule := models.PolicyRule{
+		Type:    models.PolicyPermission,
+		Subject: req.Subject,
+		Object:  req.Object,
+		Action:  req.Action,
+	}
+
+	err := ah.svc.RemoveRule(ctx, &rule)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// listGroupingsRequest represents the request body for listing grouping rules
+type listGroupingsRequest struct {
+	Skip    uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit   uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	Subject string `form:"subject" binding:"omitempty,max=100" example:"user:1"`
+	Role    string `form:"role" binding:"omitempty,max=100" example:"manager"`
+}
+
+// ListGroupings godoc
+//
+//	@Summary		List grouping rules
+//	@Description	List the "g" rules of the tenant of the request with pagination, filtered by subject and role
+//	@Tags			Authz
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Param			subject	query		string			false	"Subject"
+//	@Param			role	query		string			false	"Role"
+//	@Success		200		{object}	meta			"Grouping rules displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/authz/groupings [get]
+//	@Security		BearerAuth
+func (ah *AuthzHandler) ListGroupings(ctx *gin.Context) {
+	var req listGroupingsRequest
+	var groupingsList []utils.GroupingResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	filter := models.PolicyRule{
+		Type:    models.PolicyGrouping,
+		Subject: req.Subject,
+		Role:    req.Role,
+	}
+
+	rules, err := ah.svc.ListRules(ctx, &filter, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, rule := range rules {
+		groupingsList = append(groupingsList, utils.NewGroupingResponse(&rule))
+	}
+
+	total := uint64(len(groupingsList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, groupingsList, "groupings")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// groupingRequest represents the request body for adding or removing a grouping rule
+type groupingRequest struct {
+	Subject string `json:"subject" binding:"required,max=100" example:"user:1"`
+	Role    string `json:"role" binding:"required,max=100" example:"manager"`
+}
+
+// AddGrouping godoc
+//
+//	@Summary		Add a grouping rule
+//	@Description	Add a "g" rule giving a subject a role in the tenant of the request, it applies from the next request
+//	@Tags			Authz
+//	@Accept			json
+//	@Produce		json
+//	@Param			groupingRequest	body		groupingRequest		true	"Add grouping rule request"
+//	@Success		200				{object}	groupingResponse	"Grouping rule added"
+//	@Failure		400				{object}	errorResponse		"Validation error"
+//	@Failure		401				{object}	errorResponse		"Unauthorized error"
+//	@Failure		403				{object}	errorResponse		"Forbidden error"
+//	@Failure		409				{object}	errorResponse		"Data conflict error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
+//	@Router			/authz/groupings [post]
+//	@Security		BearerAuth
+func (ah *AuthzHandler) AddGrouping(ctx *gin.Context) {
+	var req groupingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	rule := models.PolicyRule{
+		Type:    models.PolicyGrouping,
+		Subject: req.Subject,
+		Role:    req.Role,
+	}
+
+	_, err := ah.svc.AddRule(ctx, &rule)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewGroupingResponse(&rule)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// RemoveGrouping godoc
+//
+//	@Summary		Remove a grouping rule
+//	@Description	Remove a "g" rule between a subject and a role in the tenant of the request, an admin cannot remove their own admin role
+//	@Tags			Authz
+//	@Accept			json
+//	@Produce		json
+//	@Param			groupingRequest	body		groupingRequest	true	"Remove grouping rule request"
+//	@Success		200				{object}	response		"Grouping rule removed"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		404				{object}	errorResponse	"Data not found error"
+//	@Failure		409				{object}	errorResponse	"Protected rule error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/authz/groupings [delete]
+//	@Security		BearerAuth
+func (ah *AuthzHandler) RemoveGrouping(ctx *gin.Context) {
+	var req groupingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	rule := models.PolicyRule{
+		Type:    models.PolicyGrouping,
+		Subject: req.Subject,
+		Role:    req.Role,
+	}
+
+	err := ah.svc.RemoveRule(ctx, &rule)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// getSubjectRolesRequest represents the request body for getting the roles of a subject
+type getSubjectRolesRequest struct {
+	Subject string `form:"subject" binding:"required,max=100" example:"user:1"`
+}
+
+// GetSubjectRoles godoc
+//
+//	@Summary		Get the roles of a subject
+//	@Description	Get the roles a subject is given directly, and every role it inherits through its groups and roles, in the tenant of the request
+//	@Tags			Authz
+//	@Accept			json
+//	@Produce		json
+//	@Param			subject	query		string					true	"Subject"
+//	@Success		200		{object}	subjectRolesResponse	"Roles displayed"
+//	@Failure		400		{object}	errorResponse			"Validation error"
+//	@Failure		401		{object}	errorResponse			"Unauthorized error"
+//	@Failure		403		{object}	errorResponse			"Forbidden error"
+//	@Failure		500		{object}	errorResponse			"Internal server error"
+//	@Router			/authz/roles [get]
+//	@Security		BearerAuth
+func (ah *AuthzHandler) GetSubjectRoles(ctx *gin.Context) {
+	var req getSubjectRolesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	roles, err := ah.svc.GetSubjectRoles(ctx, req.Subject)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewSubjectRolesResponse(roles)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// checkRequestRequest represents the request body for deciding on a request, as the router
+// would make it for the subject. The owner is the subject owning the resource, if any
+type checkRequestRequest struct {
+	Subject string `json:"subject" binding:"required,max=100" example:"user:1"`
+	Object  string `json:"object" binding:"required,max=100" example:"/v1/products/42"`
+	Action  string `json:"action" binding:"required,max=100" example:"PUT"`
+	Owner   string `json:"owner" binding:"omitempty,max=100" example:"user:1"`
+}
+
+// CheckRequest godoc
+//
+//	@Summary		Check a request against the policy
+//	@Description	Decide whether a subject may make a request in the tenant of the request, as the router would, with the rule that allowed it and the roles the subject has. A denial names no rule, only the roles
+//	@Tags			Authz
+//	@Accept			json
+//	@Produce		json
+//	@Param			checkRequestRequest	body		checkRequestRequest		true	"Check request request"
+//	@Success		200					{object}	authzDecisionResponse	"Decision displayed"
+//	@Failure		400					{object}	errorResponse			"Validation error"
+//	@Failure		401					{object}	errorResponse			"Unauthorized error"
+//	@Failure		403					{object}	errorResponse			"Forbidden error"
+//	@Failure		500					{object}	errorResponse			"Internal server error"
+//	@Router			/authz/check [post]
+//	@Security		BearerAuth
+func (ah *AuthzHandler) CheckRequest(ctx *gin.Context) {
+	var req checkRequestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	request := models.AuthzRequest{
+		Subject: req.Subject,
+		Object:  req.Object,
+		Action:  req.Action,
+		Owner:   req.Owner,
+	}
+
+	decision, err := ah.svc.CheckRequest(ctx, &request)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewAuthzDecisionResponse(decision)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var AuthzModule = fx.Module(
+	"authz-handler-module",
+	fx.Provide(NewAuthzHandler),
+)
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// avatarFormField is the multipart form field that carries the avatar image
+const avatarFormField = "avatar"
+
+// AvatarHandler represents the HTTP handlers for avatar-related requests
+type AvatarHandler struct {
+	svc ports.AvatarService
+}
+
+// NewAvatarHandler creates a new AvatarHandler instance
+func NewAvatarHandler(svc ports.AvatarService) *AvatarHandler {
+	return &AvatarHandler{
+		svc,
+	}
+}
+
+// UploadAvatar godoc
+//
+//	@Summary		Upload a user's avatar
+//	@Description	Replace a user's avatar with a JPEG, PNG, or GIF image of at most 5 MiB and 4096x4096 pixels, square PNG thumbnails are generated from its center
+//	@Tags			Users
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			id		path		uint64			true	"User ID"
+//	@Param			avatar	formData	file			true	"Avatar image"
+//	@Success		200		{object}	userResponse	"Avatar uploaded"
+//	@Header			200		{string}	ETag			"User version"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		412		{object}	errorResponse	"Version conflict error"
+//	@Failure		413		{object}	errorResponse	"File too large error"
+//	@Failure		415		{object}	errorResponse	"Unsupported media type error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/users/{id}/avatar [put]
+//	@Security		BearerAuth
+func (ah *AvatarHandler) UploadAvatar(ctx *gin.Context) {
+	var req getUserRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	// leave room for the multipart framing around the file itself
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, models.AvatarMaxSize+64<<10)
+
+	header, err := ctx.FormFile(avatarFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			utils.HandleError(ctx, models.ErrFileTooLarge)
+			return
+		}
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	if header.Size > models.AvatarMaxSize {
+		utils.HandleError(ctx, models.ErrFileTooLarge)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		utils.HandleError(ctx, models.ErrInternal)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.HandleError(ctx, models.ErrInternal)
+		return
+	}
+
+	user, err := ah.svc.UploadAvatar(ctx, req.ID, data)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("ETag", toETag(user.Version))
+	rsp := utils.NewUserResponse(user)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// DeleteAvatar godoc
+//
+//	@Summary		Delete a user's avatar
+//	@Description	Remove a user's avatar and its thumbnails
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"User ID"
+//	@Success		200	{object}	userResponse	"Avatar deleted"
+//	@Header			200	{string}	ETag			"User version"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		412	{object}	errorResponse	"Version conflict error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/users/{id}/avatar [delete]
+//	@Security		BearerAuth
+func (ah *AvatarHandler) DeleteAvatar(ctx *gin.Context) {
+	var req getUserRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	user, err := ah.svc.DeleteAvatar(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("ETag", toETag(user.Version))
+	rsp := utils.NewUserResponse(user)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var AvatarModule = fx.Module(
+	"avatar-handler-module",
+	fx.Provide(NewAvatarHandler),
+)
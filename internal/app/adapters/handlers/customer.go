@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// CustomerHandler represents the HTTP handlers for customer-related requests
+type CustomerHandler struct {
+	svc ports.CustomerService
+}
+
+// NewCustomerHandler creates a new CustomerHandler instance
+func NewCustomerHandler(svc ports.CustomerService) *CustomerHandler {
+	return &CustomerHandler{
+		svc,
+	}
+}
+
+// customerRequest represents the request body for creating or replacing the contact details of a customer
+type customerRequest struct {
+	Name  string `json:"name" binding:"required,max=128" example:"Jane Doe"`
+	Email string `json:"email" binding:"omitempty,email,max=255" example:"jane@example.com"`
+	Phone string `json:"phone" binding:"omitempty,max=32" example:"+6281234567890"`
+}
+
+// CreateCustomer godoc
+//
+//	@Summary		Create a new customer
+//	@Description	create a new customer without any points, the email and phone are unique when given
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			customerRequest	body		customerRequest		true	"Create customer request"
+//	@Success		200				{object}	customerResponse	"Customer created"
+//	@Failure		400				{object}	errorResponse		"Validation error"
+//	@Failure		401				{object}	errorResponse		"Unauthorized error"
+//	@Failure		403				{object}	errorResponse		"Forbidden error"
+//	@Failure		409				{object}	errorResponse		"Data conflict error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
+//	@Router			/customers [post]
+//	@Security		BearerAuth
+func (ch *CustomerHandler) CreateCustomer(ctx *gin.Context) {
+	var req customerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	customer := models.Customer{
+		Name:  req.Name,
+		Email: req.Email,
+		Phone: req.Phone,
+	}
+
+	_, err := ch.svc.CreateCustomer(ctx, &customer)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewCustomerResponse(&customer)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listCustomersRequest represents the request body for listing customers
+type listCustomersRequest struct {
+	Skip   uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit  uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	Search string `form:"q" binding:"omitempty,max=64" example:"jane"`
+}
+
+// ListCustomers godoc
+//
+//	@Summary		List customers
+//	@Description	List customers with pagination, optionally matching part of the name, email or phone
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Param			q		query		string			false	"Search"
+//	@Success		200		{object}	meta			"Customers displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/customers [get]
+//	@Security		BearerAuth
+func (ch *CustomerHandler) ListCustomers(ctx *gin.Context) {
+	var req listCustomersRequest
+	var customersList []utils.CustomerResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	customers, err := ch.svc.ListCustomers(ctx, req.Search, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, customer := range customers {
+		customersList = append(customersList, utils.NewCustomerResponse(&customer))
+	}
+
+	total := uint64(len(customersList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, customersList, "customers")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getCustomerRequest represents the request body for getting a customer
+type getCustomerRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetCustomer godoc
+//
+//	@Summary		Get a customer
+//	@Description	Get a customer with their points balance by id
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64				true	"Customer ID"
+//	@Success		200	{object}	customerResponse	"Customer displayed"
+//	@Failure		400	{object}	errorResponse		"Validation error"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		403	{object}	errorResponse		"Forbidden error"
+//	@Failure		404	{object}	errorResponse		"Data not found error"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/customers/{id} [get]
+//	@Security		BearerAuth
+func (ch *CustomerHandler) GetCustomer(ctx *gin.Context) {
+	var req getCustomerRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	customer, err := ch.svc.GetCustomer(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewCustomerResponse(customer)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// UpdateCustomer godoc
+//
+//	@Summary		Update a customer
+//	@Description	Replace the name, email and phone of a customer by id, the points only change through the ledger
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		uint64				true	"Customer ID"
+//	@Param			customerRequest	body		customerRequest		true	"Update customer request"
+//	@Success		200				{object}	customerResponse	"Customer updated"
+//	@Failure		400				{object}	errorResponse		"Validation error"
+//	@Failure		401				{object}	errorResponse		"Unauthorized error"
+//	@Failure		403				{object}	errorResponse		"Forbidden error"
+//	@Failure		404				{object}	errorResponse		"Data not found error"
+//	@Failure		409				{object}	errorResponse		"Data conflict error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
+//	@Router			/customers/{id} [put]
+//	@Security		BearerAuth
+func (ch *CustomerHandler) UpdateCustomer(ctx *gin.Context) {
+	var req customerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	customer := models.Customer{
+		ID:    id,
+		Name:  req.Name,
+		Email: req.Email,
+		Phone: req.Phone,
+	}
+
+	updatedCustomer, err := ch.svc.UpdateCustomer(ctx, &customer)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewCustomerResponse(updatedCustomer)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// deleteCustomerRequest represents the request body for deleting a customer
+type deleteCustomerRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteCustomer godoc
+//
+//	@Summary		Delete a customer
+//	@Description	Delete a customer by id that never placed an order nor had any points
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Customer ID"
+//	@Success		200	{object}	response		"Customer deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/customers/{id} [delete]
+//	@Security		BearerAuth
+func (ch *CustomerHandler) DeleteCustomer(ctx *gin.Context) {
+	var req deleteCustomerRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := ch.svc.DeleteCustomer(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// listCustomerOrdersRequest represents the request body for listing the orders of a customer
+type listCustomerOrdersRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListOrders godoc
+//
+//	@Summary		List customer orders
+//	@Description	List the purchase history of a customer with pagination, the newest first
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Customer ID"
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Orders displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/customers/{id}/orders [get]
+//	@Security		BearerAuth
+func (ch *CustomerHandler) ListOrders(ctx *gin.Context) {
+	var req listCustomerOrdersRequest
+	var ordersList []utils.OrderResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	orders, err := ch.svc.ListOrders(ctx, id, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, order := range orders {
+		ordersList = append(ordersList, utils.NewOrderResponse(&order))
+	}
+
+	total := uint64(len(ordersList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, ordersList, "orders")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// adjustPointsRequest represents the request body for adjusting the points of a customer,
+// negative points are taken from the customer
+type adjustPointsRequest struct {
+	Points int64  `json:"points" binding:"required" example:"50"`
+	Reason string `json:"reason" binding:"required,max=255" example:"goodwill for a late delivery"`
+}
+
+// AdjustPoints godoc
+//
+//	@Summary		Adjust customer points
+//	@Description	Add points to or take points from a customer by hand with a reason, recorded in their ledger
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64					true	"Customer ID"
+//	@Param			adjustPointsRequest	body		adjustPointsRequest		true	"Adjust points request"
+//	@Success		200					{object}	loyaltyEntryResponse	"Points adjusted"
+//	@Failure		400					{object}	errorResponse			"Validation or insufficient points error"
+//	@Failure		401					{object}	errorResponse			"Unauthorized error"
+//	@Failure		403					{object}	errorResponse			"Forbidden error"
+//	@Failure		404					{object}	errorResponse			"Data not found error"
+//	@Failure		500					{object}	errorResponse			"Internal server error"
+//	@Router			/customers/{id}/points [post]
+//	@Security		BearerAuth
+func (ch *CustomerHandler) AdjustPoints(ctx *gin.Context) {
+	var req adjustPointsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+
+	entry := models.LoyaltyEntry{
+		CustomerID: id,
+		Points:     req.Points,
+		Reason:     req.Reason,
+		UserID:     &payload.UserID,
+	}
+
+	createdEntry, err := ch.svc.AdjustPoints(ctx, &entry)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewLoyaltyEntryResponse(createdEntry)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listLoyaltyEntriesRequest represents the request body for listing the loyalty ledger of a customer
+type listLoyaltyEntriesRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListLoyaltyEntries godoc
+//
+//	@Summary		List customer points history
+//	@Description	List the loyalty ledger of a customer with pagination, the newest first
+//	@Tags			Customers
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Customer ID"
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Loyalty entries displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/customers/{id}/points [get]
+//	@Security		BearerAuth
+func (ch *CustomerHandler) ListLoyaltyEntries(ctx *gin.Context) {
+	var req listLoyaltyEntriesRequest
+	var entriesList []utils.LoyaltyEntryResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	entries, err := ch.svc.ListLoyaltyEntries(ctx, id, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, entry := range entries {
+		entriesList = append(entriesList, utils.NewLoyaltyEntryResponse(&entry))
+	}
+
+	total := uint64(len(entriesList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, entriesList, "loyalty_entries")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var CustomerModule = fx.Module(
+	"customer-handler-module",
+	fx.Provide(NewCustomerHandler),
+)
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// EmailChangeHandler represents the HTTP handlers for email change-related requests
+type EmailChangeHandler struct {
+	svc ports.EmailChangeService
+}
+
+// NewEmailChangeHandler creates a new EmailChangeHandler instance
+func NewEmailChangeHandler(svc ports.EmailChangeService) *EmailChangeHandler {
+	return &EmailChangeHandler{
+		svc,
+	}
+}
+
+// emailChangeLinkRequest represents the request body for following an email change link
+type emailChangeLinkRequest struct {
+	Token string `json:"token" binding:"required" example:"v4.local.Gdh5kiOTyyaQ3_bNykYDeYHO21Jg2..."`
+}
+
+// ConfirmEmailChange godoc
+//
+//	@Summary		Confirm an email change
+//	@Description	Move the user to the new email with the link sent to that address
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			emailChangeLinkRequest	body		emailChangeLinkRequest	true	"Confirmation link request"
+//	@Success		200						{object}	userResponse			"Email changed"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		410						{object}	errorResponse			"Expired link error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/users/email/confirm [post]
+func (eh *EmailChangeHandler) ConfirmEmailChange(ctx *gin.Context) {
+	var req emailChangeLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	user, err := eh.svc.ConfirmEmailChange(ctx, req.Token)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewUserResponse(user)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// RevertEmailChange godoc
+//
+//	@Summary		Revert an email change
+//	@Description	Cancel a pending email change or move the user back to the old email with the link sent to the old address
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			emailChangeLinkRequest	body		emailChangeLinkRequest	true	"Revert link request"
+//	@Success		200						{object}	userResponse			"Email change reverted"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		410						{object}	errorResponse			"Expired link error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/users/email/revert [post]
+func (eh *EmailChangeHandler) RevertEmailChange(ctx *gin.Context) {
+	var req emailChangeLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	user, err := eh.svc.RevertEmailChange(ctx, req.Token)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewUserResponse(user)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var EmailChangeModule = fx.Module(
+	"email-change-handler-module",
+	fx.Provide(NewEmailChangeHandler),
+)
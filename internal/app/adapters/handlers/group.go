@@ -0,0 +1,517 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// GroupHandler represents the HTTP handlers for group-related requests
+type GroupHandler struct {
+	svc ports.GroupService
+}
+
+// NewGroupHandler creates a new GroupHandler instance
+func NewGroupHandler(svc ports.GroupService) *GroupHandler {
+	return &GroupHandler{
+		svc,
+	}
+}
+
+// createGroupRequest represents the request body for creating a group
+type createGroupRequest struct {
+	Name        string  `json:"name" binding:"required" example:"Store 1 - Morning shift"`
+	Description string  `json:"description" example:"Cashiers working 06:00-14:00"`
+	ParentID    *uint64 `json:"parent_id" binding:"omitempty,min=1" example:"1"`
+}
+
+// CreateGroup godoc
+//
+//	@Summary		Create a new group
+//	@Description	create a new group, optionally nested inside a parent group
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			createGroupRequest	body		createGroupRequest	true	"Create group request"
+//	@Success		200					{object}	groupResponse		"Group created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/groups [post]
+//	@Security		BearerAuth
+func (gh *GroupHandler) CreateGroup(ctx *gin.Context) {
+	var req createGroupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	group := models.Group{
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentID,
+	}
+
+	_, err := gh.svc.CreateGroup(ctx, &group)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewGroupResponse(&group)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listGroupsRequest represents the request body for listing groups
+type listGroupsRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListGroups godoc
+//
+//	@Summary		List groups
+//	@Description	List groups with pagination
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Groups displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/groups [get]
+//	@Security		BearerAuth
+func (gh *GroupHandler) ListGroups(ctx *gin.Context) {
+	var req listGroupsRequest
+	var groupsList []utils.GroupResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	groups, err := gh.svc.ListGroups(ctx, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, group := range groups {
+		groupsList = append(groupsList, utils.NewGroupResponse(&group))
+	}
+
+	total := uint64(len(groupsList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, groupsList, "groups")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getGroupRequest represents the request body for getting a group
+type getGroupRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetGroup godoc
+//
+//	@Summary		Get a group
+//	@Description	Get a group by id
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Group ID"
+//	@Success		200	{object}	groupResponse	"Group displayed"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/groups/{id} [get]
+//	@Security		BearerAuth
+func (gh *GroupHandler) GetGroup(ctx *gin.Context) {
+	var req getGroupRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	group, err := gh.svc.GetGroup(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewGroupResponse(group)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// updateGroupRequest represents the request body for updating a group
+type updateGroupRequest struct {
+	Name        string  `json:"name" binding:"omitempty,required" example:"Store 1 - Evening shift"`
+	Description string  `json:"description" binding:"omitempty,required" example:"Cashiers working 14:00-22:00"`
+	ParentID    *uint64 `json:"parent_id" example:"1"`
+}
+
+// UpdateGroup godoc
+//
+//	@Summary		Update a group
+//	@Description	Update a group's name, description, or parent by id, a parent_id of 0 detaches the group
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64				true	"Group ID"
+//	@Param			updateGroupRequest	body		updateGroupRequest	true	"Update group request"
+//	@Success		200					{object}	groupResponse		"Group updated"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/groups/{id} [put]
+//	@Security		BearerAuth
+func (gh *GroupHandler) UpdateGroup(ctx *gin.Context) {
+	var req updateGroupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	group := models.Group{
+		ID:          id,
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentID,
+	}
+
+	_, err = gh.svc.UpdateGroup(ctx, &group)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewGroupResponse(&group)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// deleteGroupRequest represents the request body for deleting a group
+type deleteGroupRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteGroup godoc
+//
+//	@Summary		Delete a group
+//	@Description	Delete a group by id, its memberships are removed and child groups detached
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Group ID"
+//	@Success		200	{object}	response		"Group deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/groups/{id} [delete]
+//	@Security		BearerAuth
+func (gh *GroupHandler) DeleteGroup(ctx *gin.Context) {
+	var req deleteGroupRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := gh.svc.DeleteGroup(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// addMemberRequest represents the request body for adding a user to a group
+type addMemberRequest struct {
+	UserID uint64 `json:"user_id" binding:"required,min=1" example:"1"`
+}
+
+// AddMember godoc
+//
+//	@Summary		Add a group member
+//	@Description	Add a user to a group, the user inherits the group's roles
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64					true	"Group ID"
+//	@Param			addMemberRequest	body		addMemberRequest		true	"Add member request"
+//	@Success		200					{object}	groupMemberResponse		"Member added"
+//	@Failure		400					{object}	errorResponse			"Validation error"
+//	@Failure		401					{object}	errorResponse			"Unauthorized error"
+//	@Failure		403					{object}	errorResponse			"Forbidden error"
+//	@Failure		404					{object}	errorResponse			"Data not found error"
+//	@Failure		409					{object}	errorResponse			"Data conflict error"
+//	@Failure		500					{object}	errorResponse			"Internal server error"
+//	@Router			/groups/{id}/members [post]
+//	@Security		BearerAuth
+func (gh *GroupHandler) AddMember(ctx *gin.Context) {
+	var req addMemberRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	member, err := gh.svc.AddMember(ctx, id, req.UserID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewGroupMemberResponse(member)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listMembersRequest represents the request body for listing group members
+type listMembersRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListMembers godoc
+//
+//	@Summary		List group members
+//	@Description	List the users of a group with pagination
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Group ID"
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Members displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/groups/{id}/members [get]
+//	@Security		BearerAuth
+func (gh *GroupHandler) ListMembers(ctx *gin.Context) {
+	var req listMembersRequest
+	var usersList []utils.UserResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	users, err := gh.svc.ListMembers(ctx, id, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, user := range users {
+		usersList = append(usersList, utils.NewUserResponse(&user))
+	}
+
+	total := uint64(len(usersList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, usersList, "members")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// removeMemberRequest represents the request body for removing a user from a group
+type removeMemberRequest struct {
+	ID     uint64 `uri:"id" binding:"required,min=1" example:"1"`
+	UserID uint64 `uri:"user_id" binding:"required,min=1" example:"1"`
+}
+
+// RemoveMember godoc
+//
+//	@Summary		Remove a group member
+//	@Description	Remove a user from a group
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Group ID"
+//	@Param			user_id	path		uint64			true	"User ID"
+//	@Success		200		{object}	response		"Member removed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/groups/{id}/members/{user_id} [delete]
+//	@Security		BearerAuth
+func (gh *GroupHandler) RemoveMember(ctx *gin.Context) {
+	var req removeMemberRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := gh.svc.RemoveMember(ctx, req.ID, req.UserID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// assignGroupRoleRequest represents the request body for assigning a role to a group
+type assignGroupRoleRequest struct {
+	Role models.UserRole `json:"role" binding:"required,user_role" example:"cashier"`
+}
+
+// AssignRole godoc
+//
+//	@Summary		Assign a role to a group
+//	@Description	Assign a role to a group, every member and nested group inherits it
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		uint64					true	"Group ID"
+//	@Param			assignGroupRoleRequest	body		assignGroupRoleRequest	true	"Assign role request"
+//	@Success		200						{object}	response				"Role assigned"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		403						{object}	errorResponse			"Forbidden error"
+//	@Failure		404						{object}	errorResponse			"Data not found error"
+//	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/groups/{id}/roles [post]
+//	@Security		BearerAuth
+func (gh *GroupHandler) AssignRole(ctx *gin.Context) {
+	var req assignGroupRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err = gh.svc.AssignRole(ctx, id, req.Role)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// listGroupRolesRequest represents the request body for listing the roles of a group
+type listGroupRolesRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// ListRoles godoc
+//
+//	@Summary		List group roles
+//	@Description	List the roles assigned to a group
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Group ID"
+//	@Success		200	{object}	response		"Roles displayed"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/groups/{id}/roles [get]
+//	@Security		BearerAuth
+func (gh *GroupHandler) ListRoles(ctx *gin.Context) {
+	var req listGroupRolesRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	roles, err := gh.svc.ListRoles(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := map[string]any{
+		"roles": roles,
+	}
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// unassignGroupRoleRequest represents the request body for removing a role from a group
+type unassignGroupRoleRequest struct {
+	ID   uint64          `uri:"id" binding:"required,min=1" example:"1"`
+	Role models.UserRole `uri:"role" binding:"required,user_role" example:"cashier"`
+}
+
+// UnassignRole godoc
+//
+//	@Summary		Unassign a role from a group
+//	@Description	Remove a role from a group
+//	@Tags			Groups
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Group ID"
+//	@Param			role	path		string			true	"Role name"
+//	@Success		200		{object}	response		"Role unassigned"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/groups/{id}/roles/{role} [delete]
+//	@Security		BearerAuth
+func (gh *GroupHandler) UnassignRole(ctx *gin.Context) {
+	var req unassignGroupRoleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := gh.svc.UnassignRole(ctx, req.ID, req.Role)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+var GroupModule = fx.Module(
+	"group-handler-module",
+	fx.Provide(NewGroupHandler),
+)
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"fmt"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,6 +22,12 @@ func GetAuthPayload(ctx *gin.Context, key string) *models.TokenPayload {
 	return ctx.MustGet(key).(*models.TokenPayload)
 }
 
+// GetStoreID is a helper function to get the id of the store a request acts on from the context,
+// zero when it acts on every store
+func GetStoreID(ctx *gin.Context, key string) uint64 {
+	return ctx.GetUint64(key)
+}
+
 // toMap is a helper function to add meta and data to a map
 func toMap(m utils.Meta, data any, key string) map[string]any {
 	return map[string]any{
@@ -27,3 +35,33 @@ func toMap(m utils.Meta, data any, key string) map[string]any {
 		key:    data,
 	}
 }
+
+// toETag is a helper function to format a resource version as a strong entity tag
+func toETag(version uint64) string {
+	return fmt.Sprintf("%q", strconv.FormatUint(version, 10))
+}
+
+// ifMatchVersion is a helper function to get the resource version from the If-Match header
+func ifMatchVersion(ctx *gin.Context) (uint64, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" {
+		return 0, models.ErrPreconditionRequired
+	}
+
+	// an entity tag that is not one of ours can never match the current version
+	version, err := strconv.ParseUint(strings.Trim(header, `"`), 10, 64)
+	if err != nil || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return 0, models.ErrVersionConflict
+	}
+
+	return version, nil
+}
+
+// patchValue is a helper function to get the value a merge patch member sets, nil if absent or null
+func patchValue[T any](field models.PatchField[T]) *T {
+	if !field.Present || field.Null {
+		return nil
+	}
+
+	return &field.Value
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// InvitationHandler represents the HTTP handlers for invitation-related requests
+type InvitationHandler struct {
+	svc ports.InvitationService
+}
+
+// NewInvitationHandler creates a new InvitationHandler instance
+func NewInvitationHandler(svc ports.InvitationService) *InvitationHandler {
+	return &InvitationHandler{
+		svc,
+	}
+}
+
+// inviteRequest represents the request body for inviting a user
+type inviteRequest struct {
+	Email string          `json:"email" binding:"required,email" example:"test@example.com"`
+	Role  models.UserRole `json:"role" binding:"required,user_role" example:"cashier"`
+}
+
+// Invite godoc
+//
+//	@Summary		Invite a user
+//	@Description	Invite a user by email with a pre-assigned role, the invitee receives a signed, expiring link
+//	@Tags			Invitations
+//	@Accept			json
+//	@Produce		json
+//	@Param			inviteRequest	body		inviteRequest		true	"Invite request"
+//	@Success		200				{object}	invitationResponse	"Invitation sent"
+//	@Failure		400				{object}	errorResponse		"Validation error"
+//	@Failure		401				{object}	errorResponse		"Unauthorized error"
+//	@Failure		403				{object}	errorResponse		"Forbidden error"
+//	@Failure		409				{object}	errorResponse		"Data conflict error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
+//	@Router			/invitations [post]
+//	@Security		BearerAuth
+func (ih *InvitationHandler) Invite(ctx *gin.Context) {
+	var req inviteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+
+	invitation := models.Invitation{
+		Email:     req.Email,
+		Role:      req.Role,
+		InvitedBy: payload.UserID,
+	}
+
+	_, err := ih.svc.Invite(ctx, &invitation)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewInvitationResponse(&invitation)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listInvitationsRequest represents the request body for listing invitations
+type listInvitationsRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListInvitations godoc
+//
+//	@Summary		List invitations
+//	@Description	List invitations with pagination, newest first
+//	@Tags			Invitations
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Invitations displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/invitations [get]
+//	@Security		BearerAuth
+func (ih *InvitationHandler) ListInvitations(ctx *gin.Context) {
+	var req listInvitationsRequest
+	var invitationsList []utils.InvitationResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	invitations, err := ih.svc.ListInvitations(ctx, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, invitation := range invitations {
+		invitationsList = append(invitationsList, utils.NewInvitationResponse(&invitation))
+	}
+
+	total := uint64(len(invitationsList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, invitationsList, "invitations")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// revokeInvitationRequest represents the request body for revoking an invitation
+type revokeInvitationRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// RevokeInvitation godoc
+//
+//	@Summary		Revoke an invitation
+//	@Description	Revoke a pending invitation by id so its link can no longer be used
+//	@Tags			Invitations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Invitation ID"
+//	@Success		200	{object}	response		"Invitation revoked"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/invitations/{id} [delete]
+//	@Security		BearerAuth
+func (ih *InvitationHandler) RevokeInvitation(ctx *gin.Context) {
+	var req revokeInvitationRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := ih.svc.RevokeInvitation(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// acceptInvitationRequest represents the request body for accepting an invitation
+type acceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required" example:"v4.local.Gdh5kiOTyyaQ3_bNykYDeYHO21Jg2..."`
+	Name     string `json:"name" binding:"required" example:"John Doe"`
+	Password string `json:"password" binding:"required,min=8" example:"12345678"`
+}
+
+// AcceptInvitation godoc
+//
+//	@Summary		Accept an invitation
+//	@Description	Create the invitee's account from the invitation link, with the invited email and role
+//	@Tags			Invitations
+//	@Accept			json
+//	@Produce		json
+//	@Param			acceptInvitationRequest	body		acceptInvitationRequest	true	"Accept invitation request"
+//	@Success		200						{object}	userResponse			"User created"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		409						{object}	errorResponse			"Data conflict error"
+//	@Failure		410						{object}	errorResponse			"Expired link error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/invitations/accept [post]
+func (ih *InvitationHandler) AcceptInvitation(ctx *gin.Context) {
+	var req acceptInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	user := models.User{
+		Name:     req.Name,
+		Password: req.Password,
+	}
+
+	_, err := ih.svc.AcceptInvitation(ctx, req.Token, &user)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewUserResponse(&user)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var InvitationModule = fx.Module(
+	"invitation-handler-module",
+	fx.Provide(NewInvitationHandler),
+)
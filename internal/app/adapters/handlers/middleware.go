@@ -6,11 +6,16 @@ import (
 	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"regexp"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
+// requestIDPattern matches the request ids accepted from upstream proxies
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
 // TokenMiddleware is a author to check if the user is authenticated
 func TokenMiddleware(token ports.TokenService) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -45,20 +50,101 @@ func TokenMiddleware(token ports.TokenService) gin.HandlerFunc {
 			return
 		}
 
+		// the tokens signed before there were tenants are of users of the default one
+		if payload.TenantID == 0 {
+			payload.TenantID = models.DefaultTenantID
+		}
+
+		// a header naming another tenant than the token's is refused rather than quietly ignored
+		header := ctx.GetHeader(_constant.TenantIDHeaderKey)
+		if header != "" {
+			id, err := stringToUint64(header)
+			if err != nil || id != payload.TenantID {
+				err := models.ErrTenantMismatch
+				utils.HandleAbort(ctx, err)
+				return
+			}
+		}
+
+		// the handlers read the payload from gin, the services from the request context
 		ctx.Set(_constant.AuthorizationPayloadKey, payload)
+		ctx.Request = ctx.Request.WithContext(_constant.WithPayload(ctx.Request.Context(), payload))
+		setTenant(ctx, payload.TenantID)
+		ctx.Next()
+	}
+}
+
+// TenantMiddleware is a middleware to set the tenant a request acts on, which is the one of the
+// X-Tenant-ID header or else the default one. The header is not authenticated, which is safe as
+// the public routes it applies to only sign in or redeem links within that tenant, which still
+// need the tenant's own credentials or signed links, and RegistrationMiddleware keeps open
+// registration to the default tenant. Signed-in users always act on their own tenant, which
+// TokenMiddleware sets in place of this one after checking the header does not name another
+func TenantMiddleware(tenants ports.TenantService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tenantID := models.DefaultTenantID
+
+		header := ctx.GetHeader(_constant.TenantIDHeaderKey)
+		if header != "" {
+			id, err := stringToUint64(header)
+			if err != nil || id == 0 {
+				err := models.ErrInvalidTenant
+				utils.HandleAbort(ctx, err)
+				return
+			}
+
+			_, err = tenants.LookupTenant(ctx, id)
+			if err != nil {
+				if err == models.ErrDataNotFound {
+					err = models.ErrInvalidTenant
+				}
+				utils.HandleAbort(ctx, err)
+				return
+			}
+			tenantID = id
+		}
+
+		setTenant(ctx, tenantID)
 		ctx.Next()
 	}
 }
 
-func RoleMiddleware(casbin *author.CasbinConfig) gin.HandlerFunc {
+// setTenant sets the tenant the request acts on in its context, which the services read through
+// the context fallback of the router
+func setTenant(ctx *gin.Context, tenantID uint64) {
+	ctx.Request = ctx.Request.WithContext(_constant.WithTenantID(ctx.Request.Context(), tenantID))
+}
+
+// OwnerResolver resolves the user who owns the resource a request acts on, or 0 when it has none
+type OwnerResolver func(ctx *gin.Context) (uint64, error)
+
+// Owners maps the routes of resources with an owner, as registered with gin, to their resolver
+type Owners map[string]OwnerResolver
+
+// RoleMiddleware is a middleware to check if the user's roles allow the request, or else if the
+// policies granted to the owner do, when the user owns the resource of the route
+func RoleMiddleware(casbin *author.CasbinConfig, owners Owners) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 
 		payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
-		sub := payload.Role
+		sub := models.UserSubject(payload.UserID)
 		obj := ctx.Request.URL.Path
 		act := ctx.Request.Method
 
-		allowed, err := casbin.Enforcer.Enforce(sub, obj, act)
+		owner := ""
+		if resolve, ok := owners[ctx.FullPath()]; ok {
+			ownerID, err := resolve(ctx)
+			if err != nil {
+				err := models.ErrInternal
+				utils.HandleAbort(ctx, err)
+				return
+			}
+			if ownerID != 0 {
+				owner = models.UserSubject(ownerID)
+			}
+		}
+
+		allowed, err := casbin.Enforcer.Enforce(sub, models.TenantDomain(payload.TenantID), obj, act, owner)
 		if err != nil {
 			err := models.ErrInternal
 			utils.HandleAbort(ctx, err)
@@ -73,3 +159,80 @@ func RoleMiddleware(casbin *author.CasbinConfig) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// StoreMiddleware is a middleware to set the store a request acts on, which is the one of the
+// X-Store-ID header or else the one the user was assigned to when logging in. Acting on another
+// store, or on every store when there is none, is only allowed to the users who may act on all stores
+func StoreMiddleware(casbin *author.CasbinConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+
+		payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+		storeID := payload.StoreID
+
+		header := ctx.GetHeader(_constant.StoreIDHeaderKey)
+		if header != "" {
+			id, err := stringToUint64(header)
+			if err != nil || id == 0 {
+				err := models.ErrInvalidStore
+				utils.HandleAbort(ctx, err)
+				return
+			}
+			storeID = id
+		}
+
+		if storeID == 0 || storeID != payload.StoreID {
+			sub := models.UserSubject(payload.UserID)
+			allowed, err := casbin.Enforcer.Enforce(sub, models.TenantDomain(payload.TenantID), models.AllStoresObject, models.AllStoresAction, "")
+			if err != nil {
+				err := models.ErrInternal
+				utils.HandleAbort(ctx, err)
+				return
+			}
+			if !allowed {
+				err := models.ErrForbidden
+				utils.HandleAbort(ctx, err)
+				return
+			}
+		}
+
+		ctx.Set(_constant.StoreIDKey, storeID)
+		ctx.Next()
+	}
+}
+
+// RegistrationMiddleware is a middleware to refuse self-registration when it is turned off, and in
+// every tenant but the default one. The X-Tenant-ID header is not authenticated, users join the
+// other tenants through invitations, whose signed links carry the tenant
+func RegistrationMiddleware(open bool) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tenantID, _ := _constant.TenantID(ctx.Request.Context())
+		if !open || tenantID != models.DefaultTenantID {
+			err := models.ErrRegistrationClosed
+			utils.HandleAbort(ctx, err)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// RequestMetaMiddleware is a middleware to tag every request with an id and the client ip,
+// a well-formed request id from an upstream proxy is kept and echoed back
+func RequestMetaMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(_constant.RequestIDHeaderKey)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		meta := &models.RequestMeta{
+			ID: requestID,
+			IP: ctx.ClientIP(),
+		}
+
+		ctx.Set(_constant.RequestMetaKey, meta)
+		ctx.Header(_constant.RequestIDHeaderKey, requestID)
+
+		ctx.Next()
+	}
+}
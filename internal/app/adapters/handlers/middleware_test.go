@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistrationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		desc     string
+		open     bool
+		tenantID uint64
+		expected int
+	}{
+		{desc: "Open_DefaultTenant", open: true, tenantID: models.DefaultTenantID, expected: http.StatusOK},
+		// anyone could name another tenant in the header, they join it through an invitation
+		{desc: "Open_OtherTenant", open: true, tenantID: 2, expected: http.StatusForbidden},
+		{desc: "Closed", open: false, tenantID: models.DefaultTenantID, expected: http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/v1/users/", func(ctx *gin.Context) {
+				setTenant(ctx, tc.tenantID)
+				ctx.Next()
+			}, RegistrationMiddleware(tc.open), func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/users/", nil)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Code, "Status mismatch")
+		})
+	}
+}
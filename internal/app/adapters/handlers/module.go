@@ -8,5 +8,25 @@ var Module = fx.Module(
 	"handlers-module",
 	UserModule,
 	AuthModule,
+	RoleModule,
+	GroupModule,
+	InvitationModule,
+	EmailChangeModule,
+	AuditModule,
+	AvatarModule,
+	PreferenceModule,
+	CategoryModule,
+	ProductModule,
+	OrderModule,
+	StockModule,
+	ReportModule,
+	PromotionModule,
+	RefundModule,
+	StoreModule,
+	ReceiptModule,
+	TaxModule,
+	CustomerModule,
+	AuthzModule,
+	TenantModule,
 	RouterModule,
 )
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// OrderHandler represents the HTTP handlers for order-related requests
+type OrderHandler struct {
+	svc ports.OrderService
+}
+
+// NewOrderHandler creates a new OrderHandler instance
+func NewOrderHandler(svc ports.OrderService) *OrderHandler {
+	return &OrderHandler{
+		svc,
+	}
+}
+
+// orderItemRequest represents a line of the request body for creating an order
+type orderItemRequest struct {
+	ProductID uint64 `json:"product_id" binding:"required,min=1" example:"1"`
+	Quantity  int64  `json:"quantity" binding:"required,min=1" example:"2"`
+}
+
+// createOrderRequest represents the request body for creating an order, the paid amount is in minor currency units.
+// An open order is placed with nothing paid, and is settled or voided later. An order paid with points needs a
+// customer, whose points pay for all of it whatever the paid amount
+type createOrderRequest struct {
+	Open          bool               `json:"open" example:"false"`
+	PaymentMethod string             `json:"payment_method" binding:"required,oneof=cash card e-wallet points" example:"cash"`
+	TotalPaid     *int64             `json:"total_paid" binding:"required,min=0" example:"5000"`
+	Items         []orderItemRequest `json:"items" binding:"required,min=1,dive"`
+	CouponCode    string             `json:"coupon_code" binding:"omitempty,max=64" example:"SUMMER10"`
+	CustomerID    *uint64            `json:"customer_id" binding:"omitempty,min=1" example:"1"`
+}
+
+// CreateOrder godoc
+//
+//	@Summary		Create a new order
+//	@Description	ring up a sale, the promotions and coupon are applied, the stock of the sold products is decremented, the change is calculated and the customer earns or redeems points. An open order is paid for when it is settled
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			createOrderRequest	body		createOrderRequest	true	"Create order request"
+//	@Success		200					{object}	orderResponse		"Order created"
+//	@Failure		400					{object}	errorResponse		"Validation, insufficient stock, payment, points or coupon error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		409					{object}	errorResponse		"Promotion usage limit error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/orders [post]
+//	@Security		BearerAuth
+func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
+	var req createOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+
+	order := models.Order{
+		UserID:        payload.UserID,
+		Status:        models.OrderPaid,
+		PaymentMethod: models.PaymentMethod(req.PaymentMethod),
+		TotalPaid:     *req.TotalPaid,
+		CouponCode:    req.CouponCode,
+		StoreID:       GetStoreID(ctx, _constant.StoreIDKey),
+		CustomerID:    req.CustomerID,
+	}
+	if req.Open {
+		order.Status = models.OrderOpen
+	}
+	for _, item := range req.Items {
+		order.Items = append(order.Items, models.OrderItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	createdOrder, err := oh.svc.CreateOrder(ctx, &order)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewOrderResponse(createdOrder)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listOrdersRequest represents the request body for listing orders
+type listOrdersRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListOrders godoc
+//
+//	@Summary		List orders
+//	@Description	List orders with pagination, the newest first
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Orders displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/orders [get]
+//	@Security		BearerAuth
+func (oh *OrderHandler) ListOrders(ctx *gin.Context) {
+	var req listOrdersRequest
+	var ordersList []utils.OrderResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	orders, err := oh.svc.ListOrders(ctx, GetStoreID(ctx, _constant.StoreIDKey), req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, order := range orders {
+		ordersList = append(ordersList, utils.NewOrderResponse(&order))
+	}
+
+	total := uint64(len(ordersList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, ordersList, "orders")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getOrderRequest represents the request body for getting an order
+type getOrderRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetOrder godoc
+//
+//	@Summary		Get an order
+//	@Description	Get an order with its items by id
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Order ID"
+//	@Success		200	{object}	orderResponse	"Order displayed"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/orders/{id} [get]
+//	@Security		BearerAuth
+func (oh *OrderHandler) GetOrder(ctx *gin.Context) {
+	var req getOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	order, err := oh.svc.GetOrder(ctx, GetStoreID(ctx, _constant.StoreIDKey), req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewOrderResponse(order)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// settleOrderUriRequest represents the request uri for settling an order
+type settleOrderUriRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// settleOrderRequest represents the request body for settling an order, the paid amount is in minor currency units
+type settleOrderRequest struct {
+	PaymentMethod string `json:"payment_method" binding:"required,oneof=cash card e-wallet" example:"cash"`
+	TotalPaid     *int64 `json:"total_paid" binding:"required,min=0" example:"5000"`
+}
+
+// SettleOrder godoc
+//
+//	@Summary		Settle an order
+//	@Description	take the payment of an open order, the change is calculated and the customer earns points
+//	@Tags			Orders
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64				true	"Order ID"
+//	@Param			settleOrderRequest	body		settleOrderRequest	true	"Settle order request"
+//	@Success		200					{object}	orderResponse		"Order settled"
+//	@Failure		400					{object}	errorResponse		"Validation or insufficient payment error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Order already paid or voided error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/orders/{id}/settle [post]
+//	@Security		BearerAuth
+func (oh *OrderHandler) SettleOrder(ctx *gin.Context) {
+	var uri settleOrderUriRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	var req settleOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	payment := models.Order{
+		ID:            uri.ID,
+		PaymentMethod: models.PaymentMethod(req.PaymentMethod),
+		TotalPaid:     *req.TotalPaid,
+		StoreID:       GetStoreID(ctx, _constant.StoreIDKey),
+	}
+
+	order, err := oh.svc.SettleOrder(ctx, &payment)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewOrderResponse(order)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var OrderModule = fx.Module(
+	"order-handler-module",
+	fx.Provide(NewOrderHandler),
+)
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// PreferenceHandler represents the HTTP handlers for preference-related requests
+type PreferenceHandler struct {
+	svc ports.PreferenceService
+}
+
+// NewPreferenceHandler creates a new PreferenceHandler instance
+func NewPreferenceHandler(svc ports.PreferenceService) *PreferenceHandler {
+	return &PreferenceHandler{
+		svc,
+	}
+}
+
+// preferencesRequest represents the request body for setting preferences, keyed by preference name
+type preferencesRequest map[models.PreferenceKey]any
+
+// GetPreferences godoc
+//
+//	@Summary		Get my preferences
+//	@Description	Get all preferences of the logged in user, with server-side defaults for the ones not set
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	map[string]any	"Preferences displayed"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/users/me/preferences [get]
+//	@Security		BearerAuth
+func (ph *PreferenceHandler) GetPreferences(ctx *gin.Context) {
+	payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+
+	preferences, err := ph.svc.GetPreferences(ctx, payload.UserID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, preferences)
+}
+
+// ReplacePreferences godoc
+//
+//	@Summary		Replace my preferences
+//	@Description	Set all preferences of the logged in user, the ones left out or null are reset to their defaults
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			preferencesRequest	body		preferencesRequest	true	"Preferences"
+//	@Success		200					{object}	map[string]any		"Preferences replaced"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/users/me/preferences [put]
+//	@Security		BearerAuth
+func (ph *PreferenceHandler) ReplacePreferences(ctx *gin.Context) {
+	var req preferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+
+	preferences, err := ph.svc.ReplacePreferences(ctx, payload.UserID, models.Preferences(req))
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, preferences)
+}
+
+// UpdatePreferences godoc
+//
+//	@Summary		Update my preferences
+//	@Description	Merge the given preferences into the logged in user's ones, null resets a preference to its default
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			preferencesRequest	body		preferencesRequest	true	"Preferences"
+//	@Success		200					{object}	map[string]any		"Preferences updated"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/users/me/preferences [patch]
+//	@Security		BearerAuth
+func (ph *PreferenceHandler) UpdatePreferences(ctx *gin.Context) {
+	var req preferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+
+	preferences, err := ph.svc.UpdatePreferences(ctx, payload.UserID, models.Preferences(req))
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, preferences)
+}
+
+var PreferenceModule = fx.Module(
+	"preference-handler-module",
+	fx.Provide(NewPreferenceHandler),
+)
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// ProductHandler represents the HTTP handlers for product-related requests
+type ProductHandler struct {
+	svc ports.ProductService
+}
+
+// NewProductHandler creates a new ProductHandler instance
+func NewProductHandler(svc ports.ProductService) *ProductHandler {
+	return &ProductHandler{
+		svc,
+	}
+}
+
+// productRequest represents the request body for creating or replacing a product,
+// the price is in minor currency units and the stock is changed through stock movements
+type productRequest struct {
+	CategoryID        uint64  `json:"category_id" binding:"required,min=1" example:"1"`
+	SKU               string  `json:"sku" binding:"required,max=64" example:"BEV-COLA-330"`
+	Name              string  `json:"name" binding:"required" example:"Cola 330ml"`
+	Image             string  `json:"image" binding:"omitempty,url" example:"https://example.com/cola.png"`
+	Price             *int64  `json:"price" binding:"required,min=0" example:"1500"`
+	LowStockThreshold int64   `json:"low_stock_threshold" binding:"omitempty,min=0" example:"10"`
+	TaxClassID        *uint64 `json:"tax_class_id" binding:"omitempty,min=1" example:"1"`
+}
+
+// toProduct is a helper function to map a product request to a product
+func (req *productRequest) toProduct(id uint64) models.Product {
+	return models.Product{
+		ID:                id,
+		CategoryID:        req.CategoryID,
+		SKU:               req.SKU,
+		Name:              req.Name,
+		Image:             req.Image,
+		Price:             *req.Price,
+		LowStockThreshold: req.LowStockThreshold,
+		TaxClassID:        req.TaxClassID,
+	}
+}
+
+// CreateProduct godoc
+//
+//	@Summary		Create a new product
+//	@Description	create a new product in a category without stock, the price is in minor currency units and a product without a tax class is not taxed
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			productRequest	body		productRequest	true	"Create product request"
+//	@Success		200				{object}	productResponse	"Product created"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		409				{object}	errorResponse	"Data conflict error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/products [post]
+//	@Security		BearerAuth
+func (ph *ProductHandler) CreateProduct(ctx *gin.Context) {
+	var req productRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	product := req.toProduct(0)
+
+	_, err := ph.svc.CreateProduct(ctx, &product)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewProductResponse(&product)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listProductsRequest represents the request body for listing products
+type listProductsRequest struct {
+	Skip       uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit      uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	CategoryID uint64 `form:"category_id" binding:"omitempty,min=1" example:"1"`
+	Search     string `form:"q" binding:"omitempty,max=64" example:"cola"`
+}
+
+// ListProducts godoc
+//
+//	@Summary		List products
+//	@Description	List products with pagination, optionally by category or matching part of the name or SKU
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip		query		uint64			true	"Skip"
+//	@Param			limit		query		uint64			true	"Limit"
+//	@Param			category_id	query		uint64			false	"Category ID"
+//	@Param			q			query		string			false	"Search"
+//	@Success		200			{object}	meta			"Products displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/products [get]
+//	@Security		BearerAuth
+func (ph *ProductHandler) ListProducts(ctx *gin.Context) {
+	var req listProductsRequest
+	var productsList []utils.ProductResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	filter := models.ProductFilter{
+		CategoryID: req.CategoryID,
+		Search:     req.Search,
+		StoreID:    GetStoreID(ctx, _constant.StoreIDKey),
+	}
+
+	products, err := ph.svc.ListProducts(ctx, &filter, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, product := range products {
+		productsList = append(productsList, utils.NewProductResponse(&product))
+	}
+
+	total := uint64(len(productsList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, productsList, "products")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getProductRequest represents the request body for getting a product
+type getProductRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetProduct godoc
+//
+//	@Summary		Get a product
+//	@Description	Get a product by id
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Product ID"
+//	@Success		200	{object}	productResponse	"Product displayed"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/products/{id} [get]
+//	@Security		BearerAuth
+func (ph *ProductHandler) GetProduct(ctx *gin.Context) {
+	var req getProductRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	product, err := ph.svc.GetProduct(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewProductResponse(product)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// UpdateProduct godoc
+//
+//	@Summary		Update a product
+//	@Description	Replace a product's details by id, the price is in minor currency units
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		uint64			true	"Product ID"
+//	@Param			productRequest	body		productRequest	true	"Update product request"
+//	@Success		200				{object}	productResponse	"Product updated"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		404				{object}	errorResponse	"Data not found error"
+//	@Failure		409				{object}	errorResponse	"Data conflict error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/products/{id} [put]
+//	@Security		BearerAuth
+func (ph *ProductHandler) UpdateProduct(ctx *gin.Context) {
+	var req productRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	product := req.toProduct(id)
+
+	updatedProduct, err := ph.svc.UpdateProduct(ctx, &product)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewProductResponse(updatedProduct)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// deleteProductRequest represents the request body for deleting a product
+type deleteProductRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteProduct godoc
+//
+//	@Summary		Delete a product
+//	@Description	Delete a product by id
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Product ID"
+//	@Success		200	{object}	response		"Product deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/products/{id} [delete]
+//	@Security		BearerAuth
+func (ph *ProductHandler) DeleteProduct(ctx *gin.Context) {
+	var req deleteProductRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := ph.svc.DeleteProduct(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+var ProductModule = fx.Module(
+	"product-handler-module",
+	fx.Provide(NewProductHandler),
+)
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"time"
+)
+
+// PromotionHandler represents the HTTP handlers for promotion-related requests
+type PromotionHandler struct {
+	svc ports.PromotionService
+}
+
+// NewPromotionHandler creates a new PromotionHandler instance
+func NewPromotionHandler(svc ports.PromotionService) *PromotionHandler {
+	return &PromotionHandler{
+		svc,
+	}
+}
+
+// promotionRequest represents the request body for creating or replacing a promotion. The value is
+// a percentage or, for fixed promotions, an amount in minor currency units. A promotion covers a
+// product, a category or, without either, the whole order, and needs its coupon code to apply
+// when it has one. A zero usage limit is unlimited, and a promotion is active unless told otherwise
+type promotionRequest struct {
+	Name        string     `json:"name" binding:"required,max=128" example:"Summer sale"`
+	Type        string     `json:"type" binding:"required,oneof=percentage fixed buy_x_get_y" example:"percentage"`
+	Value       int64      `json:"value" binding:"omitempty,min=0" example:"10"`
+	ProductID   *uint64    `json:"product_id" binding:"omitempty,min=1" example:"1"`
+	CategoryID  *uint64    `json:"category_id" binding:"omitempty,min=1" example:"1"`
+	BuyQuantity int64      `json:"buy_quantity" binding:"omitempty,min=0" example:"0"`
+	GetQuantity int64      `json:"get_quantity" binding:"omitempty,min=0" example:"0"`
+	CouponCode  string     `json:"coupon_code" binding:"omitempty,max=64" example:"SUMMER10"`
+	UsageLimit  int64      `json:"usage_limit" binding:"omitempty,min=0" example:"100"`
+	StartsAt    *time.Time `json:"starts_at" example:"2024-06-01T00:00:00Z"`
+	EndsAt      *time.Time `json:"ends_at" example:"2024-09-01T00:00:00Z"`
+	Active      *bool      `json:"active" example:"true"`
+}
+
+// toPromotion is a helper function to map a promotion request to a promotion
+func (req *promotionRequest) toPromotion(id uint64) models.Promotion {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return models.Promotion{
+		ID:          id,
+		Name:        req.Name,
+		Type:        models.PromotionType(req.Type),
+		Value:       req.Value,
+		ProductID:   req.ProductID,
+		CategoryID:  req.CategoryID,
+		BuyQuantity: req.BuyQuantity,
+		GetQuantity: req.GetQuantity,
+		CouponCode:  req.CouponCode,
+		UsageLimit:  req.UsageLimit,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Active:      active,
+	}
+}
+
+// CreatePromotion godoc
+//
+//	@Summary		Create a new promotion
+//	@Description	create a new discount rule, applied automatically at checkout or with its coupon code
+//	@Tags			Promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			promotionRequest	body		promotionRequest	true	"Create promotion request"
+//	@Success		200					{object}	promotionResponse	"Promotion created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/promotions [post]
+//	@Security		BearerAuth
+func (ph *PromotionHandler) CreatePromotion(ctx *gin.Context) {
+	var req promotionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	promotion := req.toPromotion(0)
+
+	_, err := ph.svc.CreatePromotion(ctx, &promotion)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewPromotionResponse(&promotion)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listPromotionsRequest represents the request body for listing promotions
+type listPromotionsRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListPromotions godoc
+//
+//	@Summary		List promotions
+//	@Description	List promotions with pagination
+//	@Tags			Promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Promotions displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/promotions [get]
+//	@Security		BearerAuth
+func (ph *PromotionHandler) ListPromotions(ctx *gin.Context) {
+	var req listPromotionsRequest
+	var promotionsList []utils.PromotionResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	promotions, err := ph.svc.ListPromotions(ctx, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, promotion := range promotions {
+		promotionsList = append(promotionsList, utils.NewPromotionResponse(&promotion))
+	}
+
+	total := uint64(len(promotionsList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, promotionsList, "promotions")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getPromotionRequest represents the request body for getting a promotion
+type getPromotionRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetPromotion godoc
+//
+//	@Summary		Get a promotion
+//	@Description	Get a promotion by id
+//	@Tags			Promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64				true	"Promotion ID"
+//	@Success		200	{object}	promotionResponse	"Promotion displayed"
+//	@Failure		400	{object}	errorResponse		"Validation error"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		403	{object}	errorResponse		"Forbidden error"
+//	@Failure		404	{object}	errorResponse		"Data not found error"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/promotions/{id} [get]
+//	@Security		BearerAuth
+func (ph *PromotionHandler) GetPromotion(ctx *gin.Context) {
+	var req getPromotionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	promotion, err := ph.svc.GetPromotion(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewPromotionResponse(promotion)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// UpdatePromotion godoc
+//
+//	@Summary		Update a promotion
+//	@Description	Replace a promotion's rule by id, its usage count is kept
+//	@Tags			Promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64				true	"Promotion ID"
+//	@Param			promotionRequest	body		promotionRequest	true	"Update promotion request"
+//	@Success		200					{object}	promotionResponse	"Promotion updated"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/promotions/{id} [put]
+//	@Security		BearerAuth
+func (ph *PromotionHandler) UpdatePromotion(ctx *gin.Context) {
+	var req promotionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	promotion := req.toPromotion(id)
+
+	updatedPromotion, err := ph.svc.UpdatePromotion(ctx, &promotion)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewPromotionResponse(updatedPromotion)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// deletePromotionRequest represents the request body for deleting a promotion
+type deletePromotionRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeletePromotion godoc
+//
+//	@Summary		Delete a promotion
+//	@Description	Delete a promotion by id, the orders it was applied to keep their discounts
+//	@Tags			Promotions
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Promotion ID"
+//	@Success		200	{object}	response		"Promotion deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/promotions/{id} [delete]
+//	@Security		BearerAuth
+func (ph *PromotionHandler) DeletePromotion(ctx *gin.Context) {
+	var req deletePromotionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := ph.svc.DeletePromotion(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+var PromotionModule = fx.Module(
+	"promotion-handler-module",
+	fx.Provide(NewPromotionHandler),
+)
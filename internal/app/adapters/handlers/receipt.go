@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"fmt"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// ReceiptHandler represents the HTTP handlers for receipt-related requests
+type ReceiptHandler struct {
+	svc ports.ReceiptService
+}
+
+// NewReceiptHandler creates a new ReceiptHandler instance
+func NewReceiptHandler(svc ports.ReceiptService) *ReceiptHandler {
+	return &ReceiptHandler{
+		svc,
+	}
+}
+
+// receiptMediaTypes are the media types a receipt is negotiated from, the first when any is accepted
+var receiptMediaTypes = map[string]models.ReceiptFormat{
+	"application/pdf":          models.ReceiptPDF,
+	"text/html":                models.ReceiptHTML,
+	"application/octet-stream": models.ReceiptESCPOS,
+}
+
+// getReceiptRequest represents the request body for getting the receipt of an order, the format
+// takes precedence over the Accept header and the width is the paper roll in millimeters
+type getReceiptRequest struct {
+	ID     uint64 `uri:"id" binding:"required,min=1" example:"1"`
+	Format string `form:"format" binding:"omitempty,oneof=pdf escpos html" example:"pdf"`
+	Width  int    `form:"width" binding:"omitempty,oneof=58 80" example:"80"`
+}
+
+// GetReceipt godoc
+//
+//	@Summary		Get the receipt of an order
+//	@Description	Render the receipt of an order as a PDF document, an ESC/POS byte stream for a thermal printer or an HTML page, negotiated from the Accept header unless the format is given
+//	@Tags			Orders
+//	@Produce		application/pdf,application/octet-stream,text/html
+//	@Param			id		path		uint64			true	"Order ID"
+//	@Param			format	query		string			false	"Format"		Enums(pdf, escpos, html)
+//	@Param			width	query		int				false	"Paper width"	Enums(58, 80)
+//	@Success		200		{file}		binary			"Receipt rendered"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		406		{object}	errorResponse	"Format not acceptable error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/orders/{id}/receipt [get]
+//	@Security		BearerAuth
+func (rh *ReceiptHandler) GetReceipt(ctx *gin.Context) {
+	var req getReceiptRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	format := models.ReceiptFormat(req.Format)
+	if format == "" {
+		format = receiptMediaTypes[ctx.NegotiateFormat("application/pdf", "text/html", "application/octet-stream")]
+	}
+	if format == "" {
+		utils.HandleError(ctx, models.ErrNotAcceptable)
+		return
+	}
+
+	data, err := rh.svc.GetReceipt(ctx, GetStoreID(ctx, _constant.StoreIDKey), req.ID, format, models.ReceiptPaperWidth(req.Width))
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	// pages are shown in the browser, documents for printing are downloaded
+	filename := ""
+	switch format {
+	case models.ReceiptPDF:
+		filename = fmt.Sprintf("receipt_%d.pdf", req.ID)
+	case models.ReceiptESCPOS:
+		filename = fmt.Sprintf("receipt_%d.bin", req.ID)
+	}
+
+	utils.HandleDocument(ctx, format.ContentType(), filename, data)
+}
+
+var ReceiptModule = fx.Module(
+	"receipt-handler-module",
+	fx.Provide(NewReceiptHandler),
+)
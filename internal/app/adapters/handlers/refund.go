@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// RefundHandler represents the HTTP handlers for refund-related requests
+type RefundHandler struct {
+	svc ports.RefundService
+}
+
+// NewRefundHandler creates a new RefundHandler instance
+func NewRefundHandler(svc ports.RefundService) *RefundHandler {
+	return &RefundHandler{
+		svc,
+	}
+}
+
+// refundItemRequest represents an item of the request body for refunding an order
+type refundItemRequest struct {
+	OrderItemID uint64 `json:"order_item_id" binding:"required,min=1" example:"1"`
+	Quantity    int64  `json:"quantity" binding:"required,min=1" example:"1"`
+}
+
+// refundOrderRequest represents the request body for refunding an order. Without items, everything left
+// of the order is refunded, and the refund is paid with the payment method of the order unless another is given.
+// An order paid with points is refunded in points, and only such an order is
+type refundOrderRequest struct {
+	OrderID       uint64              `json:"order_id" binding:"required,min=1" example:"1"`
+	PaymentMethod string              `json:"payment_method" binding:"omitempty,oneof=cash card e-wallet points" example:"cash"`
+	Reason        string              `json:"reason" binding:"required,max=255" example:"damaged packaging"`
+	Items         []refundItemRequest `json:"items" binding:"omitempty,dive"`
+}
+
+// RefundOrder godoc
+//
+//	@Summary		Refund an order
+//	@Description	pay back some or all of what is left of a paid order, the refunded items are put back into stock
+//	@Tags			Refunds
+//	@Accept			json
+//	@Produce		json
+//	@Param			refundOrderRequest	body		refundOrderRequest	true	"Refund order request"
+//	@Success		200					{object}	refundResponse		"Order refunded"
+//	@Failure		400					{object}	errorResponse		"Validation or refund error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		412					{object}	errorResponse		"Concurrent refund error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/refunds [post]
+//	@Security		BearerAuth
+func (rh *RefundHandler) RefundOrder(ctx *gin.Context) {
+	var req refundOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+
+	refund := models.Refund{
+		OrderID:       req.OrderID,
+		UserID:        payload.UserID,
+		PaymentMethod: models.PaymentMethod(req.PaymentMethod),
+		Reason:        req.Reason,
+		StoreID:       GetStoreID(ctx, _constant.StoreIDKey),
+	}
+	for _, item := range req.Items {
+		refund.Items = append(refund.Items, models.RefundItem{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+		})
+	}
+
+	createdRefund, err := rh.svc.RefundOrder(ctx, &refund)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewRefundResponse(createdRefund)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// voidOrderRequest represents the request body for voiding an order
+type voidOrderRequest struct {
+	OrderID uint64 `json:"order_id" binding:"required,min=1" example:"1"`
+	Reason  string `json:"reason" binding:"required,max=255" example:"customer walked out"`
+}
+
+// VoidOrder godoc
+//
+//	@Summary		Void an order
+//	@Description	cancel an open order as a whole, its items are put back into stock
+//	@Tags			Refunds
+//	@Accept			json
+//	@Produce		json
+//	@Param			voidOrderRequest	body		voidOrderRequest	true	"Void order request"
+//	@Success		200					{object}	refundResponse		"Order voided"
+//	@Failure		400					{object}	errorResponse		"Validation or refund error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		412					{object}	errorResponse		"Concurrent refund error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/refunds/void [post]
+//	@Security		BearerAuth
+func (rh *RefundHandler) VoidOrder(ctx *gin.Context) {
+	var req voidOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+
+	refund := models.Refund{
+		OrderID: req.OrderID,
+		UserID:  payload.UserID,
+		Reason:  req.Reason,
+		StoreID: GetStoreID(ctx, _constant.StoreIDKey),
+	}
+
+	createdRefund, err := rh.svc.VoidOrder(ctx, &refund)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewRefundResponse(createdRefund)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listRefundsRequest represents the request body for listing refunds
+type listRefundsRequest struct {
+	Skip    uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit   uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	OrderID uint64 `form:"order_id" binding:"omitempty,min=1" example:"1"`
+}
+
+// ListRefunds godoc
+//
+//	@Summary		List refunds
+//	@Description	List refunds and voids with pagination, the newest first, optionally of an order
+//	@Tags			Refunds
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip		query		uint64			true	"Skip"
+//	@Param			limit		query		uint64			true	"Limit"
+//	@Param			order_id	query		uint64			false	"Order ID"
+//	@Success		200			{object}	meta			"Refunds displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/refunds [get]
+//	@Security		BearerAuth
+func (rh *RefundHandler) ListRefunds(ctx *gin.Context) {
+	var req listRefundsRequest
+	var refundsList []utils.RefundResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	refunds, err := rh.svc.ListRefunds(ctx, GetStoreID(ctx, _constant.StoreIDKey), req.OrderID, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, refund := range refunds {
+		refundsList = append(refundsList, utils.NewRefundResponse(&refund))
+	}
+
+	total := uint64(len(refundsList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, refundsList, "refunds")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getRefundRequest represents the request body for getting a refund
+type getRefundRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetRefund godoc
+//
+//	@Summary		Get a refund
+//	@Description	Get a refund or void by id
+//	@Tags			Refunds
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Refund ID"
+//	@Success		200	{object}	refundResponse	"Refund displayed"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/refunds/{id} [get]
+//	@Security		BearerAuth
+func (rh *RefundHandler) GetRefund(ctx *gin.Context) {
+	var req getRefundRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	refund, err := rh.svc.GetRefund(ctx, GetStoreID(ctx, _constant.StoreIDKey), req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewRefundResponse(refund)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var RefundModule = fx.Module(
+	"refund-handler-module",
+	fx.Provide(NewRefundHandler),
+)
@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"fmt"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+	"strconv"
+	"time"
+)
+
+// ReportHandler represents the HTTP handlers for sales report requests
+type ReportHandler struct {
+	svc ports.ReportService
+}
+
+// NewReportHandler creates a new ReportHandler instance
+func NewReportHandler(svc ports.ReportService) *ReportHandler {
+	return &ReportHandler{
+		svc,
+	}
+}
+
+// reportRequest represents the query of every report, the range covers both dates in the timezone
+type reportRequest struct {
+	From     string `form:"from" binding:"required,datetime=2006-01-02" example:"2024-01-01"`
+	To       string `form:"to" binding:"required,datetime=2006-01-02" example:"2024-01-31"`
+	Timezone string `form:"timezone" binding:"omitempty,timezone" example:"Asia/Jakarta"`
+	Format   string `form:"format" binding:"omitempty,oneof=json csv" example:"json"`
+}
+
+// toFilter is a helper function to turn the dates of a report request into a range in its timezone
+// covering the sales of a store, or of every store when storeID is zero
+func (req *reportRequest) toFilter(storeID uint64) (*models.ReportFilter, error) {
+	location := time.UTC
+	if req.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(req.Timezone)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	from, err := time.ParseInLocation(time.DateOnly, req.From, location)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := time.ParseInLocation(time.DateOnly, req.To, location)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ReportFilter{
+		From: from,
+		// the end date is included, so the range ends when the day after it begins
+		To:      to.AddDate(0, 0, 1),
+		StoreID: storeID,
+	}, nil
+}
+
+// csvFilename is a helper function to name the CSV export of a report
+func (req *reportRequest) csvFilename(report string) string {
+	return fmt.Sprintf("%s_%s_%s.csv", report, req.From, req.To)
+}
+
+// revenueReportRequest represents the query of the revenue report
+type revenueReportRequest struct {
+	reportRequest
+	Period string `form:"period" binding:"required,oneof=day week month" example:"day"`
+}
+
+// RevenueReport godoc
+//
+//	@Summary		Revenue report
+//	@Description	Revenue and number of orders of every day, week or month in a range of dates, weeks start on monday
+//	@Tags			Reports
+//	@Produce		json,text/csv
+//	@Param			from		query		string			true	"First date"	format(date)
+//	@Param			to			query		string			true	"Last date"		format(date)
+//	@Param			timezone	query		string			false	"IANA timezone, UTC by default"
+//	@Param			period		query		string			true	"Period"		Enums(day, week, month)
+//	@Param			format		query		string			false	"Format"		Enums(json, csv)
+//	@Success		200			{array}		revenueReportResponse	"Revenue report displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/reports/revenue [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) RevenueReport(ctx *gin.Context) {
+	var req revenueReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter(GetStoreID(ctx, _constant.StoreIDKey))
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	revenue, err := rh.svc.RevenueByPeriod(ctx, filter, models.ReportPeriod(req.Period))
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := make([]utils.RevenueReportResponse, len(revenue))
+	for i, row := range revenue {
+		rsp[i] = utils.NewRevenueReportResponse(&row)
+	}
+
+	if req.Format == "csv" {
+		records := [][]string{{"start", "orders", "revenue"}}
+		for _, row := range rsp {
+			records = append(records, []string{
+				row.Start,
+				strconv.FormatInt(row.Orders, 10),
+				strconv.FormatInt(row.Revenue, 10),
+			})
+		}
+		utils.HandleCSV(ctx, req.csvFilename("revenue"), records)
+		return
+	}
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// topProductsReportRequest represents the query of the top products report
+type topProductsReportRequest struct {
+	reportRequest
+	Limit uint64 `form:"limit" binding:"omitempty,min=1,max=100" example:"10"`
+}
+
+// TopProductsReport godoc
+//
+//	@Summary		Top products report
+//	@Description	Best selling products by revenue in a range of dates
+//	@Tags			Reports
+//	@Produce		json,text/csv
+//	@Param			from		query		string			true	"First date"	format(date)
+//	@Param			to			query		string			true	"Last date"		format(date)
+//	@Param			timezone	query		string			false	"IANA timezone, UTC by default"
+//	@Param			limit		query		uint64			false	"Number of products, 10 by default"
+//	@Param			format		query		string			false	"Format"		Enums(json, csv)
+//	@Success		200			{array}		productSalesReportResponse	"Top products report displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/reports/top-products [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) TopProductsReport(ctx *gin.Context) {
+	var req topProductsReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	filter, err := req.toFilter(GetStoreID(ctx, _constant.StoreIDKey))
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	products, err := rh.svc.TopProducts(ctx, filter, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := make([]utils.ProductSalesReportResponse, len(products))
+	for i, row := range products {
+		rsp[i] = utils.NewProductSalesReportResponse(&row)
+	}
+
+	if req.Format == "csv" {
+		records := [][]string{{"product_id", "sku", "name", "quantity", "revenue"}}
+		for _, row := range rsp {
+			records = append(records, []string{
+				strconv.FormatUint(row.ProductID, 10),
+				row.SKU,
+				row.Name,
+				strconv.FormatInt(row.Quantity, 10),
+				strconv.FormatInt(row.Revenue, 10),
+			})
+		}
+		utils.HandleCSV(ctx, req.csvFilename("top_products"), records)
+		return
+	}
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// CashiersReport godoc
+//
+//	@Summary		Sales per cashier report
+//	@Description	Revenue and number of orders rung up by each cashier in a range of dates
+//	@Tags			Reports
+//	@Produce		json,text/csv
+//	@Param			from		query		string			true	"First date"	format(date)
+//	@Param			to			query		string			true	"Last date"		format(date)
+//	@Param			timezone	query		string			false	"IANA timezone, UTC by default"
+//	@Param			format		query		string			false	"Format"		Enums(json, csv)
+//	@Success		200			{array}		cashierSalesReportResponse	"Sales per cashier report displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/reports/cashiers [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) CashiersReport(ctx *gin.Context) {
+	var req reportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter(GetStoreID(ctx, _constant.StoreIDKey))
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	cashiers, err := rh.svc.SalesByCashier(ctx, filter)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := make([]utils.CashierSalesReportResponse, len(cashiers))
+	for i, row := range cashiers {
+		rsp[i] = utils.NewCashierSalesReportResponse(&row)
+	}
+
+	if req.Format == "csv" {
+		records := [][]string{{"user_id", "name", "orders", "revenue"}}
+		for _, row := range rsp {
+			records = append(records, []string{
+				strconv.FormatUint(row.UserID, 10),
+				row.Name,
+				strconv.FormatInt(row.Orders, 10),
+				strconv.FormatInt(row.Revenue, 10),
+			})
+		}
+		utils.HandleCSV(ctx, req.csvFilename("cashiers"), records)
+		return
+	}
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// PaymentMethodsReport godoc
+//
+//	@Summary		Payment methods report
+//	@Description	Revenue and number of orders paid with each payment method in a range of dates
+//	@Tags			Reports
+//	@Produce		json,text/csv
+//	@Param			from		query		string			true	"First date"	format(date)
+//	@Param			to			query		string			true	"Last date"		format(date)
+//	@Param			timezone	query		string			false	"IANA timezone, UTC by default"
+//	@Param			format		query		string			false	"Format"		Enums(json, csv)
+//	@Success		200			{array}		paymentMethodReportResponse	"Payment methods report displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/reports/payment-methods [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) PaymentMethodsReport(ctx *gin.Context) {
+	var req reportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter(GetStoreID(ctx, _constant.StoreIDKey))
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	methods, err := rh.svc.SalesByPaymentMethod(ctx, filter)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := make([]utils.PaymentMethodReportResponse, len(methods))
+	for i, row := range methods {
+		rsp[i] = utils.NewPaymentMethodReportResponse(&row)
+	}
+
+	if req.Format == "csv" {
+		records := [][]string{{"payment_method", "orders", "revenue"}}
+		for _, row := range rsp {
+			records = append(records, []string{
+				row.PaymentMethod,
+				strconv.FormatInt(row.Orders, 10),
+				strconv.FormatInt(row.Revenue, 10),
+			})
+		}
+		utils.HandleCSV(ctx, req.csvFilename("payment_methods"), records)
+		return
+	}
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// DiscountsReport godoc
+//
+//	@Summary		Discounts report
+//	@Description	Amount taken off and number of orders discounted by each promotion in a range of dates
+//	@Tags			Reports
+//	@Produce		json,text/csv
+//	@Param			from		query		string			true	"First date"	format(date)
+//	@Param			to			query		string			true	"Last date"		format(date)
+//	@Param			timezone	query		string			false	"IANA timezone, UTC by default"
+//	@Param			format		query		string			false	"Format"		Enums(json, csv)
+//	@Success		200			{array}		discountReportResponse	"Discounts report displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/reports/discounts [get]
+//	@Security		BearerAuth
+func (rh *ReportHandler) DiscountsReport(ctx *gin.Context) {
+	var req reportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	filter, err := req.toFilter(GetStoreID(ctx, _constant.StoreIDKey))
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	discounts, err := rh.svc.Discounts(ctx, filter)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := make([]utils.DiscountReportResponse, len(discounts))
+	for i, row := range discounts {
+		rsp[i] = utils.NewDiscountReportResponse(&row)
+	}
+
+	if req.Format == "csv" {
+		records := [][]string{{"promotion_id", "name", "coupon_code", "orders", "amount"}}
+		for _, row := range rsp {
+			records = append(records, []string{
+				strconv.FormatUint(row.PromotionID, 10),
+				row.Name,
+				row.CouponCode,
+				strconv.FormatInt(row.Orders, 10),
+				strconv.FormatInt(row.Amount, 10),
+			})
+		}
+		utils.HandleCSV(ctx, req.csvFilename("discounts"), records)
+		return
+	}
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var ReportModule = fx.Module(
+	"report-handler-module",
+	fx.Provide(NewReportHandler),
+)
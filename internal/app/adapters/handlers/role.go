@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// RoleHandler represents the HTTP handlers for role-related requests
+type RoleHandler struct {
+	svc ports.RoleService
+}
+
+// NewRoleHandler creates a new RoleHandler instance
+func NewRoleHandler(svc ports.RoleService) *RoleHandler {
+	return &RoleHandler{
+		svc,
+	}
+}
+
+// createRoleRequest represents the request body for creating a role
+type createRoleRequest struct {
+	Name        models.UserRole `json:"name" binding:"required,user_role" example:"store_manager"`
+	Description string          `json:"description" example:"Manages a single store"`
+}
+
+// CreateRole godoc
+//
+//	@Summary		Create a new role
+//	@Description	create a new role that users can be assigned to
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			createRoleRequest	body		createRoleRequest	true	"Create role request"
+//	@Success		200					{object}	roleResponse		"Role created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/roles [post]
+//	@Security		BearerAuth
+func (rh *RoleHandler) CreateRole(ctx *gin.Context) {
+	var req createRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	role := models.Role{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	_, err := rh.svc.CreateRole(ctx, &role)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewRoleResponse(&role)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listRolesRequest represents the request body for listing roles
+type listRolesRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListRoles godoc
+//
+//	@Summary		List roles
+//	@Description	List roles with pagination
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Roles displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/roles [get]
+//	@Security		BearerAuth
+func (rh *RoleHandler) ListRoles(ctx *gin.Context) {
+	var req listRolesRequest
+	var rolesList []utils.RoleResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	roles, err := rh.svc.ListRoles(ctx, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, role := range roles {
+		rolesList = append(rolesList, utils.NewRoleResponse(&role))
+	}
+
+	total := uint64(len(rolesList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, rolesList, "roles")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getRoleRequest represents the request body for getting a role
+type getRoleRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetRole godoc
+//
+//	@Summary		Get a role
+//	@Description	Get a role by id
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Role ID"
+//	@Success		200	{object}	roleResponse	"Role displayed"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/roles/{id} [get]
+//	@Security		BearerAuth
+func (rh *RoleHandler) GetRole(ctx *gin.Context) {
+	var req getRoleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	role, err := rh.svc.GetRole(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewRoleResponse(role)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// updateRoleUriRequest represents the request uri for updating a role
+type updateRoleUriRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// updateRoleRequest represents the request body for updating a role
+type updateRoleRequest struct {
+	Description string `json:"description" binding:"required" example:"Manages a single store"`
+}
+
+// UpdateRole godoc
+//
+//	@Summary		Update a role
+//	@Description	Update a role's description by id, role names cannot be changed
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		uint64				true	"Role ID"
+//	@Param			updateRoleRequest	body		updateRoleRequest	true	"Update role request"
+//	@Success		200					{object}	roleResponse		"Role updated"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/roles/{id} [put]
+//	@Security		BearerAuth
+func (rh *RoleHandler) UpdateRole(ctx *gin.Context) {
+	var uri updateRoleUriRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	var req updateRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	role := models.Role{
+		ID:          uri.ID,
+		Description: req.Description,
+	}
+
+	_, err := rh.svc.UpdateRole(ctx, &role)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewRoleResponse(&role)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// deleteRoleRequest represents the request body for deleting a role
+type deleteRoleRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteRole godoc
+//
+//	@Summary		Delete a role
+//	@Description	Delete a role by id, refused while users are still assigned to it
+//	@Tags			Roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Role ID"
+//	@Success		200	{object}	response		"Role deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Role in use error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/roles/{id} [delete]
+//	@Security		BearerAuth
+func (rh *RoleHandler) DeleteRole(ctx *gin.Context) {
+	var req deleteRoleRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := rh.svc.DeleteRole(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+var RoleModule = fx.Module(
+	"role-handler-module",
+	fx.Provide(NewRoleHandler),
+)
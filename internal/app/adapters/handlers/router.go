@@ -1,17 +1,22 @@
 package handlers
 
 import (
+	"fmt"
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/author"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
 	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
 	"github.com/gin-contrib/cors"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/samber/slog-gin"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"log/slog"
+	"net/url"
 	"os"
+	"path"
 	"strings"
 	"time"
 )
@@ -42,6 +47,27 @@ func NewRouterHandler(
 	token ports.TokenService,
 	userHandler *UserHandler,
 	authHandler *AuthHandler,
+	roleHandler *RoleHandler,
+	groupHandler *GroupHandler,
+	invitationHandler *InvitationHandler,
+	emailChangeHandler *EmailChangeHandler,
+	auditHandler *AuditHandler,
+	avatarHandler *AvatarHandler,
+	preferenceHandler *PreferenceHandler,
+	categoryHandler *CategoryHandler,
+	productHandler *ProductHandler,
+	orderHandler *OrderHandler,
+	stockHandler *StockHandler,
+	reportHandler *ReportHandler,
+	promotionHandler *PromotionHandler,
+	refundHandler *RefundHandler,
+	storeHandler *StoreHandler,
+	receiptHandler *ReceiptHandler,
+	taxHandler *TaxHandler,
+	customerHandler *CustomerHandler,
+	authzHandler *AuthzHandler,
+	tenants ports.TenantService,
+	tenantHandler *TenantHandler,
 ) (*RouterHandler, error) {
 
 	// Disable debug mode in production
@@ -54,9 +80,21 @@ func NewRouterHandler(
 	allowedOrigins := config.HTTP.AllowedOrigins
 	originsList := strings.Split(allowedOrigins, ",")
 	ginConfig.AllowOrigins = originsList
+	ginConfig.AddAllowHeaders("Authorization", "If-Match", "X-Request-ID", "X-Store-ID", "X-Tenant-ID")
+	ginConfig.AddExposeHeaders("ETag", "X-Request-ID")
+
+	// Custom validators
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if ok {
+		if err := v.RegisterValidation("user_role", userRoleValidator); err != nil {
+			return nil, err
+		}
+	}
 
 	router := gin.New()
-	router.Use(sloggin.New(slog.Default()), gin.Recovery(), cors.New(ginConfig))
+	// the services read the tenant, which is kept under a typed key, from the request context
+	router.ContextWithFallback = true
+	router.Use(sloggin.New(slog.Default()), gin.Recovery(), cors.New(ginConfig), RequestMetaMiddleware(), TenantMiddleware(tenants))
 
 	//POLICY
 	casbin.LoadPolicy()
@@ -67,22 +105,203 @@ func NewRouterHandler(
 	router.Use(ginzap.Ginzap(logger, time.RFC3339, true))
 	router.Use(ginzap.RecoveryWithZap(logger, true))
 
+	// Files kept on the local filesystem are served from the path of their public url
+	if config.Storage.Driver == "" || config.Storage.Driver == "local" {
+		publicURL, err := url.Parse(config.Storage.PublicURL)
+		if err != nil {
+			return nil, err
+		}
+		mount := path.Clean("/" + publicURL.Path)
+		if mount == "/" {
+			return nil, fmt.Errorf("storage public url %q must have a path to serve files from", config.Storage.PublicURL)
+		}
+		router.Static(mount, config.Storage.Path)
+	}
+
+	// Routes of resources with an owner, the policies granted to "owner" apply to them
+	owners := Owners{
+		"/v1/users/:id":         userHandler.ResolveOwner,
+		"/v1/users/:id/history": userHandler.ResolveOwner,
+		"/v1/users/:id/avatar":  userHandler.ResolveOwner,
+	}
+
 	v1 := router.Group("/v1")
 	{
 		user := v1.Group("/users")
 		{
-			user.POST("/", userHandler.Register)
+			user.POST("/", RegistrationMiddleware(config.App.OpenRegistration), userHandler.Register)
 			user.POST("/login", authHandler.Login)
+			user.POST("/email/confirm", emailChangeHandler.ConfirmEmailChange)
+			user.POST("/email/revert", emailChangeHandler.RevertEmailChange)
 
-			authUser := user.Group("/").Use(TokenMiddleware(token), RoleMiddleware(casbin))
+			authUser := user.Group("/").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
 			{
 				authUser.GET("/", userHandler.ListUsers)
+				authUser.GET("/me/preferences", preferenceHandler.GetPreferences)
+				authUser.PUT("/me/preferences", preferenceHandler.ReplacePreferences)
+				authUser.PATCH("/me/preferences", preferenceHandler.UpdatePreferences)
 				authUser.GET("/:id", userHandler.GetUser)
+				authUser.PUT("/:id", userHandler.UpdateUser)
+				authUser.PATCH("/:id", userHandler.PatchUser)
+				authUser.DELETE("/:id", userHandler.DeleteUser)
+				authUser.GET("/:id/history", auditHandler.ListUserHistory)
+				authUser.PUT("/:id/avatar", avatarHandler.UploadAvatar)
+				authUser.DELETE("/:id/avatar", avatarHandler.DeleteAvatar)
+			}
+		}
+		role := v1.Group("/roles").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			role.POST("/", roleHandler.CreateRole)
+			role.GET("/", roleHandler.ListRoles)
+			role.GET("/:id", roleHandler.GetRole)
+			role.PUT("/:id", roleHandler.UpdateRole)
+			role.DELETE("/:id", roleHandler.DeleteRole)
+		}
+		group := v1.Group("/groups").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			group.POST("/", groupHandler.CreateGroup)
+			group.GET("/", groupHandler.ListGroups)
+			group.GET("/:id", groupHandler.GetGroup)
+			group.PUT("/:id", groupHandler.UpdateGroup)
+			group.DELETE("/:id", groupHandler.DeleteGroup)
+			group.GET("/:id/members", groupHandler.ListMembers)
+			group.POST("/:id/members", groupHandler.AddMember)
+			group.DELETE("/:id/members/:user_id", groupHandler.RemoveMember)
+			group.GET("/:id/roles", groupHandler.ListRoles)
+			group.POST("/:id/roles", groupHandler.AssignRole)
+			group.DELETE("/:id/roles/:role", groupHandler.UnassignRole)
+		}
+		category := v1.Group("/categories").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			category.POST("/", categoryHandler.CreateCategory)
+			category.GET("/", categoryHandler.ListCategories)
+			category.GET("/:id", categoryHandler.GetCategory)
+			category.PUT("/:id", categoryHandler.UpdateCategory)
+			category.DELETE("/:id", categoryHandler.DeleteCategory)
+		}
+		product := v1.Group("/products").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners), StoreMiddleware(casbin))
+		{
+			product.POST("/", productHandler.CreateProduct)
+			product.GET("/", productHandler.ListProducts)
+			product.GET("/:id", productHandler.GetProduct)
+			product.PUT("/:id", productHandler.UpdateProduct)
+			product.DELETE("/:id", productHandler.DeleteProduct)
+		}
+		order := v1.Group("/orders").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners), StoreMiddleware(casbin))
+		{
+			order.POST("/", orderHandler.CreateOrder)
+			order.GET("/", orderHandler.ListOrders)
+			order.GET("/:id", orderHandler.GetOrder)
+			order.GET("/:id/receipt", receiptHandler.GetReceipt)
+			order.POST("/:id/settle", orderHandler.SettleOrder)
+		}
+		stock := v1.Group("/stock-movements").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners), StoreMiddleware(casbin))
+		{
+			stock.POST("/", stockHandler.RecordStockMovement)
+			stock.GET("/", stockHandler.ListStockMovements)
+		}
+		report := v1.Group("/reports").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners), StoreMiddleware(casbin))
+		{
+			report.GET("/revenue", reportHandler.RevenueReport)
+			report.GET("/top-products", reportHandler.TopProductsReport)
+			report.GET("/cashiers", reportHandler.CashiersReport)
+			report.GET("/payment-methods", reportHandler.PaymentMethodsReport)
+			report.GET("/discounts", reportHandler.DiscountsReport)
+		}
+		promotion := v1.Group("/promotions").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			promotion.POST("/", promotionHandler.CreatePromotion)
+			promotion.GET("/", promotionHandler.ListPromotions)
+			promotion.GET("/:id", promotionHandler.GetPromotion)
+			promotion.PUT("/:id", promotionHandler.UpdatePromotion)
+			promotion.DELETE("/:id", promotionHandler.DeletePromotion)
+		}
+		refund := v1.Group("/refunds").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners), StoreMiddleware(casbin))
+		{
+			refund.POST("/", refundHandler.RefundOrder)
+			refund.POST("/void", refundHandler.VoidOrder)
+			refund.GET("/", refundHandler.ListRefunds)
+			refund.GET("/:id", refundHandler.GetRefund)
+		}
+		store := v1.Group("/stores").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			store.POST("/", storeHandler.CreateStore)
+			store.GET("/", storeHandler.ListStores)
+			store.GET("/:id", storeHandler.GetStore)
+			store.PUT("/:id", storeHandler.UpdateStore)
+			store.DELETE("/:id", storeHandler.DeleteStore)
+			store.GET("/:id/users", storeHandler.ListUsers)
+			store.POST("/:id/users", storeHandler.AssignUser)
+			store.DELETE("/:id/users/:user_id", storeHandler.UnassignUser)
+		}
+		taxClass := v1.Group("/tax-classes").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			taxClass.POST("/", taxHandler.CreateTaxClass)
+			taxClass.GET("/", taxHandler.ListTaxClasses)
+			taxClass.GET("/:id", taxHandler.GetTaxClass)
+			taxClass.PUT("/:id", taxHandler.UpdateTaxClass)
+			taxClass.DELETE("/:id", taxHandler.DeleteTaxClass)
+		}
+		taxRate := v1.Group("/tax-rates").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			taxRate.POST("/", taxHandler.CreateTaxRate)
+			taxRate.GET("/", taxHandler.ListTaxRates)
+			taxRate.GET("/:id", taxHandler.GetTaxRate)
+			taxRate.PUT("/:id", taxHandler.UpdateTaxRate)
+			taxRate.DELETE("/:id", taxHandler.DeleteTaxRate)
+		}
+		customer := v1.Group("/customers").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			customer.POST("/", customerHandler.CreateCustomer)
+			customer.GET("/", customerHandler.ListCustomers)
+			customer.GET("/:id", customerHandler.GetCustomer)
+			customer.PUT("/:id", customerHandler.UpdateCustomer)
+			customer.DELETE("/:id", customerHandler.DeleteCustomer)
+			customer.GET("/:id/orders", customerHandler.ListOrders)
+			customer.GET("/:id/points", customerHandler.ListLoyaltyEntries)
+			customer.POST("/:id/points", customerHandler.AdjustPoints)
+		}
+		authz := v1.Group("/authz").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			authz.GET("/policies", authzHandler.ListPolicies)
+			authz.POST("/policies", authzHandler.AddPolicy)
+			authz.DELETE("/policies", authzHandler.RemovePolicy)
+			authz.GET("/groupings", authzHandler.ListGroupings)
+			authz.POST("/groupings", authzHandler.AddGrouping)
+			authz.DELETE("/groupings", authzHandler.RemoveGrouping)
+			authz.GET("/roles", authzHandler.GetSubjectRoles)
+			authz.POST("/check", authzHandler.CheckRequest)
+		}
+		tenant := v1.Group("/tenants").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+		{
+			tenant.GET("/", tenantHandler.ListTenants)
+			tenant.POST("/", tenantHandler.CreateTenant)
+			tenant.GET("/:id", tenantHandler.GetTenant)
+		}
+		invitation := v1.Group("/invitations")
+		{
+			invitation.POST("/accept", invitationHandler.AcceptInvitation)
 
+			authInvitation := invitation.Group("/").Use(TokenMiddleware(token), RoleMiddleware(casbin, owners))
+			{
+				authInvitation.POST("/", invitationHandler.Invite)
+				authInvitation.GET("/", invitationHandler.ListInvitations)
+				authInvitation.DELETE("/:id", invitationHandler.RevokeInvitation)
 			}
 		}
 	}
 
+	// An owner declared for a route that is not registered would never be resolved
+	registered := make(map[string]bool)
+	for _, route := range router.Routes() {
+		registered[route.Path] = true
+	}
+	for route := range owners {
+		if !registered[route] {
+			return nil, fmt.Errorf("owner resolver declared for unknown route %q", route)
+		}
+	}
+
 	return &RouterHandler{
 		router,
 	}, nil
@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/author"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_casbin "github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// modelMigration is the migration that sets the casbin model the matcher is tested with
+const modelMigration = "../storages/db/postgres/migrations/000025_add_casbin_domains.up.sql"
+
+// policyCasesPath is the cases file `task policy:test` checks the seeded policy against
+const policyCasesPath = "../../../../policy_cases.csv"
+
+// publicRoutes are the routes served without a token, the policy is never asked about them
+var publicRoutes = map[string]bool{
+	"POST /v1/users/":              true,
+	"POST /v1/users/login":         true,
+	"POST /v1/users/email/confirm": true,
+	"POST /v1/users/email/revert":  true,
+	"POST /v1/invitations/accept":  true,
+}
+
+// loadModel reads the model text from the migration, so that the tests run against the model that ships
+func loadModel(t *testing.T) model.Model {
+	t.Helper()
+
+	migration, err := os.ReadFile(modelMigration)
+	require.NoError(t, err)
+
+	_, text, found := strings.Cut(string(migration), "model_text = '")
+	require.True(t, found, "model text not found in %s", modelMigration)
+	text, _, found = strings.Cut(text, "'")
+	require.True(t, found, "model text not closed in %s", modelMigration)
+
+	m, err := model.NewModelFromString(text)
+	require.NoError(t, err)
+
+	return m
+}
+
+// newTestEnforcer creates an enforcer with the shipped model and an empty policy
+func newTestEnforcer(t *testing.T) *author.CasbinConfig {
+	t.Helper()
+
+	policyPath := filepath.Join(t.TempDir(), "policy.csv")
+	require.NoError(t, os.WriteFile(policyPath, nil, 0o600))
+
+	enforcer, err := _casbin.NewSyncedEnforcer(loadModel(t), fileadapter.NewAdapter(policyPath))
+	require.NoError(t, err)
+
+	return &author.CasbinConfig{Enforcer: enforcer}
+}
+
+// newSeededEnforcer creates an enforcer with the model and policy the migrations seed in the database
+// of the environment, the test is skipped without one
+func newSeededEnforcer(t *testing.T) *author.CasbinConfig {
+	t.Helper()
+
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST is not set")
+	}
+
+	config := &configs.DB{
+		Connection: os.Getenv("DB_CONNECTION"),
+		Host:       os.Getenv("DB_HOST"),
+		Port:       os.Getenv("DB_PORT"),
+		User:       os.Getenv("DB_USER"),
+		Password:   os.Getenv("DB_PASSWORD"),
+		Name:       os.Getenv("DB_NAME"),
+		DSN:        os.Getenv("DB_DSN"),
+		DriverName: os.Getenv("DB_DRIVER_NAME"),
+	}
+
+	db, err := postgres.NewConnection(context.Background(), config)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+
+	schema, err := postgres.MigrateSchema(db)
+	require.NoError(t, err)
+
+	casbin, err := author.NewCasbinConfig(config, schema)
+	require.NoError(t, err)
+
+	return casbin
+}
+
+// apiRoutes registers every route of the router and returns the ones of the API
+func apiRoutes(t *testing.T, casbin *author.CasbinConfig) gin.RoutesInfo {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	config := &configs.Container{
+		App:     &configs.App{},
+		HTTP:    &configs.HTTP{AllowedOrigins: "http://127.0.0.1:5173"},
+		Storage: &configs.Storage{Path: t.TempDir(), PublicURL: "http://127.0.0.1:8080/uploads"},
+	}
+
+	router, err := NewRouterHandler(
+		config, casbin, nil,
+		// the handlers are not called while the routes are registered
+		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil,
+	)
+	require.NoError(t, err)
+
+	var routes gin.RoutesInfo
+	for _, route := range router.Routes() {
+		if strings.HasPrefix(route.Path, "/v1/") {
+			routes = append(routes, route)
+		}
+	}
+	require.NotEmpty(t, routes)
+
+	return routes
+}
+
+// testDomain is the domain of the tenant the requests of the tests are made in
+var testDomain = models.TenantDomain(models.DefaultTenantID)
+
+// requestPath fills the parameters of a route in, as a request for it would
+func requestPath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "42"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func TestRouter_MatcherCoversEveryRoute(t *testing.T) {
+	casbin := newTestEnforcer(t)
+	routes := apiRoutes(t, casbin)
+	enforcer := casbin.Enforcer
+
+	// a policy written as a gin route allows the requests of that route, and of no other
+	for _, route := range routes {
+		sub := models.UserSubject(1)
+		role := fmt.Sprintf("role:%s:%s", route.Method, route.Path)
+
+		_, err := enforcer.AddGroupingPolicy(sub, role, testDomain)
+		require.NoError(t, err)
+		_, err = enforcer.AddPolicy(role, models.AnyDomain, route.Path, route.Method)
+		require.NoError(t, err)
+
+		for _, other := range routes {
+			allowed, err := enforcer.Enforce(sub, testDomain, requestPath(other.Path), other.Method, "")
+			require.NoError(t, err)
+
+			expected := other.Method == route.Method && other.Path == route.Path
+			assert.Equal(t, expected, allowed, "policy %s %s on request %s %s", route.Method, route.Path, other.Method, requestPath(other.Path))
+		}
+
+		_, err = enforcer.RemoveGroupingPolicy(sub, role, testDomain)
+		require.NoError(t, err)
+	}
+}
+
+func TestRouter_PolicyCasesCoverEveryRoute(t *testing.T) {
+	routes := apiRoutes(t, newTestEnforcer(t))
+
+	file, err := os.Open(policyCasesPath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	cases, err := author.ReadPolicyCases(file, testDomain)
+	require.NoError(t, err)
+
+	allowed := make(map[string]bool)
+	for _, c := range cases {
+		if c.Expected {
+			allowed[c.Request.Action+" "+c.Request.Object] = true
+		}
+	}
+
+	// a route the seeded policy allows no one is unreachable, the cases keep it from going unnoticed
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		if publicRoutes[key] {
+			continue
+		}
+		assert.True(t, allowed[route.Method+" "+requestPath(route.Path)], "no allowed case in %s for route %s", policyCasesPath, key)
+	}
+}
+
+func TestRouter_SeededPolicyCoversEveryRoute(t *testing.T) {
+	casbin := newSeededEnforcer(t)
+	routes := apiRoutes(t, casbin)
+	enforcer := casbin.Enforcer
+
+	// the cases hold against the policy as seeded, not only against the files they were written for
+	file, err := os.Open(policyCasesPath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	cases, err := author.ReadPolicyCases(file, testDomain)
+	require.NoError(t, err)
+
+	for _, result := range author.RunPolicyCases(enforcer, cases) {
+		assert.True(t, result.Passed(), "case on line %d of %s failed", result.Case.Line, policyCasesPath)
+	}
+
+	// and every guarded route is allowed to one of the seeded roles, or to the owner of its resource
+	roles, err := enforcer.GetAllSubjects()
+	require.NoError(t, err)
+	require.NotEmpty(t, roles)
+
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		if publicRoutes[key] {
+			continue
+		}
+
+		allowed := false
+		for _, role := range roles {
+			allowed, err = enforcer.Enforce(role, testDomain, requestPath(route.Path), route.Method, "")
+			require.NoError(t, err)
+			if allowed {
+				break
+			}
+		}
+		assert.True(t, allowed, "no seeded role is allowed route %s", key)
+	}
+}
+
+func TestRouter_MatcherMethods(t *testing.T) {
+	casbin := newTestEnforcer(t)
+	enforcer := casbin.Enforcer
+
+	_, err := enforcer.AddPolicy("manager", models.AnyDomain, "/v1/customers/:id", "GET|PUT")
+	require.NoError(t, err)
+	_, err = enforcer.AddPolicy("admin", models.AnyDomain, "/v1/groups/*", ".*")
+	require.NoError(t, err)
+	_, err = enforcer.AddPolicy("admin", models.AnyDomain, models.AllStoresObject, models.AllStoresAction)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		sub      string
+		obj      string
+		act      string
+		expected bool
+	}{
+		{desc: "Alternative_First", sub: "manager", obj: "/v1/customers/42", act: "GET", expected: true},
+		{desc: "Alternative_Second", sub: "manager", obj: "/v1/customers/42", act: "PUT", expected: true},
+		{desc: "Alternative_Other", sub: "manager", obj: "/v1/customers/42", act: "DELETE", expected: false},
+		// the regex is anchored, a method only partly matching is refused
+		{desc: "Alternative_Prefix", sub: "manager", obj: "/v1/customers/42", act: "GETS", expected: false},
+		{desc: "Param_NestedPath", sub: "manager", obj: "/v1/customers/42/points", act: "GET", expected: false},
+		{desc: "Glob_Collection", sub: "admin", obj: "/v1/groups/", act: "POST", expected: true},
+		{desc: "Glob_NestedPath", sub: "admin", obj: "/v1/groups/42/members/7", act: "DELETE", expected: true},
+		{desc: "Glob_OtherPrefix", sub: "admin", obj: "/v1/groupsx/42", act: "GET", expected: false},
+		{desc: "AllStores", sub: "admin", obj: models.AllStoresObject, act: models.AllStoresAction, expected: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			allowed, err := enforcer.Enforce(tc.sub, testDomain, tc.obj, tc.act, "")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, allowed, "Decision mismatch")
+		})
+	}
+}
+
+func TestRouter_MatcherOwnership(t *testing.T) {
+	casbin := newTestEnforcer(t)
+	enforcer := casbin.Enforcer
+
+	_, err := enforcer.AddPolicy(models.OwnerSubject, models.AnyDomain, "/v1/users/:id", "GET|PUT|PATCH")
+	require.NoError(t, err)
+	_, err = enforcer.AddPolicy("admin", models.AnyDomain, "/v1/users/:id", "DELETE")
+	require.NoError(t, err)
+	_, err = enforcer.AddGroupingPolicy(models.UserSubject(1), "admin", testDomain)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		sub      string
+		act      string
+		owner    string
+		expected bool
+	}{
+		{desc: "Owner", sub: models.UserSubject(42), act: "PATCH", owner: models.UserSubject(42), expected: true},
+		{desc: "Owner_OtherAction", sub: models.UserSubject(42), act: "DELETE", owner: models.UserSubject(42), expected: false},
+		{desc: "OtherUser", sub: models.UserSubject(7), act: "GET", owner: models.UserSubject(42), expected: false},
+		{desc: "NoOwner", sub: models.UserSubject(42), act: "GET", owner: "", expected: false},
+		// the owner rules add to the roles, they do not replace them
+		{desc: "Role_NotOwner", sub: models.UserSubject(1), act: "DELETE", owner: models.UserSubject(42), expected: true},
+		{desc: "Role_NotAllowed", sub: models.UserSubject(1), act: "GET", owner: models.UserSubject(42), expected: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			allowed, err := enforcer.Enforce(tc.sub, testDomain, "/v1/users/42", tc.act, tc.owner)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, allowed, "Decision mismatch")
+		})
+	}
+}
+
+func TestRouter_MatcherDomains(t *testing.T) {
+	casbin := newTestEnforcer(t)
+	enforcer := casbin.Enforcer
+
+	otherDomain := models.TenantDomain(2)
+
+	_, err := enforcer.AddPolicy("admin", models.AnyDomain, "/v1/users/", "GET")
+	require.NoError(t, err)
+	_, err = enforcer.AddPolicy("admin", testDomain, "/v1/tenants/", "GET|POST")
+	require.NoError(t, err)
+	_, err = enforcer.AddPolicy("auditor", otherDomain, "/v1/audit/", "GET")
+	require.NoError(t, err)
+	_, err = enforcer.AddGroupingPolicy(models.UserSubject(1), "admin", testDomain)
+	require.NoError(t, err)
+	_, err = enforcer.AddGroupingPolicy(models.UserSubject(2), "admin", otherDomain)
+	require.NoError(t, err)
+	_, err = enforcer.AddGroupingPolicy(models.UserSubject(2), "auditor", otherDomain)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		sub      string
+		dom      string
+		obj      string
+		act      string
+		expected bool
+	}{
+		{desc: "AnyDomain", sub: models.UserSubject(1), dom: testDomain, obj: "/v1/users/", act: "GET", expected: true},
+		{desc: "AnyDomain_OtherTenant", sub: models.UserSubject(2), dom: otherDomain, obj: "/v1/users/", act: "GET", expected: true},
+		{desc: "OwnDomain", sub: models.UserSubject(1), dom: testDomain, obj: "/v1/tenants/", act: "POST", expected: true},
+		// a permission of one tenant is not one of the others, even for a role of the same name
+		{desc: "OtherDomainPolicy", sub: models.UserSubject(2), dom: otherDomain, obj: "/v1/tenants/", act: "POST", expected: false},
+		{desc: "OtherDomainPolicy_OwnRole", sub: models.UserSubject(2), dom: otherDomain, obj: "/v1/audit/", act: "GET", expected: true},
+		// the roles a user has in one tenant do not follow them into another
+		{desc: "RoleOfOtherDomain", sub: models.UserSubject(1), dom: otherDomain, obj: "/v1/users/", act: "GET", expected: false},
+		{desc: "RoleOfOtherDomain_Reverse", sub: models.UserSubject(2), dom: testDomain, obj: "/v1/audit/", act: "GET", expected: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			allowed, err := enforcer.Enforce(tc.sub, tc.dom, tc.obj, tc.act, "")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, allowed, "Decision mismatch")
+		})
+	}
+}
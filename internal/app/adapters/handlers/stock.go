@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// StockHandler represents the HTTP handlers for stock-related requests
+type StockHandler struct {
+	svc ports.StockService
+}
+
+// NewStockHandler creates a new StockHandler instance
+func NewStockHandler(svc ports.StockService) *StockHandler {
+	return &StockHandler{
+		svc,
+	}
+}
+
+// recordStockMovementRequest represents the request body for recording a stock movement,
+// the quantity is signed, restocks and returns add stock and adjustments may remove it. A transfer
+// moves its quantity out of the store of the request into the to_store_id one
+type recordStockMovementRequest struct {
+	ProductID uint64 `json:"product_id" binding:"required,min=1" example:"1"`
+	Type      string `json:"type" binding:"required,oneof=restock return adjustment transfer" example:"adjustment"`
+	Quantity  int64  `json:"quantity" binding:"required" example:"-2"`
+	Reason    string `json:"reason" binding:"required,max=255" example:"damaged in storage"`
+	ToStoreID uint64 `json:"to_store_id" binding:"required_if=Type transfer" example:"2"`
+}
+
+// RecordStockMovement godoc
+//
+//	@Summary		Record a stock movement
+//	@Description	append a restock, return, adjustment or transfer to the stock ledger of a product and apply it to its stock. A transfer appends the movements out of the store and into the other one together, and returns the one out of the store
+//	@Tags			Stock
+//	@Accept			json
+//	@Produce		json
+//	@Param			recordStockMovementRequest	body		recordStockMovementRequest	true	"Record stock movement request"
+//	@Success		200							{object}	stockMovementResponse		"Stock movement recorded"
+//	@Failure		400							{object}	errorResponse				"Validation or insufficient stock error"
+//	@Failure		401							{object}	errorResponse				"Unauthorized error"
+//	@Failure		403							{object}	errorResponse				"Forbidden error"
+//	@Failure		500							{object}	errorResponse				"Internal server error"
+//	@Router			/stock-movements [post]
+//	@Security		BearerAuth
+func (sh *StockHandler) RecordStockMovement(ctx *gin.Context) {
+	var req recordStockMovementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	payload := GetAuthPayload(ctx, _constant.AuthorizationPayloadKey)
+
+	movement := models.StockMovement{
+		ProductID: req.ProductID,
+		Type:      models.StockMovementType(req.Type),
+		Quantity:  req.Quantity,
+		Reason:    req.Reason,
+		UserID:    &payload.UserID,
+		StoreID:   GetStoreID(ctx, _constant.StoreIDKey),
+		ToStoreID: req.ToStoreID,
+	}
+
+	recordedMovement, err := sh.svc.RecordMovement(ctx, &movement)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewStockMovementResponse(recordedMovement)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listStockMovementsRequest represents the request body for listing stock movements
+type listStockMovementsRequest struct {
+	Skip      uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit     uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	ProductID uint64 `form:"product_id" binding:"omitempty,min=1" example:"1"`
+	Type      string `form:"type" binding:"omitempty,oneof=sale restock return adjustment transfer" example:"sale"`
+}
+
+// ListStockMovements godoc
+//
+//	@Summary		List stock movements
+//	@Description	List the stock ledger with pagination, the newest first, optionally of a product or of a type
+//	@Tags			Stock
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip		query		uint64			true	"Skip"
+//	@Param			limit		query		uint64			true	"Limit"
+//	@Param			product_id	query		uint64			false	"Product ID"
+//	@Param			type		query		string			false	"Type"	Enums(sale, restock, return, adjustment, transfer)
+//	@Success		200			{object}	meta			"Stock movements displayed"
+//	@Failure		400			{object}	errorResponse	"Validation error"
+//	@Failure		401			{object}	errorResponse	"Unauthorized error"
+//	@Failure		403			{object}	errorResponse	"Forbidden error"
+//	@Failure		500			{object}	errorResponse	"Internal server error"
+//	@Router			/stock-movements [get]
+//	@Security		BearerAuth
+func (sh *StockHandler) ListStockMovements(ctx *gin.Context) {
+	var req listStockMovementsRequest
+	var movementsList []utils.StockMovementResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	filter := models.StockMovementFilter{
+		ProductID: req.ProductID,
+		Type:      models.StockMovementType(req.Type),
+		StoreID:   GetStoreID(ctx, _constant.StoreIDKey),
+	}
+
+	movements, err := sh.svc.ListMovements(ctx, &filter, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, movement := range movements {
+		movementsList = append(movementsList, utils.NewStockMovementResponse(&movement))
+	}
+
+	total := uint64(len(movementsList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, movementsList, "stock_movements")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var StockModule = fx.Module(
+	"stock-handler-module",
+	fx.Provide(NewStockHandler),
+)
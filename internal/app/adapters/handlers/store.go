@@ -0,0 +1,393 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// StoreHandler represents the HTTP handlers for store-related requests
+type StoreHandler struct {
+	svc ports.StoreService
+}
+
+// NewStoreHandler creates a new StoreHandler instance
+func NewStoreHandler(svc ports.StoreService) *StoreHandler {
+	return &StoreHandler{
+		svc,
+	}
+}
+
+// storeRequest represents the request body for creating or replacing a store, the region picks
+// the tax rates of the region and taxes are rounded per line unless per invoice
+type storeRequest struct {
+	Name        string `json:"name" binding:"required,max=128" example:"Main store"`
+	Address     string `json:"address" binding:"omitempty,max=255" example:"Jl. Sudirman No. 1, Jakarta"`
+	Region      string `json:"region" binding:"omitempty,max=64" example:"ID-JK"`
+	TaxRounding string `json:"tax_rounding" binding:"omitempty,oneof=line invoice" example:"line"`
+}
+
+// CreateStore godoc
+//
+//	@Summary		Create a new store
+//	@Description	create a new store with a unique name
+//	@Tags			Stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			storeRequest	body		storeRequest	true	"Create store request"
+//	@Success		200				{object}	storeResponse	"Store created"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		409				{object}	errorResponse	"Data conflict error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/stores [post]
+//	@Security		BearerAuth
+func (sh *StoreHandler) CreateStore(ctx *gin.Context) {
+	var req storeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	store := models.Store{
+		Name:        req.Name,
+		Address:     req.Address,
+		Region:      req.Region,
+		TaxRounding: models.TaxRounding(req.TaxRounding),
+	}
+
+	_, err := sh.svc.CreateStore(ctx, &store)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewStoreResponse(&store)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listStoresRequest represents the request body for listing stores
+type listStoresRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListStores godoc
+//
+//	@Summary		List stores
+//	@Description	List stores with pagination
+//	@Tags			Stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Stores displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/stores [get]
+//	@Security		BearerAuth
+func (sh *StoreHandler) ListStores(ctx *gin.Context) {
+	var req listStoresRequest
+	var storesList []utils.StoreResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	stores, err := sh.svc.ListStores(ctx, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, store := range stores {
+		storesList = append(storesList, utils.NewStoreResponse(&store))
+	}
+
+	total := uint64(len(storesList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, storesList, "stores")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getStoreRequest represents the request body for getting a store
+type getStoreRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetStore godoc
+//
+//	@Summary		Get a store
+//	@Description	Get a store by id
+//	@Tags			Stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Store ID"
+//	@Success		200	{object}	storeResponse	"Store displayed"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/stores/{id} [get]
+//	@Security		BearerAuth
+func (sh *StoreHandler) GetStore(ctx *gin.Context) {
+	var req getStoreRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	store, err := sh.svc.GetStore(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewStoreResponse(store)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// UpdateStore godoc
+//
+//	@Summary		Update a store
+//	@Description	Replace the name, address, region and tax rounding of a store by id
+//	@Tags			Stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		uint64			true	"Store ID"
+//	@Param			storeRequest	body		storeRequest	true	"Update store request"
+//	@Success		200				{object}	storeResponse	"Store updated"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		404				{object}	errorResponse	"Data not found error"
+//	@Failure		409				{object}	errorResponse	"Data conflict error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/stores/{id} [put]
+//	@Security		BearerAuth
+func (sh *StoreHandler) UpdateStore(ctx *gin.Context) {
+	var req storeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	store := models.Store{
+		ID:          id,
+		Name:        req.Name,
+		Address:     req.Address,
+		Region:      req.Region,
+		TaxRounding: models.TaxRounding(req.TaxRounding),
+	}
+
+	updatedStore, err := sh.svc.UpdateStore(ctx, &store)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewStoreResponse(updatedStore)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// deleteStoreRequest represents the request body for deleting a store
+type deleteStoreRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteStore godoc
+//
+//	@Summary		Delete a store
+//	@Description	Delete a store by id that never placed an order nor held stock, its users are left unassigned
+//	@Tags			Stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Store ID"
+//	@Success		200	{object}	response		"Store deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/stores/{id} [delete]
+//	@Security		BearerAuth
+func (sh *StoreHandler) DeleteStore(ctx *gin.Context) {
+	var req deleteStoreRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := sh.svc.DeleteStore(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// assignStoreUserRequest represents the request body for assigning a user to a store
+type assignStoreUserRequest struct {
+	UserID uint64 `json:"user_id" binding:"required,min=1" example:"1"`
+}
+
+// AssignUser godoc
+//
+//	@Summary		Assign a user to a store
+//	@Description	Assign a user to a store in place of the one they were assigned to, their next token acts on it
+//	@Tags			Stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id						path		uint64					true	"Store ID"
+//	@Param			assignStoreUserRequest	body		assignStoreUserRequest	true	"Assign user request"
+//	@Success		200						{object}	response				"User assigned"
+//	@Failure		400						{object}	errorResponse			"Validation error"
+//	@Failure		401						{object}	errorResponse			"Unauthorized error"
+//	@Failure		403						{object}	errorResponse			"Forbidden error"
+//	@Failure		404						{object}	errorResponse			"Data not found error"
+//	@Failure		500						{object}	errorResponse			"Internal server error"
+//	@Router			/stores/{id}/users [post]
+//	@Security		BearerAuth
+func (sh *StoreHandler) AssignUser(ctx *gin.Context) {
+	var req assignStoreUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err = sh.svc.AssignUser(ctx, id, req.UserID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// listStoreUsersRequest represents the request body for listing the users of a store
+type listStoreUsersRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListUsers godoc
+//
+//	@Summary		List store users
+//	@Description	List the users assigned to a store with pagination
+//	@Tags			Stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Store ID"
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Users displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/stores/{id}/users [get]
+//	@Security		BearerAuth
+func (sh *StoreHandler) ListUsers(ctx *gin.Context) {
+	var req listStoreUsersRequest
+	var usersList []utils.UserResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	users, err := sh.svc.ListUsers(ctx, id, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, user := range users {
+		usersList = append(usersList, utils.NewUserResponse(&user))
+	}
+
+	total := uint64(len(usersList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, usersList, "users")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// unassignStoreUserRequest represents the request body for removing a user from a store
+type unassignStoreUserRequest struct {
+	ID     uint64 `uri:"id" binding:"required,min=1" example:"1"`
+	UserID uint64 `uri:"user_id" binding:"required,min=1" example:"1"`
+}
+
+// UnassignUser godoc
+//
+//	@Summary		Remove a user from a store
+//	@Description	Remove a user from the store they are assigned to, leaving them without one
+//	@Tags			Stores
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		uint64			true	"Store ID"
+//	@Param			user_id	path		uint64			true	"User ID"
+//	@Success		200		{object}	response		"User removed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		404		{object}	errorResponse	"Data not found error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/stores/{id}/users/{user_id} [delete]
+//	@Security		BearerAuth
+func (sh *StoreHandler) UnassignUser(ctx *gin.Context) {
+	var req unassignStoreUserRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := sh.svc.UnassignUser(ctx, req.ID, req.UserID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+var StoreModule = fx.Module(
+	"store-handler-module",
+	fx.Provide(NewStoreHandler),
+)
@@ -0,0 +1,472 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// TaxHandler represents the HTTP handlers for tax-related requests
+type TaxHandler struct {
+	svc ports.TaxService
+}
+
+// NewTaxHandler creates a new TaxHandler instance
+func NewTaxHandler(svc ports.TaxService) *TaxHandler {
+	return &TaxHandler{
+		svc,
+	}
+}
+
+// taxClassRequest represents the request body for creating or renaming a tax class
+type taxClassRequest struct {
+	Name string `json:"name" binding:"required,max=128" example:"Food"`
+}
+
+// CreateTaxClass godoc
+//
+//	@Summary		Create a new tax class
+//	@Description	create a new tax class grouping the products taxed the same way
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			taxClassRequest	body		taxClassRequest		true	"Create tax class request"
+//	@Success		200				{object}	taxClassResponse	"Tax class created"
+//	@Failure		400				{object}	errorResponse		"Validation error"
+//	@Failure		401				{object}	errorResponse		"Unauthorized error"
+//	@Failure		403				{object}	errorResponse		"Forbidden error"
+//	@Failure		409				{object}	errorResponse		"Data conflict error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
+//	@Router			/tax-classes [post]
+//	@Security		BearerAuth
+func (th *TaxHandler) CreateTaxClass(ctx *gin.Context) {
+	var req taxClassRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	class := models.TaxClass{
+		Name: req.Name,
+	}
+
+	_, err := th.svc.CreateTaxClass(ctx, &class)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewTaxClassResponse(&class)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listTaxClassesRequest represents the request body for listing tax classes
+type listTaxClassesRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListTaxClasses godoc
+//
+//	@Summary		List tax classes
+//	@Description	List tax classes with pagination
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Tax classes displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/tax-classes [get]
+//	@Security		BearerAuth
+func (th *TaxHandler) ListTaxClasses(ctx *gin.Context) {
+	var req listTaxClassesRequest
+	var classesList []utils.TaxClassResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	classes, err := th.svc.ListTaxClasses(ctx, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, class := range classes {
+		classesList = append(classesList, utils.NewTaxClassResponse(&class))
+	}
+
+	total := uint64(len(classesList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, classesList, "tax_classes")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getTaxClassRequest represents the request body for getting a tax class
+type getTaxClassRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetTaxClass godoc
+//
+//	@Summary		Get a tax class
+//	@Description	Get a tax class by id
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64				true	"Tax class ID"
+//	@Success		200	{object}	taxClassResponse	"Tax class displayed"
+//	@Failure		400	{object}	errorResponse		"Validation error"
+//	@Failure		401	{object}	errorResponse		"Unauthorized error"
+//	@Failure		403	{object}	errorResponse		"Forbidden error"
+//	@Failure		404	{object}	errorResponse		"Data not found error"
+//	@Failure		500	{object}	errorResponse		"Internal server error"
+//	@Router			/tax-classes/{id} [get]
+//	@Security		BearerAuth
+func (th *TaxHandler) GetTaxClass(ctx *gin.Context) {
+	var req getTaxClassRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	class, err := th.svc.GetTaxClass(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewTaxClassResponse(class)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// UpdateTaxClass godoc
+//
+//	@Summary		Update a tax class
+//	@Description	Rename a tax class by id
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		uint64				true	"Tax class ID"
+//	@Param			taxClassRequest	body		taxClassRequest		true	"Update tax class request"
+//	@Success		200				{object}	taxClassResponse	"Tax class updated"
+//	@Failure		400				{object}	errorResponse		"Validation error"
+//	@Failure		401				{object}	errorResponse		"Unauthorized error"
+//	@Failure		403				{object}	errorResponse		"Forbidden error"
+//	@Failure		404				{object}	errorResponse		"Data not found error"
+//	@Failure		409				{object}	errorResponse		"Data conflict error"
+//	@Failure		500				{object}	errorResponse		"Internal server error"
+//	@Router			/tax-classes/{id} [put]
+//	@Security		BearerAuth
+func (th *TaxHandler) UpdateTaxClass(ctx *gin.Context) {
+	var req taxClassRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	class := models.TaxClass{
+		ID:   id,
+		Name: req.Name,
+	}
+
+	updatedClass, err := th.svc.UpdateTaxClass(ctx, &class)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewTaxClassResponse(updatedClass)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// deleteTaxClassRequest represents the request body for deleting a tax class
+type deleteTaxClassRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteTaxClass godoc
+//
+//	@Summary		Delete a tax class
+//	@Description	Delete a tax class by id with its rates, once no product is in it
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Tax class ID"
+//	@Success		200	{object}	response		"Tax class deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Data conflict error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/tax-classes/{id} [delete]
+//	@Security		BearerAuth
+func (th *TaxHandler) DeleteTaxClass(ctx *gin.Context) {
+	var req deleteTaxClassRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := th.svc.DeleteTaxClass(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+// taxRateRequest represents the request body for creating or replacing a tax rate. The rate is in
+// millionths, so 11% is 110000. A rate of a store applies there, one of a region applies to the
+// stores of the region and one of neither applies everywhere, and it is exclusive unless told otherwise
+type taxRateRequest struct {
+	TaxClassID uint64  `json:"tax_class_id" binding:"required,min=1" example:"1"`
+	Name       string  `json:"name" binding:"required,max=128" example:"VAT"`
+	Rate       int64   `json:"rate" binding:"required,min=1,max=999999" example:"110000"`
+	Inclusive  bool    `json:"inclusive" example:"false"`
+	StoreID    *uint64 `json:"store_id" binding:"omitempty,min=1" example:"1"`
+	Region     string  `json:"region" binding:"omitempty,max=64" example:"ID-JK"`
+}
+
+// toTaxRate is a helper function to map a tax rate request to a tax rate
+func (req *taxRateRequest) toTaxRate(id uint64) models.TaxRate {
+	return models.TaxRate{
+		ID:         id,
+		TaxClassID: req.TaxClassID,
+		Name:       req.Name,
+		Rate:       req.Rate,
+		Inclusive:  req.Inclusive,
+		StoreID:    req.StoreID,
+		Region:     req.Region,
+	}
+}
+
+// CreateTaxRate godoc
+//
+//	@Summary		Create a new tax rate
+//	@Description	create a new tax rate of a tax class, inclusive in the prices or added on top of them
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			taxRateRequest	body		taxRateRequest	true	"Create tax rate request"
+//	@Success		200				{object}	taxRateResponse	"Tax rate created"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/tax-rates [post]
+//	@Security		BearerAuth
+func (th *TaxHandler) CreateTaxRate(ctx *gin.Context) {
+	var req taxRateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	rate := req.toTaxRate(0)
+
+	_, err := th.svc.CreateTaxRate(ctx, &rate)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewTaxRateResponse(&rate)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listTaxRatesRequest represents the request body for listing tax rates
+type listTaxRatesRequest struct {
+	Skip       uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit      uint64 `form:"limit" binding:"required,min=5" example:"5"`
+	TaxClassID uint64 `form:"tax_class_id" binding:"omitempty,min=1" example:"1"`
+}
+
+// ListTaxRates godoc
+//
+//	@Summary		List tax rates
+//	@Description	List tax rates with pagination, optionally of a tax class
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip			query		uint64			true	"Skip"
+//	@Param			limit			query		uint64			true	"Limit"
+//	@Param			tax_class_id	query		uint64			false	"Tax class ID"
+//	@Success		200				{object}	meta			"Tax rates displayed"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/tax-rates [get]
+//	@Security		BearerAuth
+func (th *TaxHandler) ListTaxRates(ctx *gin.Context) {
+	var req listTaxRatesRequest
+	var ratesList []utils.TaxRateResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	rates, err := th.svc.ListTaxRates(ctx, req.TaxClassID, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, rate := range rates {
+		ratesList = append(ratesList, utils.NewTaxRateResponse(&rate))
+	}
+
+	total := uint64(len(ratesList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, ratesList, "tax_rates")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getTaxRateRequest represents the request body for getting a tax rate
+type getTaxRateRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetTaxRate godoc
+//
+//	@Summary		Get a tax rate
+//	@Description	Get a tax rate by id
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Tax rate ID"
+//	@Success		200	{object}	taxRateResponse	"Tax rate displayed"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/tax-rates/{id} [get]
+//	@Security		BearerAuth
+func (th *TaxHandler) GetTaxRate(ctx *gin.Context) {
+	var req getTaxRateRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	rate, err := th.svc.GetTaxRate(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewTaxRateResponse(rate)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// UpdateTaxRate godoc
+//
+//	@Summary		Update a tax rate
+//	@Description	Replace a tax rate by id, the orders already placed keep the taxes they were charged
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		uint64			true	"Tax rate ID"
+//	@Param			taxRateRequest	body		taxRateRequest	true	"Update tax rate request"
+//	@Success		200				{object}	taxRateResponse	"Tax rate updated"
+//	@Failure		400				{object}	errorResponse	"Validation error"
+//	@Failure		401				{object}	errorResponse	"Unauthorized error"
+//	@Failure		403				{object}	errorResponse	"Forbidden error"
+//	@Failure		404				{object}	errorResponse	"Data not found error"
+//	@Failure		500				{object}	errorResponse	"Internal server error"
+//	@Router			/tax-rates/{id} [put]
+//	@Security		BearerAuth
+func (th *TaxHandler) UpdateTaxRate(ctx *gin.Context) {
+	var req taxRateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	rate := req.toTaxRate(id)
+
+	updatedRate, err := th.svc.UpdateTaxRate(ctx, &rate)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewTaxRateResponse(updatedRate)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// deleteTaxRateRequest represents the request body for deleting a tax rate
+type deleteTaxRateRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// DeleteTaxRate godoc
+//
+//	@Summary		Delete a tax rate
+//	@Description	Delete a tax rate by id, the orders already placed keep the taxes they were charged
+//	@Tags			Taxes
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Tax rate ID"
+//	@Success		200	{object}	response		"Tax rate deleted"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/tax-rates/{id} [delete]
+//	@Security		BearerAuth
+func (th *TaxHandler) DeleteTaxRate(ctx *gin.Context) {
+	var req deleteTaxRateRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	err := th.svc.DeleteTaxRate(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	utils.HandleSuccess(ctx, nil)
+}
+
+var TaxModule = fx.Module(
+	"tax-handler-module",
+	fx.Provide(NewTaxHandler),
+)
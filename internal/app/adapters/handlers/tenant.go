@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+// TenantHandler represents the HTTP handlers for tenant-related requests
+type TenantHandler struct {
+	svc ports.TenantService
+}
+
+// NewTenantHandler creates a new TenantHandler instance
+func NewTenantHandler(svc ports.TenantService) *TenantHandler {
+	return &TenantHandler{
+		svc,
+	}
+}
+
+// createTenantRequest represents the request body for creating a tenant with its first admin
+type createTenantRequest struct {
+	Name  string          `json:"name" binding:"required,max=128" example:"Acme"`
+	Admin registerRequest `json:"admin" binding:"required"`
+}
+
+// CreateTenant godoc
+//
+//	@Summary		Create a new tenant
+//	@Description	create a new tenant with a unique name and register its first admin in it
+//	@Tags			Tenants
+//	@Accept			json
+//	@Produce		json
+//	@Param			createTenantRequest	body		createTenantRequest	true	"Create tenant request"
+//	@Success		200					{object}	tenantResponse		"Tenant created"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/tenants [post]
+//	@Security		BearerAuth
+func (th *TenantHandler) CreateTenant(ctx *gin.Context) {
+	var req createTenantRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	tenant := models.Tenant{
+		Name: req.Name,
+	}
+	admin := models.User{
+		Name:     req.Admin.Name,
+		Email:    req.Admin.Email,
+		Password: req.Admin.Password,
+	}
+
+	_, createdAdmin, err := th.svc.CreateTenant(ctx, &tenant, &admin)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewTenantResponse(&tenant, createdAdmin)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// listTenantsRequest represents the request body for listing tenants
+type listTenantsRequest struct {
+	Skip  uint64 `form:"skip" binding:"required,min=0" example:"0"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"5"`
+}
+
+// ListTenants godoc
+//
+//	@Summary		List tenants
+//	@Description	List tenants with pagination
+//	@Tags			Tenants
+//	@Accept			json
+//	@Produce		json
+//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			limit	query		uint64			true	"Limit"
+//	@Success		200		{object}	meta			"Tenants displayed"
+//	@Failure		400		{object}	errorResponse	"Validation error"
+//	@Failure		401		{object}	errorResponse	"Unauthorized error"
+//	@Failure		403		{object}	errorResponse	"Forbidden error"
+//	@Failure		500		{object}	errorResponse	"Internal server error"
+//	@Router			/tenants [get]
+//	@Security		BearerAuth
+func (th *TenantHandler) ListTenants(ctx *gin.Context) {
+	var req listTenantsRequest
+	var tenantsList []utils.TenantResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	tenants, err := th.svc.ListTenants(ctx, req.Skip, req.Limit)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	for _, tenant := range tenants {
+		tenantsList = append(tenantsList, utils.NewTenantResponse(&tenant, nil))
+	}
+
+	total := uint64(len(tenantsList))
+	meta := utils.NewMeta(total, req.Limit, req.Skip)
+	rsp := toMap(meta, tenantsList, "tenants")
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// getTenantRequest represents the request body for getting a tenant
+type getTenantRequest struct {
+	ID uint64 `uri:"id" binding:"required,min=1" example:"1"`
+}
+
+// GetTenant godoc
+//
+//	@Summary		Get a tenant
+//	@Description	Get a tenant by id, users only get their own tenant unless they are in the default one
+//	@Tags			Tenants
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		uint64			true	"Tenant ID"
+//	@Success		200	{object}	tenantResponse	"Tenant displayed"
+//	@Failure		400	{object}	errorResponse	"Validation error"
+//	@Failure		401	{object}	errorResponse	"Unauthorized error"
+//	@Failure		403	{object}	errorResponse	"Forbidden error"
+//	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		500	{object}	errorResponse	"Internal server error"
+//	@Router			/tenants/{id} [get]
+//	@Security		BearerAuth
+func (th *TenantHandler) GetTenant(ctx *gin.Context) {
+	var req getTenantRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	tenant, err := th.svc.GetTenant(ctx, req.ID)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	rsp := utils.NewTenantResponse(tenant, nil)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+var TenantModule = fx.Module(
+	"tenant-handlers-module",
+	fx.Provide(NewTenantHandler),
+)
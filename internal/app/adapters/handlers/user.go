@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"encoding/json"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"go.uber.org/fx"
 )
 
@@ -30,7 +32,7 @@ type registerRequest struct {
 // Register godoc
 //
 //	@Summary		Register a new user
-//	@Description	create a new user account with default role "cashier"
+//	@Description	create a new user account with default role "cashier" in the default tenant, the other tenants are joined through invitations
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
@@ -126,6 +128,7 @@ type getUserRequest struct {
 //	@Produce		json
 //	@Param			id	path		uint64			true	"User ID"
 //	@Success		200	{object}	userResponse	"User displayed"
+//	@Header			200	{string}	ETag			"User version"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
@@ -144,33 +147,40 @@ func (uh *UserHandler) GetUser(ctx *gin.Context) {
 		return
 	}
 
+	ctx.Header("ETag", toETag(user.Version))
 	rsp := utils.NewUserResponse(user)
 
 	utils.HandleSuccess(ctx, rsp)
 }
 
-// updateUserRequest represents the request body for updating a user
+// updateUserRequest represents the request body for updating a user,
+// the current password is required to change one's own password
 type updateUserRequest struct {
-	Name     string `json:"name" binding:"omitempty,required" example:"John Doe"`
-	Email    string `json:"email" binding:"omitempty,required,email" example:"test@example.com"`
-	Password string `json:"password" binding:"omitempty,required,min=8" example:"12345678"`
-	Role     string `json:"role" binding:"omitempty,required,user_role" example:"admin"`
+	Name            string          `json:"name" binding:"omitempty,required" example:"John Doe"`
+	Email           string          `json:"email" binding:"omitempty,required,email" example:"test@example.com"`
+	Password        string          `json:"password" binding:"omitempty,required,min=8" example:"12345678"`
+	Role            models.UserRole `json:"role" binding:"omitempty,required,user_role" example:"admin"`
+	CurrentPassword string          `json:"current_password" example:"87654321"`
 }
 
 // UpdateUser godoc
 //
 //	@Summary		Update a user
-//	@Description	Update a user's name, email, password, or role by id
+//	@Description	Update a user's name, email, password, or role by id, the If-Match header must carry the ETag from the last read, a new email only takes effect once confirmed from that address. Users change only the fields the owner policy grants on their own record, and their password only with the current one
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
 //	@Param			id					path		uint64				true	"User ID"
+//	@Param			If-Match			header		string				true	"User ETag"
 //	@Param			updateUserRequest	body		updateUserRequest	true	"Update user request"
 //	@Success		200					{object}	userResponse		"User updated"
+//	@Header			200					{string}	ETag				"User version"
 //	@Failure		400					{object}	errorResponse		"Validation error"
 //	@Failure		401					{object}	errorResponse		"Unauthorized error"
 //	@Failure		403					{object}	errorResponse		"Forbidden error"
 //	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		412					{object}	errorResponse		"Version conflict error"
+//	@Failure		428					{object}	errorResponse		"Precondition required error"
 //	@Failure		500					{object}	errorResponse		"Internal server error"
 //	@Router			/users/{id} [put]
 //	@Security		BearerAuth
@@ -188,21 +198,123 @@ func (uh *UserHandler) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
 	user := models.User{
 		ID:       id,
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
 		Role:     req.Role,
+		Version:  version,
 	}
 
-	_, err = uh.svc.UpdateUser(ctx, &user)
+	updatedUser, err := uh.svc.UpdateUser(ctx, &user, req.CurrentPassword)
 	if err != nil {
 		utils.HandleError(ctx, err)
 		return
 	}
 
-	rsp := utils.NewUserResponse(&user)
+	ctx.Header("ETag", toETag(updatedUser.Version))
+	rsp := utils.NewUserResponse(updatedUser)
+
+	utils.HandleSuccess(ctx, rsp)
+}
+
+// patchUserRequest represents a JSON merge patch (RFC 7396) document for a user,
+// the current password is required to change one's own password
+type patchUserRequest struct {
+	Name            models.PatchField[string]          `json:"name" swaggertype:"string" example:"John Doe"`
+	Email           models.PatchField[string]          `json:"email" swaggertype:"string" example:"test@example.com"`
+	Password        models.PatchField[string]          `json:"password" swaggertype:"string" example:"12345678"`
+	Role            models.PatchField[models.UserRole] `json:"role" swaggertype:"string" example:"admin"`
+	CurrentPassword string                             `json:"current_password" example:"87654321"`
+}
+
+// patchUserValues holds the values set by a user merge patch for validation
+type patchUserValues struct {
+	Email    *string          `binding:"omitnil,email"`
+	Password *string          `binding:"omitnil,min=8"`
+	Role     *models.UserRole `binding:"omitnil,user_role"`
+}
+
+// PatchUser godoc
+//
+//	@Summary		Patch a user
+//	@Description	Partially update a user by id with a JSON merge patch, absent members are kept and a null role resets it to the default, a new email only takes effect once confirmed from that address, the If-Match header must carry the ETag from the last read. Users change only the fields the owner policy grants on their own record, and their password only with the current one
+//	@Tags			Users
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			id					path		uint64				true	"User ID"
+//	@Param			If-Match			header		string				true	"User ETag"
+//	@Param			patchUserRequest	body		patchUserRequest	true	"Merge patch document"
+//	@Success		200					{object}	userResponse		"User updated"
+//	@Header			200					{string}	ETag				"User version"
+//	@Failure		400					{object}	errorResponse		"Validation error"
+//	@Failure		401					{object}	errorResponse		"Unauthorized error"
+//	@Failure		403					{object}	errorResponse		"Forbidden error"
+//	@Failure		404					{object}	errorResponse		"Data not found error"
+//	@Failure		409					{object}	errorResponse		"Data conflict error"
+//	@Failure		412					{object}	errorResponse		"Version conflict error"
+//	@Failure		428					{object}	errorResponse		"Precondition required error"
+//	@Failure		500					{object}	errorResponse		"Internal server error"
+//	@Router			/users/{id} [patch]
+//	@Security		BearerAuth
+func (uh *UserHandler) PatchUser(ctx *gin.Context) {
+	var req patchUserRequest
+
+	decoder := json.NewDecoder(ctx.Request.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	values := patchUserValues{
+		Email:    patchValue(req.Email),
+		Password: patchValue(req.Password),
+		Role:     patchValue(req.Role),
+	}
+	if err := binding.Validator.ValidateStruct(values); err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	idStr := ctx.Param("id")
+	id, err := stringToUint64(idStr)
+	if err != nil {
+		utils.ValidationError(ctx, err)
+		return
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	patch := models.UserPatch{
+		ID:              id,
+		Version:         version,
+		Name:            req.Name,
+		Email:           req.Email,
+		Password:        req.Password,
+		Role:            req.Role,
+		CurrentPassword: req.CurrentPassword,
+	}
+
+	user, err := uh.svc.PatchUser(ctx, &patch)
+	if err != nil {
+		utils.HandleError(ctx, err)
+		return
+	}
+
+	ctx.Header("ETag", toETag(user.Version))
+	rsp := utils.NewUserResponse(user)
 
 	utils.HandleSuccess(ctx, rsp)
 }
@@ -244,6 +356,16 @@ func (uh *UserHandler) DeleteUser(ctx *gin.Context) {
 	utils.HandleSuccess(ctx, nil)
 }
 
+// ResolveOwner resolves the owner of a user record, which is the user itself. An invalid id has no
+// owner, the handler refuses it once the request is allowed
+func (uh *UserHandler) ResolveOwner(ctx *gin.Context) (uint64, error) {
+	id, err := stringToUint64(ctx.Param("id"))
+	if err != nil {
+		return 0, nil
+	}
+	return id, nil
+}
+
 var UserModule = fx.Module(
 	"user-handler-module",
 	fx.Provide(NewUserHandler),
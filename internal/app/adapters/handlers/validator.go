@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// rolePattern matches lowercase role names such as "admin" or "store_manager"
+var rolePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,63}$`)
+
+// userRoleValidator is a custom validator for validating the format of a role name,
+// whether the role exists is checked against the roles table by the services
+var userRoleValidator validator.Func = func(fl validator.FieldLevel) bool {
+	userRole, ok := fl.Field().Interface().(models.UserRole)
+	if !ok {
+		return false
+	}
+
+	return rolePattern.MatchString(string(userRole))
+}
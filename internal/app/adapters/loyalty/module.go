@@ -0,0 +1,13 @@
+package loyalty
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"loyalty-module",
+	fx.Provide(
+		fx.Annotate(NewLoyaltyProgram, fx.As(new(ports.LoyaltyProgram))),
+	),
+)
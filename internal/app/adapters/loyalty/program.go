@@ -0,0 +1,74 @@
+package loyalty
+
+import (
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"strconv"
+)
+
+const (
+	// defaultEarnAmount is the amount in minor units spent for each point earned when LOYALTY_EARN_AMOUNT is not set
+	defaultEarnAmount = 100
+	// defaultPointValue is the amount in minor units a point pays when LOYALTY_POINT_VALUE is not set
+	defaultPointValue = 1
+)
+
+/**
+ * Program implements ports.LoyaltyProgram interface
+ * and earns a point for every whole earn amount spent,
+ * and pays the point value for every point redeemed
+ */
+type Program struct {
+	earnAmount int64
+	pointValue int64
+}
+
+// NewLoyaltyProgram creates a new loyalty program instance
+func NewLoyaltyProgram(config *configs.Loyalty) (*Program, error) {
+	earnAmount, err := parseAmount(config.EarnAmount, defaultEarnAmount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid loyalty earn amount %q", config.EarnAmount)
+	}
+
+	pointValue, err := parseAmount(config.PointValue, defaultPointValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid loyalty point value %q", config.PointValue)
+	}
+
+	return &Program{
+		earnAmount: earnAmount,
+		pointValue: pointValue,
+	}, nil
+}
+
+// PointsEarned returns the points an order of the amount earns, partial earn amounts earn nothing
+func (p *Program) PointsEarned(amount int64) int64 {
+	if amount <= 0 {
+		return 0
+	}
+
+	return amount / p.earnAmount
+}
+
+// RedeemCost returns the points it takes to pay the amount, rounded up so that points never pay less than the amount
+func (p *Program) RedeemCost(amount int64) int64 {
+	if amount <= 0 {
+		return 0
+	}
+
+	return (amount + p.pointValue - 1) / p.pointValue
+}
+
+// parseAmount parses a positive amount in minor units, or returns the fallback if it is not set
+func parseAmount(value string, fallback int64) (int64, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	amount, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || amount <= 0 {
+		return 0, fmt.Errorf("amount must be a positive integer")
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,47 @@
+package loyalty
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLoyaltyProgram(t *testing.T) {
+	program, err := NewLoyaltyProgram(&configs.Loyalty{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(defaultEarnAmount), program.earnAmount)
+	assert.Equal(t, int64(defaultPointValue), program.pointValue)
+
+	for _, config := range []configs.Loyalty{
+		{EarnAmount: "0"},
+		{EarnAmount: "1.5"},
+		{PointValue: "-1"},
+	} {
+		_, err := NewLoyaltyProgram(&config)
+		assert.Error(t, err, "%+v", config)
+	}
+}
+
+func TestProgram_PointsEarned(t *testing.T) {
+	program, err := NewLoyaltyProgram(&configs.Loyalty{EarnAmount: "1000"})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(0), program.PointsEarned(0))
+	assert.Equal(t, int64(0), program.PointsEarned(999))
+	assert.Equal(t, int64(1), program.PointsEarned(1000))
+	assert.Equal(t, int64(12), program.PointsEarned(12999))
+}
+
+func TestProgram_RedeemCost(t *testing.T) {
+	program, err := NewLoyaltyProgram(&configs.Loyalty{PointValue: "50"})
+	require.NoError(t, err)
+
+	// a partly used point is spent whole, points never pay less than the amount
+	assert.Equal(t, int64(0), program.RedeemCost(0))
+	assert.Equal(t, int64(1), program.RedeemCost(1))
+	assert.Equal(t, int64(1), program.RedeemCost(50))
+	assert.Equal(t, int64(2), program.RedeemCost(51))
+	assert.Equal(t, int64(125), program.RedeemCost(6250))
+}
@@ -4,6 +4,9 @@ import (
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/auth"
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/author"
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/handlers"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/loyalty"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/notifiers"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/receipts"
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/repositories"
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages"
 	"go.uber.org/fx"
@@ -16,4 +19,7 @@ var Module = fx.Module(
 	repositories.Module,
 	storages.Module,
 	handlers.Module,
+	notifiers.Module,
+	receipts.Module,
+	loyalty.Module,
 )
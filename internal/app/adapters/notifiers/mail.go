@@ -0,0 +1,79 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"go.uber.org/fx"
+	"log/slog"
+	"net/smtp"
+	"strings"
+)
+
+/**
+ * Mailer implements ports.NotificationService interface
+ * and delivers notifications as plain-text emails over SMTP
+ */
+type Mailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+// NewMailer creates a new notification instance, it only logs notifications when no mail host is configured
+func NewMailer(config *configs.Mail) ports.NotificationService {
+	if config.Host == "" {
+		return &LogMailer{}
+	}
+
+	var auth smtp.Auth
+	if config.User != "" {
+		auth = smtp.PlainAuth("", config.User, config.Password, config.Host)
+	}
+
+	return &Mailer{
+		addr: fmt.Sprintf("%s:%s", config.Host, config.Port),
+		auth: auth,
+		from: config.From,
+	}
+}
+
+// Send sends the notification as an email to its recipient
+func (m *Mailer) Send(ctx context.Context, notification *models.Notification) error {
+	var msg strings.Builder
+
+	msg.WriteString("From: " + m.from + "\r\n")
+	msg.WriteString("To: " + notification.Recipient + "\r\n")
+	msg.WriteString("Subject: " + notification.Subject + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(notification.Body)
+
+	return smtp.SendMail(m.addr, m.auth, m.from, []string{notification.Recipient}, []byte(msg.String()))
+}
+
+/**
+ * LogMailer implements ports.NotificationService interface
+ * and writes notifications to the log instead of sending them, for development
+ */
+type LogMailer struct{}
+
+// Send logs the notification
+func (lm *LogMailer) Send(ctx context.Context, notification *models.Notification) error {
+	slog.Info("Notification",
+		"recipient", notification.Recipient,
+		"subject", notification.Subject,
+		"body", notification.Body,
+	)
+	return nil
+}
+
+var MailModule = fx.Module(
+	"mail-notifier-module",
+	fx.Provide(
+		fx.Annotate(NewMailer, fx.As(new(ports.NotificationService))),
+	),
+)
@@ -0,0 +1,11 @@
+package notifiers
+
+import (
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"notifiers-module",
+	MailModule,
+	StockAlertModule,
+)
@@ -0,0 +1,82 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"go.uber.org/fx"
+	"log/slog"
+)
+
+/**
+ * StockAlertMailer implements ports.StockAlerter interface
+ * and sends low-stock alerts as notifications to the inventory recipient
+ */
+type StockAlertMailer struct {
+	notifier  ports.NotificationService
+	recipient string
+}
+
+// NewStockAlerter creates a new stock alerter instance, it only logs alerts when no recipient is configured
+func NewStockAlerter(config *configs.Inventory, notifier ports.NotificationService) ports.StockAlerter {
+	if config.AlertRecipient == "" {
+		return &LogStockAlerter{}
+	}
+
+	return &StockAlertMailer{
+		notifier:  notifier,
+		recipient: config.AlertRecipient,
+	}
+}
+
+// LowStock notifies the inventory recipient that a product is running low
+func (sam *StockAlertMailer) LowStock(ctx context.Context, product *models.Product, movement *models.StockMovement) error {
+	notification := &models.Notification{
+		Recipient: sam.recipient,
+		Subject:   fmt.Sprintf("Low stock: %s", product.Name),
+		Body: fmt.Sprintf(
+			"%s (SKU %s) is down to %d in stock, at or below its threshold of %d.\n\nLast movement: %s of %d.\n",
+			product.Name,
+			product.SKU,
+			movement.StockAfter,
+			product.LowStockThreshold,
+			movement.Type,
+			movement.Quantity,
+		),
+	}
+
+	err := sam.notifier.Send(ctx, notification)
+	if err != nil {
+		// the caller does not wait on alerts, so a failure is only logged
+		slog.Error("Error sending low stock alert", "product_id", product.ID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+/**
+ * LogStockAlerter implements ports.StockAlerter interface
+ * and writes low-stock alerts to the log instead of sending them
+ */
+type LogStockAlerter struct{}
+
+// LowStock logs the alert
+func (lsa *LogStockAlerter) LowStock(ctx context.Context, product *models.Product, movement *models.StockMovement) error {
+	slog.Warn("Low stock",
+		"product_id", product.ID,
+		"sku", product.SKU,
+		"stock", movement.StockAfter,
+		"threshold", product.LowStockThreshold,
+	)
+	return nil
+}
+
+var StockAlertModule = fx.Module(
+	"stock-alert-notifier-module",
+	fx.Provide(
+		fx.Annotate(NewStockAlerter, fx.As(new(ports.StockAlerter))),
+	),
+)
@@ -0,0 +1,50 @@
+package receipts
+
+import (
+	"bytes"
+)
+
+// ESC/POS commands understood by thermal receipt printers
+var (
+	escInitialize = []byte{0x1b, '@'}
+	escBoldOn     = []byte{0x1b, 'E', 1}
+	escBoldOff    = []byte{0x1b, 'E', 0}
+	escLargeOn    = []byte{0x1d, '!', 0x11}
+	escLargeOff   = []byte{0x1d, '!', 0x00}
+	// escFeedAndCut feeds the paper past the cutter and cuts it partially
+	escFeedAndCut = []byte{0x1d, 'V', 'B', 0}
+)
+
+// renderESCPOS renders the lines of a receipt as a byte stream for a thermal printer. Printers
+// only share the ASCII characters of their code pages, so any other character is printed as '?'
+func renderESCPOS(lines []textLine) []byte {
+	var buf bytes.Buffer
+
+	buf.Write(escInitialize)
+	for _, line := range lines {
+		if line.large {
+			buf.Write(escLargeOn)
+		}
+		if line.bold {
+			buf.Write(escBoldOn)
+		}
+
+		for _, r := range line.text {
+			if r < 0x20 || r > 0x7e {
+				r = '?'
+			}
+			buf.WriteByte(byte(r))
+		}
+		buf.WriteByte('\n')
+
+		if line.bold {
+			buf.Write(escBoldOff)
+		}
+		if line.large {
+			buf.Write(escLargeOff)
+		}
+	}
+	buf.Write(escFeedAndCut)
+
+	return buf.Bytes()
+}
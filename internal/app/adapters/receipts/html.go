@@ -0,0 +1,60 @@
+package receipts
+
+import (
+	"bytes"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"html/template"
+)
+
+// receiptTemplate is the page of a receipt, it is as wide as the paper roll when printed
+var receiptTemplate = template.Must(template.New("receipt").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Doc.Number}}</title>
+<style>
+body { font-family: "Courier New", monospace; font-size: 13px; max-width: {{.Width}}mm; margin: 0 auto; padding: 4mm; }
+header, footer { text-align: center; }
+h1 { font-size: 20px; margin: 0 0 4px; }
+p { margin: 2px 0; }
+table { width: 100%; border-collapse: collapse; margin: 8px 0; border-top: 1px dashed #000; }
+td { padding: 2px 0; vertical-align: top; }
+td.amount { text-align: right; white-space: nowrap; }
+tr.strong td { font-weight: bold; }
+.quantity { color: #555; }
+</style>
+</head>
+<body>
+<header>
+{{with .Doc.Brand}}<h1>{{.}}</h1>{{end}}
+{{with .Doc.Header}}<p>{{.}}</p>{{end}}
+{{if ne .Doc.Store .Doc.Brand}}<p>{{.Doc.Store}}</p>{{end}}
+{{with .Doc.Address}}<p>{{.}}</p>{{end}}
+</header>
+<p>{{.Doc.Number}} &middot; {{.Doc.Date}}</p>
+{{with .Doc.Cashier}}<p>Cashier: {{.}}</p>{{end}}
+<table>
+{{range .Doc.Items}}<tr><td>{{.Name}}<br><span class="quantity">{{.Quantity}}</span></td><td class="amount">{{.Total}}</td></tr>
+{{end}}</table>
+<table>
+{{range .Doc.Totals}}<tr{{if .Strong}} class="strong"{{end}}><td>{{.Label}}</td><td class="amount">{{.Amount}}</td></tr>
+{{end}}</table>
+{{with .Doc.Footer}}<footer><p>{{.}}</p></footer>{{end}}
+</body>
+</html>
+`))
+
+// renderHTML renders a receipt as a standalone page
+func renderHTML(doc *document, width models.ReceiptPaperWidth) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := receiptTemplate.Execute(&buf, struct {
+		Doc   *document
+		Width int
+	}{doc, int(width)})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
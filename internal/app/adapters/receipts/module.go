@@ -0,0 +1,13 @@
+package receipts
+
+import (
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"receipts-module",
+	fx.Provide(
+		fx.Annotate(NewReceiptRenderer, fx.As(new(ports.ReceiptRenderer))),
+	),
+)
@@ -0,0 +1,97 @@
+package receipts
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+// pdfMargin is the blank space around a receipt page, in points
+const pdfMargin = 8.0
+
+// renderPDF renders the lines of a receipt as a single page as wide as the paper roll and as long as the
+// receipt, in the Courier fonts every PDF reader has. Characters outside of Latin-1 are printed as '?'
+func renderPDF(lines []textLine, width models.ReceiptPaperWidth) []byte {
+	pageWidth := float64(width) * 72 / 25.4
+	// a Courier character is 0.6 of the font size wide, the standard lines fill the page
+	size := (pageWidth - 2*pdfMargin) / (0.6 * float64(columns(width)))
+
+	var height float64
+	for _, line := range lines {
+		height += lineHeight(line, size)
+	}
+	pageHeight := height + 2*pdfMargin
+
+	var content bytes.Buffer
+	y := pageHeight - pdfMargin
+	for _, line := range lines {
+		y -= lineHeight(line, size)
+		if line.text == "" {
+			continue
+		}
+
+		font, fontSize := "F1", size
+		if line.bold {
+			font = "F2"
+		}
+		if line.large {
+			fontSize = 2 * size
+		}
+
+		fmt.Fprintf(&content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, fontSize, pdfMargin, y, pdfString(line.text))
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", pageWidth, pageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+// lineHeight is the height a line takes on the page
+func lineHeight(line textLine, size float64) float64 {
+	if line.large {
+		return 2.5 * size
+	}
+	return 1.25 * size
+}
+
+// pdfString escapes a text for a PDF string literal in the WinAnsi encoding
+func pdfString(text string) string {
+	var buf bytes.Buffer
+
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(byte(r))
+		case r < 0x20 || r > 0xff || (r >= 0x7f && r < 0xa0):
+			buf.WriteByte('?')
+		default:
+			buf.WriteByte(byte(r))
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,221 @@
+package receipts
+
+import (
+	"context"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// defaultDecimals is the number of minor unit digits of the currency when RECEIPT_DECIMALS is not set
+const defaultDecimals = 2
+
+/**
+ * Renderer implements ports.ReceiptRenderer interface
+ * and renders receipts as PDF documents, ESC/POS byte streams
+ * for thermal printers and HTML pages
+ */
+type Renderer struct {
+	brand    string
+	header   string
+	footer   string
+	currency string
+	decimals int
+	width    models.ReceiptPaperWidth
+}
+
+// NewReceiptRenderer creates a new receipt renderer instance, receipts are laid out for 80mm rolls
+// unless RECEIPT_PAPER_WIDTH is set to 58
+func NewReceiptRenderer(config *configs.Receipt) (*Renderer, error) {
+	renderer := &Renderer{
+		brand:    config.Brand,
+		header:   config.Header,
+		footer:   config.Footer,
+		currency: config.Currency,
+		decimals: defaultDecimals,
+		width:    models.ReceiptPaper80,
+	}
+
+	if config.Decimals != "" {
+		decimals, err := strconv.Atoi(config.Decimals)
+		if err != nil || decimals < 0 || decimals > 4 {
+			return nil, fmt.Errorf("invalid receipt currency decimals %q", config.Decimals)
+		}
+		renderer.decimals = decimals
+	}
+
+	if config.PaperWidth != "" {
+		width, err := strconv.Atoi(config.PaperWidth)
+		if err != nil || !validWidth(models.ReceiptPaperWidth(width)) {
+			return nil, fmt.Errorf("invalid receipt paper width %q", config.PaperWidth)
+		}
+		renderer.width = models.ReceiptPaperWidth(width)
+	}
+
+	return renderer, nil
+}
+
+// Render renders a receipt in its format
+func (r *Renderer) Render(ctx context.Context, receipt *models.Receipt) ([]byte, error) {
+	width := receipt.PaperWidth
+	if width == 0 {
+		width = r.width
+	}
+	if !validWidth(width) {
+		return nil, models.ErrNotAcceptable
+	}
+
+	doc := r.document(receipt)
+
+	switch receipt.Format {
+	case models.ReceiptPDF:
+		return renderPDF(textLines(doc, columns(width)), width), nil
+	case models.ReceiptESCPOS:
+		return renderESCPOS(textLines(doc, columns(width))), nil
+	case models.ReceiptHTML:
+		return renderHTML(doc, width)
+	default:
+		return nil, models.ErrNotAcceptable
+	}
+}
+
+// document is a receipt with its amounts formatted, laid out the same way by every format
+type document struct {
+	Brand   string
+	Header  string
+	Store   string
+	Address string
+	Number  string
+	Date    string
+	Cashier string
+	Items   []documentItem
+	Totals  []documentLine
+	Footer  string
+}
+
+// documentItem is an order item of a receipt
+type documentItem struct {
+	Name     string
+	Quantity string
+	Total    string
+}
+
+// documentLine is a labelled amount below the items of a receipt, Strong lines stand out
+type documentLine struct {
+	Label  string
+	Amount string
+	Strong bool
+}
+
+// document formats a receipt, the taxes added on top of the prices come before the total
+// and those included in them after it
+func (r *Renderer) document(receipt *models.Receipt) *document {
+	order := receipt.Order
+
+	doc := &document{
+		Brand:   r.brand,
+		Header:  r.header,
+		Number:  fmt.Sprintf("Order #%d", order.ID),
+		Date:    order.CreatedAt.Format("2006-01-02 15:04"),
+		Cashier: receipt.Cashier,
+		Footer:  r.footer,
+	}
+	if receipt.Store != nil {
+		doc.Store = receipt.Store.Name
+		doc.Address = receipt.Store.Address
+	}
+	if doc.Brand == "" {
+		doc.Brand = doc.Store
+	}
+
+	var subtotal int64
+	for _, item := range order.Items {
+		subtotal += item.TotalPrice
+		doc.Items = append(doc.Items, documentItem{
+			Name:     item.Name,
+			Quantity: fmt.Sprintf("%d x %s", item.Quantity, r.amount(item.Price)),
+			Total:    r.amount(item.TotalPrice),
+		})
+	}
+
+	exclusive := slices.ContainsFunc(order.Taxes, func(tax models.OrderTax) bool {
+		return !tax.Inclusive
+	})
+
+	if len(order.Discounts) > 0 || exclusive {
+		doc.Totals = append(doc.Totals, documentLine{Label: "Subtotal", Amount: r.amount(subtotal)})
+		for _, discount := range order.Discounts {
+			doc.Totals = append(doc.Totals, documentLine{Label: discount.Name, Amount: r.amount(-discount.Amount)})
+		}
+	}
+	for _, tax := range order.Taxes {
+		if !tax.Inclusive {
+			doc.Totals = append(doc.Totals, documentLine{Label: taxLabel(&tax), Amount: r.amount(tax.Amount)})
+		}
+	}
+	doc.Totals = append(doc.Totals, documentLine{Label: "Total", Amount: r.amount(order.TotalPrice), Strong: true})
+	for _, tax := range order.Taxes {
+		if tax.Inclusive {
+			doc.Totals = append(doc.Totals, documentLine{Label: "incl. " + taxLabel(&tax), Amount: r.amount(tax.Amount)})
+		}
+	}
+
+	if order.IsOpen() {
+		doc.Totals = append(doc.Totals, documentLine{Label: "Unpaid", Amount: r.amount(order.TotalPrice)})
+		return doc
+	}
+	doc.Totals = append(doc.Totals,
+		documentLine{Label: paymentLabel(order.PaymentMethod), Amount: r.amount(order.TotalPaid)},
+		documentLine{Label: "Change", Amount: r.amount(order.TotalChange)},
+	)
+
+	// points are counted rather than priced
+	if order.PointsRedeemed > 0 {
+		doc.Totals = append(doc.Totals, documentLine{Label: "Points redeemed", Amount: strconv.FormatInt(order.PointsRedeemed, 10)})
+	}
+	if order.PointsEarned > 0 {
+		doc.Totals = append(doc.Totals, documentLine{Label: "Points earned", Amount: strconv.FormatInt(order.PointsEarned, 10)})
+	}
+
+	return doc
+}
+
+// amount formats an amount in minor units with the currency
+func (r *Renderer) amount(value int64) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	if r.decimals == 0 {
+		return fmt.Sprintf("%s%s%d", sign, r.currency, value)
+	}
+
+	unit := int64(math.Pow10(r.decimals))
+	return fmt.Sprintf("%s%s%d.%0*d", sign, r.currency, value/unit, r.decimals, value%unit)
+}
+
+// paymentLabel names a payment method on a receipt
+func paymentLabel(method models.PaymentMethod) string {
+	label := string(method)
+	if label == "" {
+		return "Paid"
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// taxLabel names a tax on a receipt with its rate as a percentage, the rate being in millionths
+func taxLabel(tax *models.OrderTax) string {
+	percent := strconv.FormatFloat(float64(tax.Rate)*100/models.TaxRateScale, 'f', -1, 64)
+	return fmt.Sprintf("%s %s%%", tax.Name, percent)
+}
+
+// validWidth checks whether receipts can be laid out for a paper roll
+func validWidth(width models.ReceiptPaperWidth) bool {
+	return width == models.ReceiptPaper58 || width == models.ReceiptPaper80
+}
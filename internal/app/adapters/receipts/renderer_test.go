@@ -0,0 +1,189 @@
+package receipts
+
+import (
+	"bytes"
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReceipt(format models.ReceiptFormat) *models.Receipt {
+	// three colas and a bag of chips, 500 off with 11% VAT included and paid in cash
+	return &models.Receipt{
+		Order: &models.Order{
+			ID:            7,
+			PaymentMethod: models.PaymentCash,
+			TotalPrice:    6250,
+			TotalPaid:     10000,
+			TotalChange:   3750,
+			TotalDiscount: 500,
+			CreatedAt:     time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC),
+			Items: []models.OrderItem{
+				{ID: 11, Name: "Cola 330ml", Quantity: 3, Price: 1500, TotalPrice: 4500},
+				{ID: 12, Name: "Potato chips (café)", Quantity: 1, Price: 2250, TotalPrice: 2250},
+			},
+			Discounts: []models.OrderDiscount{{Name: "Summer sale", Amount: 500}},
+			TotalTax:  619,
+			Taxes: []models.OrderTax{
+				{TaxRateID: 1, Name: "VAT", Rate: 110000, Inclusive: true, TaxableAmount: 5631, Amount: 619},
+			},
+		},
+		Store:   &models.Store{Name: "Main store", Address: "1 Market Street"},
+		Cashier: "Jane",
+		Format:  format,
+	}
+}
+
+func newTestRenderer(t *testing.T) *Renderer {
+	renderer, err := NewReceiptRenderer(&configs.Receipt{
+		Brand:    "Corner Mart",
+		Footer:   "Thank you for shopping with us",
+		Currency: "$",
+	})
+	require.NoError(t, err)
+
+	return renderer
+}
+
+func TestRenderer_TextLines(t *testing.T) {
+	renderer := newTestRenderer(t)
+
+	var texts []string
+	for _, line := range textLines(renderer.document(newTestReceipt(models.ReceiptESCPOS)), 32) {
+		assert.LessOrEqual(t, len([]rune(line.text)), 32, "Line too long")
+		texts = append(texts, line.text)
+	}
+
+	assert.Contains(t, texts, "  3 x $15.00              $45.00")
+	assert.Contains(t, texts, "Summer sale               -$5.00")
+	assert.Contains(t, texts, "Total                     $62.50")
+	assert.Contains(t, texts, "incl. VAT 11%              $6.19")
+	assert.Contains(t, texts, "Change                    $37.50")
+}
+
+func TestRenderer_TextLines_ExclusiveTax(t *testing.T) {
+	renderer := newTestRenderer(t)
+
+	// a sales tax added on top of the prices, without any discount
+	receipt := newTestReceipt(models.ReceiptESCPOS)
+	receipt.Order.Discounts = nil
+	receipt.Order.TotalDiscount = 0
+	receipt.Order.TotalTax = 591
+	receipt.Order.TotalPrice = 6750 + 591
+	receipt.Order.TotalChange = 10000 - receipt.Order.TotalPrice
+	receipt.Order.Taxes = []models.OrderTax{
+		{TaxRateID: 2, Name: "Sales tax", Rate: 87500, TaxableAmount: 6750, Amount: 591},
+	}
+
+	var texts []string
+	for _, line := range textLines(renderer.document(receipt), 32) {
+		texts = append(texts, line.text)
+	}
+
+	i := slices.Index(texts, "Subtotal                  $67.50")
+	require.NotEqual(t, -1, i, "Subtotal missing")
+	assert.Equal(t, []string{
+		"Subtotal                  $67.50",
+		"Sales tax 8.75%            $5.91",
+		"Total                     $73.41",
+		"Cash                     $100.00",
+		"Change                    $26.59",
+	}, texts[i:i+5])
+	assert.NotContains(t, texts, "incl.")
+}
+
+func TestRenderer_TextLines_Points(t *testing.T) {
+	renderer := newTestRenderer(t)
+
+	// paid in full with the points of the customer, a point paying a cent
+	receipt := newTestReceipt(models.ReceiptESCPOS)
+	receipt.Order.PaymentMethod = models.PaymentPoints
+	receipt.Order.TotalPaid = receipt.Order.TotalPrice
+	receipt.Order.TotalChange = 0
+	receipt.Order.PointsRedeemed = 6250
+
+	var texts []string
+	for _, line := range textLines(renderer.document(receipt), 32) {
+		texts = append(texts, line.text)
+	}
+
+	i := slices.Index(texts, "Points                    $62.50")
+	require.NotEqual(t, -1, i, "Points payment missing")
+	assert.Equal(t, []string{
+		"Points                    $62.50",
+		"Change                     $0.00",
+		"Points redeemed             6250",
+	}, texts[i:i+3])
+	assert.NotContains(t, texts, "Points earned")
+}
+
+func TestRenderer_Render_ESCPOS(t *testing.T) {
+	renderer := newTestRenderer(t)
+
+	data, err := renderer.Render(context.Background(), newTestReceipt(models.ReceiptESCPOS))
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(data, escInitialize), "Printer not initialized")
+	assert.True(t, bytes.HasSuffix(data, escFeedAndCut), "Paper not cut")
+	// a standard line fills an 80mm roll, the total is in bold
+	assert.Contains(t, string(data), string(escBoldOn)+"Total"+strings.Repeat(" ", 37)+"$62.50\n"+string(escBoldOff))
+	assert.Contains(t, string(data), "Potato chips (caf?)")
+}
+
+func TestRenderer_Render_PDF(t *testing.T) {
+	renderer := newTestRenderer(t)
+
+	receipt := newTestReceipt(models.ReceiptPDF)
+	receipt.PaperWidth = models.ReceiptPaper58
+
+	data, err := renderer.Render(context.Background(), receipt)
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Contains(t, string(data), "/MediaBox [0 0 164.41 ")
+	assert.Contains(t, string(data), "(Potato chips \\(caf\xe9\\))")
+
+	// every object is where the cross-reference table says it is
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	require.NotNil(t, startxref)
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	assert.True(t, bytes.HasPrefix(data[xref:], []byte("xref\n")))
+
+	for i, offset := range regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data, -1) {
+		at, _ := strconv.Atoi(string(offset[1]))
+		assert.True(t, bytes.HasPrefix(data[at:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "Object %d misplaced", i+1)
+	}
+}
+
+func TestRenderer_Render_HTML(t *testing.T) {
+	renderer, err := NewReceiptRenderer(&configs.Receipt{Currency: "$"})
+	require.NoError(t, err)
+
+	receipt := newTestReceipt(models.ReceiptHTML)
+	receipt.Store.Name = "<Main> store"
+
+	data, err := renderer.Render(context.Background(), receipt)
+	require.NoError(t, err)
+
+	// without a brand the store is the heading
+	assert.Contains(t, string(data), "<h1>&lt;Main&gt; store</h1>")
+	assert.Contains(t, string(data), "max-width: 80mm")
+	assert.Contains(t, string(data), "incl. VAT 11%")
+}
+
+func TestRenderer_Render_NotAcceptable(t *testing.T) {
+	renderer := newTestRenderer(t)
+
+	_, err := renderer.Render(context.Background(), newTestReceipt("docx"))
+	assert.Equal(t, models.ErrNotAcceptable, err)
+}
@@ -0,0 +1,125 @@
+package receipts
+
+import (
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"strings"
+)
+
+// textLine is a line of a receipt printed in a monospaced font, already padded to its alignment.
+// A large line is printed at double width and height, so it holds half as many characters
+type textLine struct {
+	text  string
+	bold  bool
+	large bool
+}
+
+// columns is the number of characters a line of the standard font holds on a paper roll
+func columns(width models.ReceiptPaperWidth) int {
+	if width == models.ReceiptPaper58 {
+		return 32
+	}
+	return 48
+}
+
+// textLines lays out a receipt for a roll holding the given number of characters on a line
+func textLines(doc *document, width int) []textLine {
+	var lines []textLine
+
+	for _, text := range wrap(doc.Brand, width/2) {
+		lines = append(lines, textLine{text: center(text, width/2), bold: true, large: true})
+	}
+	for _, header := range []string{doc.Header, doc.Store, doc.Address} {
+		if header == "" || header == doc.Brand {
+			continue
+		}
+		for _, text := range wrap(header, width) {
+			lines = append(lines, textLine{text: center(text, width)})
+		}
+	}
+
+	lines = append(lines,
+		textLine{},
+		textLine{text: spread(doc.Number, doc.Date, width)},
+	)
+	if doc.Cashier != "" {
+		lines = append(lines, textLine{text: fmt.Sprintf("Cashier: %s", doc.Cashier)})
+	}
+	lines = append(lines, textLine{text: strings.Repeat("-", width)})
+
+	for _, item := range doc.Items {
+		for _, text := range wrap(item.Name, width) {
+			lines = append(lines, textLine{text: text})
+		}
+		lines = append(lines, textLine{text: spread("  "+item.Quantity, item.Total, width)})
+	}
+
+	lines = append(lines, textLine{text: strings.Repeat("-", width)})
+	for _, total := range doc.Totals {
+		lines = append(lines, textLine{text: spread(total.Label, total.Amount, width), bold: total.Strong})
+	}
+
+	if doc.Footer != "" {
+		lines = append(lines, textLine{})
+		for _, text := range wrap(doc.Footer, width) {
+			lines = append(lines, textLine{text: center(text, width)})
+		}
+	}
+
+	return lines
+}
+
+// spread puts a label on the left of a line and an amount on the right, cutting the label short when
+// both do not fit
+func spread(left, right string, width int) string {
+	room := width - len([]rune(right)) - 1
+	if room < 0 {
+		room = 0
+	}
+
+	label := []rune(left)
+	if len(label) > room {
+		label = label[:room]
+	}
+
+	return string(label) + strings.Repeat(" ", width-len(label)-len([]rune(right))) + right
+}
+
+// center pads a text to the middle of a line
+func center(text string, width int) string {
+	padding := (width - len([]rune(text))) / 2
+	if padding <= 0 {
+		return text
+	}
+	return strings.Repeat(" ", padding) + text
+}
+
+// wrap breaks a text into lines of at most width characters, between words where it can
+func wrap(text string, width int) []string {
+	var lines []string
+	var line []rune
+
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+
+		if len(line) > 0 && len(line)+1+len(runes) > width {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, runes...)
+
+		// a word longer than a line is broken where the line ends
+		for len(line) > width {
+			lines = append(lines, string(line[:width]))
+			line = line[width:]
+		}
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+
+	return lines
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+/**
+ * AuditRepository implements ports.AuditRepository interface
+ * and provides an access to the postgres database
+ */
+type AuditRepository struct {
+	db *postgres.DB
+}
+
+// NewAuditRepository creates a new audit repositories instance
+func NewAuditRepository(db *postgres.DB) *AuditRepository {
+	return &AuditRepository{
+		db,
+	}
+}
+
+// CreateAuditRecord appends a new audit record to the database
+func (ar *AuditRepository) CreateAuditRecord(ctx context.Context, record *models.AuditRecord) (*models.AuditRecord, error) {
+	query := ar.db.QueryBuilder.Insert("audit_records").
+		Columns("actor_id", "target_id", "action", "changes", "ip", "request_id").
+		Values(record.ActorID, record.TargetID, record.Action, record.Changes, record.IP, record.RequestID).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ar.db.QueryRow(ctx, sql, args...).Scan(
+		&record.ID,
+		&record.ActorID,
+		&record.TargetID,
+		&record.Action,
+		&record.Changes,
+		&record.IP,
+		&record.RequestID,
+		&record.CreatedAt,
+		&record.TenantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// ListAuditRecords lists the audit records matching a filter from the database, newest first
+func (ar *AuditRepository) ListAuditRecords(ctx context.Context, filter *models.AuditFilter, skip, limit uint64) ([]models.AuditRecord, error) {
+	var records []models.AuditRecord
+
+	query := ar.db.QueryBuilder.Select("*").
+		From("audit_records").
+		Where(sq.Eq{"target_id": filter.TargetID}).
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	if filter.ActorID != 0 {
+		query = query.Where(sq.Eq{"actor_id": filter.ActorID})
+	}
+	if filter.Action != "" {
+		query = query.Where(sq.Eq{"action": filter.Action})
+	}
+	if !filter.From.IsZero() {
+		query = query.Where(sq.GtOrEq{"created_at": filter.From})
+	}
+	if !filter.To.IsZero() {
+		query = query.Where(sq.Lt{"created_at": filter.To})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ar.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var record models.AuditRecord
+		err := rows.Scan(
+			&record.ID,
+			&record.ActorID,
+			&record.TargetID,
+			&record.Action,
+			&record.Changes,
+			&record.IP,
+			&record.RequestID,
+			&record.CreatedAt,
+			&record.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+var AuditRepositoryModule = fx.Module(
+	"audit-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewAuditRepository, fx.As(new(ports.AuditRepository))),
+	),
+)
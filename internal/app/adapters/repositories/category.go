@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * CategoryRepository implements ports.CategoryRepository interface
+ * and provides an access to the postgres database
+ */
+type CategoryRepository struct {
+	db *postgres.DB
+}
+
+// NewCategoryRepository creates a new category repositories instance
+func NewCategoryRepository(db *postgres.DB) *CategoryRepository {
+	return &CategoryRepository{
+		db,
+	}
+}
+
+// CreateCategory creates a new category in the database
+func (cr *CategoryRepository) CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	query := cr.db.QueryBuilder.Insert("categories").
+		Columns("name").
+		Values(category.Name).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&category.ID,
+		&category.Name,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+		&category.TenantID,
+	)
+	if err != nil {
+		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// GetCategoryByID gets a category by ID from the database
+func (cr *CategoryRepository) GetCategoryByID(ctx context.Context, id uint64) (*models.Category, error) {
+	var category models.Category
+
+	query := cr.db.QueryBuilder.Select("*").
+		From("categories").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&category.ID,
+		&category.Name,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+		&category.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// ListCategories lists all categories from the database
+func (cr *CategoryRepository) ListCategories(ctx context.Context, skip, limit uint64) ([]models.Category, error) {
+	var category models.Category
+	var categories []models.Category
+
+	query := cr.db.QueryBuilder.Select("*").
+		From("categories").
+		OrderBy("name").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+			&category.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+// UpdateCategory updates a category's name by ID in the database
+func (cr *CategoryRepository) UpdateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	query := cr.db.QueryBuilder.Update("categories").
+		Set("name", category.Name).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": category.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&category.ID,
+		&category.Name,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+		&category.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// DeleteCategory deletes a category by ID from the database
+func (cr *CategoryRepository) DeleteCategory(ctx context.Context, id uint64) error {
+	query := cr.db.QueryBuilder.Delete("categories").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = cr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := cr.db.ErrorCode(err); errCode == "23503" {
+			return models.ErrCategoryInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+var CategoryRepositoryModule = fx.Module(
+	"categories-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewCategoryRepository, fx.As(new(ports.CategoryRepository))),
+	),
+)
@@ -0,0 +1,334 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * CustomerRepository implements ports.CustomerRepository interface
+ * and provides an access to the postgres database
+ */
+type CustomerRepository struct {
+	db *postgres.DB
+}
+
+// NewCustomerRepository creates a new customer repositories instance
+func NewCustomerRepository(db *postgres.DB) *CustomerRepository {
+	return &CustomerRepository{
+		db,
+	}
+}
+
+// CreateCustomer creates a new customer in the database
+func (cr *CustomerRepository) CreateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	query := cr.db.QueryBuilder.Insert("customers").
+		Columns("name", "email", "phone").
+		Values(customer.Name, customer.Email, customer.Phone).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&customer.ID,
+		&customer.Name,
+		&customer.Email,
+		&customer.Phone,
+		&customer.Points,
+		&customer.CreatedAt,
+		&customer.UpdatedAt,
+		&customer.TenantID,
+	)
+	if err != nil {
+		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// GetCustomerByID gets a customer by ID from the database
+func (cr *CustomerRepository) GetCustomerByID(ctx context.Context, id uint64) (*models.Customer, error) {
+	var customer models.Customer
+
+	query := cr.db.QueryBuilder.Select("*").
+		From("customers").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&customer.ID,
+		&customer.Name,
+		&customer.Email,
+		&customer.Phone,
+		&customer.Points,
+		&customer.CreatedAt,
+		&customer.UpdatedAt,
+		&customer.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+// ListCustomers lists the customers from the database, matching the search on name, email or phone
+func (cr *CustomerRepository) ListCustomers(ctx context.Context, search string, skip, limit uint64) ([]models.Customer, error) {
+	var customer models.Customer
+	var customers []models.Customer
+
+	query := cr.db.QueryBuilder.Select("*").
+		From("customers").
+		OrderBy("name", "id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	if search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		query = query.Where(sq.Or{
+			sq.ILike{"name": pattern},
+			sq.ILike{"email": pattern},
+			sq.ILike{"phone": pattern},
+		})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&customer.ID,
+			&customer.Name,
+			&customer.Email,
+			&customer.Phone,
+			&customer.Points,
+			&customer.CreatedAt,
+			&customer.UpdatedAt,
+			&customer.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		customers = append(customers, customer)
+	}
+
+	return customers, rows.Err()
+}
+
+// UpdateCustomer replaces a customer's contact details by ID in the database, the points are left to the ledger
+func (cr *CustomerRepository) UpdateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	query := cr.db.QueryBuilder.Update("customers").
+		Set("name", customer.Name).
+		Set("email", customer.Email).
+		Set("phone", customer.Phone).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": customer.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = cr.db.QueryRow(ctx, sql, args...).Scan(
+		&customer.ID,
+		&customer.Name,
+		&customer.Email,
+		&customer.Phone,
+		&customer.Points,
+		&customer.CreatedAt,
+		&customer.UpdatedAt,
+		&customer.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		if errCode := cr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// DeleteCustomer deletes a customer by ID from the database
+func (cr *CustomerRepository) DeleteCustomer(ctx context.Context, id uint64) error {
+	query := cr.db.QueryBuilder.Delete("customers").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = cr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := cr.db.ErrorCode(err); errCode == "23503" {
+			return models.ErrCustomerInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CreateLoyaltyEntry records an entry in the loyalty ledger of a customer in a single transaction
+func (cr *CustomerRepository) CreateLoyaltyEntry(ctx context.Context, entry *models.LoyaltyEntry) (*models.LoyaltyEntry, error) {
+	tx, err := cr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = recordLoyaltyEntry(ctx, cr.db, tx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ListLoyaltyEntries lists the ledger of a customer from the database, the newest first
+func (cr *CustomerRepository) ListLoyaltyEntries(ctx context.Context, customerID, skip, limit uint64) ([]models.LoyaltyEntry, error) {
+	var entry models.LoyaltyEntry
+	var entries []models.LoyaltyEntry
+
+	query := cr.db.QueryBuilder.Select("*").
+		From("loyalty_entries").
+		Where(sq.Eq{"customer_id": customerID}).
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := cr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&entry.ID,
+			&entry.CustomerID,
+			&entry.Type,
+			&entry.Points,
+			&entry.BalanceAfter,
+			&entry.Reason,
+			&entry.OrderID,
+			&entry.UserID,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// recordLoyaltyEntry locks the customer, appends the entry to their ledger and updates their
+// balance within the given transaction. Only the points a refund takes back may overdraw the
+// balance, as they may have been redeemed already
+func recordLoyaltyEntry(ctx context.Context, db *postgres.DB, tx pgx.Tx, entry *models.LoyaltyEntry) error {
+	var points int64
+
+	query := db.QueryBuilder.Select("points").
+		From("customers").
+		Where(sq.Eq{"id": entry.CustomerID}).
+		Suffix("FOR UPDATE")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&points)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return models.ErrInvalidCustomer
+		}
+		return err
+	}
+
+	entry.BalanceAfter = points + entry.Points
+	if entry.BalanceAfter < 0 && entry.Type != models.LoyaltyReversal {
+		return models.ErrInsufficientPoints
+	}
+
+	update := db.QueryBuilder.Update("customers").
+		Set("points", entry.BalanceAfter).
+		Where(sq.Eq{"id": entry.CustomerID})
+
+	sql, args, err = update.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	insert := db.QueryBuilder.Insert("loyalty_entries").
+		Columns("customer_id", "type", "points", "balance_after", "reason", "order_id", "user_id").
+		Values(entry.CustomerID, entry.Type, entry.Points, entry.BalanceAfter, entry.Reason, entry.OrderID, entry.UserID).
+		Suffix("RETURNING id, created_at")
+
+	sql, args, err = insert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRow(ctx, sql, args...).Scan(&entry.ID, &entry.CreatedAt)
+}
+
+var CustomerRepositoryModule = fx.Module(
+	"customers-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewCustomerRepository, fx.As(new(ports.CustomerRepository))),
+	),
+)
@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * EmailChangeRepository implements ports.EmailChangeRepository interface
+ * and provides an access to the postgres database
+ */
+type EmailChangeRepository struct {
+	db *postgres.DB
+}
+
+// NewEmailChangeRepository creates a new email change repositories instance
+func NewEmailChangeRepository(db *postgres.DB) *EmailChangeRepository {
+	return &EmailChangeRepository{
+		db,
+	}
+}
+
+// CreateEmailChange creates a new email change in the database
+func (ecr *EmailChangeRepository) CreateEmailChange(ctx context.Context, change *models.EmailChange) (*models.EmailChange, error) {
+	query := ecr.db.QueryBuilder.Insert("email_changes").
+		Columns("user_id", "old_email", "new_email", "token_id", "revert_token_id", "expires_at").
+		Values(change.UserID, change.OldEmail, change.NewEmail, change.TokenID, change.RevertTokenID, change.ExpiresAt).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ecr.db.QueryRow(ctx, sql, args...).Scan(
+		&change.ID,
+		&change.UserID,
+		&change.OldEmail,
+		&change.NewEmail,
+		&change.TokenID,
+		&change.RevertTokenID,
+		&change.ExpiresAt,
+		&change.ConfirmedAt,
+		&change.RevertedAt,
+		&change.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// GetEmailChangeByTokenID gets an email change by the id signed into its confirmation link from the database
+func (ecr *EmailChangeRepository) GetEmailChangeByTokenID(ctx context.Context, tokenID string) (*models.EmailChange, error) {
+	query := ecr.db.QueryBuilder.Select("*").
+		From("email_changes").
+		Where(sq.Eq{"token_id": tokenID}).
+		Limit(1)
+
+	return ecr.getEmailChange(ctx, query)
+}
+
+// GetEmailChangeByRevertTokenID gets an email change by the id signed into its revert link from the database
+func (ecr *EmailChangeRepository) GetEmailChangeByRevertTokenID(ctx context.Context, tokenID string) (*models.EmailChange, error) {
+	query := ecr.db.QueryBuilder.Select("*").
+		From("email_changes").
+		Where(sq.Eq{"revert_token_id": tokenID}).
+		Limit(1)
+
+	return ecr.getEmailChange(ctx, query)
+}
+
+// getEmailChange runs a query that selects a single email change
+func (ecr *EmailChangeRepository) getEmailChange(ctx context.Context, query sq.SelectBuilder) (*models.EmailChange, error) {
+	var change models.EmailChange
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ecr.db.QueryRow(ctx, sql, args...).Scan(
+		&change.ID,
+		&change.UserID,
+		&change.OldEmail,
+		&change.NewEmail,
+		&change.TokenID,
+		&change.RevertTokenID,
+		&change.ExpiresAt,
+		&change.ConfirmedAt,
+		&change.RevertedAt,
+		&change.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+// DeletePendingEmailChanges deletes the email changes of a user that were never confirmed or reverted from the database
+func (ecr *EmailChangeRepository) DeletePendingEmailChanges(ctx context.Context, userID uint64) error {
+	query := ecr.db.QueryBuilder.Delete("email_changes").
+		Where(sq.Eq{"user_id": userID, "confirmed_at": nil, "reverted_at": nil})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = ecr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange marks a pending email change as confirmed in the database
+func (ecr *EmailChangeRepository) ConfirmEmailChange(ctx context.Context, id uint64) error {
+	query := ecr.db.QueryBuilder.Update("email_changes").
+		Set("confirmed_at", time.Now()).
+		Where(sq.Eq{"id": id, "confirmed_at": nil, "reverted_at": nil})
+
+	return ecr.closeEmailChange(ctx, query)
+}
+
+// RevertEmailChange marks an email change that was not reverted yet as reverted in the database
+func (ecr *EmailChangeRepository) RevertEmailChange(ctx context.Context, id uint64) error {
+	query := ecr.db.QueryBuilder.Update("email_changes").
+		Set("reverted_at", time.Now()).
+		Where(sq.Eq{"id": id, "reverted_at": nil})
+
+	return ecr.closeEmailChange(ctx, query)
+}
+
+// closeEmailChange runs a conditional update, a link that lost the race to another request is invalid
+func (ecr *EmailChangeRepository) closeEmailChange(ctx context.Context, query sq.UpdateBuilder) error {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := ecr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrInvalidLink
+	}
+
+	return nil
+}
+
+var EmailChangeRepositoryModule = fx.Module(
+	"email-changes-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewEmailChangeRepository, fx.As(new(ports.EmailChangeRepository))),
+	),
+)
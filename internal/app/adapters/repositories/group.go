@@ -0,0 +1,405 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * GroupRepository implements ports.GroupRepository interface
+ * and provides an access to the postgres database
+ */
+type GroupRepository struct {
+	db *postgres.DB
+}
+
+// NewGroupRepository creates a new group repositories instance
+func NewGroupRepository(db *postgres.DB) *GroupRepository {
+	return &GroupRepository{
+		db,
+	}
+}
+
+// CreateGroup creates a new group in the database
+func (gr *GroupRepository) CreateGroup(ctx context.Context, group *models.Group) (*models.Group, error) {
+	query := gr.db.QueryBuilder.Insert("groups").
+		Columns("name", "description", "parent_id").
+		Values(group.Name, group.Description, group.ParentID).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = gr.db.QueryRow(ctx, sql, args...).Scan(
+		&group.ID,
+		&group.Name,
+		&group.Description,
+		&group.ParentID,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.TenantID,
+	)
+	if err != nil {
+		if errCode := gr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// GetGroupByID gets a group by ID from the database
+func (gr *GroupRepository) GetGroupByID(ctx context.Context, id uint64) (*models.Group, error) {
+	var group models.Group
+
+	query := gr.db.QueryBuilder.Select("*").
+		From("groups").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = gr.db.QueryRow(ctx, sql, args...).Scan(
+		&group.ID,
+		&group.Name,
+		&group.Description,
+		&group.ParentID,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// ListGroups lists all groups from the database
+func (gr *GroupRepository) ListGroups(ctx context.Context, skip, limit uint64) ([]models.Group, error) {
+	var group models.Group
+	var groups []models.Group
+
+	query := gr.db.QueryBuilder.Select("*").
+		From("groups").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		group.ParentID = nil
+		err := rows.Scan(
+			&group.ID,
+			&group.Name,
+			&group.Description,
+			&group.ParentID,
+			&group.CreatedAt,
+			&group.UpdatedAt,
+			&group.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// ListAncestorIDs walks up the parent chain of a group in the database
+func (gr *GroupRepository) ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error) {
+	var ancestorIDs []uint64
+
+	sql := `WITH RECURSIVE ancestors AS (
+		SELECT parent_id FROM groups WHERE id = $1
+		UNION
+		SELECT g.parent_id FROM groups g JOIN ancestors a ON g.id = a.parent_id
+	)
+	SELECT parent_id FROM ancestors WHERE parent_id IS NOT NULL`
+
+	rows, err := gr.db.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ancestorID uint64
+		err := rows.Scan(&ancestorID)
+		if err != nil {
+			return nil, err
+		}
+
+		ancestorIDs = append(ancestorIDs, ancestorID)
+	}
+
+	return ancestorIDs, rows.Err()
+}
+
+// UpdateGroup updates a group by ID in the database
+func (gr *GroupRepository) UpdateGroup(ctx context.Context, group *models.Group) (*models.Group, error) {
+	name := utils.NullString(group.Name)
+	description := utils.NullString(group.Description)
+
+	query := gr.db.QueryBuilder.Update("groups").
+		Set("name", sq.Expr("COALESCE(?, name)", name)).
+		Set("description", sq.Expr("COALESCE(?, description)", description)).
+		Set("parent_id", group.ParentID).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": group.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = gr.db.QueryRow(ctx, sql, args...).Scan(
+		&group.ID,
+		&group.Name,
+		&group.Description,
+		&group.ParentID,
+		&group.CreatedAt,
+		&group.UpdatedAt,
+		&group.TenantID,
+	)
+	if err != nil {
+		if errCode := gr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// DeleteGroup deletes a group by ID from the database
+func (gr *GroupRepository) DeleteGroup(ctx context.Context, id uint64) error {
+	query := gr.db.QueryBuilder.Delete("groups").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = gr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddMember inserts a group membership into the database
+func (gr *GroupRepository) AddMember(ctx context.Context, member *models.GroupMember) (*models.GroupMember, error) {
+	query := gr.db.QueryBuilder.Insert("group_members").
+		Columns("group_id", "user_id").
+		Values(member.GroupID, member.UserID).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = gr.db.QueryRow(ctx, sql, args...).Scan(
+		&member.GroupID,
+		&member.UserID,
+		&member.CreatedAt,
+	)
+	if err != nil {
+		if errCode := gr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// ListMembers lists the users of a group from the database
+func (gr *GroupRepository) ListMembers(ctx context.Context, groupID, skip, limit uint64) ([]models.User, error) {
+	var user models.User
+	var users []models.User
+
+	query := gr.db.QueryBuilder.Select("u.*").
+		From("users u").
+		Join("group_members gm ON gm.user_id = u.id").
+		Where(sq.Eq{"gm.group_id": groupID}).
+		OrderBy("u.id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.Password,
+			&user.Role,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.Version,
+			&user.Avatar,
+			&user.StoreID,
+			&user.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// DeleteMember deletes a group membership from the database
+func (gr *GroupRepository) DeleteMember(ctx context.Context, groupID, userID uint64) error {
+	query := gr.db.QueryBuilder.Delete("group_members").
+		Where(sq.Eq{"group_id": groupID, "user_id": userID})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := gr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// AddRole inserts a group role assignment into the database
+func (gr *GroupRepository) AddRole(ctx context.Context, groupID uint64, role models.UserRole) error {
+	query := gr.db.QueryBuilder.Insert("group_roles").
+		Columns("group_id", "role").
+		Values(groupID, role)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = gr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		switch gr.db.ErrorCode(err) {
+		case "23505":
+			return models.ErrConflictingData
+		case "23503":
+			return models.ErrInvalidRole
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ListRoles lists the roles assigned to a group from the database
+func (gr *GroupRepository) ListRoles(ctx context.Context, groupID uint64) ([]models.UserRole, error) {
+	var roles []models.UserRole
+
+	query := gr.db.QueryBuilder.Select("role").
+		From("group_roles").
+		Where(sq.Eq{"group_id": groupID}).
+		OrderBy("role")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := gr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var role string
+		err := rows.Scan(&role)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, models.UserRole(role))
+	}
+
+	return roles, nil
+}
+
+// DeleteRole deletes a group role assignment from the database
+func (gr *GroupRepository) DeleteRole(ctx context.Context, groupID uint64, role models.UserRole) error {
+	query := gr.db.QueryBuilder.Delete("group_roles").
+		Where(sq.Eq{"group_id": groupID, "role": role})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := gr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrDataNotFound
+	}
+
+	return nil
+}
+
+var GroupRepositoryModule = fx.Module(
+	"groups-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewGroupRepository, fx.As(new(ports.GroupRepository))),
+	),
+)
@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * InvitationRepository implements ports.InvitationRepository interface
+ * and provides an access to the postgres database
+ */
+type InvitationRepository struct {
+	db *postgres.DB
+}
+
+// NewInvitationRepository creates a new invitation repositories instance
+func NewInvitationRepository(db *postgres.DB) *InvitationRepository {
+	return &InvitationRepository{
+		db,
+	}
+}
+
+// CreateInvitation creates a new invitation in the database
+func (ir *InvitationRepository) CreateInvitation(ctx context.Context, invitation *models.Invitation) (*models.Invitation, error) {
+	query := ir.db.QueryBuilder.Insert("invitations").
+		Columns("email", "role", "token_id", "invited_by", "expires_at").
+		Values(invitation.Email, invitation.Role, invitation.TokenID, invitation.InvitedBy, invitation.ExpiresAt).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ir.db.QueryRow(ctx, sql, args...).Scan(
+		&invitation.ID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.TokenID,
+		&invitation.InvitedBy,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+		&invitation.RevokedAt,
+		&invitation.CreatedAt,
+		&invitation.TenantID,
+	)
+	if err != nil {
+		if errCode := ir.db.ErrorCode(err); errCode == "23503" {
+			return nil, models.ErrInvalidRole
+		}
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// GetInvitationByID gets an invitation by ID from the database
+func (ir *InvitationRepository) GetInvitationByID(ctx context.Context, id uint64) (*models.Invitation, error) {
+	query := ir.db.QueryBuilder.Select("*").
+		From("invitations").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	return ir.getInvitation(ctx, query)
+}
+
+// GetInvitationByTokenID gets an invitation by the id signed into its link from the database
+func (ir *InvitationRepository) GetInvitationByTokenID(ctx context.Context, tokenID string) (*models.Invitation, error) {
+	query := ir.db.QueryBuilder.Select("*").
+		From("invitations").
+		Where(sq.Eq{"token_id": tokenID}).
+		Limit(1)
+
+	return ir.getInvitation(ctx, query)
+}
+
+// GetPendingInvitationByEmail gets the latest open invitation of an email from the database
+func (ir *InvitationRepository) GetPendingInvitationByEmail(ctx context.Context, email string) (*models.Invitation, error) {
+	query := ir.db.QueryBuilder.Select("*").
+		From("invitations").
+		Where(sq.Eq{"email": email, "accepted_at": nil, "revoked_at": nil}).
+		OrderBy("id DESC").
+		Limit(1)
+
+	return ir.getInvitation(ctx, query)
+}
+
+// getInvitation runs a query that selects a single invitation
+func (ir *InvitationRepository) getInvitation(ctx context.Context, query sq.SelectBuilder) (*models.Invitation, error) {
+	var invitation models.Invitation
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ir.db.QueryRow(ctx, sql, args...).Scan(
+		&invitation.ID,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.TokenID,
+		&invitation.InvitedBy,
+		&invitation.ExpiresAt,
+		&invitation.AcceptedAt,
+		&invitation.RevokedAt,
+		&invitation.CreatedAt,
+		&invitation.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+// ListInvitations lists all invitations from the database, newest first
+func (ir *InvitationRepository) ListInvitations(ctx context.Context, skip, limit uint64) ([]models.Invitation, error) {
+	var invitations []models.Invitation
+
+	query := ir.db.QueryBuilder.Select("*").
+		From("invitations").
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ir.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var invitation models.Invitation
+		err := rows.Scan(
+			&invitation.ID,
+			&invitation.Email,
+			&invitation.Role,
+			&invitation.TokenID,
+			&invitation.InvitedBy,
+			&invitation.ExpiresAt,
+			&invitation.AcceptedAt,
+			&invitation.RevokedAt,
+			&invitation.CreatedAt,
+			&invitation.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation marks an open invitation as accepted in the database
+func (ir *InvitationRepository) AcceptInvitation(ctx context.Context, id uint64) error {
+	return ir.closeInvitation(ctx, id, "accepted_at")
+}
+
+// RevokeInvitation marks an open invitation as revoked in the database
+func (ir *InvitationRepository) RevokeInvitation(ctx context.Context, id uint64) error {
+	return ir.closeInvitation(ctx, id, "revoked_at")
+}
+
+// closeInvitation sets the given timestamp column only while the invitation is still open,
+// so an invitation can never be both accepted and revoked
+func (ir *InvitationRepository) closeInvitation(ctx context.Context, id uint64, column string) error {
+	query := ir.db.QueryBuilder.Update("invitations").
+		Set(column, time.Now()).
+		Where(sq.Eq{"id": id, "accepted_at": nil, "revoked_at": nil})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := ir.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrInvalidLink
+	}
+
+	return nil
+}
+
+var InvitationRepositoryModule = fx.Module(
+	"invitations-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewInvitationRepository, fx.As(new(ports.InvitationRepository))),
+	),
+)
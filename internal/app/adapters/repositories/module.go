@@ -7,4 +7,21 @@ import (
 var Module = fx.Module(
 	"repositories-module",
 	UserRepositoryModule,
+	RoleRepositoryModule,
+	GroupRepositoryModule,
+	InvitationRepositoryModule,
+	EmailChangeRepositoryModule,
+	AuditRepositoryModule,
+	PreferenceRepositoryModule,
+	CategoryRepositoryModule,
+	ProductRepositoryModule,
+	OrderRepositoryModule,
+	StockRepositoryModule,
+	ReportRepositoryModule,
+	PromotionRepositoryModule,
+	RefundRepositoryModule,
+	StoreRepositoryModule,
+	TaxRepositoryModule,
+	CustomerRepositoryModule,
+	TenantRepositoryModule,
 )
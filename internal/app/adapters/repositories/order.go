@@ -0,0 +1,609 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * OrderRepository implements ports.OrderRepository interface
+ * and provides an access to the postgres database
+ */
+type OrderRepository struct {
+	db *postgres.DB
+}
+
+// NewOrderRepository creates a new order repositories instance
+func NewOrderRepository(db *postgres.DB) *OrderRepository {
+	return &OrderRepository{
+		db,
+	}
+}
+
+// orderItemColumns are the order item columns in the order they are scanned,
+// the product of a deleted product is read as zero
+var orderItemColumns = []string{
+	"id", "order_id", "COALESCE(product_id, 0)", "sku", "name", "quantity", "price", "total_price",
+}
+
+// CreateOrder inserts the order with its items, discounts and taxes, records a sale movement for each
+// item, counts a use of each applied promotion and records the points of the customer in a single
+// transaction, which locks the ordered products. The items should be sorted by product, so concurrent
+// orders lock the product rows in the same order
+func (or *OrderRepository) CreateOrder(ctx context.Context, order *models.Order) (*models.Order, []models.StockMovement, error) {
+	tx, err := or.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	insert := or.db.QueryBuilder.Insert("orders").
+		Columns(
+			"user_id", "payment_method", "total_price", "total_paid", "total_change", "coupon_code", "total_discount",
+			"store_id", "total_tax", "customer_id", "points_earned", "points_redeemed", "status", "paid_at",
+		).
+		Values(
+			order.UserID, order.PaymentMethod, order.TotalPrice, order.TotalPaid, order.TotalChange,
+			order.CouponCode, order.TotalDiscount, order.StoreID, order.TotalTax, order.CustomerID,
+			order.PointsEarned, order.PointsRedeemed, order.Status, paidAt(order.Status),
+		).
+		Suffix("RETURNING id, created_at, updated_at, paid_at")
+
+	sql, args, err := insert.ToSql()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&order.ID,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.PaidAt,
+	)
+	if err != nil {
+		if errCode := or.db.ErrorCode(err); errCode == "23503" {
+			if or.db.ConstraintName(err) == "orders_customer_id_fkey" {
+				return nil, nil, models.ErrInvalidCustomer
+			}
+			return nil, nil, models.ErrInvalidStore
+		}
+		return nil, nil, err
+	}
+
+	movements := make([]models.StockMovement, len(order.Items))
+
+	for i := range order.Items {
+		item := &order.Items[i]
+		item.OrderID = order.ID
+
+		movement := &movements[i]
+		movement.ProductID = item.ProductID
+		movement.Type = models.StockSale
+		movement.Quantity = -item.Quantity
+		movement.OrderID = &order.ID
+		movement.UserID = &order.UserID
+		movement.StoreID = order.StoreID
+
+		err := recordMovement(ctx, or.db, tx, movement)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		insert := or.db.QueryBuilder.Insert("order_items").
+			Columns("order_id", "product_id", "sku", "name", "quantity", "price", "total_price").
+			Values(item.OrderID, item.ProductID, item.SKU, item.Name, item.Quantity, item.Price, item.TotalPrice).
+			Suffix("RETURNING id")
+
+		sql, args, err := insert.ToSql()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(&item.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for i := range order.Discounts {
+		discount := &order.Discounts[i]
+		discount.OrderID = order.ID
+
+		err := or.usePromotion(ctx, tx, discount.PromotionID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		insert := or.db.QueryBuilder.Insert("order_discounts").
+			Columns("order_id", "promotion_id", "name", "coupon_code", "amount").
+			Values(discount.OrderID, discount.PromotionID, discount.Name, discount.CouponCode, discount.Amount).
+			Suffix("RETURNING id")
+
+		sql, args, err := insert.ToSql()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(&discount.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for i := range order.Taxes {
+		tax := &order.Taxes[i]
+		tax.OrderID = order.ID
+
+		insert := or.db.QueryBuilder.Insert("order_taxes").
+			Columns("order_id", "tax_rate_id", "name", "rate", "inclusive", "taxable_amount", "amount").
+			Values(tax.OrderID, tax.TaxRateID, tax.Name, tax.Rate, tax.Inclusive, tax.TaxableAmount, tax.Amount).
+			Suffix("RETURNING id")
+
+		sql, args, err := insert.ToSql()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(&tax.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// the points are redeemed before they are earned, so an order never pays with the points it earns
+	if order.PointsRedeemed > 0 {
+		err := or.recordPoints(ctx, tx, order, models.LoyaltyRedeem, -order.PointsRedeemed)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if order.PointsEarned > 0 {
+		err := or.recordPoints(ctx, tx, order, models.LoyaltyEarn, order.PointsEarned)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return order, movements, nil
+}
+
+// paidAt returns the time an order placed with the given status is paid for, which is when it is
+// placed unless it is opened to be settled later
+func paidAt(status models.OrderStatus) any {
+	if status != models.OrderPaid {
+		return nil
+	}
+	return sq.Expr("now()")
+}
+
+// SettleOrder takes the payment of an open order and records the points its customer earns, in a
+// single transaction which locks the order, so an order is not settled once it was voided
+func (or *OrderRepository) SettleOrder(ctx context.Context, order *models.Order) (*models.Order, error) {
+	tx, err := or.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	lock := or.db.QueryBuilder.Select("status").
+		From("orders").
+		Where(sq.Eq{"id": order.ID}).
+		Suffix("FOR UPDATE")
+
+	sql, args, err := lock.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var status models.OrderStatus
+	err = tx.QueryRow(ctx, sql, args...).Scan(&status)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	// an open order is voided by its only refund
+	count := or.db.QueryBuilder.Select("count(*)").
+		From("refunds").
+		Where(sq.Eq{"order_id": order.ID})
+
+	sql, args, err = count.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var refunds int
+	err = tx.QueryRow(ctx, sql, args...).Scan(&refunds)
+	if err != nil {
+		return nil, err
+	}
+	if status != models.OrderOpen || refunds > 0 {
+		return nil, models.ErrOrderNotOpen
+	}
+
+	update := or.db.QueryBuilder.Update("orders").
+		Set("status", models.OrderPaid).
+		Set("payment_method", order.PaymentMethod).
+		Set("total_paid", order.TotalPaid).
+		Set("total_change", order.TotalChange).
+		Set("points_earned", order.PointsEarned).
+		Set("paid_at", sq.Expr("now()")).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": order.ID}).
+		Suffix("RETURNING updated_at, paid_at")
+
+	sql, args, err = update.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&order.UpdatedAt, &order.PaidAt)
+	if err != nil {
+		return nil, err
+	}
+	order.Status = models.OrderPaid
+
+	if order.PointsEarned > 0 {
+		err := or.recordPoints(ctx, tx, order, models.LoyaltyEarn, order.PointsEarned)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// GetOrderByID gets an order with its items by ID from the database
+func (or *OrderRepository) GetOrderByID(ctx context.Context, id uint64) (*models.Order, error) {
+	var order models.Order
+
+	query := or.db.QueryBuilder.Select("*").
+		From("orders").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = or.db.QueryRow(ctx, sql, args...).Scan(
+		&order.ID,
+		&order.UserID,
+		&order.PaymentMethod,
+		&order.TotalPrice,
+		&order.TotalPaid,
+		&order.TotalChange,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.CouponCode,
+		&order.TotalDiscount,
+		&order.StoreID,
+		&order.TotalTax,
+		&order.CustomerID,
+		&order.PointsEarned,
+		&order.PointsRedeemed,
+		&order.TenantID,
+		&order.Status,
+		&order.PaidAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	items, err := or.listItems(ctx, []uint64{order.ID})
+	if err != nil {
+		return nil, err
+	}
+	order.Items = items[order.ID]
+
+	discounts, err := or.listDiscounts(ctx, []uint64{order.ID})
+	if err != nil {
+		return nil, err
+	}
+	order.Discounts = discounts[order.ID]
+
+	taxes, err := or.listTaxes(ctx, []uint64{order.ID})
+	if err != nil {
+		return nil, err
+	}
+	order.Taxes = taxes[order.ID]
+
+	return &order, nil
+}
+
+// ListOrders lists the orders with their items from the database, the newest first
+func (or *OrderRepository) ListOrders(ctx context.Context, storeID, skip, limit uint64) ([]models.Order, error) {
+	query := or.db.QueryBuilder.Select("*").
+		From("orders").
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	if storeID != 0 {
+		query = query.Where(sq.Eq{"store_id": storeID})
+	}
+
+	return or.listOrders(ctx, query)
+}
+
+// ListCustomerOrders lists the orders of a customer with their items from the database, the newest first
+func (or *OrderRepository) ListCustomerOrders(ctx context.Context, customerID, skip, limit uint64) ([]models.Order, error) {
+	query := or.db.QueryBuilder.Select("*").
+		From("orders").
+		Where(sq.Eq{"customer_id": customerID}).
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	return or.listOrders(ctx, query)
+}
+
+// listOrders selects the orders of the query with their items, discounts and taxes
+func (or *OrderRepository) listOrders(ctx context.Context, query sq.SelectBuilder) ([]models.Order, error) {
+	var order models.Order
+	var orders []models.Order
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := or.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint64
+	for rows.Next() {
+		err := rows.Scan(
+			&order.ID,
+			&order.UserID,
+			&order.PaymentMethod,
+			&order.TotalPrice,
+			&order.TotalPaid,
+			&order.TotalChange,
+			&order.CreatedAt,
+			&order.UpdatedAt,
+			&order.CouponCode,
+			&order.TotalDiscount,
+			&order.StoreID,
+			&order.TotalTax,
+			&order.CustomerID,
+			&order.PointsEarned,
+			&order.PointsRedeemed,
+			&order.TenantID,
+			&order.Status,
+			&order.PaidAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+		ids = append(ids, order.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(orders) == 0 {
+		return orders, nil
+	}
+
+	items, err := or.listItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	discounts, err := or.listDiscounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	taxes, err := or.listTaxes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range orders {
+		orders[i].Items = items[orders[i].ID]
+		orders[i].Discounts = discounts[orders[i].ID]
+		orders[i].Taxes = taxes[orders[i].ID]
+	}
+
+	return orders, nil
+}
+
+// recordPoints records a loyalty entry of the order for its customer within the given transaction
+func (or *OrderRepository) recordPoints(ctx context.Context, tx pgx.Tx, order *models.Order, entryType models.LoyaltyEntryType, points int64) error {
+	entry := &models.LoyaltyEntry{
+		CustomerID: *order.CustomerID,
+		Type:       entryType,
+		Points:     points,
+		OrderID:    &order.ID,
+		UserID:     &order.UserID,
+	}
+
+	return recordLoyaltyEntry(ctx, or.db, tx, entry)
+}
+
+// listItems selects the items of the given orders, grouped by order
+func (or *OrderRepository) listItems(ctx context.Context, orderIDs []uint64) (map[uint64][]models.OrderItem, error) {
+	items := map[uint64][]models.OrderItem{}
+
+	query := or.db.QueryBuilder.Select(orderItemColumns...).
+		From("order_items").
+		Where(sq.Eq{"order_id": orderIDs}).
+		OrderBy("id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := or.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.OrderItem
+
+		err := rows.Scan(
+			&item.ID,
+			&item.OrderID,
+			&item.ProductID,
+			&item.SKU,
+			&item.Name,
+			&item.Quantity,
+			&item.Price,
+			&item.TotalPrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		items[item.OrderID] = append(items[item.OrderID], item)
+	}
+
+	return items, rows.Err()
+}
+
+// listDiscounts selects the discounts of the given orders, grouped by order
+func (or *OrderRepository) listDiscounts(ctx context.Context, orderIDs []uint64) (map[uint64][]models.OrderDiscount, error) {
+	discounts := map[uint64][]models.OrderDiscount{}
+
+	query := or.db.QueryBuilder.Select("*").
+		From("order_discounts").
+		Where(sq.Eq{"order_id": orderIDs}).
+		OrderBy("id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := or.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var discount models.OrderDiscount
+
+		err := rows.Scan(
+			&discount.ID,
+			&discount.OrderID,
+			&discount.PromotionID,
+			&discount.Name,
+			&discount.CouponCode,
+			&discount.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		discounts[discount.OrderID] = append(discounts[discount.OrderID], discount)
+	}
+
+	return discounts, rows.Err()
+}
+
+// listTaxes selects the taxes of the given orders, grouped by order
+func (or *OrderRepository) listTaxes(ctx context.Context, orderIDs []uint64) (map[uint64][]models.OrderTax, error) {
+	taxes := map[uint64][]models.OrderTax{}
+
+	query := or.db.QueryBuilder.Select("*").
+		From("order_taxes").
+		Where(sq.Eq{"order_id": orderIDs}).
+		OrderBy("id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := or.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tax models.OrderTax
+
+		err := rows.Scan(
+			&tax.ID,
+			&tax.OrderID,
+			&tax.TaxRateID,
+			&tax.Name,
+			&tax.Rate,
+			&tax.Inclusive,
+			&tax.TaxableAmount,
+			&tax.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		taxes[tax.OrderID] = append(taxes[tax.OrderID], tax)
+	}
+
+	return taxes, rows.Err()
+}
+
+// usePromotion counts a use of a promotion unless it has reached its usage limit,
+// the row lock taken by the update makes concurrent orders wait for each other
+func (or *OrderRepository) usePromotion(ctx context.Context, tx pgx.Tx, id uint64) error {
+	update := or.db.QueryBuilder.Update("promotions").
+		Set("usage_count", sq.Expr("usage_count + 1")).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Or{sq.Eq{"usage_limit": 0}, sq.Expr("usage_count < usage_limit")})
+
+	sql, args, err := update.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	// the promotion was used up, or deleted, since the order was priced
+	if tag.RowsAffected() == 0 {
+		return models.ErrPromotionExhausted
+	}
+
+	return nil
+}
+
+var OrderRepositoryModule = fx.Module(
+	"orders-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewOrderRepository, fx.As(new(ports.OrderRepository))),
+	),
+)
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+/**
+ * PreferenceRepository implements ports.PreferenceRepository interface
+ * and provides an access to the postgres database
+ */
+type PreferenceRepository struct {
+	db *postgres.DB
+}
+
+// NewPreferenceRepository creates a new preference repositories instance
+func NewPreferenceRepository(db *postgres.DB) *PreferenceRepository {
+	return &PreferenceRepository{
+		db,
+	}
+}
+
+// GetPreferences selects the preferences a user has set from the database
+func (pr *PreferenceRepository) GetPreferences(ctx context.Context, userID uint64) (models.Preferences, error) {
+	preferences := models.Preferences{}
+
+	query := pr.db.QueryBuilder.Select("key", "value").
+		From("user_preferences").
+		Where(sq.Eq{"user_id": userID})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key models.PreferenceKey
+		var raw []byte
+		var value any
+
+		err := rows.Scan(&key, &raw)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(raw, &value)
+		if err != nil {
+			return nil, err
+		}
+
+		preferences[key] = value
+	}
+
+	return preferences, rows.Err()
+}
+
+// SavePreferences upserts the set preferences and deletes the reset ones in a single transaction
+func (pr *PreferenceRepository) SavePreferences(ctx context.Context, userID uint64, set models.Preferences, reset []models.PreferenceKey) error {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if len(reset) > 0 {
+		keys := make([]string, len(reset))
+		for i, key := range reset {
+			keys[i] = string(key)
+		}
+
+		query := pr.db.QueryBuilder.Delete("user_preferences").
+			Where(sq.Eq{"user_id": userID, "key": keys})
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(set) > 0 {
+		query := pr.db.QueryBuilder.Insert("user_preferences").
+			Columns("user_id", "key", "value").
+			Suffix(`ON CONFLICT ("user_id", "key") DO UPDATE SET "value" = EXCLUDED."value", "updated_at" = now()`)
+
+		for key, value := range set {
+			// raw json bytes are stored as they are, a plain string would not be valid json
+			raw, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			query = query.Values(userID, string(key), raw)
+		}
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+var PreferenceRepositoryModule = fx.Module(
+	"preference-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewPreferenceRepository, fx.As(new(ports.PreferenceRepository))),
+	),
+)
@@ -0,0 +1,261 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * ProductRepository implements ports.ProductRepository interface
+ * and provides an access to the postgres database
+ */
+type ProductRepository struct {
+	db *postgres.DB
+}
+
+// NewProductRepository creates a new product repositories instance
+func NewProductRepository(db *postgres.DB) *ProductRepository {
+	return &ProductRepository{
+		db,
+	}
+}
+
+// likeEscaper escapes the pattern characters of a LIKE operand
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// CreateProduct creates a new product without stock in the database
+func (pr *ProductRepository) CreateProduct(ctx context.Context, product *models.Product) (*models.Product, error) {
+	query := pr.db.QueryBuilder.Insert("products").
+		Columns("category_id", "sku", "name", "image", "price", "low_stock_threshold", "tax_class_id").
+		Values(product.CategoryID, product.SKU, product.Name, product.Image, product.Price, product.LowStockThreshold, product.TaxClassID).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&product.ID,
+		&product.CategoryID,
+		&product.SKU,
+		&product.Name,
+		&product.Image,
+		&product.Price,
+		&product.Stock,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.LowStockThreshold,
+		&product.TaxClassID,
+		&product.TenantID,
+	)
+	if err != nil {
+		switch pr.db.ErrorCode(err) {
+		case "23505":
+			return nil, models.ErrConflictingData
+		case "23503":
+			if pr.db.ConstraintName(err) == "products_tax_class_id_fkey" {
+				return nil, models.ErrInvalidTaxClass
+			}
+			return nil, models.ErrInvalidCategory
+		}
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// GetProductByID gets a product by ID from the database
+func (pr *ProductRepository) GetProductByID(ctx context.Context, id uint64) (*models.Product, error) {
+	var product models.Product
+
+	query := pr.db.QueryBuilder.Select("*").
+		From("products").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&product.ID,
+		&product.CategoryID,
+		&product.SKU,
+		&product.Name,
+		&product.Image,
+		&product.Price,
+		&product.Stock,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.LowStockThreshold,
+		&product.TaxClassID,
+		&product.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// ListProducts lists the products matching a filter from the database, with their stock
+// in the store of the filter, if any, where a product the store never had is out of stock
+func (pr *ProductRepository) ListProducts(ctx context.Context, filter *models.ProductFilter, skip, limit uint64) ([]models.Product, error) {
+	var product models.Product
+	var products []models.Product
+
+	query := pr.db.QueryBuilder.Select("*").
+		From("products").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	if filter.StoreID != 0 {
+		query = pr.db.QueryBuilder.Select(
+			"products.id", "products.category_id", "products.sku", "products.name", "products.image", "products.price",
+			"COALESCE(store_stocks.stock, 0)", "products.created_at", "products.updated_at", "products.low_stock_threshold",
+			"products.tax_class_id",
+		).
+			From("products").
+			LeftJoin("store_stocks ON store_stocks.product_id = products.id AND store_stocks.store_id = ?", filter.StoreID).
+			OrderBy("products.id").
+			Limit(limit).
+			Offset((skip - 1) * limit)
+	}
+
+	if filter.CategoryID != 0 {
+		query = query.Where(sq.Eq{"category_id": filter.CategoryID})
+	}
+	if filter.Search != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+		query = query.Where(sq.Or{
+			sq.ILike{"name": pattern},
+			sq.ILike{"sku": pattern},
+		})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&product.ID,
+			&product.CategoryID,
+			&product.SKU,
+			&product.Name,
+			&product.Image,
+			&product.Price,
+			&product.Stock,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.LowStockThreshold,
+			&product.TaxClassID,
+			&product.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+// UpdateProduct replaces a product's details by ID in the database, except for its stock
+func (pr *ProductRepository) UpdateProduct(ctx context.Context, product *models.Product) (*models.Product, error) {
+	query := pr.db.QueryBuilder.Update("products").
+		Set("category_id", product.CategoryID).
+		Set("sku", product.SKU).
+		Set("name", product.Name).
+		Set("image", product.Image).
+		Set("price", product.Price).
+		Set("low_stock_threshold", product.LowStockThreshold).
+		Set("tax_class_id", product.TaxClassID).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": product.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = pr.db.QueryRow(ctx, sql, args...).Scan(
+		&product.ID,
+		&product.CategoryID,
+		&product.SKU,
+		&product.Name,
+		&product.Image,
+		&product.Price,
+		&product.Stock,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.LowStockThreshold,
+		&product.TaxClassID,
+		&product.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		switch pr.db.ErrorCode(err) {
+		case "23505":
+			return nil, models.ErrConflictingData
+		case "23503":
+			if pr.db.ConstraintName(err) == "products_tax_class_id_fkey" {
+				return nil, models.ErrInvalidTaxClass
+			}
+			return nil, models.ErrInvalidCategory
+		}
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// DeleteProduct deletes a product by ID from the database
+func (pr *ProductRepository) DeleteProduct(ctx context.Context, id uint64) error {
+	query := pr.db.QueryBuilder.Delete("products").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = pr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var ProductRepositoryModule = fx.Module(
+	"products-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewProductRepository, fx.As(new(ports.ProductRepository))),
+	),
+)
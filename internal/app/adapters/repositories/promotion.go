@@ -0,0 +1,254 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * PromotionRepository implements ports.PromotionRepository interface
+ * and provides an access to the postgres database
+ */
+type PromotionRepository struct {
+	db *postgres.DB
+}
+
+// NewPromotionRepository creates a new promotion repositories instance
+func NewPromotionRepository(db *postgres.DB) *PromotionRepository {
+	return &PromotionRepository{
+		db,
+	}
+}
+
+// scanPromotion scans a promotions row, a pgx.Rows can be scanned as well
+func scanPromotion(row pgx.Row, promotion *models.Promotion) error {
+	return row.Scan(
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.Type,
+		&promotion.Value,
+		&promotion.ProductID,
+		&promotion.CategoryID,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		&promotion.CouponCode,
+		&promotion.UsageLimit,
+		&promotion.UsageCount,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		&promotion.Active,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+		&promotion.TenantID,
+	)
+}
+
+// CreatePromotion creates a new promotion in the database
+func (pr *PromotionRepository) CreatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	query := pr.db.QueryBuilder.Insert("promotions").
+		Columns(
+			"name", "type", "value", "product_id", "category_id", "buy_quantity", "get_quantity",
+			"coupon_code", "usage_limit", "starts_at", "ends_at", "active",
+		).
+		Values(
+			promotion.Name, promotion.Type, promotion.Value, promotion.ProductID, promotion.CategoryID,
+			promotion.BuyQuantity, promotion.GetQuantity, promotion.CouponCode, promotion.UsageLimit,
+			promotion.StartsAt, promotion.EndsAt, promotion.Active,
+		).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanPromotion(pr.db.QueryRow(ctx, sql, args...), promotion)
+	if err != nil {
+		switch pr.db.ErrorCode(err) {
+		case "23505":
+			return nil, models.ErrConflictingData
+		case "23503":
+			return nil, models.ErrInvalidPromotion
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// GetPromotionByID gets a promotion by ID from the database
+func (pr *PromotionRepository) GetPromotionByID(ctx context.Context, id uint64) (*models.Promotion, error) {
+	var promotion models.Promotion
+
+	query := pr.db.QueryBuilder.Select("*").
+		From("promotions").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanPromotion(pr.db.QueryRow(ctx, sql, args...), &promotion)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+// GetPromotionByCouponCode gets a promotion by its coupon code from the database
+func (pr *PromotionRepository) GetPromotionByCouponCode(ctx context.Context, code string) (*models.Promotion, error) {
+	var promotion models.Promotion
+
+	query := pr.db.QueryBuilder.Select("*").
+		From("promotions").
+		Where(sq.Eq{"coupon_code": code}).
+		Where(sq.NotEq{"coupon_code": ""}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanPromotion(pr.db.QueryRow(ctx, sql, args...), &promotion)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+// ListPromotions lists all promotions from the database
+func (pr *PromotionRepository) ListPromotions(ctx context.Context, skip, limit uint64) ([]models.Promotion, error) {
+	query := pr.db.QueryBuilder.Select("*").
+		From("promotions").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	return pr.listPromotions(ctx, query)
+}
+
+// ListAutomaticPromotions lists the active promotions without a coupon code
+// whose validity window contains the given time from the database
+func (pr *PromotionRepository) ListAutomaticPromotions(ctx context.Context, at time.Time) ([]models.Promotion, error) {
+	query := pr.db.QueryBuilder.Select("*").
+		From("promotions").
+		Where(sq.Eq{"active": true, "coupon_code": ""}).
+		Where(sq.Or{sq.Eq{"starts_at": nil}, sq.LtOrEq{"starts_at": at}}).
+		Where(sq.Or{sq.Eq{"ends_at": nil}, sq.Gt{"ends_at": at}}).
+		OrderBy("id")
+
+	return pr.listPromotions(ctx, query)
+}
+
+// listPromotions runs a query selecting promotions
+func (pr *PromotionRepository) listPromotions(ctx context.Context, query sq.SelectBuilder) ([]models.Promotion, error) {
+	var promotion models.Promotion
+	var promotions []models.Promotion
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := scanPromotion(rows, &promotion)
+		if err != nil {
+			return nil, err
+		}
+
+		promotions = append(promotions, promotion)
+	}
+
+	return promotions, rows.Err()
+}
+
+// UpdatePromotion replaces a promotion's rule by ID in the database, except for its usage count
+func (pr *PromotionRepository) UpdatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	query := pr.db.QueryBuilder.Update("promotions").
+		Set("name", promotion.Name).
+		Set("type", promotion.Type).
+		Set("value", promotion.Value).
+		Set("product_id", promotion.ProductID).
+		Set("category_id", promotion.CategoryID).
+		Set("buy_quantity", promotion.BuyQuantity).
+		Set("get_quantity", promotion.GetQuantity).
+		Set("coupon_code", promotion.CouponCode).
+		Set("usage_limit", promotion.UsageLimit).
+		Set("starts_at", promotion.StartsAt).
+		Set("ends_at", promotion.EndsAt).
+		Set("active", promotion.Active).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": promotion.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanPromotion(pr.db.QueryRow(ctx, sql, args...), promotion)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		switch pr.db.ErrorCode(err) {
+		case "23505":
+			return nil, models.ErrConflictingData
+		// a missing product or category, or a usage limit below the usage count
+		case "23503", "23514":
+			return nil, models.ErrInvalidPromotion
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// DeletePromotion deletes a promotion by ID from the database
+func (pr *PromotionRepository) DeletePromotion(ctx context.Context, id uint64) error {
+	query := pr.db.QueryBuilder.Delete("promotions").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = pr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var PromotionRepositoryModule = fx.Module(
+	"promotions-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewPromotionRepository, fx.As(new(ports.PromotionRepository))),
+	),
+)
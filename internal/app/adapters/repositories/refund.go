@@ -0,0 +1,330 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * RefundRepository implements ports.RefundRepository interface
+ * and provides an access to the postgres database
+ */
+type RefundRepository struct {
+	db *postgres.DB
+}
+
+// NewRefundRepository creates a new refund repositories instance
+func NewRefundRepository(db *postgres.DB) *RefundRepository {
+	return &RefundRepository{
+		db,
+	}
+}
+
+// CreateRefund inserts the refund with its items and records a return movement into the store of the
+// refund for each item of a product that still exists, in a single transaction. The order is locked first, so refunds of the
+// same order are placed one at a time, and none is placed when another came in or the order was
+// settled since the order's refunds were read. The items should be sorted by product, so the product rows are locked in the
+// same order as by orders. The points it refunds and takes back are recorded in the ledger of the
+// customer of the order
+func (rr *RefundRepository) CreateRefund(ctx context.Context, refund *models.Refund, previousRefunds int) (*models.Refund, error) {
+	tx, err := rr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	lock := rr.db.QueryBuilder.Select("customer_id", "status").
+		From("orders").
+		Where(sq.Eq{"id": refund.OrderID}).
+		Suffix("FOR UPDATE")
+
+	sql, args, err := lock.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var customerID *uint64
+	var status models.OrderStatus
+	err = tx.QueryRow(ctx, sql, args...).Scan(&customerID, &status)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+	// open orders are voided, paid ones refunded
+	if (status == models.OrderOpen) != (refund.Type == models.RefundTypeVoid) {
+		return nil, models.ErrVersionConflict
+	}
+
+	count := rr.db.QueryBuilder.Select("count(*)").
+		From("refunds").
+		Where(sq.Eq{"order_id": refund.OrderID})
+
+	sql, args, err = count.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var refunds int
+	err = tx.QueryRow(ctx, sql, args...).Scan(&refunds)
+	if err != nil {
+		return nil, err
+	}
+	if refunds != previousRefunds {
+		return nil, models.ErrVersionConflict
+	}
+
+	insert := rr.db.QueryBuilder.Insert("refunds").
+		Columns(
+			"order_id", "user_id", "type", "payment_method", "amount", "reason", "store_id", "points_refunded",
+			"points_reversed",
+		).
+		Values(
+			refund.OrderID, refund.UserID, refund.Type, refund.PaymentMethod, refund.Amount, refund.Reason,
+			refund.StoreID, refund.PointsRefunded, refund.PointsReversed,
+		).
+		Suffix("RETURNING id, created_at")
+
+	sql, args, err = insert.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(
+		&refund.ID,
+		&refund.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range refund.Items {
+		item := &refund.Items[i]
+		item.RefundID = refund.ID
+
+		insert := rr.db.QueryBuilder.Insert("refund_items").
+			Columns("refund_id", "order_item_id", "product_id", "quantity").
+			Values(item.RefundID, item.OrderItemID, item.ProductID, item.Quantity).
+			Suffix("RETURNING id")
+
+		sql, args, err := insert.ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		err = tx.QueryRow(ctx, sql, args...).Scan(&item.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.ProductID == 0 {
+			continue
+		}
+
+		movement := models.StockMovement{
+			ProductID: item.ProductID,
+			Type:      models.StockReturn,
+			Quantity:  item.Quantity,
+			Reason:    refund.Reason,
+			OrderID:   &refund.OrderID,
+			UserID:    &refund.UserID,
+			StoreID:   refund.StoreID,
+		}
+
+		err = recordMovement(ctx, rr.db, tx, &movement)
+		if err != nil {
+			// the product was deleted since the order was read
+			if err == models.ErrInvalidProduct {
+				continue
+			}
+			return nil, err
+		}
+	}
+
+	if customerID != nil {
+		entries := []models.LoyaltyEntry{
+			{Type: models.LoyaltyRefund, Points: refund.PointsRefunded},
+			{Type: models.LoyaltyReversal, Points: -refund.PointsReversed},
+		}
+
+		for _, entry := range entries {
+			if entry.Points == 0 {
+				continue
+			}
+
+			entry.CustomerID = *customerID
+			entry.Reason = refund.Reason
+			entry.OrderID = &refund.OrderID
+			entry.UserID = &refund.UserID
+
+			err := recordLoyaltyEntry(ctx, rr.db, tx, &entry)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// GetRefundByID gets a refund with its items by ID from the database
+func (rr *RefundRepository) GetRefundByID(ctx context.Context, id uint64) (*models.Refund, error) {
+	query := rr.db.QueryBuilder.Select("*").
+		From("refunds").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	refunds, err := rr.listRefunds(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(refunds) == 0 {
+		return nil, models.ErrDataNotFound
+	}
+
+	return &refunds[0], nil
+}
+
+// ListRefunds lists the refunds with their items from the database, the newest first
+func (rr *RefundRepository) ListRefunds(ctx context.Context, storeID, orderID, skip, limit uint64) ([]models.Refund, error) {
+	query := rr.db.QueryBuilder.Select("*").
+		From("refunds").
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	if storeID != 0 {
+		query = query.Where(sq.Eq{"store_id": storeID})
+	}
+	if orderID != 0 {
+		query = query.Where(sq.Eq{"order_id": orderID})
+	}
+
+	return rr.listRefunds(ctx, query)
+}
+
+// ListOrderRefunds lists every refund of an order with its items from the database, the oldest first
+func (rr *RefundRepository) ListOrderRefunds(ctx context.Context, orderID uint64) ([]models.Refund, error) {
+	query := rr.db.QueryBuilder.Select("*").
+		From("refunds").
+		Where(sq.Eq{"order_id": orderID}).
+		OrderBy("id")
+
+	return rr.listRefunds(ctx, query)
+}
+
+// listRefunds runs a query selecting refunds and selects their items
+func (rr *RefundRepository) listRefunds(ctx context.Context, query sq.SelectBuilder) ([]models.Refund, error) {
+	var refund models.Refund
+	var refunds []models.Refund
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint64
+	for rows.Next() {
+		err := rows.Scan(
+			&refund.ID,
+			&refund.OrderID,
+			&refund.UserID,
+			&refund.Type,
+			&refund.PaymentMethod,
+			&refund.Amount,
+			&refund.Reason,
+			&refund.CreatedAt,
+			&refund.StoreID,
+			&refund.PointsRefunded,
+			&refund.PointsReversed,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		refunds = append(refunds, refund)
+		ids = append(ids, refund.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(refunds) == 0 {
+		return refunds, nil
+	}
+
+	items, err := rr.listItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range refunds {
+		refunds[i].Items = items[refunds[i].ID]
+	}
+
+	return refunds, nil
+}
+
+// listItems selects the items of the given refunds, grouped by refund
+func (rr *RefundRepository) listItems(ctx context.Context, refundIDs []uint64) (map[uint64][]models.RefundItem, error) {
+	items := map[uint64][]models.RefundItem{}
+
+	query := rr.db.QueryBuilder.Select("*").
+		From("refund_items").
+		Where(sq.Eq{"refund_id": refundIDs}).
+		OrderBy("id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item models.RefundItem
+
+		err := rows.Scan(
+			&item.ID,
+			&item.RefundID,
+			&item.OrderItemID,
+			&item.ProductID,
+			&item.Quantity,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		items[item.RefundID] = append(items[item.RefundID], item)
+	}
+
+	return items, rows.Err()
+}
+
+var RefundRepositoryModule = fx.Module(
+	"refunds-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewRefundRepository, fx.As(new(ports.RefundRepository))),
+	),
+)
@@ -0,0 +1,353 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+/**
+ * ReportRepository implements ports.ReportRepository interface
+ * and provides an access to the postgres database
+ */
+type ReportRepository struct {
+	db *postgres.DB
+}
+
+// NewReportRepository creates a new report repositories instance
+func NewReportRepository(db *postgres.DB) *ReportRepository {
+	return &ReportRepository{
+		db,
+	}
+}
+
+// inRange returns the condition for the orders paid for within the range of a report,
+// at its store if it has one. An order counts when it is paid for, which is after it was placed
+// for the open orders settled later. Open orders made no sale yet and voided ones never will
+func inRange(filter *models.ReportFilter) sq.And {
+	conditions := sq.And{
+		sq.GtOrEq{"orders.paid_at": filter.From},
+		sq.Lt{"orders.paid_at": filter.To},
+		sq.Eq{"orders.status": models.OrderPaid},
+	}
+	if filter.StoreID != 0 {
+		conditions = append(conditions, sq.Eq{"orders.store_id": filter.StoreID})
+	}
+
+	return conditions
+}
+
+// refundedInRange returns the condition for the refunds given within the range of a report,
+// at its store if it has one
+func refundedInRange(filter *models.ReportFilter) sq.And {
+	conditions := sq.And{
+		sq.GtOrEq{"refunds.created_at": filter.From},
+		sq.Lt{"refunds.created_at": filter.To},
+		sq.Eq{"refunds.type": models.RefundTypeRefund},
+	}
+	if filter.StoreID != 0 {
+		conditions = append(conditions, sq.Eq{"refunds.store_id": filter.StoreID})
+	}
+
+	return conditions
+}
+
+// RevenueByPeriod sums the orders of each period that has any from the database, less the refunds
+// given in the period. The periods are truncated in the timezone of the report, so a day runs from
+// local midnight to local midnight
+func (rr *ReportRepository) RevenueByPeriod(ctx context.Context, filter *models.ReportFilter, period models.ReportPeriod) ([]models.RevenueReport, error) {
+	var revenue []models.RevenueReport
+
+	location := filter.Location()
+
+	refunds := sq.Select().
+		Column(sq.Expr("date_trunc(?, refunds.created_at AT TIME ZONE ?)", string(period), location.String())).
+		Column("0").
+		Column("-refunds.amount").
+		From("refunds").
+		Where(refundedInRange(filter))
+
+	sales := sq.Select().
+		Column(sq.Expr("date_trunc(?, orders.paid_at AT TIME ZONE ?) AS start", string(period), location.String())).
+		Column("1 AS orders").
+		Column("orders.total_price AS revenue").
+		From("orders").
+		Where(inRange(filter)).
+		SuffixExpr(sq.ConcatExpr("UNION ALL ", refunds))
+
+	query := rr.db.QueryBuilder.Select("start", "sum(orders)", "sum(revenue)").
+		FromSelect(sales, "takings").
+		GroupBy("start").
+		OrderBy("start")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.RevenueReport
+		var start time.Time
+
+		err := rows.Scan(
+			&start,
+			&row.Orders,
+			&row.Revenue,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// the truncated timestamp has no timezone, it is the local time of the report
+		row.Start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location)
+		revenue = append(revenue, row)
+	}
+
+	return revenue, rows.Err()
+}
+
+// TopProducts sums the sales of each product from the database less the items refunded in the range,
+// the highest revenue first
+func (rr *ReportRepository) TopProducts(ctx context.Context, filter *models.ReportFilter, limit uint64) ([]models.ProductSalesReport, error) {
+	var products []models.ProductSalesReport
+
+	refunds := sq.Select(
+		"COALESCE(order_items.product_id, 0)",
+		"order_items.sku",
+		"order_items.name",
+		"-refund_items.quantity",
+		"-order_items.price * refund_items.quantity",
+	).
+		From("refund_items").
+		Join("refunds ON refunds.id = refund_items.refund_id").
+		Join("order_items ON order_items.id = refund_items.order_item_id").
+		Where(refundedInRange(filter))
+
+	sales := sq.Select(
+		"COALESCE(order_items.product_id, 0) AS product_id",
+		"order_items.sku AS sku",
+		"order_items.name AS name",
+		"order_items.quantity AS quantity",
+		"order_items.total_price AS revenue",
+	).
+		From("order_items").
+		Join("orders ON orders.id = order_items.order_id").
+		Where(inRange(filter)).
+		SuffixExpr(sq.ConcatExpr("UNION ALL ", refunds))
+
+	query := rr.db.QueryBuilder.Select(
+		"product_id",
+		"sku",
+		"name",
+		"sum(quantity) AS quantity",
+		"sum(revenue) AS revenue",
+	).
+		FromSelect(sales, "takings").
+		GroupBy("product_id", "sku", "name").
+		OrderBy("revenue DESC", "quantity DESC", "sku").
+		Limit(limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.ProductSalesReport
+
+		err := rows.Scan(
+			&row.ProductID,
+			&row.SKU,
+			&row.Name,
+			&row.Quantity,
+			&row.Revenue,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, row)
+	}
+
+	return products, rows.Err()
+}
+
+// SalesByCashier sums the orders of each cashier from the database less the refunds given in the range
+// of the orders they rang up, the highest revenue first. The name of a deleted cashier is empty
+func (rr *ReportRepository) SalesByCashier(ctx context.Context, filter *models.ReportFilter) ([]models.CashierSalesReport, error) {
+	var cashiers []models.CashierSalesReport
+
+	refunds := sq.Select("orders.user_id", "0", "-refunds.amount").
+		From("refunds").
+		Join("orders ON orders.id = refunds.order_id").
+		Where(refundedInRange(filter))
+
+	sales := sq.Select("orders.user_id AS user_id", "1 AS orders", "orders.total_price AS revenue").
+		From("orders").
+		Where(inRange(filter)).
+		SuffixExpr(sq.ConcatExpr("UNION ALL ", refunds))
+
+	query := rr.db.QueryBuilder.Select(
+		"takings.user_id",
+		"COALESCE(users.name, '')",
+		"sum(takings.orders)",
+		"sum(takings.revenue) AS revenue",
+	).
+		FromSelect(sales, "takings").
+		LeftJoin("users ON users.id = takings.user_id").
+		GroupBy("takings.user_id", "users.name").
+		OrderBy("revenue DESC", "takings.user_id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.CashierSalesReport
+
+		err := rows.Scan(
+			&row.UserID,
+			&row.Name,
+			&row.Orders,
+			&row.Revenue,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		cashiers = append(cashiers, row)
+	}
+
+	return cashiers, rows.Err()
+}
+
+// SalesByPaymentMethod sums the orders of each payment method from the database less the refunds
+// paid back with it in the range
+func (rr *ReportRepository) SalesByPaymentMethod(ctx context.Context, filter *models.ReportFilter) ([]models.PaymentMethodReport, error) {
+	var methods []models.PaymentMethodReport
+
+	refunds := sq.Select("refunds.payment_method", "0", "-refunds.amount").
+		From("refunds").
+		Where(refundedInRange(filter))
+
+	sales := sq.Select("orders.payment_method AS payment_method", "1 AS orders", "orders.total_price AS revenue").
+		From("orders").
+		Where(inRange(filter)).
+		SuffixExpr(sq.ConcatExpr("UNION ALL ", refunds))
+
+	query := rr.db.QueryBuilder.Select(
+		"payment_method",
+		"sum(orders)",
+		"sum(revenue) AS revenue",
+	).
+		FromSelect(sales, "takings").
+		GroupBy("payment_method").
+		OrderBy("revenue DESC", "payment_method")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.PaymentMethodReport
+
+		err := rows.Scan(
+			&row.PaymentMethod,
+			&row.Orders,
+			&row.Revenue,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		methods = append(methods, row)
+	}
+
+	return methods, rows.Err()
+}
+
+// Discounts sums the discounts of each promotion from the database
+func (rr *ReportRepository) Discounts(ctx context.Context, filter *models.ReportFilter) ([]models.DiscountReport, error) {
+	var discounts []models.DiscountReport
+
+	query := rr.db.QueryBuilder.Select(
+		"order_discounts.promotion_id",
+		"order_discounts.name",
+		"order_discounts.coupon_code",
+		"count(*)",
+		"sum(order_discounts.amount) AS amount",
+	).
+		From("order_discounts").
+		Join("orders ON orders.id = order_discounts.order_id").
+		Where(inRange(filter)).
+		GroupBy("order_discounts.promotion_id", "order_discounts.name", "order_discounts.coupon_code").
+		OrderBy("amount DESC", "order_discounts.promotion_id")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.DiscountReport
+
+		err := rows.Scan(
+			&row.PromotionID,
+			&row.Name,
+			&row.CouponCode,
+			&row.Orders,
+			&row.Amount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		discounts = append(discounts, row)
+	}
+
+	return discounts, rows.Err()
+}
+
+var ReportRepositoryModule = fx.Module(
+	"reports-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewReportRepository, fx.As(new(ports.ReportRepository))),
+	),
+)
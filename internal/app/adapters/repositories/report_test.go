@@ -0,0 +1,177 @@
+package repositories_test
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/repositories"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// connect migrates and connects to the database of the environment, the test is skipped without one
+func connect(t *testing.T) *postgres.DB {
+	t.Helper()
+
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST is not set")
+	}
+
+	db, err := postgres.NewConnection(context.Background(), &configs.DB{
+		Connection: os.Getenv("DB_CONNECTION"),
+		Host:       os.Getenv("DB_HOST"),
+		Port:       os.Getenv("DB_PORT"),
+		User:       os.Getenv("DB_USER"),
+		Password:   os.Getenv("DB_PASSWORD"),
+		Name:       os.Getenv("DB_NAME"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+
+	require.NoError(t, db.Migrate())
+
+	return db
+}
+
+// day returns the given hour of a day in January 2020
+func day(d, hour int) time.Time {
+	return time.Date(2020, time.January, d, hour, 0, 0, 0, time.UTC)
+}
+
+// takings records the sales of a store of a tenant of its own, which keeps the reports clear of the rows of other tests:
+//   - a cash order of three items of 1000 paid for on the first, one of which is refunded on the second
+//   - a card order of one item of 2000 opened on the first and settled on the second
+//   - an open order of 500 on the first, which is never paid for
+func takings(t *testing.T, db *postgres.DB) (context.Context, uint64) {
+	t.Helper()
+
+	var tenantID uint64
+	err := db.QueryRow(context.Background(), `INSERT INTO tenants (name) VALUES ($1) RETURNING id`, gofakeit.UUID()).Scan(&tenantID)
+	require.NoError(t, err)
+	ctx := _constant.WithTenantID(context.Background(), tenantID)
+
+	var storeID uint64
+	err = db.QueryRow(ctx, `INSERT INTO stores (name) VALUES ('Main store') RETURNING id`).Scan(&storeID)
+	require.NoError(t, err)
+
+	var paidID, paidItemID uint64
+	err = db.QueryRow(ctx, `
+		INSERT INTO orders (user_id, store_id, status, payment_method, total_price, total_paid, total_change, created_at, paid_at)
+		VALUES (1, $1, 'paid', 'cash', 3000, 5000, 2000, $2, $2) RETURNING id`, storeID, day(1, 10)).Scan(&paidID)
+	require.NoError(t, err)
+	err = db.QueryRow(ctx, `
+		INSERT INTO order_items (order_id, sku, name, quantity, price, total_price)
+		VALUES ($1, 'APL', 'Apple', 3, 1000, 3000) RETURNING id`, paidID).Scan(&paidItemID)
+	require.NoError(t, err)
+
+	var refundID uint64
+	err = db.QueryRow(ctx, `
+		INSERT INTO refunds (order_id, user_id, store_id, type, payment_method, amount, reason, created_at)
+		VALUES ($1, 1, $2, 'refund', 'cash', 1000, 'damaged', $3) RETURNING id`, paidID, storeID, day(2, 10)).Scan(&refundID)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `
+		INSERT INTO refund_items (refund_id, order_item_id, quantity)
+		VALUES ($1, $2, 1)`, refundID, paidItemID)
+	require.NoError(t, err)
+
+	var settledID uint64
+	err = db.QueryRow(ctx, `
+		INSERT INTO orders (user_id, store_id, status, payment_method, total_price, total_paid, total_change, created_at, paid_at)
+		VALUES (1, $1, 'paid', 'card', 2000, 2000, 0, $2, $3) RETURNING id`, storeID, day(1, 11), day(2, 12)).Scan(&settledID)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `
+		INSERT INTO order_items (order_id, sku, name, quantity, price, total_price)
+		VALUES ($1, 'BNN', 'Banana', 1, 2000, 2000)`, settledID)
+	require.NoError(t, err)
+
+	_, err = db.Exec(ctx, `
+		INSERT INTO orders (user_id, store_id, status, payment_method, total_price, total_paid, total_change, created_at)
+		VALUES (1, $1, 'open', 'cash', 500, 0, 0, $2)`, storeID, day(1, 12))
+	require.NoError(t, err)
+
+	return ctx, storeID
+}
+
+func TestReportRepository_RevenueByPeriod(t *testing.T) {
+	db := connect(t)
+	ctx, storeID := takings(t, db)
+
+	repo := repositories.NewReportRepository(db)
+
+	revenue, err := repo.RevenueByPeriod(ctx, &models.ReportFilter{
+		From:    day(1, 0),
+		To:      day(3, 0),
+		StoreID: storeID,
+	}, models.ReportDay)
+	require.NoError(t, err)
+
+	// the settled order counts on the day it was paid for
+	assert.Equal(t, []models.RevenueReport{
+		{Start: day(1, 0), Orders: 1, Revenue: 3000},
+		{Start: day(2, 0), Orders: 1, Revenue: 1000},
+	}, revenue, "Revenue mismatch")
+}
+
+func TestReportRepository_TopProducts(t *testing.T) {
+	db := connect(t)
+	ctx, storeID := takings(t, db)
+
+	repo := repositories.NewReportRepository(db)
+
+	products, err := repo.TopProducts(ctx, &models.ReportFilter{
+		From:    day(1, 0),
+		To:      day(3, 0),
+		StoreID: storeID,
+	}, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []models.ProductSalesReport{
+		{SKU: "APL", Name: "Apple", Quantity: 2, Revenue: 2000},
+		{SKU: "BNN", Name: "Banana", Quantity: 1, Revenue: 2000},
+	}, products, "Products mismatch")
+}
+
+func TestReportRepository_SalesByCashier(t *testing.T) {
+	db := connect(t)
+	ctx, storeID := takings(t, db)
+
+	repo := repositories.NewReportRepository(db)
+
+	cashiers, err := repo.SalesByCashier(ctx, &models.ReportFilter{
+		From:    day(1, 0),
+		To:      day(3, 0),
+		StoreID: storeID,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, cashiers, 1, "Cashiers length mismatch")
+	assert.Equal(t, uint64(1), cashiers[0].UserID, "User ID mismatch")
+	assert.Equal(t, int64(2), cashiers[0].Orders, "Orders mismatch")
+	assert.Equal(t, int64(4000), cashiers[0].Revenue, "Revenue mismatch")
+}
+
+func TestReportRepository_SalesByPaymentMethod(t *testing.T) {
+	db := connect(t)
+	ctx, storeID := takings(t, db)
+
+	repo := repositories.NewReportRepository(db)
+
+	methods, err := repo.SalesByPaymentMethod(ctx, &models.ReportFilter{
+		From:    day(1, 0),
+		To:      day(3, 0),
+		StoreID: storeID,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []models.PaymentMethodReport{
+		{PaymentMethod: models.PaymentCard, Orders: 1, Revenue: 2000},
+		{PaymentMethod: models.PaymentCash, Orders: 1, Revenue: 2000},
+	}, methods, "Payment methods mismatch")
+}
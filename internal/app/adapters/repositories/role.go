@@ -0,0 +1,238 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * RoleRepository implements ports.RoleRepository interface
+ * and provides an access to the postgres database
+ */
+type RoleRepository struct {
+	db *postgres.DB
+}
+
+// NewRoleRepository creates a new role repositories instance
+func NewRoleRepository(db *postgres.DB) *RoleRepository {
+	return &RoleRepository{
+		db,
+	}
+}
+
+// CreateRole creates a new role in the database
+func (rr *RoleRepository) CreateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	query := rr.db.QueryBuilder.Insert("roles").
+		Columns("name", "description").
+		Values(role.Name, role.Description).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rr.db.QueryRow(ctx, sql, args...).Scan(
+		&role.ID,
+		&role.Name,
+		&role.Description,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		if errCode := rr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// GetRoleByID gets a role by ID from the database
+func (rr *RoleRepository) GetRoleByID(ctx context.Context, id uint64) (*models.Role, error) {
+	var role models.Role
+
+	query := rr.db.QueryBuilder.Select("*").
+		From("roles").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rr.db.QueryRow(ctx, sql, args...).Scan(
+		&role.ID,
+		&role.Name,
+		&role.Description,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// GetRoleByName gets a role by name from the database
+func (rr *RoleRepository) GetRoleByName(ctx context.Context, name models.UserRole) (*models.Role, error) {
+	var role models.Role
+
+	query := rr.db.QueryBuilder.Select("*").
+		From("roles").
+		Where(sq.Eq{"name": name}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rr.db.QueryRow(ctx, sql, args...).Scan(
+		&role.ID,
+		&role.Name,
+		&role.Description,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// ListRoles lists all roles from the database
+func (rr *RoleRepository) ListRoles(ctx context.Context, skip, limit uint64) ([]models.Role, error) {
+	var role models.Role
+	var roles []models.Role
+
+	query := rr.db.QueryBuilder.Select("*").
+		From("roles").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := rr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&role.ID,
+			&role.Name,
+			&role.Description,
+			&role.CreatedAt,
+			&role.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+// UpdateRole updates a role's description by ID in the database
+func (rr *RoleRepository) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	query := rr.db.QueryBuilder.Update("roles").
+		Set("description", role.Description).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": role.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = rr.db.QueryRow(ctx, sql, args...).Scan(
+		&role.ID,
+		&role.Name,
+		&role.Description,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// DeleteRole deletes a role by ID from the database
+func (rr *RoleRepository) DeleteRole(ctx context.Context, id uint64) error {
+	query := rr.db.QueryBuilder.Delete("roles").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = rr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := rr.db.ErrorCode(err); errCode == "23503" {
+			return models.ErrRoleInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CountUsersByRole counts the users assigned to a role in the database
+func (rr *RoleRepository) CountUsersByRole(ctx context.Context, name models.UserRole) (uint64, error) {
+	var count uint64
+
+	query := rr.db.QueryBuilder.Select("COUNT(*)").
+		From("users").
+		Where(sq.Eq{"role": name})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = rr.db.QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+var RoleRepositoryModule = fx.Module(
+	"roles-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewRoleRepository, fx.As(new(ports.RoleRepository))),
+	),
+)
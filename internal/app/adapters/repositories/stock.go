@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * StockRepository implements ports.StockRepository interface
+ * and provides an access to the postgres database
+ */
+type StockRepository struct {
+	db *postgres.DB
+}
+
+// NewStockRepository creates a new stock repositories instance
+func NewStockRepository(db *postgres.DB) *StockRepository {
+	return &StockRepository{
+		db,
+	}
+}
+
+// RecordMovement locks the product, appends a movement to its ledger and applies it to the
+// product stock in a single transaction, refusing movements that would take the stock below zero
+func (sr *StockRepository) RecordMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	tx, err := sr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = recordMovement(ctx, sr.db, tx, movement)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+// TransferStock moves the quantity of a transfer out of its store into its ToStoreID one in a single
+// transaction, as a movement in the ledger of each, so that the stock is never missing from both or
+// counted in both. Both movements lock the product first, as every other movement does
+func (sr *StockRepository) TransferStock(ctx context.Context, transfer *models.StockMovement) (*models.StockMovement, error) {
+	tx, err := sr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// the store is looked up as the tenant sees it, the stores of other tenants are not found
+	query := sr.db.QueryBuilder.Select("id").
+		From("stores").
+		Where(sq.Eq{"id": transfer.ToStoreID})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var storeID uint64
+	err = tx.QueryRow(ctx, sql, args...).Scan(&storeID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrInvalidStore
+		}
+		return nil, err
+	}
+
+	out := *transfer
+	out.Quantity = -transfer.Quantity
+
+	err = recordMovement(ctx, sr.db, tx, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	in := *transfer
+	in.StoreID = transfer.ToStoreID
+	in.ToStoreID = 0
+
+	err = recordMovement(ctx, sr.db, tx, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// ListMovements lists the stock movements matching a filter from the database, the newest first
+func (sr *StockRepository) ListMovements(ctx context.Context, filter *models.StockMovementFilter, skip, limit uint64) ([]models.StockMovement, error) {
+	var movement models.StockMovement
+	var movements []models.StockMovement
+
+	query := sr.db.QueryBuilder.Select("*").
+		From("stock_movements").
+		OrderBy("id DESC").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	if filter.ProductID != 0 {
+		query = query.Where(sq.Eq{"product_id": filter.ProductID})
+	}
+	if filter.Type != "" {
+		query = query.Where(sq.Eq{"type": filter.Type})
+	}
+	if filter.StoreID != 0 {
+		query = query.Where(sq.Eq{"store_id": filter.StoreID})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := sr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&movement.ID,
+			&movement.ProductID,
+			&movement.Type,
+			&movement.Quantity,
+			&movement.StockAfter,
+			&movement.Reason,
+			&movement.OrderID,
+			&movement.UserID,
+			&movement.CreatedAt,
+			&movement.StoreID,
+			&movement.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		movements = append(movements, movement)
+	}
+
+	return movements, rows.Err()
+}
+
+// recordMovement applies a movement to the stock of its product in its store and appends it to the
+// ledger within a transaction. The product row is locked until the transaction ends, so movements
+// of the same product are serialized and the ledger's running stock always matches the store's,
+// while products.stock keeps the sum of every store
+func recordMovement(ctx context.Context, db *postgres.DB, tx pgx.Tx, movement *models.StockMovement) error {
+	var id uint64
+	var stock int64
+
+	query := db.QueryBuilder.Select("id").
+		From("products").
+		Where(sq.Eq{"id": movement.ProductID}).
+		Suffix("FOR UPDATE")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, sql, args...).Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return models.ErrInvalidProduct
+		}
+		return err
+	}
+
+	query = db.QueryBuilder.Select("stock").
+		From("store_stocks").
+		Where(sq.Eq{"store_id": movement.StoreID, "product_id": movement.ProductID})
+
+	sql, args, err = query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	// a store without a row for the product has never had any of it
+	err = tx.QueryRow(ctx, sql, args...).Scan(&stock)
+	if err != nil && err != pgx.ErrNoRows {
+		return err
+	}
+
+	movement.StockAfter = stock + movement.Quantity
+	if movement.StockAfter < 0 {
+		return models.ErrInsufficientStock
+	}
+
+	update := db.QueryBuilder.Update("products").
+		Set("stock", sq.Expr("stock + ?", movement.Quantity)).
+		Set("updated_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": movement.ProductID})
+
+	sql, args, err = update.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	upsert := db.QueryBuilder.Insert("store_stocks").
+		Columns("store_id", "product_id", "stock").
+		Values(movement.StoreID, movement.ProductID, movement.StockAfter).
+		Suffix("ON CONFLICT (store_id, product_id) DO UPDATE SET stock = EXCLUDED.stock")
+
+	sql, args, err = upsert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := db.ErrorCode(err); errCode == "23503" {
+			return models.ErrInvalidStore
+		}
+		return err
+	}
+
+	insert := db.QueryBuilder.Insert("stock_movements").
+		Columns("product_id", "type", "quantity", "stock_after", "reason", "order_id", "user_id", "store_id").
+		Values(
+			movement.ProductID,
+			movement.Type,
+			movement.Quantity,
+			movement.StockAfter,
+			movement.Reason,
+			movement.OrderID,
+			movement.UserID,
+			movement.StoreID,
+		).
+		Suffix("RETURNING id, created_at")
+
+	sql, args, err = insert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRow(ctx, sql, args...).Scan(
+		&movement.ID,
+		&movement.CreatedAt,
+	)
+}
+
+var StockRepositoryModule = fx.Module(
+	"stocks-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewStockRepository, fx.As(new(ports.StockRepository))),
+	),
+)
@@ -0,0 +1,304 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * StoreRepository implements ports.StoreRepository interface
+ * and provides an access to the postgres database
+ */
+type StoreRepository struct {
+	db *postgres.DB
+}
+
+// NewStoreRepository creates a new store repositories instance
+func NewStoreRepository(db *postgres.DB) *StoreRepository {
+	return &StoreRepository{
+		db,
+	}
+}
+
+// CreateStore creates a new store in the database
+func (sr *StoreRepository) CreateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	query := sr.db.QueryBuilder.Insert("stores").
+		Columns("name", "address", "region", "tax_rounding").
+		Values(store.Name, store.Address, store.Region, store.TaxRounding).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.db.QueryRow(ctx, sql, args...).Scan(
+		&store.ID,
+		&store.Name,
+		&store.Address,
+		&store.CreatedAt,
+		&store.UpdatedAt,
+		&store.Region,
+		&store.TaxRounding,
+		&store.TenantID,
+	)
+	if err != nil {
+		if errCode := sr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// GetStoreByID gets a store by ID from the database
+func (sr *StoreRepository) GetStoreByID(ctx context.Context, id uint64) (*models.Store, error) {
+	var store models.Store
+
+	query := sr.db.QueryBuilder.Select("*").
+		From("stores").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.db.QueryRow(ctx, sql, args...).Scan(
+		&store.ID,
+		&store.Name,
+		&store.Address,
+		&store.CreatedAt,
+		&store.UpdatedAt,
+		&store.Region,
+		&store.TaxRounding,
+		&store.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &store, nil
+}
+
+// ListStores lists all stores from the database
+func (sr *StoreRepository) ListStores(ctx context.Context, skip, limit uint64) ([]models.Store, error) {
+	var store models.Store
+	var stores []models.Store
+
+	query := sr.db.QueryBuilder.Select("*").
+		From("stores").
+		OrderBy("name").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := sr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&store.ID,
+			&store.Name,
+			&store.Address,
+			&store.CreatedAt,
+			&store.UpdatedAt,
+			&store.Region,
+			&store.TaxRounding,
+			&store.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		stores = append(stores, store)
+	}
+
+	return stores, rows.Err()
+}
+
+// UpdateStore replaces a store's details by ID in the database
+func (sr *StoreRepository) UpdateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	query := sr.db.QueryBuilder.Update("stores").
+		Set("name", store.Name).
+		Set("address", store.Address).
+		Set("region", store.Region).
+		Set("tax_rounding", store.TaxRounding).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": store.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = sr.db.QueryRow(ctx, sql, args...).Scan(
+		&store.ID,
+		&store.Name,
+		&store.Address,
+		&store.CreatedAt,
+		&store.UpdatedAt,
+		&store.Region,
+		&store.TaxRounding,
+		&store.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		if errCode := sr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// DeleteStore deletes a store by ID from the database, its users are left unassigned
+func (sr *StoreRepository) DeleteStore(ctx context.Context, id uint64) error {
+	query := sr.db.QueryBuilder.Delete("stores").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = sr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := sr.db.ErrorCode(err); errCode == "23503" {
+			return models.ErrStoreInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+// AssignUser sets the store of a user in the database, which is a change to the user
+func (sr *StoreRepository) AssignUser(ctx context.Context, storeID, userID uint64) error {
+	query := sr.db.QueryBuilder.Update("users").
+		Set("store_id", storeID).
+		Set("updated_at", time.Now()).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": userID})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := sr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := sr.db.ErrorCode(err); errCode == "23503" {
+			return models.ErrInvalidStore
+		}
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// ListUsers lists the users assigned to a store from the database
+func (sr *StoreRepository) ListUsers(ctx context.Context, storeID, skip, limit uint64) ([]models.User, error) {
+	var user models.User
+	var users []models.User
+
+	query := sr.db.QueryBuilder.Select("*").
+		From("users").
+		Where(sq.Eq{"store_id": storeID}).
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := sr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.Password,
+			&user.Role,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.Version,
+			&user.Avatar,
+			&user.StoreID,
+			&user.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// UnassignUser clears the store of a user assigned to the given store in the database
+func (sr *StoreRepository) UnassignUser(ctx context.Context, storeID, userID uint64) error {
+	query := sr.db.QueryBuilder.Update("users").
+		Set("store_id", nil).
+		Set("updated_at", time.Now()).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": userID, "store_id": storeID})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := sr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return models.ErrDataNotFound
+	}
+
+	return nil
+}
+
+var StoreRepositoryModule = fx.Module(
+	"stores-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewStoreRepository, fx.As(new(ports.StoreRepository))),
+	),
+)
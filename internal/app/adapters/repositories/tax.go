@@ -0,0 +1,372 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * TaxRepository implements ports.TaxRepository interface
+ * and provides an access to the postgres database
+ */
+type TaxRepository struct {
+	db *postgres.DB
+}
+
+// NewTaxRepository creates a new tax repositories instance
+func NewTaxRepository(db *postgres.DB) *TaxRepository {
+	return &TaxRepository{
+		db,
+	}
+}
+
+// CreateTaxClass creates a new tax class in the database
+func (tr *TaxRepository) CreateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error) {
+	query := tr.db.QueryBuilder.Insert("tax_classes").
+		Columns("name").
+		Values(class.Name).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.db.QueryRow(ctx, sql, args...).Scan(
+		&class.ID,
+		&class.Name,
+		&class.CreatedAt,
+		&class.UpdatedAt,
+		&class.TenantID,
+	)
+	if err != nil {
+		if errCode := tr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return class, nil
+}
+
+// GetTaxClassByID gets a tax class by ID from the database
+func (tr *TaxRepository) GetTaxClassByID(ctx context.Context, id uint64) (*models.TaxClass, error) {
+	var class models.TaxClass
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("tax_classes").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.db.QueryRow(ctx, sql, args...).Scan(
+		&class.ID,
+		&class.Name,
+		&class.CreatedAt,
+		&class.UpdatedAt,
+		&class.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &class, nil
+}
+
+// ListTaxClasses lists all tax classes from the database
+func (tr *TaxRepository) ListTaxClasses(ctx context.Context, skip, limit uint64) ([]models.TaxClass, error) {
+	var class models.TaxClass
+	var classes []models.TaxClass
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("tax_classes").
+		OrderBy("name").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&class.ID,
+			&class.Name,
+			&class.CreatedAt,
+			&class.UpdatedAt,
+			&class.TenantID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		classes = append(classes, class)
+	}
+
+	return classes, rows.Err()
+}
+
+// UpdateTaxClass updates a tax class's name by ID in the database
+func (tr *TaxRepository) UpdateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error) {
+	query := tr.db.QueryBuilder.Update("tax_classes").
+		Set("name", class.Name).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": class.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.db.QueryRow(ctx, sql, args...).Scan(
+		&class.ID,
+		&class.Name,
+		&class.CreatedAt,
+		&class.UpdatedAt,
+		&class.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		if errCode := tr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return class, nil
+}
+
+// DeleteTaxClass deletes a tax class by ID from the database, its rates are deleted with it
+func (tr *TaxRepository) DeleteTaxClass(ctx context.Context, id uint64) error {
+	query := tr.db.QueryBuilder.Delete("tax_classes").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := tr.db.ErrorCode(err); errCode == "23503" {
+			return models.ErrTaxClassInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CreateTaxRate creates a new tax rate in the database
+func (tr *TaxRepository) CreateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	query := tr.db.QueryBuilder.Insert("tax_rates").
+		Columns("tax_class_id", "name", "rate", "inclusive", "store_id", "region").
+		Values(rate.TaxClassID, rate.Name, rate.Rate, rate.Inclusive, rate.StoreID, rate.Region).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.scanTaxRate(tr.db.QueryRow(ctx, sql, args...), rate)
+	if err != nil {
+		return nil, tr.taxRateError(err)
+	}
+
+	return rate, nil
+}
+
+// GetTaxRateByID gets a tax rate by ID from the database
+func (tr *TaxRepository) GetTaxRateByID(ctx context.Context, id uint64) (*models.TaxRate, error) {
+	var rate models.TaxRate
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("tax_rates").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.scanTaxRate(tr.db.QueryRow(ctx, sql, args...), &rate)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+// ListTaxRates lists the tax rates from the database, of a tax class unless taxClassID is zero
+func (tr *TaxRepository) ListTaxRates(ctx context.Context, taxClassID, skip, limit uint64) ([]models.TaxRate, error) {
+	query := tr.db.QueryBuilder.Select("*").
+		From("tax_rates").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	if taxClassID != 0 {
+		query = query.Where(sq.Eq{"tax_class_id": taxClassID})
+	}
+
+	return tr.listTaxRates(ctx, query)
+}
+
+// UpdateTaxRate replaces a tax rate by ID in the database
+func (tr *TaxRepository) UpdateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	query := tr.db.QueryBuilder.Update("tax_rates").
+		Set("tax_class_id", rate.TaxClassID).
+		Set("name", rate.Name).
+		Set("rate", rate.Rate).
+		Set("inclusive", rate.Inclusive).
+		Set("store_id", rate.StoreID).
+		Set("region", rate.Region).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"id": rate.ID}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.scanTaxRate(tr.db.QueryRow(ctx, sql, args...), rate)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, tr.taxRateError(err)
+	}
+
+	return rate, nil
+}
+
+// DeleteTaxRate deletes a tax rate by ID from the database
+func (tr *TaxRepository) DeleteTaxRate(ctx context.Context, id uint64) error {
+	query := tr.db.QueryBuilder.Delete("tax_rates").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListStoreTaxRates lists the tax rates of a store, of its region and of neither from the database
+func (tr *TaxRepository) ListStoreTaxRates(ctx context.Context, store *models.Store) ([]models.TaxRate, error) {
+	scopes := sq.Or{
+		sq.Eq{"store_id": store.ID},
+		sq.Eq{"store_id": nil, "region": ""},
+	}
+	if store.Region != "" {
+		scopes = append(scopes, sq.Eq{"store_id": nil, "region": store.Region})
+	}
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("tax_rates").
+		Where(scopes).
+		OrderBy("id")
+
+	return tr.listTaxRates(ctx, query)
+}
+
+// listTaxRates runs a query selecting tax rates
+func (tr *TaxRepository) listTaxRates(ctx context.Context, query sq.SelectBuilder) ([]models.TaxRate, error) {
+	var rates []models.TaxRate
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rate models.TaxRate
+
+		err := tr.scanTaxRate(rows, &rate)
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, rows.Err()
+}
+
+// scanTaxRate scans a row of the tax rates table
+func (tr *TaxRepository) scanTaxRate(row pgx.Row, rate *models.TaxRate) error {
+	return row.Scan(
+		&rate.ID,
+		&rate.TaxClassID,
+		&rate.Name,
+		&rate.Rate,
+		&rate.Inclusive,
+		&rate.StoreID,
+		&rate.Region,
+		&rate.CreatedAt,
+		&rate.UpdatedAt,
+	)
+}
+
+// taxRateError maps the errors of writing a tax rate, telling apart its missing tax class and store
+func (tr *TaxRepository) taxRateError(err error) error {
+	switch tr.db.ErrorCode(err) {
+	case "23503":
+		if tr.db.ConstraintName(err) == "tax_rates_store_id_fkey" {
+			return models.ErrInvalidStore
+		}
+		return models.ErrInvalidTaxClass
+	case "23514":
+		return models.ErrInvalidTaxRate
+	}
+	return err
+}
+
+var TaxRepositoryModule = fx.Module(
+	"taxes-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewTaxRepository, fx.As(new(ports.TaxRepository))),
+	),
+)
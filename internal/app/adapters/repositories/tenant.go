@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"go.uber.org/fx"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+/**
+ * TenantRepository implements ports.TenantRepository interface
+ * and provides an access to the postgres database
+ */
+type TenantRepository struct {
+	db *postgres.DB
+}
+
+// NewTenantRepository creates a new tenant repositories instance
+func NewTenantRepository(db *postgres.DB) *TenantRepository {
+	return &TenantRepository{
+		db,
+	}
+}
+
+// CreateTenant creates a new tenant in the database
+func (tr *TenantRepository) CreateTenant(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error) {
+	query := tr.db.QueryBuilder.Insert("tenants").
+		Columns("name").
+		Values(tenant.Name).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.db.QueryRow(ctx, sql, args...).Scan(
+		&tenant.ID,
+		&tenant.Name,
+		&tenant.CreatedAt,
+		&tenant.UpdatedAt,
+	)
+	if err != nil {
+		if errCode := tr.db.ErrorCode(err); errCode == "23505" {
+			return nil, models.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return tenant, nil
+}
+
+// GetTenantByID gets a tenant by ID from the database
+func (tr *TenantRepository) GetTenantByID(ctx context.Context, id uint64) (*models.Tenant, error) {
+	var tenant models.Tenant
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("tenants").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = tr.db.QueryRow(ctx, sql, args...).Scan(
+		&tenant.ID,
+		&tenant.Name,
+		&tenant.CreatedAt,
+		&tenant.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &tenant, nil
+}
+
+// ListTenants lists all tenants from the database
+func (tr *TenantRepository) ListTenants(ctx context.Context, skip, limit uint64) ([]models.Tenant, error) {
+	var tenant models.Tenant
+	var tenants []models.Tenant
+
+	query := tr.db.QueryBuilder.Select("*").
+		From("tenants").
+		OrderBy("id").
+		Limit(limit).
+		Offset((skip - 1) * limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tr.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err := rows.Scan(
+			&tenant.ID,
+			&tenant.Name,
+			&tenant.CreatedAt,
+			&tenant.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		tenants = append(tenants, tenant)
+	}
+
+	return tenants, rows.Err()
+}
+
+// DeleteTenant deletes a tenant by ID from the database
+func (tr *TenantRepository) DeleteTenant(ctx context.Context, id uint64) error {
+	query := tr.db.QueryBuilder.Delete("tenants").
+		Where(sq.Eq{"id": id})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tr.db.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var TenantRepositoryModule = fx.Module(
+	"tenants-repositories-module",
+	fx.Provide(
+		fx.Annotate(NewTenantRepository, fx.As(new(ports.TenantRepository))),
+	),
+)
@@ -35,6 +35,14 @@ func (ur *UserRepository) CreateUser(ctx context.Context, user *models.User) (*m
 		Values(user.Name, user.Email, user.Password).
 		Suffix("RETURNING *")
 
+	// the role is left to the column default unless it was pre-assigned, e.g. by an invitation
+	if user.Role != "" {
+		query = ur.db.QueryBuilder.Insert("users").
+			Columns("name", "email", "password", "role").
+			Values(user.Name, user.Email, user.Password, user.Role).
+			Suffix("RETURNING *")
+	}
+
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
@@ -48,10 +56,17 @@ func (ur *UserRepository) CreateUser(ctx context.Context, user *models.User) (*m
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Version,
+		&user.Avatar,
+		&user.StoreID,
+		&user.TenantID,
 	)
 	if err != nil {
-		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
+		switch ur.db.ErrorCode(err) {
+		case "23505":
 			return nil, models.ErrConflictingData
+		case "23503":
+			return nil, models.ErrInvalidRole
 		}
 		return nil, err
 	}
@@ -81,6 +96,10 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uint64) (*models.U
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Version,
+		&user.Avatar,
+		&user.StoreID,
+		&user.TenantID,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -114,6 +133,10 @@ func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*mo
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Version,
+		&user.Avatar,
+		&user.StoreID,
+		&user.TenantID,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -156,6 +179,10 @@ func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64) ([]
 			&user.Role,
 			&user.CreatedAt,
 			&user.UpdatedAt,
+			&user.Version,
+			&user.Avatar,
+			&user.StoreID,
+			&user.TenantID,
 		)
 		if err != nil {
 			return nil, err
@@ -180,7 +207,8 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, user *models.User) (*m
 		Set("password", sq.Expr("COALESCE(?, password)", password)).
 		Set("role", sq.Expr("COALESCE(?, role)", role)).
 		Set("updated_at", time.Now()).
-		Where(sq.Eq{"id": user.ID}).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": user.ID, "version": user.Version}).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -196,10 +224,21 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, user *models.User) (*m
 		&user.Role,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Version,
+		&user.Avatar,
+		&user.StoreID,
+		&user.TenantID,
 	)
 	if err != nil {
-		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
+		// no row matched the id and version pair, someone else updated the user first
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrVersionConflict
+		}
+		switch ur.db.ErrorCode(err) {
+		case "23505":
 			return nil, models.ErrConflictingData
+		case "23503":
+			return nil, models.ErrInvalidRole
 		}
 		return nil, err
 	}
@@ -207,6 +246,60 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, user *models.User) (*m
 	return user, nil
 }
 
+// PatchUser writes only the changed columns of a user by ID and version in the database
+func (ur *UserRepository) PatchUser(ctx context.Context, id, version uint64, changes []models.UserChange) (*models.User, error) {
+	var user models.User
+
+	query := ur.db.QueryBuilder.Update("users")
+
+	for _, change := range changes {
+		if change.To == nil {
+			query = query.Set(string(change.Field), sq.Expr("DEFAULT"))
+			continue
+		}
+		query = query.Set(string(change.Field), change.To)
+	}
+
+	query = query.
+		Set("updated_at", time.Now()).
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": id, "version": version}).
+		Suffix("RETURNING *")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ur.db.QueryRow(ctx, sql, args...).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.Version,
+		&user.Avatar,
+		&user.StoreID,
+		&user.TenantID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, models.ErrVersionConflict
+		}
+		switch ur.db.ErrorCode(err) {
+		case "23505":
+			return nil, models.ErrConflictingData
+		case "23503":
+			return nil, models.ErrInvalidRole
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 // DeleteUser deletes a user by ID from the database
 func (ur *UserRepository) DeleteUser(ctx context.Context, id uint64) error {
 	query := ur.db.QueryBuilder.Delete("users").
@@ -3,17 +3,25 @@ package postgres
 import (
 	"context"
 	"embed"
+	"errors"
 	"fmt"
 	"github.com/Masterminds/squirrel"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
 	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/fx"
+	"strconv"
 )
 
+// tenantRole is the role queries run as, row-level security limits it to the rows of the tenant
+// set on the connection. Migrations run as the connecting user, who owns the tables and bypasses it
+const tenantRole = "app_tenant"
+
 // migrationsFS is a filesystem that embeds the migrations folder
 //
 //go:embed migrations/*.sql
@@ -42,12 +50,26 @@ func NewConnection(ctx context.Context, config *configs.DB) (*DB, error) {
 		config.Name,
 	)
 
-	db, err := pgxpool.New(ctx, url)
+	// pooled connections switch to the tenant role, which only exists once the migrations ran,
+	// so the database is checked on a connection of its own
+	conn, err := pgx.Connect(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn.Ping(ctx)
+	conn.Close(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	poolConfig, err := pgxpool.ParseConfig(url)
 	if err != nil {
 		return nil, err
 	}
+	poolConfig.BeforeAcquire = setTenant
 
-	err = db.Ping(ctx)
+	db, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -61,6 +83,18 @@ func NewConnection(ctx context.Context, config *configs.DB) (*DB, error) {
 	}, nil
 }
 
+// setTenant switches a connection to the tenant of the context it is acquired with, before every
+// query or transaction. Without a tenant, the connection sees no rows of the tenants' tables
+func setTenant(ctx context.Context, conn *pgx.Conn) bool {
+	tenantID := ""
+	if id, ok := _constant.TenantID(ctx); ok && id != 0 {
+		tenantID = strconv.FormatUint(id, 10)
+	}
+
+	_, err := conn.Exec(ctx, "SELECT set_config('role', $1, false), set_config('app.tenant_id', $2, false)", tenantRole, tenantID)
+	return err == nil
+}
+
 // Migrate runs the database migration
 func (db *DB) Migrate() error {
 	driver, err := iofs.New(migrationsFS, "migrations")
@@ -81,12 +115,39 @@ func (db *DB) Migrate() error {
 	return nil
 }
 
-// ErrorCode returns the error code of the given error
+// ErrorCode returns the error code of the given error, or "" when it is not a postgres one
 func (db *DB) ErrorCode(err error) string {
-	pgErr := err.(*pgconn.PgError)
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return ""
+	}
 	return pgErr.Code
 }
 
+// ConstraintName returns the name of the constraint the given error violates, or "" when it is
+// not a postgres one
+func (db *DB) ConstraintName(err error) string {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return ""
+	}
+	return pgErr.ConstraintName
+}
+
+// Schema is the database schema once it is migrated to the latest version, which the components
+// that read it while they are built depend on, so that they never see the one before an upgrade
+type Schema struct{}
+
+// MigrateSchema migrates the database to the latest version
+func MigrateSchema(db *DB) (*Schema, error) {
+	err := db.Migrate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate the database: %w", err)
+	}
+
+	return &Schema{}, nil
+}
+
 // Close closes the database connection
 func (db *DB) Close() {
 	db.Pool.Close()
@@ -100,6 +161,7 @@ var Module = fx.Module(
 	"postgres-module",
 	fx.Provide(
 		NewConnection,
+		MigrateSchema,
 		ProvideContext,
 	),
 )
@@ -0,0 +1,26 @@
+DELETE FROM casbin_rule WHERE ptype = 'g' AND v0 LIKE 'user:%';
+
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 IN ('/v1/roles/', '/v1/roles/:id', '/v1/users/');
+
+UPDATE casbin_model
+SET model_text = '[request_definition]
+        r = sub, obj, act
+
+        [policy_definition]
+        p = sub, obj, act
+
+        [role_definition]
+        g = _, _
+
+        [policy_effect]
+        e = some(where (p.eft == allow))
+
+        [matchers]
+        m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act'
+WHERE model_name = 'rbac_model';
+
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "users_role_fkey";
+
+ALTER TABLE "users" ALTER COLUMN "role" DROP NOT NULL;
+
+DROP TABLE IF EXISTS "roles";
@@ -0,0 +1,57 @@
+CREATE TABLE "roles" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "roles_name" ON "roles" ("name");
+
+INSERT INTO "roles" ("name", "description")
+VALUES ('admin', 'Manages users, roles and the back office'),
+       ('cashier', 'Rings up sales at the point of sale'),
+       ('user', 'Read-only access to the user directory');
+
+-- keep any free-text role that was already assigned to a user
+INSERT INTO "roles" ("name")
+SELECT DISTINCT "role" FROM "users" WHERE "role" IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+UPDATE "users" SET "role" = 'cashier' WHERE "role" IS NULL;
+
+ALTER TABLE "users" ALTER COLUMN "role" SET NOT NULL;
+
+ALTER TABLE "users"
+    ADD CONSTRAINT "users_role_fkey" FOREIGN KEY ("role") REFERENCES "roles" ("name")
+        ON UPDATE CASCADE ON DELETE RESTRICT;
+
+-- assign every existing user to its role in casbin
+INSERT INTO casbin_rule (ptype, v0, v1)
+SELECT 'g', 'user:' || "id", "role" FROM "users";
+
+-- paths are matched with keyMatch2, so that policies can name item routes such as '/v1/roles/:id'
+UPDATE casbin_model
+SET model_text = '[request_definition]
+        r = sub, obj, act
+
+        [policy_definition]
+        p = sub, obj, act
+
+        [role_definition]
+        g = _, _
+
+        [policy_effect]
+        e = some(where (p.eft == allow))
+
+        [matchers]
+        m = g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && r.act == p.act'
+WHERE model_name = 'rbac_model';
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/roles/', 'GET'),
+       ('p', 'admin', '/v1/roles/', 'POST'),
+       ('p', 'admin', '/v1/roles/:id', 'GET'),
+       ('p', 'admin', '/v1/roles/:id', 'PUT'),
+       ('p', 'admin', '/v1/roles/:id', 'DELETE'),
+       ('p', 'admin', '/v1/users/', 'GET');
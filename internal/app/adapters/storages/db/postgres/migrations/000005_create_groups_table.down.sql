@@ -0,0 +1,9 @@
+DELETE FROM casbin_rule WHERE ptype = 'g' AND (v0 LIKE 'group:%' OR v1 LIKE 'group:%');
+
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '/v1/groups/';
+
+DROP TABLE IF EXISTS "group_roles";
+
+DROP TABLE IF EXISTS "group_members";
+
+DROP TABLE IF EXISTS "groups";
@@ -0,0 +1,32 @@
+CREATE TABLE "groups" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    "parent_id" bigint REFERENCES "groups" ("id") ON DELETE SET NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    CONSTRAINT "groups_parent_check" CHECK ("parent_id" <> "id")
+);
+
+CREATE UNIQUE INDEX "groups_name" ON "groups" ("name");
+
+CREATE INDEX "groups_parent_id" ON "groups" ("parent_id");
+
+CREATE TABLE "group_members" (
+    "group_id" bigint NOT NULL REFERENCES "groups" ("id") ON DELETE CASCADE,
+    "user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("group_id", "user_id")
+);
+
+CREATE INDEX "group_members_user_id" ON "group_members" ("user_id");
+
+CREATE TABLE "group_roles" (
+    "group_id" bigint NOT NULL REFERENCES "groups" ("id") ON DELETE CASCADE,
+    "role" varchar NOT NULL REFERENCES "roles" ("name") ON UPDATE CASCADE ON DELETE RESTRICT,
+    PRIMARY KEY ("group_id", "role")
+);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/groups/', 'GET'),
+       ('p', 'admin', '/v1/groups/', 'POST');
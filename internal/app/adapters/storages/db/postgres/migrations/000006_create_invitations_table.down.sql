@@ -0,0 +1,3 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '/v1/invitations/';
+
+DROP TABLE IF EXISTS "invitations";
@@ -0,0 +1,19 @@
+CREATE TABLE "invitations" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "email" varchar NOT NULL,
+    "role" varchar NOT NULL REFERENCES "roles" ("name") ON UPDATE CASCADE ON DELETE CASCADE,
+    "token_id" varchar NOT NULL,
+    "invited_by" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "expires_at" timestamptz NOT NULL,
+    "accepted_at" timestamptz,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "invitations_token_id" ON "invitations" ("token_id");
+
+CREATE INDEX "invitations_email" ON "invitations" ("email");
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/invitations/', 'GET'),
+       ('p', 'admin', '/v1/invitations/', 'POST');
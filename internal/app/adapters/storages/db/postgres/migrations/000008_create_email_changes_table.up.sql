@@ -0,0 +1,18 @@
+CREATE TABLE "email_changes" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "old_email" varchar NOT NULL,
+    "new_email" varchar NOT NULL,
+    "token_id" varchar NOT NULL,
+    "revert_token_id" varchar NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "confirmed_at" timestamptz,
+    "reverted_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "email_changes_token_id" ON "email_changes" ("token_id");
+
+CREATE UNIQUE INDEX "email_changes_revert_token_id" ON "email_changes" ("revert_token_id");
+
+CREATE INDEX "email_changes_user_id" ON "email_changes" ("user_id");
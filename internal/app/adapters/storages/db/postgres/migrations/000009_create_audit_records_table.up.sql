@@ -0,0 +1,24 @@
+CREATE TABLE "audit_records" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "actor_id" bigint,
+    "target_id" bigint NOT NULL,
+    "action" varchar NOT NULL,
+    "changes" jsonb NOT NULL DEFAULT '[]',
+    "ip" varchar NOT NULL DEFAULT '',
+    "request_id" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "audit_records_target_id" ON "audit_records" ("target_id", "created_at");
+
+-- the trail outlives the users it describes, so there are no foreign keys,
+-- and rows can only ever be appended
+CREATE FUNCTION "audit_records_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_records is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_records_append_only"
+BEFORE UPDATE OR DELETE OR TRUNCATE ON "audit_records"
+FOR EACH STATEMENT EXECUTE FUNCTION "audit_records_append_only"();
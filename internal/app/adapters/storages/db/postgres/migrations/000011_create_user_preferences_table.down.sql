@@ -0,0 +1,3 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 = '/v1/users/me/preferences';
+
+DROP TABLE IF EXISTS "user_preferences";
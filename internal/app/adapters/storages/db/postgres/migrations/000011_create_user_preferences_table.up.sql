@@ -0,0 +1,15 @@
+CREATE TABLE "user_preferences" (
+    "user_id" bigint NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "key" varchar NOT NULL,
+    "value" jsonb NOT NULL,
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("user_id", "key")
+);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/users/me/preferences', 'GET'),
+       ('p', 'admin', '/v1/users/me/preferences', 'PUT'),
+       ('p', 'admin', '/v1/users/me/preferences', 'PATCH'),
+       ('p', 'cashier', '/v1/users/me/preferences', 'GET'),
+       ('p', 'cashier', '/v1/users/me/preferences', 'PUT'),
+       ('p', 'cashier', '/v1/users/me/preferences', 'PATCH');
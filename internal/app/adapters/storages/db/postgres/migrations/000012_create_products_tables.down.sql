@@ -0,0 +1,5 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 IN ('/v1/categories/', '/v1/products/');
+
+DROP TABLE IF EXISTS "products";
+
+DROP TABLE IF EXISTS "categories";
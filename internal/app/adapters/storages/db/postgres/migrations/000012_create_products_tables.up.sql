@@ -0,0 +1,32 @@
+CREATE TABLE "categories" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "categories_name" ON "categories" ("name");
+
+CREATE TABLE "products" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "category_id" bigint NOT NULL REFERENCES "categories" ("id") ON DELETE RESTRICT,
+    "sku" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "image" varchar NOT NULL DEFAULT '',
+    "price" bigint NOT NULL CHECK ("price" >= 0),
+    "stock" bigint NOT NULL DEFAULT 0 CHECK ("stock" >= 0),
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "products_sku" ON "products" ("sku");
+
+CREATE INDEX "products_category_id" ON "products" ("category_id");
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/categories/', 'GET'),
+       ('p', 'admin', '/v1/categories/', 'POST'),
+       ('p', 'admin', '/v1/products/', 'GET'),
+       ('p', 'admin', '/v1/products/', 'POST'),
+       ('p', 'cashier', '/v1/categories/', 'GET'),
+       ('p', 'cashier', '/v1/products/', 'GET');
@@ -0,0 +1,5 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 = '/v1/orders/';
+
+DROP TABLE IF EXISTS "order_items";
+
+DROP TABLE IF EXISTS "orders";
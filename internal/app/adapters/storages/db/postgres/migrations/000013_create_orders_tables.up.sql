@@ -0,0 +1,33 @@
+CREATE TABLE "orders" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "user_id" bigint NOT NULL,
+    "payment_method" varchar NOT NULL CHECK ("payment_method" IN ('cash', 'card', 'e-wallet')),
+    "total_price" bigint NOT NULL CHECK ("total_price" >= 0),
+    "total_paid" bigint NOT NULL CHECK ("total_paid" >= "total_price"),
+    "total_change" bigint NOT NULL CHECK ("total_change" = "total_paid" - "total_price"),
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "orders_user_id" ON "orders" ("user_id");
+
+CREATE TABLE "order_items" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "order_id" bigint NOT NULL REFERENCES "orders" ("id") ON DELETE CASCADE,
+    "product_id" bigint REFERENCES "products" ("id") ON DELETE SET NULL,
+    "sku" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "quantity" bigint NOT NULL CHECK ("quantity" > 0),
+    "price" bigint NOT NULL CHECK ("price" >= 0),
+    "total_price" bigint NOT NULL CHECK ("total_price" = "price" * "quantity")
+);
+
+CREATE INDEX "order_items_order_id" ON "order_items" ("order_id");
+
+CREATE INDEX "order_items_product_id" ON "order_items" ("product_id");
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/orders/', 'GET'),
+       ('p', 'admin', '/v1/orders/', 'POST'),
+       ('p', 'cashier', '/v1/orders/', 'GET'),
+       ('p', 'cashier', '/v1/orders/', 'POST');
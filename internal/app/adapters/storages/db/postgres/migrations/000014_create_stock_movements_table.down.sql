@@ -0,0 +1,7 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 = '/v1/stock-movements/';
+
+DROP TABLE IF EXISTS "stock_movements";
+
+DROP FUNCTION IF EXISTS "stock_movements_append_only"();
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "low_stock_threshold";
@@ -0,0 +1,37 @@
+ALTER TABLE "products" ADD COLUMN "low_stock_threshold" bigint NOT NULL DEFAULT 0 CHECK ("low_stock_threshold" >= 0);
+
+CREATE TABLE "stock_movements" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "product_id" bigint NOT NULL,
+    "type" varchar NOT NULL CHECK ("type" IN ('sale', 'return', 'restock', 'adjustment', 'transfer')),
+    "quantity" bigint NOT NULL CHECK ("quantity" <> 0),
+    "stock_after" bigint NOT NULL CHECK ("stock_after" >= 0),
+    "reason" varchar NOT NULL DEFAULT '',
+    "order_id" bigint,
+    "user_id" bigint,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "stock_movements_product_id" ON "stock_movements" ("product_id", "id");
+
+-- products.stock is the materialized sum of the ledger, which outlives the products
+-- and orders it describes, so there are no foreign keys, and rows can only ever be appended
+CREATE FUNCTION "stock_movements_append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'stock_movements is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "stock_movements_append_only"
+BEFORE UPDATE OR DELETE OR TRUNCATE ON "stock_movements"
+FOR EACH STATEMENT EXECUTE FUNCTION "stock_movements_append_only"();
+
+-- the stock of existing products opens the ledger
+INSERT INTO "stock_movements" ("product_id", "type", "quantity", "stock_after", "reason")
+SELECT "id", 'adjustment', "stock", "stock", 'opening balance'
+FROM "products"
+WHERE "stock" > 0;
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/stock-movements/', 'GET'),
+       ('p', 'admin', '/v1/stock-movements/', 'POST');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "orders_created_at";
+
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 LIKE '/v1/reports/%';
@@ -0,0 +1,8 @@
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/reports/revenue', 'GET'),
+       ('p', 'admin', '/v1/reports/top-products', 'GET'),
+       ('p', 'admin', '/v1/reports/cashiers', 'GET'),
+       ('p', 'admin', '/v1/reports/payment-methods', 'GET');
+
+-- reports group orders by day, so index the timestamp they are filtered on
+CREATE INDEX "orders_created_at" ON "orders" ("created_at");
@@ -0,0 +1,9 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 IN ('/v1/promotions/', '/v1/reports/discounts');
+
+DROP TABLE IF EXISTS "order_discounts";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "total_discount";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "coupon_code";
+
+DROP TABLE IF EXISTS "promotions";
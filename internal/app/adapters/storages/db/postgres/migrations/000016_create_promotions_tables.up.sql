@@ -0,0 +1,46 @@
+CREATE TABLE "promotions" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "type" varchar NOT NULL CHECK ("type" IN ('percentage', 'fixed', 'buy_x_get_y')),
+    "value" bigint NOT NULL DEFAULT 0 CHECK ("value" >= 0),
+    "product_id" bigint REFERENCES "products" ("id") ON DELETE CASCADE,
+    "category_id" bigint REFERENCES "categories" ("id") ON DELETE CASCADE,
+    "buy_quantity" bigint NOT NULL DEFAULT 0 CHECK ("buy_quantity" >= 0),
+    "get_quantity" bigint NOT NULL DEFAULT 0 CHECK ("get_quantity" >= 0),
+    "coupon_code" varchar NOT NULL DEFAULT '',
+    "usage_limit" bigint NOT NULL DEFAULT 0 CHECK ("usage_limit" >= 0),
+    "usage_count" bigint NOT NULL DEFAULT 0 CHECK ("usage_count" >= 0),
+    "starts_at" timestamptz,
+    "ends_at" timestamptz CHECK ("ends_at" > "starts_at"),
+    "active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("product_id" IS NULL OR "category_id" IS NULL),
+    CHECK ("usage_limit" = 0 OR "usage_count" <= "usage_limit")
+);
+
+-- promotions without a coupon code apply automatically
+CREATE UNIQUE INDEX "promotions_coupon_code" ON "promotions" ("coupon_code") WHERE "coupon_code" <> '';
+
+ALTER TABLE "orders" ADD COLUMN "coupon_code" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "orders" ADD COLUMN "total_discount" bigint NOT NULL DEFAULT 0 CHECK ("total_discount" >= 0);
+
+-- the applied promotion is copied, so the discount outlives it
+CREATE TABLE "order_discounts" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "order_id" bigint NOT NULL REFERENCES "orders" ("id") ON DELETE CASCADE,
+    "promotion_id" bigint NOT NULL,
+    "name" varchar NOT NULL,
+    "coupon_code" varchar NOT NULL DEFAULT '',
+    "amount" bigint NOT NULL CHECK ("amount" > 0)
+);
+
+CREATE INDEX "order_discounts_order_id" ON "order_discounts" ("order_id");
+
+CREATE INDEX "order_discounts_promotion_id" ON "order_discounts" ("promotion_id");
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/promotions/', 'GET'),
+       ('p', 'admin', '/v1/promotions/', 'POST'),
+       ('p', 'admin', '/v1/reports/discounts', 'GET');
@@ -0,0 +1,20 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 IN ('/v1/refunds/', '/v1/refunds/void');
+
+DELETE FROM casbin_rule
+WHERE ptype = 'p' AND v0 = 'manager' AND v1 IN ('/v1/orders/', '/v1/categories/', '/v1/products/');
+
+DROP TRIGGER IF EXISTS "orders_append_only" ON "orders";
+
+DROP TABLE IF EXISTS "refund_items";
+
+DROP TABLE IF EXISTS "refunds";
+
+DROP FUNCTION IF EXISTS "append_only"();
+
+ALTER TABLE "orders" DROP CONSTRAINT "orders_total_change_check";
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_total_change_check" CHECK ("total_change" = "total_paid" - "total_price");
+
+ALTER TABLE "orders" DROP CONSTRAINT "orders_total_paid_check";
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_total_paid_check" CHECK ("total_paid" >= "total_price");
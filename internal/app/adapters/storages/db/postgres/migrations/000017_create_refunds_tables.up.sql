@@ -0,0 +1,71 @@
+INSERT INTO "roles" ("name", "description")
+VALUES ('manager', 'Refunds and voids sales at the point of sale')
+ON CONFLICT DO NOTHING;
+
+-- an order can be rung up before it is paid for, and is voided if it never is
+ALTER TABLE "orders" DROP CONSTRAINT "orders_total_paid_check";
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_total_paid_check" CHECK ("total_paid" = 0 OR "total_paid" >= "total_price");
+
+ALTER TABLE "orders" DROP CONSTRAINT "orders_total_change_check";
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_total_change_check" CHECK ("total_change" = GREATEST("total_paid" - "total_price", 0));
+
+CREATE TABLE "refunds" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "order_id" bigint NOT NULL REFERENCES "orders" ("id"),
+    "user_id" bigint NOT NULL,
+    "type" varchar NOT NULL CHECK ("type" IN ('refund', 'void')),
+    "payment_method" varchar NOT NULL DEFAULT '',
+    "amount" bigint NOT NULL DEFAULT 0 CHECK ("amount" >= 0),
+    "reason" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK (
+        ("type" = 'refund' AND "payment_method" IN ('cash', 'card', 'e-wallet')) OR
+        ("type" = 'void' AND "payment_method" = '' AND "amount" = 0)
+    )
+);
+
+CREATE INDEX "refunds_order_id" ON "refunds" ("order_id");
+
+-- the product is copied from the order item, so the refund outlives it
+CREATE TABLE "refund_items" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "refund_id" bigint NOT NULL REFERENCES "refunds" ("id"),
+    "order_item_id" bigint NOT NULL REFERENCES "order_items" ("id"),
+    "product_id" bigint NOT NULL DEFAULT 0,
+    "quantity" bigint NOT NULL CHECK ("quantity" > 0)
+);
+
+CREATE INDEX "refund_items_refund_id" ON "refund_items" ("refund_id");
+
+-- orders are undone by refunds, so neither is ever changed once placed
+CREATE FUNCTION "append_only"() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION '% is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "orders_append_only"
+BEFORE UPDATE OR DELETE OR TRUNCATE ON "orders"
+FOR EACH STATEMENT EXECUTE FUNCTION "append_only"();
+
+CREATE TRIGGER "refunds_append_only"
+BEFORE UPDATE OR DELETE OR TRUNCATE ON "refunds"
+FOR EACH STATEMENT EXECUTE FUNCTION "append_only"();
+
+CREATE TRIGGER "refund_items_append_only"
+BEFORE UPDATE OR DELETE OR TRUNCATE ON "refund_items"
+FOR EACH STATEMENT EXECUTE FUNCTION "append_only"();
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/refunds/', 'GET'),
+       ('p', 'admin', '/v1/refunds/', 'POST'),
+       ('p', 'admin', '/v1/refunds/void', 'POST'),
+       ('p', 'manager', '/v1/refunds/', 'GET'),
+       ('p', 'manager', '/v1/refunds/', 'POST'),
+       ('p', 'manager', '/v1/refunds/void', 'POST'),
+       ('p', 'manager', '/v1/orders/', 'GET'),
+       ('p', 'manager', '/v1/orders/', 'POST'),
+       ('p', 'manager', '/v1/categories/', 'GET'),
+       ('p', 'manager', '/v1/products/', 'GET');
@@ -0,0 +1,17 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 IN ('/v1/stores/', 'stores');
+
+ALTER TABLE "refunds" DROP COLUMN IF EXISTS "store_id";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "store_id";
+
+DROP INDEX IF EXISTS "stock_movements_store_id_product_id";
+
+ALTER TABLE "stock_movements" DROP COLUMN IF EXISTS "store_id";
+
+CREATE INDEX "stock_movements_product_id" ON "stock_movements" ("product_id", "id");
+
+DROP TABLE IF EXISTS "store_stocks";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "store_id";
+
+DROP TABLE IF EXISTS "stores";
@@ -0,0 +1,59 @@
+CREATE TABLE "stores" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "address" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "stores_name" ON "stores" ("name");
+
+-- everything so far happened in a single shop, which becomes the first store, with the id 1
+-- which the columns below default to while they are added
+INSERT INTO "stores" ("name") VALUES ('Main store');
+
+ALTER TABLE "users" ADD COLUMN "store_id" bigint REFERENCES "stores" ("id") ON DELETE SET NULL;
+
+UPDATE "users" SET "store_id" = 1;
+
+CREATE INDEX "users_store_id" ON "users" ("store_id");
+
+-- products.stock is the stock of a product across every store
+CREATE TABLE "store_stocks" (
+    "store_id" bigint NOT NULL REFERENCES "stores" ("id") ON DELETE RESTRICT,
+    "product_id" bigint NOT NULL REFERENCES "products" ("id") ON DELETE CASCADE,
+    "stock" bigint NOT NULL DEFAULT 0 CHECK ("stock" >= 0),
+    PRIMARY KEY ("store_id", "product_id")
+);
+
+INSERT INTO "store_stocks" ("store_id", "product_id", "stock")
+SELECT 1, "id", "stock"
+FROM "products"
+WHERE "stock" > 0;
+
+-- stock_after of a movement is the stock of the product in the store of the movement
+ALTER TABLE "stock_movements" ADD COLUMN "store_id" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE "stock_movements" ALTER COLUMN "store_id" DROP DEFAULT;
+
+DROP INDEX "stock_movements_product_id";
+
+CREATE INDEX "stock_movements_store_id_product_id" ON "stock_movements" ("store_id", "product_id", "id");
+
+ALTER TABLE "orders" ADD COLUMN "store_id" bigint NOT NULL DEFAULT 1 REFERENCES "stores" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "orders" ALTER COLUMN "store_id" DROP DEFAULT;
+
+CREATE INDEX "orders_store_id_created_at" ON "orders" ("store_id", "created_at");
+
+ALTER TABLE "refunds" ADD COLUMN "store_id" bigint NOT NULL DEFAULT 1 REFERENCES "stores" ("id") ON DELETE RESTRICT;
+
+ALTER TABLE "refunds" ALTER COLUMN "store_id" DROP DEFAULT;
+
+CREATE INDEX "refunds_store_id" ON "refunds" ("store_id", "id");
+
+-- the store a request acts on is checked against this request, whoever is allowed it acts on any store
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/stores/', 'GET'),
+       ('p', 'admin', '/v1/stores/', 'POST'),
+       ('p', 'admin', 'stores', 'all');
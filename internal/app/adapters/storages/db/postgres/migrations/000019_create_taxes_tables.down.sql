@@ -0,0 +1,15 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 IN ('/v1/tax-classes/', '/v1/tax-rates/');
+
+DROP TABLE IF EXISTS "order_taxes";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "total_tax";
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "tax_class_id";
+
+ALTER TABLE "stores" DROP COLUMN IF EXISTS "tax_rounding";
+
+ALTER TABLE "stores" DROP COLUMN IF EXISTS "region";
+
+DROP TABLE IF EXISTS "tax_rates";
+
+DROP TABLE IF EXISTS "tax_classes";
@@ -0,0 +1,58 @@
+CREATE TABLE "tax_classes" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "tax_classes_name" ON "tax_classes" ("name");
+
+-- the rate is in millionths, a rate of a store applies there, one of a region applies to the stores
+-- of the region and one of neither applies everywhere, the most specific rates of a class win
+CREATE TABLE "tax_rates" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "tax_class_id" bigint NOT NULL REFERENCES "tax_classes" ("id") ON DELETE CASCADE,
+    "name" varchar NOT NULL,
+    "rate" bigint NOT NULL CHECK ("rate" > 0 AND "rate" < 1000000),
+    "inclusive" boolean NOT NULL DEFAULT false,
+    "store_id" bigint REFERENCES "stores" ("id") ON DELETE CASCADE,
+    "region" varchar NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    CHECK ("store_id" IS NULL OR "region" = '')
+);
+
+CREATE INDEX "tax_rates_tax_class_id" ON "tax_rates" ("tax_class_id");
+
+CREATE INDEX "tax_rates_store_id" ON "tax_rates" ("store_id");
+
+ALTER TABLE "stores" ADD COLUMN "region" varchar NOT NULL DEFAULT '';
+
+ALTER TABLE "stores" ADD COLUMN "tax_rounding" varchar NOT NULL DEFAULT 'line' CHECK ("tax_rounding" IN ('line', 'invoice'));
+
+-- products without a tax class are not taxed
+ALTER TABLE "products" ADD COLUMN "tax_class_id" bigint REFERENCES "tax_classes" ("id") ON DELETE RESTRICT;
+
+CREATE INDEX "products_tax_class_id" ON "products" ("tax_class_id");
+
+ALTER TABLE "orders" ADD COLUMN "total_tax" bigint NOT NULL DEFAULT 0 CHECK ("total_tax" >= 0);
+
+-- the applied rate is copied, so the tax outlives it
+CREATE TABLE "order_taxes" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "order_id" bigint NOT NULL REFERENCES "orders" ("id") ON DELETE CASCADE,
+    "tax_rate_id" bigint NOT NULL,
+    "name" varchar NOT NULL,
+    "rate" bigint NOT NULL,
+    "inclusive" boolean NOT NULL,
+    "taxable_amount" bigint NOT NULL CHECK ("taxable_amount" >= 0),
+    "amount" bigint NOT NULL CHECK ("amount" >= 0)
+);
+
+CREATE INDEX "order_taxes_order_id" ON "order_taxes" ("order_id");
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/tax-classes/', 'GET'),
+       ('p', 'admin', '/v1/tax-classes/', 'POST'),
+       ('p', 'admin', '/v1/tax-rates/', 'GET'),
+       ('p', 'admin', '/v1/tax-rates/', 'POST');
@@ -0,0 +1,28 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 = '/v1/customers/';
+
+ALTER TABLE "refunds" DROP COLUMN IF EXISTS "points_reversed";
+
+ALTER TABLE "refunds" DROP COLUMN IF EXISTS "points_refunded";
+
+ALTER TABLE "refunds" DROP CONSTRAINT "refunds_check";
+
+ALTER TABLE "refunds" ADD CONSTRAINT "refunds_check" CHECK (
+    ("type" = 'refund' AND "payment_method" IN ('cash', 'card', 'e-wallet')) OR
+    ("type" = 'void' AND "payment_method" = '' AND "amount" = 0)
+);
+
+ALTER TABLE "orders" DROP CONSTRAINT IF EXISTS "orders_points_check";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "points_redeemed";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "points_earned";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "customer_id";
+
+ALTER TABLE "orders" DROP CONSTRAINT "orders_payment_method_check";
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_payment_method_check" CHECK ("payment_method" IN ('cash', 'card', 'e-wallet'));
+
+DROP TABLE IF EXISTS "loyalty_entries";
+
+DROP TABLE IF EXISTS "customers";
@@ -0,0 +1,71 @@
+-- points is the materialized balance of the loyalty ledger, a customer is only ever deleted
+-- before buying anything or earning any point, so both outlive nothing they describe
+CREATE TABLE "customers" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "email" varchar NOT NULL DEFAULT '',
+    "phone" varchar NOT NULL DEFAULT '',
+    "points" bigint NOT NULL DEFAULT 0,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "customers_email" ON "customers" ("email") WHERE "email" <> '';
+
+CREATE UNIQUE INDEX "customers_phone" ON "customers" ("phone") WHERE "phone" <> '';
+
+-- the points taken back by a refund can overdraw the balance, a redemption cannot
+CREATE TABLE "loyalty_entries" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "customer_id" bigint NOT NULL REFERENCES "customers" ("id"),
+    "type" varchar NOT NULL CHECK ("type" IN ('earn', 'redeem', 'refund', 'reversal', 'adjustment')),
+    "points" bigint NOT NULL CHECK ("points" <> 0),
+    "balance_after" bigint NOT NULL,
+    "reason" varchar NOT NULL DEFAULT '',
+    "order_id" bigint REFERENCES "orders" ("id"),
+    "user_id" bigint,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX "loyalty_entries_customer_id" ON "loyalty_entries" ("customer_id", "id");
+
+CREATE TRIGGER "loyalty_entries_append_only"
+BEFORE UPDATE OR DELETE OR TRUNCATE ON "loyalty_entries"
+FOR EACH STATEMENT EXECUTE FUNCTION "append_only"();
+
+-- an order paid with points is paid in full, and earns none
+ALTER TABLE "orders" DROP CONSTRAINT "orders_payment_method_check";
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_payment_method_check" CHECK ("payment_method" IN ('cash', 'card', 'e-wallet', 'points'));
+
+ALTER TABLE "orders" ADD COLUMN "customer_id" bigint REFERENCES "customers" ("id");
+
+ALTER TABLE "orders" ADD COLUMN "points_earned" bigint NOT NULL DEFAULT 0 CHECK ("points_earned" >= 0);
+
+ALTER TABLE "orders" ADD COLUMN "points_redeemed" bigint NOT NULL DEFAULT 0 CHECK ("points_redeemed" >= 0);
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_points_check" CHECK (
+    ("customer_id" IS NOT NULL OR ("points_earned" = 0 AND "points_redeemed" = 0)) AND
+    ("payment_method" = 'points' OR "points_redeemed" = 0)
+);
+
+CREATE INDEX "orders_customer_id" ON "orders" ("customer_id", "id");
+
+ALTER TABLE "refunds" DROP CONSTRAINT "refunds_check";
+
+ALTER TABLE "refunds" ADD CONSTRAINT "refunds_check" CHECK (
+    ("type" = 'refund' AND "payment_method" IN ('cash', 'card', 'e-wallet', 'points')) OR
+    ("type" = 'void' AND "payment_method" = '' AND "amount" = 0)
+);
+
+ALTER TABLE "refunds" ADD COLUMN "points_refunded" bigint NOT NULL DEFAULT 0 CHECK ("points_refunded" >= 0);
+
+ALTER TABLE "refunds" ADD COLUMN "points_reversed" bigint NOT NULL DEFAULT 0 CHECK ("points_reversed" >= 0);
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/customers/', 'GET'),
+       ('p', 'admin', '/v1/customers/', 'POST'),
+       ('p', 'manager', '/v1/customers/', 'GET'),
+       ('p', 'manager', '/v1/customers/', 'POST'),
+       ('p', 'cashier', '/v1/customers/', 'GET'),
+       ('p', 'cashier', '/v1/customers/', 'POST');
@@ -0,0 +1,8 @@
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/authz/policies', 'GET'),
+       ('p', 'admin', '/v1/authz/policies', 'POST'),
+       ('p', 'admin', '/v1/authz/policies', 'DELETE'),
+       ('p', 'admin', '/v1/authz/groupings', 'GET'),
+       ('p', 'admin', '/v1/authz/groupings', 'POST'),
+       ('p', 'admin', '/v1/authz/groupings', 'DELETE'),
+       ('p', 'admin', '/v1/authz/roles', 'GET');
@@ -0,0 +1,33 @@
+-- the policies of this migration use patterns and method alternations, so they are dropped with the matcher
+DELETE FROM casbin_rule WHERE ptype = 'p' AND (v1 LIKE '%:%' OR v1 LIKE '%*%' OR v2 LIKE '%|%' OR v2 LIKE '%*%');
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/admin', 'GET'),
+       ('p', 'user', '/v1/users/', 'GET'),
+       ('p', 'user', '/v1/users/login', 'POST');
+
+INSERT INTO casbin_rule (ptype, v0, v1)
+VALUES ('g', 'alice', 'admin'),
+       ('g', 'bob', 'user');
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/roles/:id', 'GET'),
+       ('p', 'admin', '/v1/roles/:id', 'PUT'),
+       ('p', 'admin', '/v1/roles/:id', 'DELETE');
+
+UPDATE casbin_model
+SET model_text = '[request_definition]
+        r = sub, obj, act
+
+        [policy_definition]
+        p = sub, obj, act
+
+        [role_definition]
+        g = _, _
+
+        [policy_effect]
+        e = some(where (p.eft == allow))
+
+        [matchers]
+        m = g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && r.act == p.act'
+WHERE model_name = 'rbac_model';
@@ -0,0 +1,70 @@
+-- paths are matched with keyMatch2, so policies can name gin routes such as
+-- '/v1/users/:id' or '/v1/groups/*', and methods with an anchored regex, so
+-- a single policy can allow 'GET|PUT|PATCH'. Exact policies match as before
+UPDATE casbin_model
+SET model_text = '[request_definition]
+        r = sub, obj, act
+
+        [policy_definition]
+        p = sub, obj, act
+
+        [role_definition]
+        g = _, _
+
+        [policy_effect]
+        e = some(where (p.eft == allow))
+
+        [matchers]
+        m = g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && regexMatch(r.act, "^(" + p.act + ")$")'
+WHERE model_name = 'rbac_model';
+
+-- the first seeds were examples, for users and a role that never existed
+DELETE FROM casbin_rule
+WHERE (ptype = 'p' AND v0 = 'admin' AND v1 = '/admin')
+   OR (ptype = 'p' AND v0 = 'user')
+   OR (ptype = 'g' AND v0 IN ('alice', 'bob'));
+
+-- the role routes were seeded one method at a time, they are allowed together below
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '/v1/roles/:id';
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'admin', '/v1/users/:id', 'GET|PUT|PATCH|DELETE'),
+       ('p', 'admin', '/v1/users/:id/history', 'GET'),
+       ('p', 'admin', '/v1/users/:id/avatar', 'PUT|DELETE'),
+       ('p', 'admin', '/v1/roles/:id', 'GET|PUT|DELETE'),
+       ('p', 'admin', '/v1/groups/:id', 'GET|PUT|DELETE'),
+       ('p', 'admin', '/v1/groups/:id/members', 'GET|POST'),
+       ('p', 'admin', '/v1/groups/:id/members/:user_id', 'DELETE'),
+       ('p', 'admin', '/v1/groups/:id/roles', 'GET|POST'),
+       ('p', 'admin', '/v1/groups/:id/roles/:role', 'DELETE'),
+       ('p', 'admin', '/v1/categories/:id', 'GET|PUT|DELETE'),
+       ('p', 'admin', '/v1/products/:id', 'GET|PUT|DELETE'),
+       ('p', 'admin', '/v1/orders/:id', 'GET'),
+       ('p', 'admin', '/v1/orders/:id/receipt', 'GET'),
+       ('p', 'admin', '/v1/promotions/:id', 'GET|PUT|DELETE'),
+       ('p', 'admin', '/v1/refunds/:id', 'GET'),
+       ('p', 'admin', '/v1/stores/:id', 'GET|PUT|DELETE'),
+       ('p', 'admin', '/v1/stores/:id/users', 'GET|POST'),
+       ('p', 'admin', '/v1/stores/:id/users/:user_id', 'DELETE'),
+       ('p', 'admin', '/v1/tax-classes/:id', 'GET|PUT|DELETE'),
+       ('p', 'admin', '/v1/tax-rates/:id', 'GET|PUT|DELETE'),
+       ('p', 'admin', '/v1/customers/:id', 'GET|PUT|DELETE'),
+       ('p', 'admin', '/v1/customers/:id/orders', 'GET'),
+       ('p', 'admin', '/v1/customers/:id/points', 'GET|POST'),
+       ('p', 'admin', '/v1/invitations/:id', 'DELETE'),
+       ('p', 'manager', '/v1/users/me/preferences', 'GET|PUT|PATCH'),
+       ('p', 'manager', '/v1/categories/:id', 'GET'),
+       ('p', 'manager', '/v1/products/:id', 'GET'),
+       ('p', 'manager', '/v1/orders/:id', 'GET'),
+       ('p', 'manager', '/v1/orders/:id/receipt', 'GET'),
+       ('p', 'manager', '/v1/refunds/:id', 'GET'),
+       ('p', 'manager', '/v1/customers/:id', 'GET|PUT'),
+       ('p', 'manager', '/v1/customers/:id/orders', 'GET'),
+       ('p', 'manager', '/v1/customers/:id/points', 'GET'),
+       ('p', 'cashier', '/v1/categories/:id', 'GET'),
+       ('p', 'cashier', '/v1/products/:id', 'GET'),
+       ('p', 'cashier', '/v1/orders/:id', 'GET'),
+       ('p', 'cashier', '/v1/orders/:id/receipt', 'GET'),
+       ('p', 'cashier', '/v1/customers/:id', 'GET|PUT'),
+       ('p', 'cashier', '/v1/customers/:id/orders', 'GET'),
+       ('p', 'cashier', '/v1/customers/:id/points', 'GET');
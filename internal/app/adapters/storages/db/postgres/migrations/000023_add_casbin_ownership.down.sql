@@ -0,0 +1,19 @@
+DELETE FROM casbin_rule
+WHERE ptype = 'p' AND v0 = 'owner';
+
+UPDATE casbin_model
+SET model_text = '[request_definition]
+        r = sub, obj, act
+
+        [policy_definition]
+        p = sub, obj, act
+
+        [role_definition]
+        g = _, _
+
+        [policy_effect]
+        e = some(where (p.eft == allow))
+
+        [matchers]
+        m = g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && regexMatch(r.act, "^(" + p.act + ")$")'
+WHERE model_name = 'rbac_model';
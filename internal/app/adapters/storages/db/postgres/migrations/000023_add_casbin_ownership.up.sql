@@ -0,0 +1,26 @@
+-- requests carry the owner of the resource they act on, as resolved by the
+-- route, so that policies granted to 'owner' apply to the requests a user
+-- makes on resources of their own. Requests without an owner carry ''
+UPDATE casbin_model
+SET model_text = '[request_definition]
+        r = sub, obj, act, owner
+
+        [policy_definition]
+        p = sub, obj, act
+
+        [role_definition]
+        g = _, _
+
+        [policy_effect]
+        e = some(where (p.eft == allow))
+
+        [matchers]
+        m = (g(r.sub, p.sub) || (p.sub == "owner" && r.sub == r.owner)) && keyMatch2(r.obj, p.obj) && regexMatch(r.act, "^(" + p.act + ")$")'
+WHERE model_name = 'rbac_model';
+
+-- users read and edit their own record, the user service refuses a change of
+-- their own role
+INSERT INTO casbin_rule (ptype, v0, v1, v2)
+VALUES ('p', 'owner', '/v1/users/:id', 'GET|PUT|PATCH'),
+       ('p', 'owner', '/v1/users/:id/history', 'GET'),
+       ('p', 'owner', '/v1/users/:id/avatar', 'PUT|DELETE');
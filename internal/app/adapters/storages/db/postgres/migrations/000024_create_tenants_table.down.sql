@@ -0,0 +1,91 @@
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE USAGE, SELECT ON SEQUENCES FROM "app_tenant";
+ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES FROM "app_tenant";
+DROP OWNED BY "app_tenant";
+DROP ROLE IF EXISTS "app_tenant";
+
+DROP POLICY IF EXISTS "tenant_isolation" ON "loyalty_entries";
+ALTER TABLE "loyalty_entries" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "tax_rates";
+ALTER TABLE "tax_rates" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "store_stocks";
+ALTER TABLE "store_stocks" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "refund_items";
+ALTER TABLE "refund_items" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "refunds";
+ALTER TABLE "refunds" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "order_taxes";
+ALTER TABLE "order_taxes" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "order_discounts";
+ALTER TABLE "order_discounts" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "order_items";
+ALTER TABLE "order_items" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "user_preferences";
+ALTER TABLE "user_preferences" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "email_changes";
+ALTER TABLE "email_changes" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "group_roles";
+ALTER TABLE "group_roles" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "group_members";
+ALTER TABLE "group_members" DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "tenant_isolation" ON "customers";
+ALTER TABLE "customers" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "tax_classes";
+ALTER TABLE "tax_classes" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "stores";
+ALTER TABLE "stores" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "promotions";
+ALTER TABLE "promotions" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "stock_movements";
+ALTER TABLE "stock_movements" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "orders";
+ALTER TABLE "orders" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "products";
+ALTER TABLE "products" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "categories";
+ALTER TABLE "categories" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "audit_records";
+ALTER TABLE "audit_records" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "invitations";
+ALTER TABLE "invitations" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "groups";
+ALTER TABLE "groups" DISABLE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS "tenant_isolation" ON "users";
+ALTER TABLE "users" DISABLE ROW LEVEL SECURITY;
+
+-- fails when two tenants share a name, an email or a code, which they have to be told apart from first
+DROP INDEX IF EXISTS "customers_phone";
+CREATE UNIQUE INDEX "customers_phone" ON "customers" ("phone") WHERE "phone" <> '';
+DROP INDEX IF EXISTS "customers_email";
+CREATE UNIQUE INDEX "customers_email" ON "customers" ("email") WHERE "email" <> '';
+DROP INDEX IF EXISTS "tax_classes_name";
+CREATE UNIQUE INDEX "tax_classes_name" ON "tax_classes" ("name");
+DROP INDEX IF EXISTS "stores_name";
+CREATE UNIQUE INDEX "stores_name" ON "stores" ("name");
+DROP INDEX IF EXISTS "promotions_coupon_code";
+CREATE UNIQUE INDEX "promotions_coupon_code" ON "promotions" ("coupon_code") WHERE "coupon_code" <> '';
+DROP INDEX IF EXISTS "products_sku";
+CREATE UNIQUE INDEX "products_sku" ON "products" ("sku");
+DROP INDEX IF EXISTS "categories_name";
+CREATE UNIQUE INDEX "categories_name" ON "categories" ("name");
+DROP INDEX IF EXISTS "groups_name";
+CREATE UNIQUE INDEX "groups_name" ON "groups" ("name");
+DROP INDEX IF EXISTS "email";
+CREATE UNIQUE INDEX "email" ON "users" ("email");
+
+ALTER TABLE "customers" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "tax_classes" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "stores" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "promotions" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "stock_movements" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "products" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "audit_records" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "invitations" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "groups" DROP COLUMN IF EXISTS "tenant_id";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "tenant_id";
+
+DROP FUNCTION IF EXISTS "current_tenant_id"();
+
+DROP TABLE IF EXISTS "tenants";
@@ -0,0 +1,173 @@
+CREATE TABLE "tenants" (
+    "id" BIGSERIAL PRIMARY KEY,
+    "name" varchar NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX "tenants_name" ON "tenants" ("name");
+
+-- the records made before there were tenants belong to the default one
+INSERT INTO "tenants" ("id", "name") VALUES (1, 'default');
+
+SELECT setval('tenants_id_seq', (SELECT max("id") FROM "tenants"));
+
+-- the tenant a connection acts on is set by the application before every query, none
+-- matches no row
+CREATE FUNCTION "current_tenant_id"() RETURNS bigint AS $$
+    SELECT NULLIF(current_setting('app.tenant_id', true), '')::bigint;
+$$ LANGUAGE sql STABLE;
+
+-- the tables every other record hangs off carry their tenant, new rows take the one of the
+-- connection
+ALTER TABLE "users" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "users" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+CREATE INDEX "users_tenant_id" ON "users" ("tenant_id");
+
+ALTER TABLE "groups" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "groups" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+ALTER TABLE "invitations" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "invitations" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+ALTER TABLE "audit_records" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "audit_records" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+ALTER TABLE "categories" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "categories" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+ALTER TABLE "products" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "products" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+ALTER TABLE "orders" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "orders" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+CREATE INDEX "orders_tenant_id_created_at" ON "orders" ("tenant_id", "created_at");
+
+ALTER TABLE "stock_movements" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "stock_movements" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+ALTER TABLE "promotions" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "promotions" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+ALTER TABLE "stores" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "stores" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+ALTER TABLE "tax_classes" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "tax_classes" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+ALTER TABLE "customers" ADD COLUMN "tenant_id" bigint NOT NULL DEFAULT 1 REFERENCES "tenants" ("id") ON DELETE RESTRICT;
+ALTER TABLE "customers" ALTER COLUMN "tenant_id" SET DEFAULT current_tenant_id();
+
+-- names, emails and codes only have to be unique within a tenant
+DROP INDEX "email";
+CREATE UNIQUE INDEX "email" ON "users" ("tenant_id", "email");
+DROP INDEX "groups_name";
+CREATE UNIQUE INDEX "groups_name" ON "groups" ("tenant_id", "name");
+DROP INDEX "categories_name";
+CREATE UNIQUE INDEX "categories_name" ON "categories" ("tenant_id", "name");
+DROP INDEX "products_sku";
+CREATE UNIQUE INDEX "products_sku" ON "products" ("tenant_id", "sku");
+DROP INDEX "promotions_coupon_code";
+CREATE UNIQUE INDEX "promotions_coupon_code" ON "promotions" ("tenant_id", "coupon_code") WHERE "coupon_code" <> '';
+DROP INDEX "stores_name";
+CREATE UNIQUE INDEX "stores_name" ON "stores" ("tenant_id", "name");
+DROP INDEX "tax_classes_name";
+CREATE UNIQUE INDEX "tax_classes_name" ON "tax_classes" ("tenant_id", "name");
+DROP INDEX "customers_email";
+CREATE UNIQUE INDEX "customers_email" ON "customers" ("tenant_id", "email") WHERE "email" <> '';
+DROP INDEX "customers_phone";
+CREATE UNIQUE INDEX "customers_phone" ON "customers" ("tenant_id", "phone") WHERE "phone" <> '';
+
+-- a row is only visible, and can only be written, by connections acting on its tenant.
+-- The other tables are reached through the row they belong to
+ALTER TABLE "users" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "users" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "groups" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "groups" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "invitations" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "invitations" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "audit_records" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "audit_records" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "categories" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "categories" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "products" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "products" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "orders" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "orders" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "stock_movements" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "stock_movements" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "promotions" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "promotions" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "stores" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "stores" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "tax_classes" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "tax_classes" USING ("tenant_id" = current_tenant_id());
+ALTER TABLE "customers" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "customers" USING ("tenant_id" = current_tenant_id());
+
+ALTER TABLE "group_members" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "group_members" USING (
+    EXISTS (SELECT 1 FROM "groups" WHERE "groups"."id" = "group_members"."group_id") AND
+    EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "group_members"."user_id")
+);
+ALTER TABLE "group_roles" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "group_roles" USING (
+    EXISTS (SELECT 1 FROM "groups" WHERE "groups"."id" = "group_roles"."group_id")
+);
+ALTER TABLE "email_changes" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "email_changes" USING (
+    EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "email_changes"."user_id")
+);
+ALTER TABLE "user_preferences" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "user_preferences" USING (
+    EXISTS (SELECT 1 FROM "users" WHERE "users"."id" = "user_preferences"."user_id")
+);
+ALTER TABLE "order_items" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "order_items" USING (
+    EXISTS (SELECT 1 FROM "orders" WHERE "orders"."id" = "order_items"."order_id")
+);
+ALTER TABLE "order_discounts" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "order_discounts" USING (
+    EXISTS (SELECT 1 FROM "orders" WHERE "orders"."id" = "order_discounts"."order_id")
+);
+ALTER TABLE "order_taxes" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "order_taxes" USING (
+    EXISTS (SELECT 1 FROM "orders" WHERE "orders"."id" = "order_taxes"."order_id")
+);
+ALTER TABLE "refunds" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "refunds" USING (
+    EXISTS (SELECT 1 FROM "orders" WHERE "orders"."id" = "refunds"."order_id")
+);
+ALTER TABLE "refund_items" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "refund_items" USING (
+    EXISTS (SELECT 1 FROM "refunds" WHERE "refunds"."id" = "refund_items"."refund_id")
+);
+ALTER TABLE "store_stocks" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "store_stocks" USING (
+    EXISTS (SELECT 1 FROM "stores" WHERE "stores"."id" = "store_stocks"."store_id")
+);
+ALTER TABLE "tax_rates" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "tax_rates" USING (
+    EXISTS (SELECT 1 FROM "tax_classes" WHERE "tax_classes"."id" = "tax_rates"."tax_class_id")
+);
+ALTER TABLE "loyalty_entries" ENABLE ROW LEVEL SECURITY;
+CREATE POLICY "tenant_isolation" ON "loyalty_entries" USING (
+    EXISTS (SELECT 1 FROM "customers" WHERE "customers"."id" = "loyalty_entries"."customer_id")
+);
+
+-- the table owner bypasses row-level security, so the application queries as a role of its own,
+-- which the connecting user switches to. Tables made by later migrations are granted to it too
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'app_tenant') THEN
+        CREATE ROLE "app_tenant" NOLOGIN;
+    END IF;
+END
+$$;
+
+GRANT "app_tenant" TO CURRENT_USER;
+GRANT USAGE ON SCHEMA public TO "app_tenant";
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO "app_tenant";
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO "app_tenant";
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO "app_tenant";
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO "app_tenant";
@@ -0,0 +1,29 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 = 'tenant:1' AND v2 LIKE '/v1/tenants/%';
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 = 'tenant:1' AND v2 = '/v1/roles/:id' AND v3 = 'PUT|DELETE';
+UPDATE casbin_rule SET v3 = 'GET|PUT|DELETE'
+WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '*' AND v2 = '/v1/roles/:id' AND v3 = 'GET';
+UPDATE casbin_rule SET v1 = '*'
+WHERE ptype = 'p' AND v0 = 'admin' AND v1 = 'tenant:1' AND v2 = '/v1/roles/' AND v3 = 'POST';
+
+-- without domains, only the rules of every tenant and the roles of the default one are kept
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v1 <> '*';
+DELETE FROM casbin_rule WHERE ptype = 'g' AND v2 <> 'tenant:1';
+UPDATE casbin_rule SET v1 = v2, v2 = v3, v3 = '' WHERE ptype = 'p';
+UPDATE casbin_rule SET v2 = '' WHERE ptype = 'g';
+
+UPDATE casbin_model
+SET model_text = '[request_definition]
+        r = sub, obj, act, owner
+
+        [policy_definition]
+        p = sub, obj, act
+
+        [role_definition]
+        g = _, _
+
+        [policy_effect]
+        e = some(where (p.eft == allow))
+
+        [matchers]
+        m = (g(r.sub, p.sub) || (p.sub == "owner" && r.sub == r.owner)) && keyMatch2(r.obj, p.obj) && regexMatch(r.act, "^(" + p.act + ")$")'
+WHERE model_name = 'rbac_model';
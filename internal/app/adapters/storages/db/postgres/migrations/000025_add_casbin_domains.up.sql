@@ -0,0 +1,33 @@
+-- requests and rules carry a domain, the tenant they are made in. Users only have the roles granted
+-- in the domain of the request, and the rules of the '*' domain apply in every tenant
+UPDATE casbin_model
+SET model_text = '[request_definition]
+        r = sub, dom, obj, act, owner
+
+        [policy_definition]
+        p = sub, dom, obj, act
+
+        [role_definition]
+        g = _, _, _
+
+        [policy_effect]
+        e = some(where (p.eft == allow))
+
+        [matchers]
+        m = (g(r.sub, p.sub, r.dom) || (p.sub == "owner" && r.sub == r.owner)) && (p.dom == r.dom || p.dom == "*") && keyMatch2(r.obj, p.obj) && regexMatch(r.act, "^(" + p.act + ")$")'
+WHERE model_name = 'rbac_model';
+
+-- the rules so far apply everywhere, and the roles so far were granted in the default tenant
+UPDATE casbin_rule SET v3 = v2, v2 = v1, v1 = '*' WHERE ptype = 'p';
+UPDATE casbin_rule SET v2 = 'tenant:1' WHERE ptype = 'g';
+
+-- the roles are shared by every tenant, so only the admins of the default tenant change them
+UPDATE casbin_rule SET v1 = 'tenant:1'
+WHERE ptype = 'p' AND v0 = 'admin' AND v2 = '/v1/roles/' AND v3 = 'POST';
+UPDATE casbin_rule SET v3 = 'GET'
+WHERE ptype = 'p' AND v0 = 'admin' AND v1 = '*' AND v2 = '/v1/roles/:id' AND v3 = 'GET|PUT|DELETE';
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2, v3)
+VALUES ('p', 'admin', 'tenant:1', '/v1/roles/:id', 'PUT|DELETE'),
+       ('p', 'admin', 'tenant:1', '/v1/tenants/', 'GET|POST'),
+       ('p', 'admin', 'tenant:1', '/v1/tenants/:id', 'GET');
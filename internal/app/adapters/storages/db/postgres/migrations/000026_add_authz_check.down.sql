@@ -0,0 +1 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v2 = '/v1/authz/check';
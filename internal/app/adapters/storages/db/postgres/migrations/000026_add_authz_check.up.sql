@@ -0,0 +1,2 @@
+INSERT INTO casbin_rule (ptype, v0, v1, v2, v3)
+VALUES ('p', 'admin', '*', '/v1/authz/check', 'POST');
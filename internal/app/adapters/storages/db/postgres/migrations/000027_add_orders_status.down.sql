@@ -0,0 +1,17 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v2 = '/v1/orders/:id/settle';
+
+DROP TRIGGER IF EXISTS "orders_settle_only" ON "orders";
+
+DROP FUNCTION IF EXISTS "orders_settle_only"();
+
+DROP TRIGGER IF EXISTS "orders_append_only" ON "orders";
+
+ALTER TABLE "orders" DROP CONSTRAINT "orders_total_paid_check";
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_total_paid_check" CHECK ("total_paid" = 0 OR "total_paid" >= "total_price");
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "status";
+
+CREATE TRIGGER "orders_append_only"
+BEFORE UPDATE OR DELETE OR TRUNCATE ON "orders"
+FOR EACH STATEMENT EXECUTE FUNCTION "append_only"();
@@ -0,0 +1,39 @@
+-- an order is opened when it is rung up before it is paid for, and is then either settled or voided.
+-- Orders placed so far with nothing paid were left open
+ALTER TABLE "orders" ADD COLUMN "status" varchar NOT NULL DEFAULT 'paid' CHECK ("status" IN ('open', 'paid'));
+
+DROP TRIGGER "orders_append_only" ON "orders";
+
+UPDATE "orders" SET "status" = 'open' WHERE "total_paid" = 0 AND "total_price" > 0;
+
+ALTER TABLE "orders" DROP CONSTRAINT "orders_total_paid_check";
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_total_paid_check" CHECK (
+    ("status" = 'open' AND "total_paid" = 0) OR
+    ("status" = 'paid' AND "total_paid" >= "total_price")
+);
+
+CREATE TRIGGER "orders_append_only"
+BEFORE DELETE OR TRUNCATE ON "orders"
+FOR EACH STATEMENT EXECUTE FUNCTION "append_only"();
+
+-- settling an open order takes its payment, nothing else of it ever changes
+CREATE FUNCTION "orders_settle_only"() RETURNS trigger AS $$
+BEGIN
+    IF OLD."status" <> 'open' OR NEW."status" <> 'paid' OR
+       to_jsonb(NEW) - ARRAY['status', 'payment_method', 'total_paid', 'total_change', 'points_earned', 'updated_at'] <>
+       to_jsonb(OLD) - ARRAY['status', 'payment_method', 'total_paid', 'total_change', 'points_earned', 'updated_at'] THEN
+        RAISE EXCEPTION 'orders are only updated to settle them';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "orders_settle_only"
+BEFORE UPDATE ON "orders"
+FOR EACH ROW EXECUTE FUNCTION "orders_settle_only"();
+
+INSERT INTO casbin_rule (ptype, v0, v1, v2, v3)
+VALUES ('p', 'admin', '*', '/v1/orders/:id/settle', 'POST'),
+       ('p', 'manager', '*', '/v1/orders/:id/settle', 'POST'),
+       ('p', 'cashier', '*', '/v1/orders/:id/settle', 'POST');
@@ -0,0 +1 @@
+DELETE FROM casbin_rule WHERE ptype = 'p' AND v0 = 'owner' AND v2 LIKE '/v1/users/:id/fields/%';
@@ -0,0 +1,6 @@
+-- the fields users change on their own record are granted to the owner field by field, the role is
+-- left out so that nobody changes their own. Edits of other users' records are decided by the route
+INSERT INTO casbin_rule (ptype, v0, v1, v2, v3)
+VALUES ('p', 'owner', '*', '/v1/users/:id/fields/name', 'PUT'),
+       ('p', 'owner', '*', '/v1/users/:id/fields/email', 'PUT'),
+       ('p', 'owner', '*', '/v1/users/:id/fields/password', 'PUT');
@@ -0,0 +1,18 @@
+CREATE OR REPLACE FUNCTION "orders_settle_only"() RETURNS trigger AS $$
+BEGIN
+    IF OLD."status" <> 'open' OR NEW."status" <> 'paid' OR
+       to_jsonb(NEW) - ARRAY['status', 'payment_method', 'total_paid', 'total_change', 'points_earned', 'updated_at'] <>
+       to_jsonb(OLD) - ARRAY['status', 'payment_method', 'total_paid', 'total_change', 'points_earned', 'updated_at'] THEN
+        RAISE EXCEPTION 'orders are only updated to settle them';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS "orders_store_id_paid_at";
+
+DROP INDEX IF EXISTS "orders_tenant_id_paid_at";
+
+ALTER TABLE "orders" DROP CONSTRAINT IF EXISTS "orders_paid_at_check";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "paid_at";
@@ -0,0 +1,27 @@
+-- the reports count an order when it is paid for, which is when it is placed unless it was opened
+-- and settled later. The orders settled so far were last updated when they were settled
+ALTER TABLE "orders" ADD COLUMN "paid_at" timestamptz;
+
+ALTER TABLE "orders" DISABLE TRIGGER "orders_settle_only";
+
+UPDATE "orders" SET "paid_at" = "updated_at" WHERE "status" = 'paid';
+
+ALTER TABLE "orders" ENABLE TRIGGER "orders_settle_only";
+
+ALTER TABLE "orders" ADD CONSTRAINT "orders_paid_at_check" CHECK (("status" = 'paid') = ("paid_at" IS NOT NULL));
+
+CREATE INDEX "orders_tenant_id_paid_at" ON "orders" ("tenant_id", "paid_at");
+
+CREATE INDEX "orders_store_id_paid_at" ON "orders" ("store_id", "paid_at");
+
+-- settling an open order takes its payment and the time of it, nothing else of it ever changes
+CREATE OR REPLACE FUNCTION "orders_settle_only"() RETURNS trigger AS $$
+BEGIN
+    IF OLD."status" <> 'open' OR NEW."status" <> 'paid' OR
+       to_jsonb(NEW) - ARRAY['status', 'payment_method', 'total_paid', 'total_change', 'points_earned', 'paid_at', 'updated_at'] <>
+       to_jsonb(OLD) - ARRAY['status', 'payment_method', 'total_paid', 'total_change', 'points_earned', 'paid_at', 'updated_at'] THEN
+        RAISE EXCEPTION 'orders are only updated to settle them';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
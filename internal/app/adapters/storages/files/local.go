@@ -0,0 +1,87 @@
+package files
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+/**
+ * LocalStorage implements ports.FileRepository interface
+ * and stores files in a directory of the local filesystem,
+ * which the http server exposes under the public url
+ */
+type LocalStorage struct {
+	root      string
+	publicURL string
+}
+
+// NewLocalStorage creates a new local filesystem storage instance
+func NewLocalStorage(config *configs.Storage) (*LocalStorage, error) {
+	err := os.MkdirAll(config.Path, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{
+		root:      config.Path,
+		publicURL: strings.TrimSuffix(config.PublicURL, "/"),
+	}, nil
+}
+
+// Put writes the file to a temporary name first and renames it into place,
+// so a reader never sees a partially written file
+func (ls *LocalStorage) Put(ctx context.Context, key, contentType string, data []byte) error {
+	name := ls.path(key)
+
+	err := os.MkdirAll(filepath.Dir(name), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmp.Name(), 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), name)
+}
+
+// Delete removes the file from the filesystem
+func (ls *LocalStorage) Delete(ctx context.Context, key string) error {
+	err := os.Remove(ls.path(key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// URL returns the public url of the file
+func (ls *LocalStorage) URL(key string) string {
+	return ls.publicURL + "/" + strings.TrimPrefix(path.Clean("/"+key), "/")
+}
+
+// path maps a key to a filename inside the root directory, a key can never escape it
+func (ls *LocalStorage) path(key string) string {
+	return filepath.Join(ls.root, filepath.FromSlash(path.Clean("/"+key)))
+}
@@ -0,0 +1,27 @@
+package files
+
+import (
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"go.uber.org/fx"
+)
+
+// NewFileRepository creates the file storage selected by the storage driver, local by default
+func NewFileRepository(config *configs.Storage) (ports.FileRepository, error) {
+	switch config.Driver {
+	case "", "local":
+		return NewLocalStorage(config)
+	case "s3":
+		return NewS3Storage(config)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", config.Driver)
+	}
+}
+
+var Module = fx.Module(
+	"files-storage-module",
+	fx.Provide(
+		fx.Annotate(NewFileRepository, fx.As(new(ports.FileRepository))),
+	),
+)
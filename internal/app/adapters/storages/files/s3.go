@@ -0,0 +1,238 @@
+package files
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// amzDateFormat is the timestamp format of the x-amz-date header
+	amzDateFormat = "20060102T150405Z"
+	// signingAlgorithm is the only algorithm supported by AWS signature version 4
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+)
+
+/**
+ * S3Storage implements ports.FileRepository interface
+ * and stores files in a bucket of an S3-compatible object storage,
+ * such as AWS S3 or MinIO, using path-style urls and signature version 4
+ */
+type S3Storage struct {
+	client    *http.Client
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicURL string
+}
+
+// NewS3Storage creates a new S3-compatible storage instance
+func NewS3Storage(config *configs.Storage) (*S3Storage, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("storage endpoint %q must be an absolute url", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("storage bucket is not set")
+	}
+
+	region := config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	base := strings.TrimSuffix(endpoint.String(), "/") + "/" + uriEncode(config.Bucket, true)
+
+	publicURL := strings.TrimSuffix(config.PublicURL, "/")
+	if publicURL == "" {
+		publicURL = base
+	}
+
+	return &S3Storage{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		endpoint:  base,
+		region:    region,
+		bucket:    config.Bucket,
+		accessKey: config.AccessKey,
+		secretKey: config.SecretKey,
+		publicURL: publicURL,
+	}, nil
+}
+
+// Put uploads the file as an object of the bucket
+func (s *S3Storage) Put(ctx context.Context, key, contentType string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	return s.do(req, data, http.StatusOK)
+}
+
+// Delete removes the object from the bucket, deleting a missing object succeeds in S3
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.do(req, nil, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+// URL returns the public url of the object
+func (s *S3Storage) URL(key string) string {
+	return s.publicURL + "/" + uriEncode(key, false)
+}
+
+// objectURL returns the path-style api url of an object
+func (s *S3Storage) objectURL(key string) string {
+	return s.endpoint + "/" + uriEncode(key, false)
+}
+
+// do signs and sends a request, any status other than the expected ones is an error
+func (s *S3Storage) do(req *http.Request, payload []byte, expected ...int) error {
+	s.sign(req, hashHex(payload), time.Now())
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	for _, status := range expected {
+		if rsp.StatusCode == status {
+			return nil
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+	return fmt.Errorf("storage %s %s: %s: %s", req.Method, req.URL.Path, rsp.Status, bytes.TrimSpace(body))
+}
+
+// sign adds the signature version 4 headers to a request, every header
+// already set on the request is signed along with the host
+func (s *S3Storage) sign(req *http.Request, payloadHash string, t time.Time) {
+	req.Header.Set("X-Amz-Date", t.UTC().Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := []string{"host"}
+	for name := range req.Header {
+		signedHeaders = append(signedHeaders, strings.ToLower(name))
+	}
+	sort.Strings(signedHeaders)
+
+	req.Header.Set("Authorization", s.authorization(req, signedHeaders, payloadHash, t))
+}
+
+// authorization computes the authorization header value of a request
+func (s *S3Storage) authorization(req *http.Request, signedHeaders []string, payloadHash string, t time.Time) string {
+	t = t.UTC()
+	scope := strings.Join([]string{t.Format("20060102"), s.region, "s3", "aws4_request"}, "/")
+
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		t.Format(amzDateFormat),
+		scope,
+		hashHex([]byte(canonicalRequest(req, signedHeaders, payloadHash))),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), t.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	return fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm,
+		s.accessKey,
+		scope,
+		strings.Join(signedHeaders, ";"),
+		signature,
+	)
+}
+
+// canonicalRequest builds the canonical form of a request that signature version 4 signs
+func canonicalRequest(req *http.Request, signedHeaders []string, payloadHash string) string {
+	var headers strings.Builder
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		}
+		headers.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	query := req.URL.Query()
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		path,
+		strings.Join(params, "&"),
+		headers.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+}
+
+// uriEncode percent-encodes every byte except the unreserved characters,
+// slashes are kept as they are unless encodeSlash is set
+func uriEncode(value string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// hashHex returns the hex encoded sha256 hash of data
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the hmac-sha256 of data with the given key
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -2,6 +2,7 @@ package storages
 
 import (
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
+	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/files"
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/redis"
 	"go.uber.org/fx"
 )
@@ -9,4 +10,5 @@ import (
 var Module = fx.Options(
 	postgres.Module,
 	redis.Module,
+	files.Module,
 )
@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"fmt"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
 	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
 	"go.uber.org/fx"
@@ -12,14 +14,16 @@ import (
 
 /**
  * Redis implements ports.CacheRepository interface
- * and provides an access to the redis library
+ * and provides an access to the redis library,
+ * its pub/sub channels are shared with other adapters.
+ * Cache keys are kept apart per tenant
  */
 type Redis struct {
 	client *redis.Client
 }
 
 // New creates a new instance of Redis
-func NewConnection(ctx context.Context, config *configs.Redis) (ports.CacheRepository, error) {
+func NewConnection(ctx context.Context, config *configs.Redis) (*Redis, error) {
 	client := redis.NewClient(&redis.Options{
 		Addr:     config.Addr,
 		Password: config.Password,
@@ -34,21 +38,31 @@ func NewConnection(ctx context.Context, config *configs.Redis) (ports.CacheRepos
 	return &Redis{client}, nil
 }
 
+// tenantKey prefixes a key with the tenant of the context, so that a tenant never reads what was
+// cached for another. Contexts without a tenant share the entries of the data every tenant shares
+func tenantKey(ctx context.Context, key string) string {
+	tenantID, _ := _constant.TenantID(ctx)
+	if tenantID == 0 {
+		return "shared:" + key
+	}
+	return fmt.Sprintf("tenant:%d:%s", tenantID, key)
+}
+
 // Set stores the value in the redis database
 func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
-	return r.client.Set(ctx, key, value, ttl).Err()
+	return r.client.Set(ctx, tenantKey(ctx, key), value, ttl).Err()
 }
 
 // Get retrieves the value from the redis database
 func (r *Redis) Get(ctx context.Context, key string) ([]byte, error) {
-	res, err := r.client.Get(ctx, key).Result()
+	res, err := r.client.Get(ctx, tenantKey(ctx, key)).Result()
 	bytes := []byte(res)
 	return bytes, err
 }
 
 // Delete removes the value from the redis database
 func (r *Redis) Delete(ctx context.Context, key string) error {
-	return r.client.Del(ctx, key).Err()
+	return r.client.Del(ctx, tenantKey(ctx, key)).Err()
 }
 
 // DeleteByPrefix removes the value from the redis database with the given prefix
@@ -58,7 +72,7 @@ func (r *Redis) DeleteByPrefix(ctx context.Context, prefix string) error {
 
 	for {
 		var err error
-		keys, cursor, err = r.client.Scan(ctx, cursor, prefix, 100).Result()
+		keys, cursor, err = r.client.Scan(ctx, cursor, tenantKey(ctx, prefix), 100).Result()
 		if err != nil {
 			return err
 		}
@@ -78,6 +92,48 @@ func (r *Redis) DeleteByPrefix(ctx context.Context, prefix string) error {
 	return nil
 }
 
+// Publish sends a message to every subscriber of the channel
+func (r *Redis) Publish(ctx context.Context, channel string, message []byte) error {
+	return r.client.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe receives the messages published on the channel until the context is done, the
+// subscription is restored after a lost connection, without the messages sent meanwhile
+func (r *Redis) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	pubsub := r.client.Subscribe(ctx, channel)
+
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	messages := make(chan []byte)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		received := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-received:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
 // Close closes the connection to the redis database
 func (r *Redis) Close() error {
 	return r.client.Close()
@@ -86,6 +142,6 @@ func (r *Redis) Close() error {
 var Module = fx.Module(
 	"cache-redis-module",
 	fx.Provide(
-		fx.Annotate(NewConnection, fx.As(new(ports.CacheRepository))),
+		fx.Annotate(NewConnection, fx.As(new(ports.CacheRepository)), fx.As(fx.Self())),
 	),
 )
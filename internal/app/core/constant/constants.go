@@ -4,4 +4,9 @@ const (
 	AuthorizationHeaderKey  = "authorization"
 	AuthorizationType       = "bearer"
 	AuthorizationPayloadKey = "authorization_payload"
+	RequestIDHeaderKey      = "X-Request-ID"
+	RequestMetaKey          = "request_meta"
+	StoreIDHeaderKey        = "X-Store-ID"
+	StoreIDKey              = "store_id"
+	TenantIDHeaderKey       = "X-Tenant-ID"
 )
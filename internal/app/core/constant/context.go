@@ -0,0 +1,48 @@
+package constant
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+// contextKey is the type of the keys kept in a context, so that they cannot collide with the keys
+// of other packages
+type contextKey string
+
+const (
+	// tenantIDKey is the key of the id of the tenant a request acts on
+	tenantIDKey contextKey = "tenant_id"
+	// payloadKey is the key of the token payload of the user a request is made by
+	payloadKey contextKey = "authorization_payload"
+)
+
+// WithTenantID returns a copy of ctx which acts on the tenant of the given id
+func WithTenantID(ctx context.Context, tenantID uint64) context.Context {
+	return context.WithValue(ctx, tenantIDKey, tenantID)
+}
+
+// TenantID returns the id of the tenant ctx acts on, and whether it has one
+func TenantID(ctx context.Context) (uint64, bool) {
+	tenantID, ok := ctx.Value(tenantIDKey).(uint64)
+	return tenantID, ok
+}
+
+// TenantDomain returns the casbin domain of the tenant ctx acts on, or ErrInvalidTenant without one
+func TenantDomain(ctx context.Context) (string, error) {
+	tenantID, ok := TenantID(ctx)
+	if !ok || tenantID == 0 {
+		return "", models.ErrInvalidTenant
+	}
+	return models.TenantDomain(tenantID), nil
+}
+
+// WithPayload returns a copy of ctx which is made by the user of the given token payload
+func WithPayload(ctx context.Context, payload *models.TokenPayload) context.Context {
+	return context.WithValue(ctx, payloadKey, payload)
+}
+
+// Payload returns the token payload of the user ctx is made by, and whether it has one
+func Payload(ctx context.Context) (*models.TokenPayload, bool) {
+	payload, ok := ctx.Value(payloadKey).(*models.TokenPayload)
+	return payload, ok && payload != nil
+}
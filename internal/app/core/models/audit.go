@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+)
+
+// AuditAction is an enum for the kind of change an audit record describes
+type AuditAction string
+
+// AuditAction enum values
+const (
+	AuditUserCreate       AuditAction = "user.create"
+	AuditUserUpdate       AuditAction = "user.update"
+	AuditUserDelete       AuditAction = "user.delete"
+	AuditUserEmailRequest AuditAction = "user.email_request"
+	AuditUserEmailConfirm AuditAction = "user.email_confirm"
+	AuditUserEmailRevert  AuditAction = "user.email_revert"
+	AuditPolicyAdd        AuditAction = "policy.add"
+	AuditPolicyRemove     AuditAction = "policy.remove"
+)
+
+// RedactedValue replaces secrets, such as password hashes, in an audit diff
+const RedactedValue = "[redacted]"
+
+// AuditChange is the value of a single field before and after a change
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// AuditRecord is an append-only entity that records who changed a user, how, and from where.
+// Policy changes are recorded too, against the user the rule is about, or no user at all
+type AuditRecord struct {
+	ID        uint64
+	ActorID   *uint64
+	TargetID  uint64
+	Action    AuditAction
+	Changes   []AuditChange
+	IP        string
+	RequestID string
+	CreatedAt time.Time
+	TenantID  uint64
+}
+
+// AuditFilter narrows down the audit records of a target, zero values match everything
+type AuditFilter struct {
+	TargetID uint64
+	ActorID  uint64
+	Action   AuditAction
+	From     time.Time
+	To       time.Time
+}
+
+// RequestMeta is an entity that represents where a request came from
+type RequestMeta struct {
+	ID string
+	IP string
+}
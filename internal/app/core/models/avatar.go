@@ -0,0 +1,18 @@
+package models
+
+// AvatarSizes are the edge lengths in pixels of the square thumbnails kept for every avatar
+var AvatarSizes = []int{256, 128, 64}
+
+const (
+	// AvatarMaxSize is the largest avatar upload accepted, in bytes
+	AvatarMaxSize = 5 << 20
+	// AvatarMaxDimension is the largest width or height of an accepted avatar image, in pixels
+	AvatarMaxDimension = 4096
+)
+
+// AvatarContentTypes are the image types accepted as avatars
+var AvatarContentTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+}
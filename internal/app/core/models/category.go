@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+// Category is an entity that represents a group of products in the catalog
+type Category struct {
+	ID        uint64
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	TenantID  uint64
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+)
+
+// Customer is an entity that represents a shopper known to the business. Points is the balance
+// of their loyalty ledger, which only ever changes through loyalty entries
+type Customer struct {
+	ID        uint64
+	Name      string
+	Email     string
+	Phone     string
+	Points    int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	TenantID  uint64
+}
+
+// LoyaltyEntryType is the kind of change a loyalty entry makes
+type LoyaltyEntryType string
+
+// LoyaltyEntryType enum values
+const (
+	// LoyaltyEarn credits the points an order earned
+	LoyaltyEarn LoyaltyEntryType = "earn"
+	// LoyaltyRedeem debits the points an order was paid with
+	LoyaltyRedeem LoyaltyEntryType = "redeem"
+	// LoyaltyRefund credits the points a refund pays back
+	LoyaltyRefund LoyaltyEntryType = "refund"
+	// LoyaltyReversal debits the earned points of what a refund gives back
+	LoyaltyReversal LoyaltyEntryType = "reversal"
+	// LoyaltyAdjustment corrects a balance by hand, with a reason
+	LoyaltyAdjustment LoyaltyEntryType = "adjustment"
+)
+
+// LoyaltyEntry is an entry of the append-only loyalty ledger of a customer. Points is signed,
+// BalanceAfter is the balance once the entry is applied, which customers.points materializes.
+// OrderID is set for the entries of orders and their refunds, and UserID is who made the entry
+type LoyaltyEntry struct {
+	ID           uint64
+	CustomerID   uint64
+	Type         LoyaltyEntryType
+	Points       int64
+	BalanceAfter int64
+	Reason       string
+	OrderID      *uint64
+	UserID       *uint64
+	CreatedAt    time.Time
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// EmailChange is an entity that represents a user's request to move to a new email,
+// which only takes effect once confirmed from the new address
+type EmailChange struct {
+	ID            uint64
+	UserID        uint64
+	OldEmail      string
+	NewEmail      string
+	TokenID       string
+	RevertTokenID string
+	ExpiresAt     time.Time
+	ConfirmedAt   *time.Time
+	RevertedAt    *time.Time
+	CreatedAt     time.Time
+}
+
+// IsPending reports whether the email change can still be confirmed at the given time
+func (ec *EmailChange) IsPending(now time.Time) bool {
+	return ec.ConfirmedAt == nil && ec.RevertedAt == nil && now.Before(ec.ExpiresAt)
+}
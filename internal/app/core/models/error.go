@@ -37,4 +37,86 @@ var (
 	ErrUnauthorized = errors.New("user is unauthorized to access the resource")
 	// ErrForbidden is an error for when the user is forbidden to access the resource
 	ErrForbidden = errors.New("user is forbidden to access the resource")
+	// ErrInvalidCurrentPassword is an error for when users change their own password without giving the current one
+	ErrInvalidCurrentPassword = errors.New("current password is invalid")
+	// ErrInvalidRole is an error for when the assigned role does not exist
+	ErrInvalidRole = errors.New("role does not exist")
+	// ErrRoleInUse is an error for when a role is still assigned to users
+	ErrRoleInUse = errors.New("role is still assigned to users")
+	// ErrGroupCycle is an error for when a group would be nested inside itself
+	ErrGroupCycle = errors.New("group cannot be nested inside itself or its descendants")
+	// ErrVersionConflict is an error for when data was modified since the version the client last read
+	ErrVersionConflict = errors.New("data has been modified by another request")
+	// ErrPreconditionRequired is an error for when a conditional request header is not provided
+	ErrPreconditionRequired = errors.New("if-match header is not provided")
+	// ErrNotNullable is an error for when a patch sets a required field to null
+	ErrNotNullable = errors.New("field cannot be null")
+	// ErrUnsupportedMediaType is an error for when an uploaded file is not of an accepted type
+	ErrUnsupportedMediaType = errors.New("file type is not supported")
+	// ErrFileTooLarge is an error for when an uploaded file exceeds the size limit
+	ErrFileTooLarge = errors.New("file is too large")
+	// ErrNotAcceptable is an error for when none of the formats a client accepts can be rendered
+	ErrNotAcceptable = errors.New("format is not acceptable")
+	// ErrUnknownPreference is an error for when a preference key is not defined
+	ErrUnknownPreference = errors.New("preference is not defined")
+	// ErrInvalidPreference is an error for when a preference value does not match its schema
+	ErrInvalidPreference = errors.New("preference value is invalid")
+	// ErrInvalidCategory is an error for when the assigned category does not exist
+	ErrInvalidCategory = errors.New("category does not exist")
+	// ErrCategoryInUse is an error for when a category still has products
+	ErrCategoryInUse = errors.New("category still has products")
+	// ErrInvalidProduct is an error for when an ordered product does not exist
+	ErrInvalidProduct = errors.New("product does not exist")
+	// ErrInvalidStockMovement is an error for when a manual stock movement has the wrong type, sign or no reason
+	ErrInvalidStockMovement = errors.New("stock movement is invalid")
+	// ErrInvalidReport is an error for when a report ends before it starts, covers too long a range or has an unknown period
+	ErrInvalidReport = errors.New("report range or period is invalid")
+	// ErrInvalidPromotion is an error for when a promotion has the wrong value, quantities, scope or validity window
+	ErrInvalidPromotion = errors.New("promotion is invalid")
+	// ErrInvalidCoupon is an error for when a coupon code does not exist, is not valid at the time or does not apply to the order
+	ErrInvalidCoupon = errors.New("coupon code is invalid or does not apply")
+	// ErrPromotionExhausted is an error for when a promotion has reached its usage limit
+	ErrPromotionExhausted = errors.New("promotion has reached its usage limit")
+	// ErrInvalidRefund is an error for when a refund gives back more than is left of an order, or a void is not of an open order
+	ErrInvalidRefund = errors.New("refund is invalid")
+	// ErrInvalidPayment is an error for when an open order is paid for, or is to be paid with points
+	ErrInvalidPayment = errors.New("open order cannot be paid when it is placed")
+	// ErrOrderNotOpen is an error for when an order to settle was already paid for or voided
+	ErrOrderNotOpen = errors.New("order is not open")
+	// ErrInvalidTaxClass is an error for when the assigned tax class does not exist
+	ErrInvalidTaxClass = errors.New("tax class does not exist")
+	// ErrTaxClassInUse is an error for when a tax class still has products
+	ErrTaxClassInUse = errors.New("tax class still has products")
+	// ErrInvalidTaxRate is an error for when a tax rate is out of range or is of both a store and a region
+	ErrInvalidTaxRate = errors.New("tax rate is invalid")
+	// ErrInvalidStore is an error for when the store a request acts on does not exist or is malformed
+	ErrInvalidStore = errors.New("store does not exist")
+	// ErrStoreRequired is an error for when a request that changes a store is not acting on one
+	ErrStoreRequired = errors.New("store is not selected")
+	// ErrStoreInUse is an error for when a store still has orders or stock
+	ErrStoreInUse = errors.New("store still has orders or stock")
+	// ErrInvalidCustomer is an error for when the customer of an order does not exist
+	ErrInvalidCustomer = errors.New("customer does not exist")
+	// ErrCustomerRequired is an error for when points are used without a customer
+	ErrCustomerRequired = errors.New("customer is not selected")
+	// ErrCustomerInUse is an error for when a customer has orders or loyalty points history
+	ErrCustomerInUse = errors.New("customer has orders or loyalty points history")
+	// ErrInsufficientPoints is an error for when a customer has fewer points than are redeemed
+	ErrInsufficientPoints = errors.New("customer has insufficient points")
+	// ErrInvalidLoyaltyEntry is an error for when a points adjustment is empty or has no reason
+	ErrInvalidLoyaltyEntry = errors.New("loyalty points adjustment is invalid")
+	// ErrInvalidPolicy is an error for when a policy rule is malformed
+	ErrInvalidPolicy = errors.New("policy rule is invalid")
+	// ErrProtectedPolicy is an error for when a policy rule is needed to administer the policy itself
+	ErrProtectedPolicy = errors.New("policy rule is protected")
+	// ErrRegistrationClosed is an error for when open self-registration is disabled
+	ErrRegistrationClosed = errors.New("registration is by invitation only")
+	// ErrInvalidLink is an error for when a signed link is invalid or has already been used
+	ErrInvalidLink = errors.New("link is invalid or has already been used")
+	// ErrExpiredLink is an error for when a signed link has expired
+	ErrExpiredLink = errors.New("link has expired")
+	// ErrInvalidTenant is an error for when the tenant a request acts on does not exist or is malformed
+	ErrInvalidTenant = errors.New("tenant does not exist")
+	// ErrTenantMismatch is an error for when a signed-in user asks to act on another tenant than their own
+	ErrTenantMismatch = errors.New("user does not belong to the tenant")
 )
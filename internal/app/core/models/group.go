@@ -0,0 +1,29 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Group is an entity that represents a team of users, such as a store or a shift
+type Group struct {
+	ID          uint64
+	Name        string
+	Description string
+	ParentID    *uint64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	TenantID    uint64
+}
+
+// GroupMember is an entity that represents a user's membership in a group
+type GroupMember struct {
+	GroupID   uint64
+	UserID    uint64
+	CreatedAt time.Time
+}
+
+// GroupSubject returns the casbin subject that identifies a group in grouping rules
+func GroupSubject(id uint64) string {
+	return fmt.Sprintf("group:%d", id)
+}
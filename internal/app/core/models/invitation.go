@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// Invitation is an entity that represents an admin's invitation for a new user to join
+type Invitation struct {
+	ID         uint64
+	Email      string
+	Role       UserRole
+	TokenID    string
+	InvitedBy  uint64
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	TenantID   uint64
+}
+
+// IsPending reports whether the invitation can still be accepted at the given time
+func (i *Invitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
+}
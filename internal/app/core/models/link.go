@@ -0,0 +1,11 @@
+package models
+
+// LinkPurpose is an enum for what a signed link sent to a user can be used for
+type LinkPurpose string
+
+// LinkPurpose enum values
+const (
+	LinkInvitation   LinkPurpose = "invitation"
+	LinkEmailConfirm LinkPurpose = "email/confirm"
+	LinkEmailRevert  LinkPurpose = "email/revert"
+)
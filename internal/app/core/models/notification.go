@@ -0,0 +1,8 @@
+package models
+
+// Notification is an entity that represents a message delivered to a user
+type Notification struct {
+	Recipient string
+	Subject   string
+	Body      string
+}
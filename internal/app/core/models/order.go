@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+)
+
+// PaymentMethod is how an order is paid
+type PaymentMethod string
+
+// PaymentMethod enum values
+const (
+	PaymentCash    PaymentMethod = "cash"
+	PaymentCard    PaymentMethod = "card"
+	PaymentEWallet PaymentMethod = "e-wallet"
+	// PaymentPoints pays with the loyalty points of the customer of the order
+	PaymentPoints PaymentMethod = "points"
+)
+
+// OrderStatus is whether the payment of an order was taken
+type OrderStatus string
+
+// OrderStatus enum values
+const (
+	// OrderOpen is an order rung up before it is paid for, it is settled or voided later
+	OrderOpen OrderStatus = "open"
+	OrderPaid OrderStatus = "paid"
+)
+
+// Order is an entity that represents a sale rung up by a cashier,
+// all amounts are in minor units of the currency. TotalPrice is what
+// is left to pay once TotalDiscount is taken off the items and the
+// exclusive taxes are added. TotalTax is every tax of the order,
+// inclusive or not, broken down by rate in Taxes. An open order
+// has nothing paid yet, it is settled with its payment or voided.
+// Orders are never changed otherwise, they are undone by refunds.
+// An order is placed at a store and its stock is taken from there.
+// An order of a customer earns them points unless it is paid with
+// points, in which case PointsRedeemed pays for all of it. PaidAt is
+// when it was paid for, it is nil while the order is open
+type Order struct {
+	ID             uint64
+	UserID         uint64
+	Status         OrderStatus
+	PaymentMethod  PaymentMethod
+	TotalPrice     int64
+	TotalPaid      int64
+	TotalChange    int64
+	Items          []OrderItem
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CouponCode     string
+	TotalDiscount  int64
+	Discounts      []OrderDiscount
+	StoreID        uint64
+	TotalTax       int64
+	Taxes          []OrderTax
+	CustomerID     *uint64
+	PointsEarned   int64
+	PointsRedeemed int64
+	TenantID       uint64
+	PaidAt         *time.Time
+}
+
+// IsOpen checks whether the order waits for its payment
+func (o *Order) IsOpen() bool {
+	return o.Status == OrderOpen
+}
+
+// OrderItem is a line of an order. The SKU, name and price are copied from the product
+// when it is sold, so the order still reads the same after the product changes or is deleted,
+// in which case ProductID is zero
+type OrderItem struct {
+	ID         uint64
+	OrderID    uint64
+	ProductID  uint64
+	SKU        string
+	Name       string
+	Quantity   int64
+	Price      int64
+	TotalPrice int64
+}
+
+// OrderDiscount is a promotion applied to an order. The name and coupon code
+// are copied from the promotion, so the order still reads the same after the
+// promotion changes or is deleted
+type OrderDiscount struct {
+	ID          uint64
+	OrderID     uint64
+	PromotionID uint64
+	Name        string
+	CouponCode  string
+	Amount      int64
+}
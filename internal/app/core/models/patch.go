@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/json"
+)
+
+// PatchField is a member of a JSON merge patch (RFC 7396) document,
+// it tells apart an absent member, an explicit null and a set value
+type PatchField[T any] struct {
+	Present bool
+	Null    bool
+	Value   T
+}
+
+// UnmarshalJSON is only called for members present in the document
+func (pf *PatchField[T]) UnmarshalJSON(data []byte) error {
+	pf.Present = true
+
+	if string(data) == "null" {
+		pf.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &pf.Value)
+}
+
+// UserPatch is a merge patch for a user, applied only if Version is still current.
+// CurrentPassword is not a member of the user, users changing their own password give it
+type UserPatch struct {
+	ID              uint64
+	Version         uint64
+	Name            PatchField[string]
+	Email           PatchField[string]
+	Password        PatchField[string]
+	Role            PatchField[UserRole]
+	CurrentPassword string
+}
+
+// UserField is an enum for the user attributes a patch can change
+type UserField string
+
+// UserField enum values, named after the users table columns
+const (
+	UserNameField     UserField = "name"
+	UserEmailField    UserField = "email"
+	UserPasswordField UserField = "password"
+	UserRoleField     UserField = "role"
+	UserAvatarField   UserField = "avatar"
+)
+
+// UserChange is a field-level change of a user, a nil To resets the field to its default
+type UserChange struct {
+	Field UserField
+	From  any
+	To    any
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+)
+
+// PolicyType is an enum for the kind of a casbin rule
+type PolicyType string
+
+// PolicyType enum values
+const (
+	// PolicyPermission is a "p, <subject>, <domain>, <object>, <action>" rule allowing a subject to act on an object
+	PolicyPermission PolicyType = "p"
+	// PolicyGrouping is a "g, <subject>, <role>, <domain>" rule giving a subject a role, or making it a member of a group
+	PolicyGrouping PolicyType = "g"
+)
+
+// AuthzObjectPrefix is the path of the API that administers the policy, the admin role's
+// rules on it cannot be removed through the API itself
+const AuthzObjectPrefix = "/v1/authz/"
+
+// TenantsObjectPrefix and RolesObjectPrefix are the paths of what every tenant shares, only the
+// admins of the default tenant can be granted rules on them
+const (
+	TenantsObjectPrefix = "/v1/tenants"
+	RolesObjectPrefix   = "/v1/roles"
+)
+
+// OwnerSubject is the subject of the permission rules that apply to a user acting on a resource
+// they own. Routes resolve the owner of their resource, it cannot be granted as a role
+const OwnerSubject = "owner"
+
+// UserFieldObject returns the casbin object of a field of a user's record. The rules granted to
+// the owner on it decide which fields users may change on their own record
+func UserFieldObject(id uint64, field UserField) string {
+	return fmt.Sprintf("/v1/users/%d/fields/%s", id, field)
+}
+
+// PolicyRule is an entity that represents a casbin rule. A permission rule has an object and an action,
+// a grouping rule has a role instead. Subjects and roles are role names, "user:<id>" or "group:<id>".
+// Domain is the tenant the rule applies in, "tenant:<id>", or AnyDomain for a permission of every tenant
+type PolicyRule struct {
+	Type    PolicyType
+	Subject string
+	Domain  string
+	Object  string
+	Action  string
+	Role    string
+}
+
+// Values returns the values of a rule in the order casbin stores them
+func (r *PolicyRule) Values() []string {
+	if r.Type == PolicyGrouping {
+		return []string{r.Subject, r.Role, r.Domain}
+	}
+	return []string{r.Subject, r.Domain, r.Object, r.Action}
+}
+
+// SubjectRoles is an entity that represents the roles of a casbin subject in a domain. Roles are the ones
+// granted by its own grouping rules, ImplicitRoles add the ones inherited through its groups and roles
+type SubjectRoles struct {
+	Subject       string
+	Domain        string
+	Roles         []string
+	ImplicitRoles []string
+}
+
+// AuthzRequest is an entity that represents a request to the policy as the router makes it, a subject
+// acting on an object in a domain. Owner is the subject that owns the resource, empty when it has none
+type AuthzRequest struct {
+	Subject string
+	Domain  string
+	Object  string
+	Action  string
+	Owner   string
+}
+
+// AuthzDecision is an entity that represents the decision of the policy on a request. Rules are the
+// permission rules that allowed it, Roles the ones the subject has in the domain of the request
+type AuthzDecision struct {
+	Request AuthzRequest
+	Allowed bool
+	Rules   []PolicyRule
+	Roles   []string
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"regexp"
+	"time"
+	// timezones are validated against the embedded database, minimal images often ship none
+	_ "time/tzdata"
+)
+
+// PreferenceKey is an enum for the keys of the user preferences store
+type PreferenceKey string
+
+// PreferenceKey enum values
+const (
+	PreferenceLanguage               PreferenceKey = "language"
+	PreferenceTimezone               PreferenceKey = "timezone"
+	PreferenceTheme                  PreferenceKey = "theme"
+	PreferenceNotificationsEmail     PreferenceKey = "notifications.email"
+	PreferenceNotificationsSecurity  PreferenceKey = "notifications.security"
+	PreferenceNotificationsMarketing PreferenceKey = "notifications.marketing"
+)
+
+// PreferenceType is an enum for the value types a preference can hold
+type PreferenceType string
+
+// PreferenceType enum values
+const (
+	PreferenceString PreferenceType = "string"
+	PreferenceBool   PreferenceType = "bool"
+)
+
+// PreferenceSchema describes the type, default and accepted values of a preference
+type PreferenceSchema struct {
+	Type    PreferenceType
+	Default any
+	// Allowed lists the accepted values of an enumerated string preference
+	Allowed []string
+	// Valid checks the value of a free-form string preference
+	Valid func(value string) bool
+}
+
+// Preferences maps preference keys to their values, a nil value resets a preference to its default
+type Preferences map[PreferenceKey]any
+
+// languageTagPattern matches the common BCP 47 forms, such as "en" or "pt-BR"
+var languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// PreferenceSchemas defines every preference a user can set, along with its server-side default
+var PreferenceSchemas = map[PreferenceKey]PreferenceSchema{
+	PreferenceLanguage: {
+		Type:    PreferenceString,
+		Default: "en",
+		Valid:   languageTagPattern.MatchString,
+	},
+	PreferenceTimezone: {
+		Type:    PreferenceString,
+		Default: "UTC",
+		Valid: func(value string) bool {
+			// an empty name and "Local" are accepted by time.LoadLocation but name no timezone
+			if value == "" || value == "Local" {
+				return false
+			}
+			_, err := time.LoadLocation(value)
+			return err == nil
+		},
+	},
+	PreferenceTheme: {
+		Type:    PreferenceString,
+		Default: "system",
+		Allowed: []string{"system", "light", "dark"},
+	},
+	PreferenceNotificationsEmail: {
+		Type:    PreferenceBool,
+		Default: true,
+	},
+	PreferenceNotificationsSecurity: {
+		Type:    PreferenceBool,
+		Default: true,
+	},
+	PreferenceNotificationsMarketing: {
+		Type:    PreferenceBool,
+		Default: false,
+	},
+}
+
+// DefaultPreferences returns every preference set to its default
+func DefaultPreferences() Preferences {
+	preferences := make(Preferences, len(PreferenceSchemas))
+	for key, schema := range PreferenceSchemas {
+		preferences[key] = schema.Default
+	}
+	return preferences
+}
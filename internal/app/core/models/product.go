@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// Product is an entity that represents an item for sale,
+// its price is in minor units of the currency, such as cents.
+// Stock is only changed through stock movements, and an alert
+// is raised once it falls to the low-stock threshold. A product
+// without a tax class is not taxed
+type Product struct {
+	ID                uint64
+	CategoryID        uint64
+	SKU               string
+	Name              string
+	Image             string
+	Price             int64
+	Stock             int64
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	LowStockThreshold int64
+	TaxClassID        *uint64
+	TenantID          uint64
+}
+
+// ProductFilter narrows down a list of products
+type ProductFilter struct {
+	CategoryID uint64
+	// Search matches part of the name or SKU, case-insensitively
+	Search string
+	// StoreID lists the stock of the products in a store rather than across every store
+	StoreID uint64
+}
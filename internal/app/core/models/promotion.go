@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+)
+
+// PromotionType is how a promotion takes money off an order
+type PromotionType string
+
+// PromotionType enum values
+const (
+	// PromotionPercentage takes Value percent off each item it covers
+	PromotionPercentage PromotionType = "percentage"
+	// PromotionFixed takes Value minor units off the items it covers, once per order
+	PromotionFixed PromotionType = "fixed"
+	// PromotionBuyXGetY gives GetQuantity of every BuyQuantity + GetQuantity units
+	// it covers for free, the cheapest units first
+	PromotionBuyXGetY PromotionType = "buy_x_get_y"
+)
+
+// Promotion is an entity that represents a discount rule applied at checkout.
+// It covers a single product, every product of a category or, without either,
+// the whole order. A promotion with a coupon code only applies when the code is
+// given, the others apply to every order placed within their validity window.
+// A zero UsageLimit is unlimited
+type Promotion struct {
+	ID          uint64
+	Name        string
+	Type        PromotionType
+	Value       int64
+	ProductID   *uint64
+	CategoryID  *uint64
+	BuyQuantity int64
+	GetQuantity int64
+	CouponCode  string
+	UsageLimit  int64
+	UsageCount  int64
+	StartsAt    *time.Time
+	EndsAt      *time.Time
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	TenantID    uint64
+}
+
+// IsAvailable checks whether the promotion can be applied to an order placed at the given time
+func (p *Promotion) IsAvailable(at time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.StartsAt != nil && at.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !at.Before(*p.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// IsExhausted checks whether the promotion has been used as many times as it may be
+func (p *Promotion) IsExhausted() bool {
+	return p.UsageLimit > 0 && p.UsageCount >= p.UsageLimit
+}
+
+// Covers checks whether the promotion applies to the given product
+func (p *Promotion) Covers(product *Product) bool {
+	switch {
+	case p.ProductID != nil:
+		return *p.ProductID == product.ID
+	case p.CategoryID != nil:
+		return *p.CategoryID == product.CategoryID
+	default:
+		return true
+	}
+}
+
+// IsOrderWide checks whether the promotion covers the whole order
+func (p *Promotion) IsOrderWide() bool {
+	return p.ProductID == nil && p.CategoryID == nil
+}
@@ -0,0 +1,46 @@
+package models
+
+// ReceiptFormat is the document format a receipt is rendered in
+type ReceiptFormat string
+
+// ReceiptFormat enum values
+const (
+	// ReceiptPDF is a printable document, one page as long as the receipt
+	ReceiptPDF ReceiptFormat = "pdf"
+	// ReceiptESCPOS is a byte stream sent as is to a thermal printer
+	ReceiptESCPOS ReceiptFormat = "escpos"
+	// ReceiptHTML is a page shown in a browser or sent by mail
+	ReceiptHTML ReceiptFormat = "html"
+)
+
+// ContentType returns the media type of a receipt rendered in the format
+func (f ReceiptFormat) ContentType() string {
+	switch f {
+	case ReceiptPDF:
+		return "application/pdf"
+	case ReceiptHTML:
+		return "text/html; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// ReceiptPaperWidth is the width in millimeters of the paper roll of a thermal printer
+type ReceiptPaperWidth int
+
+// ReceiptPaperWidth enum values
+const (
+	ReceiptPaper58 ReceiptPaperWidth = 58
+	ReceiptPaper80 ReceiptPaperWidth = 80
+)
+
+// Receipt is what is handed to the customer for an order, rendered in Format.
+// PaperWidth is the roll the receipt is laid out for, the configured one when it is zero.
+// Cashier is the name of the user who placed the order, empty once the user is deleted
+type Receipt struct {
+	Order      *Order
+	Store      *Store
+	Cashier    string
+	Format     ReceiptFormat
+	PaperWidth ReceiptPaperWidth
+}
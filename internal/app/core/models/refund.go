@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// RefundType is how a sale is undone
+type RefundType string
+
+// RefundType enum values
+const (
+	// RefundTypeRefund pays back some or all of the items of a paid order
+	RefundTypeRefund RefundType = "refund"
+	// RefundTypeVoid cancels an open order as a whole, without any payment
+	RefundTypeVoid RefundType = "void"
+)
+
+// Refund is an entity that undoes some or all of an order, which is never changed itself.
+// Amount is the refund payment made with PaymentMethod, in minor units of the currency,
+// both are empty for voids. The refunded items are put back into the stock of the store
+// the order was placed at, which is the store of the refund. A refund of an order paid with points
+// gives them back as PointsRefunded, and takes back the share of the points the order earned as
+// PointsReversed
+type Refund struct {
+	ID             uint64
+	OrderID        uint64
+	UserID         uint64
+	Type           RefundType
+	PaymentMethod  PaymentMethod
+	Amount         int64
+	Reason         string
+	Items          []RefundItem
+	CreatedAt      time.Time
+	StoreID        uint64
+	PointsRefunded int64
+	PointsReversed int64
+}
+
+// RefundItem is a quantity of an order item given back. ProductID is copied from
+// the order item, and is zero when the product was deleted before the refund,
+// in which case the item is not put back into stock
+type RefundItem struct {
+	ID          uint64
+	RefundID    uint64
+	OrderItemID uint64
+	ProductID   uint64
+	Quantity    int64
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"time"
+)
+
+// ReportPeriod is the length of the buckets a revenue report is grouped by
+type ReportPeriod string
+
+// ReportPeriod enum values
+const (
+	ReportDay   ReportPeriod = "day"
+	ReportWeek  ReportPeriod = "week"
+	ReportMonth ReportPeriod = "month"
+)
+
+const (
+	// ReportCacheTTL is how long a report is cached, so takings are at most this late
+	ReportCacheTTL = 5 * time.Minute
+	// ReportMaxRange is the longest range of dates a report covers
+	ReportMaxRange = 366 * 24 * time.Hour
+)
+
+// ReportFilter is the range of a report, From is inclusive and To is exclusive.
+// Both are in the timezone the report is in, which decides where days begin.
+// A report covers the sales of a store, or of every store when StoreID is zero
+type ReportFilter struct {
+	From    time.Time
+	To      time.Time
+	StoreID uint64
+}
+
+// Location returns the timezone of the report
+func (rf *ReportFilter) Location() *time.Location {
+	return rf.From.Location()
+}
+
+// RevenueReport is the takings of one period, which starts at Start
+type RevenueReport struct {
+	Start   time.Time
+	Orders  int64
+	Revenue int64
+}
+
+// ProductSalesReport is how much of a product was sold. Products are identified by
+// the SKU and name they were sold under, and ProductID is zero once the product is deleted
+type ProductSalesReport struct {
+	ProductID uint64
+	SKU       string
+	Name      string
+	Quantity  int64
+	Revenue   int64
+}
+
+// CashierSalesReport is the takings rung up by a cashier
+type CashierSalesReport struct {
+	UserID  uint64
+	Name    string
+	Orders  int64
+	Revenue int64
+}
+
+// PaymentMethodReport is the takings paid with a payment method
+type PaymentMethodReport struct {
+	PaymentMethod PaymentMethod
+	Orders        int64
+	Revenue       int64
+}
+
+// DiscountReport is how much a promotion took off the orders it was applied to,
+// promotions are identified by the name and coupon code they were applied under
+type DiscountReport struct {
+	PromotionID uint64
+	Name        string
+	CouponCode  string
+	Orders      int64
+	Amount      int64
+}
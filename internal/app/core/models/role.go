@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Role is an entity that represents a role users can be assigned to
+type Role struct {
+	ID          uint64
+	Name        UserRole
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// UserSubject returns the casbin subject that identifies a user in grouping rules
+func UserSubject(id uint64) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+// ParseUserSubject returns the id of the user a casbin subject identifies, if it identifies one
+func ParseUserSubject(sub string) (uint64, bool) {
+	idStr, ok := strings.CutPrefix(sub, "user:")
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+)
+
+// StockMovementType is the kind of change a stock movement makes
+type StockMovementType string
+
+// StockMovementType enum values
+const (
+	StockSale       StockMovementType = "sale"
+	StockReturn     StockMovementType = "return"
+	StockRestock    StockMovementType = "restock"
+	StockAdjustment StockMovementType = "adjustment"
+	StockTransfer   StockMovementType = "transfer"
+)
+
+// StockMovement is an entry of the append-only stock ledger of a product. Quantity is signed,
+// StockAfter is the stock once the movement is applied, which products.stock materializes.
+// OrderID is only set for sales and the returns of their refunds, and UserID is nil for the
+// opening balance of the ledger. Every store keeps its own stock of a product, StockAfter is
+// the stock in the store of the movement. ToStoreID is only given to record a transfer, which
+// moves the stock out of StoreID into it
+type StockMovement struct {
+	ID         uint64
+	ProductID  uint64
+	Type       StockMovementType
+	Quantity   int64
+	StockAfter int64
+	Reason     string
+	OrderID    *uint64
+	UserID     *uint64
+	CreatedAt  time.Time
+	StoreID    uint64
+	TenantID   uint64
+	ToStoreID  uint64
+}
+
+// StockBefore returns the stock of the product before the movement was applied
+func (sm *StockMovement) StockBefore() int64 {
+	return sm.StockAfter - sm.Quantity
+}
+
+// StockMovementFilter narrows down a list of stock movements
+type StockMovementFilter struct {
+	ProductID uint64
+	Type      StockMovementType
+	StoreID   uint64
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// AllStoresObject and AllStoresAction make up the casbin request a user must be allowed
+// to act on a store other than the one they are assigned to, or on every store at once
+const (
+	AllStoresObject = "stores"
+	AllStoresAction = "all"
+)
+
+// Store is an entity that represents a shop of the business. Products are shared by
+// every store, while their stock, the orders and the refunds belong to one store.
+// The taxes of its orders are those of the store or of its region, rounded as TaxRounding says
+type Store struct {
+	ID          uint64
+	Name        string
+	Address     string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Region      string
+	TaxRounding TaxRounding
+	TenantID    uint64
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+)
+
+// TaxRateScale is what a tax rate of a hundred percent is, rates are in millionths
+const TaxRateScale = 1_000_000
+
+// TaxRounding is when the taxes of an order are rounded to minor units
+type TaxRounding string
+
+// TaxRounding enum values
+const (
+	// TaxRoundingLine rounds the tax of each line of an order, which are then added up
+	TaxRoundingLine TaxRounding = "line"
+	// TaxRoundingInvoice adds up the exact taxes of the lines and rounds each total once
+	TaxRoundingInvoice TaxRounding = "invoice"
+)
+
+// TaxClass is an entity that groups the products taxed the same way, such as food or alcohol
+type TaxClass struct {
+	ID        uint64
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	TenantID  uint64
+}
+
+// TaxRate is an entity that represents a tax levied on the products of a class. Rate is in
+// millionths, so 11% is 110000. An inclusive tax is part of the price, an exclusive one is added
+// on top of it. A rate applies at a store when StoreID is set, at the stores of a region when
+// Region is set, or else everywhere. At a store, only the most specific rates of a class apply
+type TaxRate struct {
+	ID         uint64
+	TaxClassID uint64
+	Name       string
+	Rate       int64
+	Inclusive  bool
+	StoreID    *uint64
+	Region     string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// specificity ranks how closely a rate is tied to a store
+func (tr *TaxRate) specificity() int {
+	switch {
+	case tr.StoreID != nil:
+		return 2
+	case tr.Region != "":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// MoreSpecific checks whether a rate is tied more closely to a store than another
+func (tr *TaxRate) MoreSpecific(other *TaxRate) bool {
+	return tr.specificity() > other.specificity()
+}
+
+// OrderTax is the total of a tax rate over the lines of an order it applies to. The name
+// and rate are copied from the tax rate, so the order still reads the same after the rate
+// changes or is deleted. TaxableAmount is what the tax is levied on, without any tax
+type OrderTax struct {
+	ID            uint64
+	OrderID       uint64
+	TaxRateID     uint64
+	Name          string
+	Rate          int64
+	Inclusive     bool
+	TaxableAmount int64
+	Amount        int64
+}
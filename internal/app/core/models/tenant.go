@@ -0,0 +1,27 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultTenantID is the tenant that owned every record before there were tenants,
+// and the one requests act on when they do not name a tenant
+const DefaultTenantID uint64 = 1
+
+// AnyDomain is the casbin domain of the permission rules that apply in every tenant
+const AnyDomain = "*"
+
+// Tenant is an entity that represents an organization hosted on the deployment.
+// Every record belongs to one tenant and can only be reached by requests acting on it
+type Tenant struct {
+	ID        uint64
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TenantDomain returns the casbin domain that holds the grouping and permission rules of a tenant
+func TenantDomain(id uint64) string {
+	return fmt.Sprintf("tenant:%d", id)
+}
@@ -4,9 +4,13 @@ import (
 	"github.com/google/uuid"
 )
 
-// TokenPayload is an entity that represents the payload of the token
+// TokenPayload is an entity that represents the payload of the token,
+// StoreID is the store the user was assigned to when logging in, zero for none.
+// TenantID is the tenant of the user, every request made with the token acts on it
 type TokenPayload struct {
-	ID     uuid.UUID
-	UserID uint64
-	Role   string
+	ID       uuid.UUID
+	UserID   uint64
+	Role     UserRole
+	StoreID  uint64
+	TenantID uint64
 }
@@ -1,19 +1,41 @@
 package models
 
 import (
+	"fmt"
 	"time"
 )
 
 // UserRole is an enum for user's role
 type UserRole string
 
-// User is an entity that represents a user
+// UserRole built-in values, seeded into the roles table
+const (
+	Admin   UserRole = "admin"
+	Manager UserRole = "manager"
+	Cashier UserRole = "cashier"
+)
+
+// User is an entity that represents a user, StoreID is the store
+// the user works at and is nil while they are not assigned to one
 type User struct {
 	ID        uint64
 	Name      string
 	Email     string
 	Password  string
-	Role      string
+	Role      UserRole
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	Version   uint64
+	Avatar    string
+	StoreID   *uint64
+	TenantID  uint64
+}
+
+// AvatarURL returns the url of the user's avatar thumbnail of the given size,
+// or an empty string when the user has not uploaded an avatar
+func (u *User) AvatarURL(size int) string {
+	if u.Avatar == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/%d.png", u.Avatar, size)
 }
@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=audit.go -destination=mock/audit.go -package=mock
+
+// AuditRepository is an interface for interacting with audit-related data
+type AuditRepository interface {
+	// CreateAuditRecord appends a new audit record to the database
+	CreateAuditRecord(ctx context.Context, record *models.AuditRecord) (*models.AuditRecord, error)
+	// ListAuditRecords selects a filtered list of audit records with pagination, newest first
+	ListAuditRecords(ctx context.Context, filter *models.AuditFilter, skip, limit uint64) ([]models.AuditRecord, error)
+}
+
+// AuditService is an interface for interacting with audit-related business logic
+type AuditService interface {
+	// RecordUserChange writes an audit record of a user going from one state to another,
+	// before is nil for a new user and after is nil for a deleted one
+	RecordUserChange(ctx context.Context, action models.AuditAction, before, after *models.User) error
+	// RecordPolicyChange writes an audit record of a policy rule being added or removed
+	RecordPolicyChange(ctx context.Context, action models.AuditAction, rule *models.PolicyRule) error
+	// ListUserHistory returns a filtered list of a user's audit records with pagination
+	ListUserHistory(ctx context.Context, filter *models.AuditFilter, skip, limit uint64) ([]models.AuditRecord, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=authz.go -destination=mock/authz.go -package=mock
+
+// AuthzService is an interface for administering the authorization policy of the tenant of the request
+type AuthzService interface {
+	// ListRules returns the rules of the filter's type with pagination, empty filter values match everything
+	ListRules(ctx context.Context, filter *models.PolicyRule, skip, limit uint64) ([]models.PolicyRule, error)
+	// AddRule adds a permission or grouping rule
+	AddRule(ctx context.Context, rule *models.PolicyRule) (*models.PolicyRule, error)
+	// RemoveRule removes a permission or grouping rule
+	RemoveRule(ctx context.Context, rule *models.PolicyRule) error
+	// GetSubjectRoles returns the direct and inherited roles of a subject
+	GetSubjectRoles(ctx context.Context, subject string) (*models.SubjectRoles, error)
+	// CheckRequest decides on a request as the router would, with the rules that allowed it
+	CheckRequest(ctx context.Context, request *models.AuthzRequest) (*models.AuthzDecision, error)
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=avatar.go -destination=mock/avatar.go -package=mock
+
+// AvatarService is an interface for interacting with avatar-related business logic
+type AvatarService interface {
+	// UploadAvatar validates an image, stores its thumbnails and sets it as the user's avatar
+	UploadAvatar(ctx context.Context, id uint64, data []byte) (*models.User, error)
+	// DeleteAvatar removes the user's avatar and its thumbnails
+	DeleteAvatar(ctx context.Context, id uint64) (*models.User, error)
+}
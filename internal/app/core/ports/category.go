@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=category.go -destination=mock/category.go -package=mock
+
+// CategoryRepository is an interface for interacting with category-related data
+type CategoryRepository interface {
+	// CreateCategory inserts a new category into the database
+	CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error)
+	// GetCategoryByID selects a category by id
+	GetCategoryByID(ctx context.Context, id uint64) (*models.Category, error)
+	// ListCategories selects a list of categories with pagination
+	ListCategories(ctx context.Context, skip, limit uint64) ([]models.Category, error)
+	// UpdateCategory updates a category
+	UpdateCategory(ctx context.Context, category *models.Category) (*models.Category, error)
+	// DeleteCategory deletes a category
+	DeleteCategory(ctx context.Context, id uint64) error
+}
+
+// CategoryService is an interface for interacting with category-related business logic
+type CategoryService interface {
+	// CreateCategory creates a new category
+	CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error)
+	// GetCategory returns a category by id
+	GetCategory(ctx context.Context, id uint64) (*models.Category, error)
+	// ListCategories returns a list of categories with pagination
+	ListCategories(ctx context.Context, skip, limit uint64) ([]models.Category, error)
+	// UpdateCategory updates a category
+	UpdateCategory(ctx context.Context, category *models.Category) (*models.Category, error)
+	// DeleteCategory deletes a category that no longer has products
+	DeleteCategory(ctx context.Context, id uint64) error
+}
@@ -0,0 +1,55 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=customer.go -destination=mock/customer.go -package=mock
+
+// CustomerRepository is an interface for interacting with customer-related data
+type CustomerRepository interface {
+	// CreateCustomer inserts a new customer into the database
+	CreateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error)
+	// GetCustomerByID selects a customer by id
+	GetCustomerByID(ctx context.Context, id uint64) (*models.Customer, error)
+	// ListCustomers selects a list of customers with pagination, filtered by name, email or phone
+	ListCustomers(ctx context.Context, search string, skip, limit uint64) ([]models.Customer, error)
+	// UpdateCustomer updates the contact details of a customer
+	UpdateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error)
+	// DeleteCustomer deletes a customer
+	DeleteCustomer(ctx context.Context, id uint64) error
+	// CreateLoyaltyEntry locks the customer, appends the entry to their ledger and updates their balance,
+	// all in a single transaction. It fails with ErrInsufficientPoints if the entry would overdraw the balance
+	CreateLoyaltyEntry(ctx context.Context, entry *models.LoyaltyEntry) (*models.LoyaltyEntry, error)
+	// ListLoyaltyEntries selects the ledger of a customer with pagination, newest first
+	ListLoyaltyEntries(ctx context.Context, customerID, skip, limit uint64) ([]models.LoyaltyEntry, error)
+}
+
+// CustomerService is an interface for interacting with customer-related business logic
+type CustomerService interface {
+	// CreateCustomer creates a new customer
+	CreateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error)
+	// GetCustomer returns a customer by id
+	GetCustomer(ctx context.Context, id uint64) (*models.Customer, error)
+	// ListCustomers returns a list of customers with pagination, filtered by name, email or phone
+	ListCustomers(ctx context.Context, search string, skip, limit uint64) ([]models.Customer, error)
+	// UpdateCustomer updates the contact details of a customer
+	UpdateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error)
+	// DeleteCustomer deletes a customer without orders or loyalty points history
+	DeleteCustomer(ctx context.Context, id uint64) error
+	// ListOrders returns the purchase history of a customer with pagination, newest first
+	ListOrders(ctx context.Context, customerID, skip, limit uint64) ([]models.Order, error)
+	// AdjustPoints corrects the points balance of a customer by hand
+	AdjustPoints(ctx context.Context, entry *models.LoyaltyEntry) (*models.LoyaltyEntry, error)
+	// ListLoyaltyEntries returns the loyalty ledger of a customer with pagination, newest first
+	ListLoyaltyEntries(ctx context.Context, customerID, skip, limit uint64) ([]models.LoyaltyEntry, error)
+}
+
+// LoyaltyProgram is an interface for the rules of earning and redeeming loyalty points
+type LoyaltyProgram interface {
+	// PointsEarned returns the points an order of the amount earns
+	PointsEarned(amount int64) int64
+	// RedeemCost returns the points it takes to pay the amount
+	RedeemCost(amount int64) int64
+}
@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=emailChange.go -destination=mock/emailChange.go -package=mock
+
+// EmailChangeRepository is an interface for interacting with email change-related data
+type EmailChangeRepository interface {
+	// CreateEmailChange inserts a new email change into the database
+	CreateEmailChange(ctx context.Context, change *models.EmailChange) (*models.EmailChange, error)
+	// GetEmailChangeByTokenID selects an email change by the id signed into its confirmation link
+	GetEmailChangeByTokenID(ctx context.Context, tokenID string) (*models.EmailChange, error)
+	// GetEmailChangeByRevertTokenID selects an email change by the id signed into its revert link
+	GetEmailChangeByRevertTokenID(ctx context.Context, tokenID string) (*models.EmailChange, error)
+	// DeletePendingEmailChanges deletes the email changes of a user that are not confirmed or reverted
+	DeletePendingEmailChanges(ctx context.Context, userID uint64) error
+	// ConfirmEmailChange marks a pending email change as confirmed
+	ConfirmEmailChange(ctx context.Context, id uint64) error
+	// RevertEmailChange marks an email change as reverted
+	RevertEmailChange(ctx context.Context, id uint64) error
+}
+
+// EmailChangeService is an interface for interacting with email change-related business logic
+type EmailChangeService interface {
+	// RequestEmailChange stores a pending email change and sends the confirmation and revert links
+	RequestEmailChange(ctx context.Context, user *models.User, email string) (*models.EmailChange, error)
+	// ConfirmEmailChange moves the user to the new email
+	ConfirmEmailChange(ctx context.Context, token string) (*models.User, error)
+	// RevertEmailChange cancels a pending email change or moves the user back to the old email
+	RevertEmailChange(ctx context.Context, token string) (*models.User, error)
+}
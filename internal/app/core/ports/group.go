@@ -0,0 +1,62 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=group.go -destination=mock/group.go -package=mock
+
+// GroupRepository is an interface for interacting with group-related data
+type GroupRepository interface {
+	// CreateGroup inserts a new group into the database
+	CreateGroup(ctx context.Context, group *models.Group) (*models.Group, error)
+	// GetGroupByID selects a group by id
+	GetGroupByID(ctx context.Context, id uint64) (*models.Group, error)
+	// ListGroups selects a list of groups with pagination
+	ListGroups(ctx context.Context, skip, limit uint64) ([]models.Group, error)
+	// ListAncestorIDs selects the ids of a group's parent, grandparent and so on
+	ListAncestorIDs(ctx context.Context, id uint64) ([]uint64, error)
+	// UpdateGroup updates a group
+	UpdateGroup(ctx context.Context, group *models.Group) (*models.Group, error)
+	// DeleteGroup deletes a group
+	DeleteGroup(ctx context.Context, id uint64) error
+	// AddMember inserts a user into a group
+	AddMember(ctx context.Context, member *models.GroupMember) (*models.GroupMember, error)
+	// ListMembers selects the users of a group with pagination
+	ListMembers(ctx context.Context, groupID, skip, limit uint64) ([]models.User, error)
+	// DeleteMember removes a user from a group
+	DeleteMember(ctx context.Context, groupID, userID uint64) error
+	// AddRole assigns a role to a group
+	AddRole(ctx context.Context, groupID uint64, role models.UserRole) error
+	// ListRoles selects the roles assigned to a group
+	ListRoles(ctx context.Context, groupID uint64) ([]models.UserRole, error)
+	// DeleteRole removes a role from a group
+	DeleteRole(ctx context.Context, groupID uint64, role models.UserRole) error
+}
+
+// GroupService is an interface for interacting with group-related business logic
+type GroupService interface {
+	// CreateGroup creates a new group
+	CreateGroup(ctx context.Context, group *models.Group) (*models.Group, error)
+	// GetGroup returns a group by id
+	GetGroup(ctx context.Context, id uint64) (*models.Group, error)
+	// ListGroups returns a list of groups with pagination
+	ListGroups(ctx context.Context, skip, limit uint64) ([]models.Group, error)
+	// UpdateGroup updates a group
+	UpdateGroup(ctx context.Context, group *models.Group) (*models.Group, error)
+	// DeleteGroup deletes a group
+	DeleteGroup(ctx context.Context, id uint64) error
+	// AddMember adds a user to a group
+	AddMember(ctx context.Context, groupID, userID uint64) (*models.GroupMember, error)
+	// ListMembers returns the users of a group with pagination
+	ListMembers(ctx context.Context, groupID, skip, limit uint64) ([]models.User, error)
+	// RemoveMember removes a user from a group
+	RemoveMember(ctx context.Context, groupID, userID uint64) error
+	// AssignRole assigns a role to every member of a group
+	AssignRole(ctx context.Context, groupID uint64, role models.UserRole) error
+	// ListRoles returns the roles assigned to a group
+	ListRoles(ctx context.Context, groupID uint64) ([]models.UserRole, error)
+	// UnassignRole removes a role from a group
+	UnassignRole(ctx context.Context, groupID uint64, role models.UserRole) error
+}
@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=invitation.go -destination=mock/invitation.go -package=mock
+
+// InvitationRepository is an interface for interacting with invitation-related data
+type InvitationRepository interface {
+	// CreateInvitation inserts a new invitation into the database
+	CreateInvitation(ctx context.Context, invitation *models.Invitation) (*models.Invitation, error)
+	// GetInvitationByID selects an invitation by id
+	GetInvitationByID(ctx context.Context, id uint64) (*models.Invitation, error)
+	// GetInvitationByTokenID selects an invitation by the id signed into its link
+	GetInvitationByTokenID(ctx context.Context, tokenID string) (*models.Invitation, error)
+	// GetPendingInvitationByEmail selects the latest invitation of an email that is not accepted or revoked
+	GetPendingInvitationByEmail(ctx context.Context, email string) (*models.Invitation, error)
+	// ListInvitations selects a list of invitations with pagination
+	ListInvitations(ctx context.Context, skip, limit uint64) ([]models.Invitation, error)
+	// AcceptInvitation marks a pending invitation as accepted
+	AcceptInvitation(ctx context.Context, id uint64) error
+	// RevokeInvitation marks a pending invitation as revoked
+	RevokeInvitation(ctx context.Context, id uint64) error
+}
+
+// InvitationService is an interface for interacting with invitation-related business logic
+type InvitationService interface {
+	// Invite creates an invitation and sends its link to the invitee
+	Invite(ctx context.Context, invitation *models.Invitation) (*models.Invitation, error)
+	// ListInvitations returns a list of invitations with pagination
+	ListInvitations(ctx context.Context, skip, limit uint64) ([]models.Invitation, error)
+	// RevokeInvitation revokes a pending invitation
+	RevokeInvitation(ctx context.Context, id uint64) error
+	// AcceptInvitation creates the invitee's account with the invited role
+	AcceptInvitation(ctx context.Context, token string, user *models.User) (*models.User, error)
+}
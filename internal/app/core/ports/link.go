@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"time"
+)
+
+//go:generate mockgen -source=link.go -destination=mock/link.go -package=mock
+
+// LinkService is an interface for creating and verifying signed, expiring links sent to users
+type LinkService interface {
+	// CreateLink signs a subject for the given purpose and the tenant of the request, and returns the link and when it expires
+	CreateLink(ctx context.Context, purpose models.LinkPurpose, subject string) (string, time.Time, error)
+	// VerifyLink verifies a link token for the given purpose and returns its subject and tenant
+	VerifyLink(purpose models.LinkPurpose, token string) (string, uint64, error)
+}
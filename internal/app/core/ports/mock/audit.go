@@ -0,0 +1,137 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=audit.go -destination=mock/audit.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditRecord mocks base method.
+func (m *MockAuditRepository) CreateAuditRecord(ctx context.Context, record *models.AuditRecord) (*models.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditRecord", ctx, record)
+	ret0, _ := ret[0].(*models.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditRecord indicates an expected call of CreateAuditRecord.
+func (mr *MockAuditRepositoryMockRecorder) CreateAuditRecord(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditRecord", reflect.TypeOf((*MockAuditRepository)(nil).CreateAuditRecord), ctx, record)
+}
+
+// ListAuditRecords mocks base method.
+func (m *MockAuditRepository) ListAuditRecords(ctx context.Context, filter *models.AuditFilter, skip, limit uint64) ([]models.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditRecords", ctx, filter, skip, limit)
+	ret0, _ := ret[0].([]models.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditRecords indicates an expected call of ListAuditRecords.
+func (mr *MockAuditRepositoryMockRecorder) ListAuditRecords(ctx, filter, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditRecords", reflect.TypeOf((*MockAuditRepository)(nil).ListAuditRecords), ctx, filter, skip, limit)
+}
+
+// MockAuditService is a mock of AuditService interface.
+type MockAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditServiceMockRecorder
+}
+
+// MockAuditServiceMockRecorder is the mock recorder for MockAuditService.
+type MockAuditServiceMockRecorder struct {
+	mock *MockAuditService
+}
+
+// NewMockAuditService creates a new mock instance.
+func NewMockAuditService(ctrl *gomock.Controller) *MockAuditService {
+	mock := &MockAuditService{ctrl: ctrl}
+	mock.recorder = &MockAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditService) EXPECT() *MockAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// ListUserHistory mocks base method.
+func (m *MockAuditService) ListUserHistory(ctx context.Context, filter *models.AuditFilter, skip, limit uint64) ([]models.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserHistory", ctx, filter, skip, limit)
+	ret0, _ := ret[0].([]models.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserHistory indicates an expected call of ListUserHistory.
+func (mr *MockAuditServiceMockRecorder) ListUserHistory(ctx, filter, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserHistory", reflect.TypeOf((*MockAuditService)(nil).ListUserHistory), ctx, filter, skip, limit)
+}
+
+// RecordPolicyChange mocks base method.
+func (m *MockAuditService) RecordPolicyChange(ctx context.Context, action models.AuditAction, rule *models.PolicyRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPolicyChange", ctx, action, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordPolicyChange indicates an expected call of RecordPolicyChange.
+func (mr *MockAuditServiceMockRecorder) RecordPolicyChange(ctx, action, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPolicyChange", reflect.TypeOf((*MockAuditService)(nil).RecordPolicyChange), ctx, action, rule)
+}
+
+// RecordUserChange mocks base method.
+func (m *MockAuditService) RecordUserChange(ctx context.Context, action models.AuditAction, before, after *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordUserChange", ctx, action, before, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordUserChange indicates an expected call of RecordUserChange.
+func (mr *MockAuditServiceMockRecorder) RecordUserChange(ctx, action, before, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUserChange", reflect.TypeOf((*MockAuditService)(nil).RecordUserChange), ctx, action, before, after)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: authz.go
+//
+// Generated by this command:
+//
+//	mockgen -source=authz.go -destination=mock/authz.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthzService is a mock of AuthzService interface.
+type MockAuthzService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthzServiceMockRecorder
+}
+
+// MockAuthzServiceMockRecorder is the mock recorder for MockAuthzService.
+type MockAuthzServiceMockRecorder struct {
+	mock *MockAuthzService
+}
+
+// NewMockAuthzService creates a new mock instance.
+func NewMockAuthzService(ctrl *gomock.Controller) *MockAuthzService {
+	mock := &MockAuthzService{ctrl: ctrl}
+	mock.recorder = &MockAuthzServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthzService) EXPECT() *MockAuthzServiceMockRecorder {
+	return m.recorder
+}
+
+// AddRule mocks base method.
+func (m *MockAuthzService) AddRule(ctx context.Context, rule *models.PolicyRule) (*models.PolicyRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRule", ctx, rule)
+	ret0, _ := ret[0].(*models.PolicyRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRule indicates an expected call of AddRule.
+func (mr *MockAuthzServiceMockRecorder) AddRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRule", reflect.TypeOf((*MockAuthzService)(nil).AddRule), ctx, rule)
+}
+
+// CheckRequest mocks base method.
+func (m *MockAuthzService) CheckRequest(ctx context.Context, request *models.AuthzRequest) (*models.AuthzDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRequest", ctx, request)
+	ret0, _ := ret[0].(*models.AuthzDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckRequest indicates an expected call of CheckRequest.
+func (mr *MockAuthzServiceMockRecorder) CheckRequest(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRequest", reflect.TypeOf((*MockAuthzService)(nil).CheckRequest), ctx, request)
+}
+
+// GetSubjectRoles mocks base method.
+func (m *MockAuthzService) GetSubjectRoles(ctx context.Context, subject string) (*models.SubjectRoles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubjectRoles", ctx, subject)
+	ret0, _ := ret[0].(*models.SubjectRoles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubjectRoles indicates an expected call of GetSubjectRoles.
+func (mr *MockAuthzServiceMockRecorder) GetSubjectRoles(ctx, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubjectRoles", reflect.TypeOf((*MockAuthzService)(nil).GetSubjectRoles), ctx, subject)
+}
+
+// ListRules mocks base method.
+func (m *MockAuthzService) ListRules(ctx context.Context, filter *models.PolicyRule, skip, limit uint64) ([]models.PolicyRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", ctx, filter, skip, limit)
+	ret0, _ := ret[0].([]models.PolicyRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockAuthzServiceMockRecorder) ListRules(ctx, filter, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockAuthzService)(nil).ListRules), ctx, filter, skip, limit)
+}
+
+// RemoveRule mocks base method.
+func (m *MockAuthzService) RemoveRule(ctx context.Context, rule *models.PolicyRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRule", ctx, rule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveRule indicates an expected call of RemoveRule.
+func (mr *MockAuthzServiceMockRecorder) RemoveRule(ctx, rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRule", reflect.TypeOf((*MockAuthzService)(nil).RemoveRule), ctx, rule)
+}
@@ -0,0 +1,338 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: customer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=customer.go -destination=mock/customer.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomerRepository is a mock of CustomerRepository interface.
+type MockCustomerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerRepositoryMockRecorder
+}
+
+// MockCustomerRepositoryMockRecorder is the mock recorder for MockCustomerRepository.
+type MockCustomerRepositoryMockRecorder struct {
+	mock *MockCustomerRepository
+}
+
+// NewMockCustomerRepository creates a new mock instance.
+func NewMockCustomerRepository(ctrl *gomock.Controller) *MockCustomerRepository {
+	mock := &MockCustomerRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerRepository) EXPECT() *MockCustomerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateCustomer mocks base method.
+func (m *MockCustomerRepository) CreateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomer", ctx, customer)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomer indicates an expected call of CreateCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) CreateCustomer(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).CreateCustomer), ctx, customer)
+}
+
+// CreateLoyaltyEntry mocks base method.
+func (m *MockCustomerRepository) CreateLoyaltyEntry(ctx context.Context, entry *models.LoyaltyEntry) (*models.LoyaltyEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoyaltyEntry", ctx, entry)
+	ret0, _ := ret[0].(*models.LoyaltyEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoyaltyEntry indicates an expected call of CreateLoyaltyEntry.
+func (mr *MockCustomerRepositoryMockRecorder) CreateLoyaltyEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoyaltyEntry", reflect.TypeOf((*MockCustomerRepository)(nil).CreateLoyaltyEntry), ctx, entry)
+}
+
+// DeleteCustomer mocks base method.
+func (m *MockCustomerRepository) DeleteCustomer(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomer indicates an expected call of DeleteCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) DeleteCustomer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).DeleteCustomer), ctx, id)
+}
+
+// GetCustomerByID mocks base method.
+func (m *MockCustomerRepository) GetCustomerByID(ctx context.Context, id uint64) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomerByID", ctx, id)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomerByID indicates an expected call of GetCustomerByID.
+func (mr *MockCustomerRepositoryMockRecorder) GetCustomerByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomerByID", reflect.TypeOf((*MockCustomerRepository)(nil).GetCustomerByID), ctx, id)
+}
+
+// ListCustomers mocks base method.
+func (m *MockCustomerRepository) ListCustomers(ctx context.Context, search string, skip, limit uint64) ([]models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomers", ctx, search, skip, limit)
+	ret0, _ := ret[0].([]models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomers indicates an expected call of ListCustomers.
+func (mr *MockCustomerRepositoryMockRecorder) ListCustomers(ctx, search, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomers", reflect.TypeOf((*MockCustomerRepository)(nil).ListCustomers), ctx, search, skip, limit)
+}
+
+// ListLoyaltyEntries mocks base method.
+func (m *MockCustomerRepository) ListLoyaltyEntries(ctx context.Context, customerID, skip, limit uint64) ([]models.LoyaltyEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoyaltyEntries", ctx, customerID, skip, limit)
+	ret0, _ := ret[0].([]models.LoyaltyEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoyaltyEntries indicates an expected call of ListLoyaltyEntries.
+func (mr *MockCustomerRepositoryMockRecorder) ListLoyaltyEntries(ctx, customerID, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoyaltyEntries", reflect.TypeOf((*MockCustomerRepository)(nil).ListLoyaltyEntries), ctx, customerID, skip, limit)
+}
+
+// UpdateCustomer mocks base method.
+func (m *MockCustomerRepository) UpdateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomer", ctx, customer)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomer indicates an expected call of UpdateCustomer.
+func (mr *MockCustomerRepositoryMockRecorder) UpdateCustomer(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomer", reflect.TypeOf((*MockCustomerRepository)(nil).UpdateCustomer), ctx, customer)
+}
+
+// MockCustomerService is a mock of CustomerService interface.
+type MockCustomerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomerServiceMockRecorder
+}
+
+// MockCustomerServiceMockRecorder is the mock recorder for MockCustomerService.
+type MockCustomerServiceMockRecorder struct {
+	mock *MockCustomerService
+}
+
+// NewMockCustomerService creates a new mock instance.
+func NewMockCustomerService(ctrl *gomock.Controller) *MockCustomerService {
+	mock := &MockCustomerService{ctrl: ctrl}
+	mock.recorder = &MockCustomerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomerService) EXPECT() *MockCustomerServiceMockRecorder {
+	return m.recorder
+}
+
+// AdjustPoints mocks base method.
+func (m *MockCustomerService) AdjustPoints(ctx context.Context, entry *models.LoyaltyEntry) (*models.LoyaltyEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustPoints", ctx, entry)
+	ret0, _ := ret[0].(*models.LoyaltyEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustPoints indicates an expected call of AdjustPoints.
+func (mr *MockCustomerServiceMockRecorder) AdjustPoints(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustPoints", reflect.TypeOf((*MockCustomerService)(nil).AdjustPoints), ctx, entry)
+}
+
+// CreateCustomer mocks base method.
+func (m *MockCustomerService) CreateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomer", ctx, customer)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomer indicates an expected call of CreateCustomer.
+func (mr *MockCustomerServiceMockRecorder) CreateCustomer(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomer", reflect.TypeOf((*MockCustomerService)(nil).CreateCustomer), ctx, customer)
+}
+
+// DeleteCustomer mocks base method.
+func (m *MockCustomerService) DeleteCustomer(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomer", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomer indicates an expected call of DeleteCustomer.
+func (mr *MockCustomerServiceMockRecorder) DeleteCustomer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomer", reflect.TypeOf((*MockCustomerService)(nil).DeleteCustomer), ctx, id)
+}
+
+// GetCustomer mocks base method.
+func (m *MockCustomerService) GetCustomer(ctx context.Context, id uint64) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomer", ctx, id)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomer indicates an expected call of GetCustomer.
+func (mr *MockCustomerServiceMockRecorder) GetCustomer(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomer", reflect.TypeOf((*MockCustomerService)(nil).GetCustomer), ctx, id)
+}
+
+// ListCustomers mocks base method.
+func (m *MockCustomerService) ListCustomers(ctx context.Context, search string, skip, limit uint64) ([]models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomers", ctx, search, skip, limit)
+	ret0, _ := ret[0].([]models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomers indicates an expected call of ListCustomers.
+func (mr *MockCustomerServiceMockRecorder) ListCustomers(ctx, search, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomers", reflect.TypeOf((*MockCustomerService)(nil).ListCustomers), ctx, search, skip, limit)
+}
+
+// ListLoyaltyEntries mocks base method.
+func (m *MockCustomerService) ListLoyaltyEntries(ctx context.Context, customerID, skip, limit uint64) ([]models.LoyaltyEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLoyaltyEntries", ctx, customerID, skip, limit)
+	ret0, _ := ret[0].([]models.LoyaltyEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLoyaltyEntries indicates an expected call of ListLoyaltyEntries.
+func (mr *MockCustomerServiceMockRecorder) ListLoyaltyEntries(ctx, customerID, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoyaltyEntries", reflect.TypeOf((*MockCustomerService)(nil).ListLoyaltyEntries), ctx, customerID, skip, limit)
+}
+
+// ListOrders mocks base method.
+func (m *MockCustomerService) ListOrders(ctx context.Context, customerID, skip, limit uint64) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, customerID, skip, limit)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockCustomerServiceMockRecorder) ListOrders(ctx, customerID, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockCustomerService)(nil).ListOrders), ctx, customerID, skip, limit)
+}
+
+// UpdateCustomer mocks base method.
+func (m *MockCustomerService) UpdateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomer", ctx, customer)
+	ret0, _ := ret[0].(*models.Customer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomer indicates an expected call of UpdateCustomer.
+func (mr *MockCustomerServiceMockRecorder) UpdateCustomer(ctx, customer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomer", reflect.TypeOf((*MockCustomerService)(nil).UpdateCustomer), ctx, customer)
+}
+
+// MockLoyaltyProgram is a mock of LoyaltyProgram interface.
+type MockLoyaltyProgram struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoyaltyProgramMockRecorder
+}
+
+// MockLoyaltyProgramMockRecorder is the mock recorder for MockLoyaltyProgram.
+type MockLoyaltyProgramMockRecorder struct {
+	mock *MockLoyaltyProgram
+}
+
+// NewMockLoyaltyProgram creates a new mock instance.
+func NewMockLoyaltyProgram(ctrl *gomock.Controller) *MockLoyaltyProgram {
+	mock := &MockLoyaltyProgram{ctrl: ctrl}
+	mock.recorder = &MockLoyaltyProgramMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoyaltyProgram) EXPECT() *MockLoyaltyProgramMockRecorder {
+	return m.recorder
+}
+
+// PointsEarned mocks base method.
+func (m *MockLoyaltyProgram) PointsEarned(amount int64) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PointsEarned", amount)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// PointsEarned indicates an expected call of PointsEarned.
+func (mr *MockLoyaltyProgramMockRecorder) PointsEarned(amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PointsEarned", reflect.TypeOf((*MockLoyaltyProgram)(nil).PointsEarned), amount)
+}
+
+// RedeemCost mocks base method.
+func (m *MockLoyaltyProgram) RedeemCost(amount int64) int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeemCost", amount)
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// RedeemCost indicates an expected call of RedeemCost.
+func (mr *MockLoyaltyProgramMockRecorder) RedeemCost(amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeemCost", reflect.TypeOf((*MockLoyaltyProgram)(nil).RedeemCost), amount)
+}
@@ -0,0 +1,196 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: emailChange.go
+//
+// Generated by this command:
+//
+//	mockgen -source=emailChange.go -destination=mock/emailChange.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEmailChangeRepository is a mock of EmailChangeRepository interface.
+type MockEmailChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailChangeRepositoryMockRecorder
+}
+
+// MockEmailChangeRepositoryMockRecorder is the mock recorder for MockEmailChangeRepository.
+type MockEmailChangeRepositoryMockRecorder struct {
+	mock *MockEmailChangeRepository
+}
+
+// NewMockEmailChangeRepository creates a new mock instance.
+func NewMockEmailChangeRepository(ctrl *gomock.Controller) *MockEmailChangeRepository {
+	mock := &MockEmailChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockEmailChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailChangeRepository) EXPECT() *MockEmailChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEmailChange mocks base method.
+func (m *MockEmailChangeRepository) ConfirmEmailChange(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockEmailChangeRepositoryMockRecorder) ConfirmEmailChange(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockEmailChangeRepository)(nil).ConfirmEmailChange), ctx, id)
+}
+
+// CreateEmailChange mocks base method.
+func (m *MockEmailChangeRepository) CreateEmailChange(ctx context.Context, change *models.EmailChange) (*models.EmailChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailChange", ctx, change)
+	ret0, _ := ret[0].(*models.EmailChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmailChange indicates an expected call of CreateEmailChange.
+func (mr *MockEmailChangeRepositoryMockRecorder) CreateEmailChange(ctx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailChange", reflect.TypeOf((*MockEmailChangeRepository)(nil).CreateEmailChange), ctx, change)
+}
+
+// DeletePendingEmailChanges mocks base method.
+func (m *MockEmailChangeRepository) DeletePendingEmailChanges(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePendingEmailChanges", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePendingEmailChanges indicates an expected call of DeletePendingEmailChanges.
+func (mr *MockEmailChangeRepositoryMockRecorder) DeletePendingEmailChanges(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePendingEmailChanges", reflect.TypeOf((*MockEmailChangeRepository)(nil).DeletePendingEmailChanges), ctx, userID)
+}
+
+// GetEmailChangeByRevertTokenID mocks base method.
+func (m *MockEmailChangeRepository) GetEmailChangeByRevertTokenID(ctx context.Context, tokenID string) (*models.EmailChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailChangeByRevertTokenID", ctx, tokenID)
+	ret0, _ := ret[0].(*models.EmailChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailChangeByRevertTokenID indicates an expected call of GetEmailChangeByRevertTokenID.
+func (mr *MockEmailChangeRepositoryMockRecorder) GetEmailChangeByRevertTokenID(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailChangeByRevertTokenID", reflect.TypeOf((*MockEmailChangeRepository)(nil).GetEmailChangeByRevertTokenID), ctx, tokenID)
+}
+
+// GetEmailChangeByTokenID mocks base method.
+func (m *MockEmailChangeRepository) GetEmailChangeByTokenID(ctx context.Context, tokenID string) (*models.EmailChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmailChangeByTokenID", ctx, tokenID)
+	ret0, _ := ret[0].(*models.EmailChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmailChangeByTokenID indicates an expected call of GetEmailChangeByTokenID.
+func (mr *MockEmailChangeRepositoryMockRecorder) GetEmailChangeByTokenID(ctx, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmailChangeByTokenID", reflect.TypeOf((*MockEmailChangeRepository)(nil).GetEmailChangeByTokenID), ctx, tokenID)
+}
+
+// RevertEmailChange mocks base method.
+func (m *MockEmailChangeRepository) RevertEmailChange(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertEmailChange", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevertEmailChange indicates an expected call of RevertEmailChange.
+func (mr *MockEmailChangeRepositoryMockRecorder) RevertEmailChange(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertEmailChange", reflect.TypeOf((*MockEmailChangeRepository)(nil).RevertEmailChange), ctx, id)
+}
+
+// MockEmailChangeService is a mock of EmailChangeService interface.
+type MockEmailChangeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailChangeServiceMockRecorder
+}
+
+// MockEmailChangeServiceMockRecorder is the mock recorder for MockEmailChangeService.
+type MockEmailChangeServiceMockRecorder struct {
+	mock *MockEmailChangeService
+}
+
+// NewMockEmailChangeService creates a new mock instance.
+func NewMockEmailChangeService(ctrl *gomock.Controller) *MockEmailChangeService {
+	mock := &MockEmailChangeService{ctrl: ctrl}
+	mock.recorder = &MockEmailChangeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailChangeService) EXPECT() *MockEmailChangeServiceMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEmailChange mocks base method.
+func (m *MockEmailChangeService) ConfirmEmailChange(ctx context.Context, token string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, token)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockEmailChangeServiceMockRecorder) ConfirmEmailChange(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockEmailChangeService)(nil).ConfirmEmailChange), ctx, token)
+}
+
+// RequestEmailChange mocks base method.
+func (m *MockEmailChangeService) RequestEmailChange(ctx context.Context, user *models.User, email string) (*models.EmailChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailChange", ctx, user, email)
+	ret0, _ := ret[0].(*models.EmailChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestEmailChange indicates an expected call of RequestEmailChange.
+func (mr *MockEmailChangeServiceMockRecorder) RequestEmailChange(ctx, user, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChange", reflect.TypeOf((*MockEmailChangeService)(nil).RequestEmailChange), ctx, user, email)
+}
+
+// RevertEmailChange mocks base method.
+func (m *MockEmailChangeService) RevertEmailChange(ctx context.Context, token string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertEmailChange", ctx, token)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertEmailChange indicates an expected call of RevertEmailChange.
+func (mr *MockEmailChangeServiceMockRecorder) RevertEmailChange(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertEmailChange", reflect.TypeOf((*MockEmailChangeService)(nil).RevertEmailChange), ctx, token)
+}
@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: link.go
+//
+// Generated by this command:
+//
+//	mockgen -source=link.go -destination=mock/link.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLinkService is a mock of LinkService interface.
+type MockLinkService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkServiceMockRecorder
+}
+
+// MockLinkServiceMockRecorder is the mock recorder for MockLinkService.
+type MockLinkServiceMockRecorder struct {
+	mock *MockLinkService
+}
+
+// NewMockLinkService creates a new mock instance.
+func NewMockLinkService(ctrl *gomock.Controller) *MockLinkService {
+	mock := &MockLinkService{ctrl: ctrl}
+	mock.recorder = &MockLinkServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkService) EXPECT() *MockLinkServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateLink mocks base method.
+func (m *MockLinkService) CreateLink(ctx context.Context, purpose models.LinkPurpose, subject string) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLink", ctx, purpose, subject)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateLink indicates an expected call of CreateLink.
+func (mr *MockLinkServiceMockRecorder) CreateLink(ctx, purpose, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockLinkService)(nil).CreateLink), ctx, purpose, subject)
+}
+
+// VerifyLink mocks base method.
+func (m *MockLinkService) VerifyLink(purpose models.LinkPurpose, token string) (string, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLink", purpose, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifyLink indicates an expected call of VerifyLink.
+func (mr *MockLinkServiceMockRecorder) VerifyLink(purpose, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLink", reflect.TypeOf((*MockLinkService)(nil).VerifyLink), purpose, token)
+}
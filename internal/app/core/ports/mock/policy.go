@@ -0,0 +1,256 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: policy.go
+//
+// Generated by this command:
+//
+//	mockgen -source=policy.go -destination=mock/policy.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPolicyService is a mock of PolicyService interface.
+type MockPolicyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyServiceMockRecorder
+}
+
+// MockPolicyServiceMockRecorder is the mock recorder for MockPolicyService.
+type MockPolicyServiceMockRecorder struct {
+	mock *MockPolicyService
+}
+
+// NewMockPolicyService creates a new mock instance.
+func NewMockPolicyService(ctrl *gomock.Controller) *MockPolicyService {
+	mock := &MockPolicyService{ctrl: ctrl}
+	mock.recorder = &MockPolicyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPolicyService) EXPECT() *MockPolicyServiceMockRecorder {
+	return m.recorder
+}
+
+// AddGroupMember mocks base method.
+func (m *MockPolicyService) AddGroupMember(ctx context.Context, groupID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGroupMember indicates an expected call of AddGroupMember.
+func (mr *MockPolicyServiceMockRecorder) AddGroupMember(ctx, groupID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupMember", reflect.TypeOf((*MockPolicyService)(nil).AddGroupMember), ctx, groupID, userID)
+}
+
+// AddGroupRole mocks base method.
+func (m *MockPolicyService) AddGroupRole(ctx context.Context, groupID uint64, role models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGroupRole", ctx, groupID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddGroupRole indicates an expected call of AddGroupRole.
+func (mr *MockPolicyServiceMockRecorder) AddGroupRole(ctx, groupID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGroupRole", reflect.TypeOf((*MockPolicyService)(nil).AddGroupRole), ctx, groupID, role)
+}
+
+// AddRule mocks base method.
+func (m *MockPolicyService) AddRule(rule *models.PolicyRule) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRule", rule)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRule indicates an expected call of AddRule.
+func (mr *MockPolicyServiceMockRecorder) AddRule(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRule", reflect.TypeOf((*MockPolicyService)(nil).AddRule), rule)
+}
+
+// AddUserRole mocks base method.
+func (m *MockPolicyService) AddUserRole(ctx context.Context, userID uint64, role models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserRole indicates an expected call of AddUserRole.
+func (mr *MockPolicyServiceMockRecorder) AddUserRole(ctx, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserRole", reflect.TypeOf((*MockPolicyService)(nil).AddUserRole), ctx, userID, role)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockPolicyService) DeleteGroup(ctx context.Context, groupID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", ctx, groupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockPolicyServiceMockRecorder) DeleteGroup(ctx, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockPolicyService)(nil).DeleteGroup), ctx, groupID)
+}
+
+// DeleteGroupMember mocks base method.
+func (m *MockPolicyService) DeleteGroupMember(ctx context.Context, groupID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupMember", ctx, groupID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroupMember indicates an expected call of DeleteGroupMember.
+func (mr *MockPolicyServiceMockRecorder) DeleteGroupMember(ctx, groupID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupMember", reflect.TypeOf((*MockPolicyService)(nil).DeleteGroupMember), ctx, groupID, userID)
+}
+
+// DeleteGroupRole mocks base method.
+func (m *MockPolicyService) DeleteGroupRole(ctx context.Context, groupID uint64, role models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroupRole", ctx, groupID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroupRole indicates an expected call of DeleteGroupRole.
+func (mr *MockPolicyServiceMockRecorder) DeleteGroupRole(ctx, groupID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupRole", reflect.TypeOf((*MockPolicyService)(nil).DeleteGroupRole), ctx, groupID, role)
+}
+
+// DeleteRole mocks base method.
+func (m *MockPolicyService) DeleteRole(ctx context.Context, role models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockPolicyServiceMockRecorder) DeleteRole(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockPolicyService)(nil).DeleteRole), ctx, role)
+}
+
+// DeleteUser mocks base method.
+func (m *MockPolicyService) DeleteUser(ctx context.Context, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockPolicyServiceMockRecorder) DeleteUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockPolicyService)(nil).DeleteUser), ctx, userID)
+}
+
+// Explain mocks base method.
+func (m *MockPolicyService) Explain(request *models.AuthzRequest) (*models.AuthzDecision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Explain", request)
+	ret0, _ := ret[0].(*models.AuthzDecision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Explain indicates an expected call of Explain.
+func (mr *MockPolicyServiceMockRecorder) Explain(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Explain", reflect.TypeOf((*MockPolicyService)(nil).Explain), request)
+}
+
+// GetSubjectRoles mocks base method.
+func (m *MockPolicyService) GetSubjectRoles(subject, domain string) (*models.SubjectRoles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubjectRoles", subject, domain)
+	ret0, _ := ret[0].(*models.SubjectRoles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubjectRoles indicates an expected call of GetSubjectRoles.
+func (mr *MockPolicyServiceMockRecorder) GetSubjectRoles(subject, domain any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubjectRoles", reflect.TypeOf((*MockPolicyService)(nil).GetSubjectRoles), subject, domain)
+}
+
+// ListRules mocks base method.
+func (m *MockPolicyService) ListRules(filter *models.PolicyRule) ([]models.PolicyRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", filter)
+	ret0, _ := ret[0].([]models.PolicyRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockPolicyServiceMockRecorder) ListRules(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockPolicyService)(nil).ListRules), filter)
+}
+
+// RemoveRule mocks base method.
+func (m *MockPolicyService) RemoveRule(rule *models.PolicyRule) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveRule", rule)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveRule indicates an expected call of RemoveRule.
+func (mr *MockPolicyServiceMockRecorder) RemoveRule(rule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRule", reflect.TypeOf((*MockPolicyService)(nil).RemoveRule), rule)
+}
+
+// UpdateGroupParent mocks base method.
+func (m *MockPolicyService) UpdateGroupParent(ctx context.Context, groupID uint64, from, to *uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupParent", ctx, groupID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroupParent indicates an expected call of UpdateGroupParent.
+func (mr *MockPolicyServiceMockRecorder) UpdateGroupParent(ctx, groupID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupParent", reflect.TypeOf((*MockPolicyService)(nil).UpdateGroupParent), ctx, groupID, from, to)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockPolicyService) UpdateUserRole(ctx context.Context, userID uint64, from, to models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", ctx, userID, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockPolicyServiceMockRecorder) UpdateUserRole(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockPolicyService)(nil).UpdateUserRole), ctx, userID, from, to)
+}
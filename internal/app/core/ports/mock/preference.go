@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: preference.go
+//
+// Generated by this command:
+//
+//	mockgen -source=preference.go -destination=mock/preference.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreferenceRepository is a mock of PreferenceRepository interface.
+type MockPreferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceRepositoryMockRecorder
+}
+
+// MockPreferenceRepositoryMockRecorder is the mock recorder for MockPreferenceRepository.
+type MockPreferenceRepositoryMockRecorder struct {
+	mock *MockPreferenceRepository
+}
+
+// NewMockPreferenceRepository creates a new mock instance.
+func NewMockPreferenceRepository(ctrl *gomock.Controller) *MockPreferenceRepository {
+	mock := &MockPreferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockPreferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceRepository) EXPECT() *MockPreferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockPreferenceRepository) GetPreferences(ctx context.Context, userID uint64) (models.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].(models.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockPreferenceRepositoryMockRecorder) GetPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockPreferenceRepository)(nil).GetPreferences), ctx, userID)
+}
+
+// SavePreferences mocks base method.
+func (m *MockPreferenceRepository) SavePreferences(ctx context.Context, userID uint64, set models.Preferences, reset []models.PreferenceKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreferences", ctx, userID, set, reset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreferences indicates an expected call of SavePreferences.
+func (mr *MockPreferenceRepositoryMockRecorder) SavePreferences(ctx, userID, set, reset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferences", reflect.TypeOf((*MockPreferenceRepository)(nil).SavePreferences), ctx, userID, set, reset)
+}
+
+// MockPreferenceService is a mock of PreferenceService interface.
+type MockPreferenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceServiceMockRecorder
+}
+
+// MockPreferenceServiceMockRecorder is the mock recorder for MockPreferenceService.
+type MockPreferenceServiceMockRecorder struct {
+	mock *MockPreferenceService
+}
+
+// NewMockPreferenceService creates a new mock instance.
+func NewMockPreferenceService(ctrl *gomock.Controller) *MockPreferenceService {
+	mock := &MockPreferenceService{ctrl: ctrl}
+	mock.recorder = &MockPreferenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceService) EXPECT() *MockPreferenceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPreferences mocks base method.
+func (m *MockPreferenceService) GetPreferences(ctx context.Context, userID uint64) (models.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", ctx, userID)
+	ret0, _ := ret[0].(models.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockPreferenceServiceMockRecorder) GetPreferences(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockPreferenceService)(nil).GetPreferences), ctx, userID)
+}
+
+// ReplacePreferences mocks base method.
+func (m *MockPreferenceService) ReplacePreferences(ctx context.Context, userID uint64, preferences models.Preferences) (models.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePreferences", ctx, userID, preferences)
+	ret0, _ := ret[0].(models.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplacePreferences indicates an expected call of ReplacePreferences.
+func (mr *MockPreferenceServiceMockRecorder) ReplacePreferences(ctx, userID, preferences any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePreferences", reflect.TypeOf((*MockPreferenceService)(nil).ReplacePreferences), ctx, userID, preferences)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockPreferenceService) UpdatePreferences(ctx context.Context, userID uint64, preferences models.Preferences) (models.Preferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", ctx, userID, preferences)
+	ret0, _ := ret[0].(models.Preferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockPreferenceServiceMockRecorder) UpdatePreferences(ctx, userID, preferences any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockPreferenceService)(nil).UpdatePreferences), ctx, userID, preferences)
+}
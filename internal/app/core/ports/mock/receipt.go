@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: receipt.go
+//
+// Generated by this command:
+//
+//	mockgen -source=receipt.go -destination=mock/receipt.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReceiptRenderer is a mock of ReceiptRenderer interface.
+type MockReceiptRenderer struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptRendererMockRecorder
+}
+
+// MockReceiptRendererMockRecorder is the mock recorder for MockReceiptRenderer.
+type MockReceiptRendererMockRecorder struct {
+	mock *MockReceiptRenderer
+}
+
+// NewMockReceiptRenderer creates a new mock instance.
+func NewMockReceiptRenderer(ctrl *gomock.Controller) *MockReceiptRenderer {
+	mock := &MockReceiptRenderer{ctrl: ctrl}
+	mock.recorder = &MockReceiptRendererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptRenderer) EXPECT() *MockReceiptRendererMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockReceiptRenderer) Render(ctx context.Context, receipt *models.Receipt) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", ctx, receipt)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockReceiptRendererMockRecorder) Render(ctx, receipt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockReceiptRenderer)(nil).Render), ctx, receipt)
+}
+
+// MockReceiptService is a mock of ReceiptService interface.
+type MockReceiptService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReceiptServiceMockRecorder
+}
+
+// MockReceiptServiceMockRecorder is the mock recorder for MockReceiptService.
+type MockReceiptServiceMockRecorder struct {
+	mock *MockReceiptService
+}
+
+// NewMockReceiptService creates a new mock instance.
+func NewMockReceiptService(ctrl *gomock.Controller) *MockReceiptService {
+	mock := &MockReceiptService{ctrl: ctrl}
+	mock.recorder = &MockReceiptServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReceiptService) EXPECT() *MockReceiptServiceMockRecorder {
+	return m.recorder
+}
+
+// GetReceipt mocks base method.
+func (m *MockReceiptService) GetReceipt(ctx context.Context, storeID, orderID uint64, format models.ReceiptFormat, width models.ReceiptPaperWidth) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceipt", ctx, storeID, orderID, format, width)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceipt indicates an expected call of GetReceipt.
+func (mr *MockReceiptServiceMockRecorder) GetReceipt(ctx, storeID, orderID, format, width any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceipt", reflect.TypeOf((*MockReceiptService)(nil).GetReceipt), ctx, storeID, orderID, format, width)
+}
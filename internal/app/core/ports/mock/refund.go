@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: refund.go
+//
+// Generated by this command:
+//
+//	mockgen -source=refund.go -destination=mock/refund.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRefundRepository is a mock of RefundRepository interface.
+type MockRefundRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefundRepositoryMockRecorder
+}
+
+// MockRefundRepositoryMockRecorder is the mock recorder for MockRefundRepository.
+type MockRefundRepositoryMockRecorder struct {
+	mock *MockRefundRepository
+}
+
+// NewMockRefundRepository creates a new mock instance.
+func NewMockRefundRepository(ctrl *gomock.Controller) *MockRefundRepository {
+	mock := &MockRefundRepository{ctrl: ctrl}
+	mock.recorder = &MockRefundRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefundRepository) EXPECT() *MockRefundRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateRefund mocks base method.
+func (m *MockRefundRepository) CreateRefund(ctx context.Context, refund *models.Refund, previousRefunds int) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefund", ctx, refund, previousRefunds)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefund indicates an expected call of CreateRefund.
+func (mr *MockRefundRepositoryMockRecorder) CreateRefund(ctx, refund, previousRefunds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefund", reflect.TypeOf((*MockRefundRepository)(nil).CreateRefund), ctx, refund, previousRefunds)
+}
+
+// GetRefundByID mocks base method.
+func (m *MockRefundRepository) GetRefundByID(ctx context.Context, id uint64) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefundByID", ctx, id)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefundByID indicates an expected call of GetRefundByID.
+func (mr *MockRefundRepositoryMockRecorder) GetRefundByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefundByID", reflect.TypeOf((*MockRefundRepository)(nil).GetRefundByID), ctx, id)
+}
+
+// ListOrderRefunds mocks base method.
+func (m *MockRefundRepository) ListOrderRefunds(ctx context.Context, orderID uint64) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderRefunds", ctx, orderID)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderRefunds indicates an expected call of ListOrderRefunds.
+func (mr *MockRefundRepositoryMockRecorder) ListOrderRefunds(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderRefunds", reflect.TypeOf((*MockRefundRepository)(nil).ListOrderRefunds), ctx, orderID)
+}
+
+// ListRefunds mocks base method.
+func (m *MockRefundRepository) ListRefunds(ctx context.Context, storeID, orderID, skip, limit uint64) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRefunds", ctx, storeID, orderID, skip, limit)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRefunds indicates an expected call of ListRefunds.
+func (mr *MockRefundRepositoryMockRecorder) ListRefunds(ctx, storeID, orderID, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefunds", reflect.TypeOf((*MockRefundRepository)(nil).ListRefunds), ctx, storeID, orderID, skip, limit)
+}
+
+// MockRefundService is a mock of RefundService interface.
+type MockRefundService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefundServiceMockRecorder
+}
+
+// MockRefundServiceMockRecorder is the mock recorder for MockRefundService.
+type MockRefundServiceMockRecorder struct {
+	mock *MockRefundService
+}
+
+// NewMockRefundService creates a new mock instance.
+func NewMockRefundService(ctrl *gomock.Controller) *MockRefundService {
+	mock := &MockRefundService{ctrl: ctrl}
+	mock.recorder = &MockRefundServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefundService) EXPECT() *MockRefundServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRefund mocks base method.
+func (m *MockRefundService) GetRefund(ctx context.Context, storeID, id uint64) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefund", ctx, storeID, id)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefund indicates an expected call of GetRefund.
+func (mr *MockRefundServiceMockRecorder) GetRefund(ctx, storeID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefund", reflect.TypeOf((*MockRefundService)(nil).GetRefund), ctx, storeID, id)
+}
+
+// ListRefunds mocks base method.
+func (m *MockRefundService) ListRefunds(ctx context.Context, storeID, orderID, skip, limit uint64) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRefunds", ctx, storeID, orderID, skip, limit)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRefunds indicates an expected call of ListRefunds.
+func (mr *MockRefundServiceMockRecorder) ListRefunds(ctx, storeID, orderID, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRefunds", reflect.TypeOf((*MockRefundService)(nil).ListRefunds), ctx, storeID, orderID, skip, limit)
+}
+
+// RefundOrder mocks base method.
+func (m *MockRefundService) RefundOrder(ctx context.Context, refund *models.Refund) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundOrder", ctx, refund)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundOrder indicates an expected call of RefundOrder.
+func (mr *MockRefundServiceMockRecorder) RefundOrder(ctx, refund any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundOrder", reflect.TypeOf((*MockRefundService)(nil).RefundOrder), ctx, refund)
+}
+
+// VoidOrder mocks base method.
+func (m *MockRefundService) VoidOrder(ctx context.Context, refund *models.Refund) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VoidOrder", ctx, refund)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VoidOrder indicates an expected call of VoidOrder.
+func (mr *MockRefundServiceMockRecorder) VoidOrder(ctx, refund any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VoidOrder", reflect.TypeOf((*MockRefundService)(nil).VoidOrder), ctx, refund)
+}
@@ -0,0 +1,214 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: report.go
+//
+// Generated by this command:
+//
+//	mockgen -source=report.go -destination=mock/report.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReportRepository is a mock of ReportRepository interface.
+type MockReportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRepositoryMockRecorder
+}
+
+// MockReportRepositoryMockRecorder is the mock recorder for MockReportRepository.
+type MockReportRepositoryMockRecorder struct {
+	mock *MockReportRepository
+}
+
+// NewMockReportRepository creates a new mock instance.
+func NewMockReportRepository(ctrl *gomock.Controller) *MockReportRepository {
+	mock := &MockReportRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRepository) EXPECT() *MockReportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Discounts mocks base method.
+func (m *MockReportRepository) Discounts(ctx context.Context, filter *models.ReportFilter) ([]models.DiscountReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discounts", ctx, filter)
+	ret0, _ := ret[0].([]models.DiscountReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Discounts indicates an expected call of Discounts.
+func (mr *MockReportRepositoryMockRecorder) Discounts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discounts", reflect.TypeOf((*MockReportRepository)(nil).Discounts), ctx, filter)
+}
+
+// RevenueByPeriod mocks base method.
+func (m *MockReportRepository) RevenueByPeriod(ctx context.Context, filter *models.ReportFilter, period models.ReportPeriod) ([]models.RevenueReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevenueByPeriod", ctx, filter, period)
+	ret0, _ := ret[0].([]models.RevenueReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevenueByPeriod indicates an expected call of RevenueByPeriod.
+func (mr *MockReportRepositoryMockRecorder) RevenueByPeriod(ctx, filter, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevenueByPeriod", reflect.TypeOf((*MockReportRepository)(nil).RevenueByPeriod), ctx, filter, period)
+}
+
+// SalesByCashier mocks base method.
+func (m *MockReportRepository) SalesByCashier(ctx context.Context, filter *models.ReportFilter) ([]models.CashierSalesReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesByCashier", ctx, filter)
+	ret0, _ := ret[0].([]models.CashierSalesReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesByCashier indicates an expected call of SalesByCashier.
+func (mr *MockReportRepositoryMockRecorder) SalesByCashier(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesByCashier", reflect.TypeOf((*MockReportRepository)(nil).SalesByCashier), ctx, filter)
+}
+
+// SalesByPaymentMethod mocks base method.
+func (m *MockReportRepository) SalesByPaymentMethod(ctx context.Context, filter *models.ReportFilter) ([]models.PaymentMethodReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesByPaymentMethod", ctx, filter)
+	ret0, _ := ret[0].([]models.PaymentMethodReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesByPaymentMethod indicates an expected call of SalesByPaymentMethod.
+func (mr *MockReportRepositoryMockRecorder) SalesByPaymentMethod(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesByPaymentMethod", reflect.TypeOf((*MockReportRepository)(nil).SalesByPaymentMethod), ctx, filter)
+}
+
+// TopProducts mocks base method.
+func (m *MockReportRepository) TopProducts(ctx context.Context, filter *models.ReportFilter, limit uint64) ([]models.ProductSalesReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopProducts", ctx, filter, limit)
+	ret0, _ := ret[0].([]models.ProductSalesReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopProducts indicates an expected call of TopProducts.
+func (mr *MockReportRepositoryMockRecorder) TopProducts(ctx, filter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopProducts", reflect.TypeOf((*MockReportRepository)(nil).TopProducts), ctx, filter, limit)
+}
+
+// MockReportService is a mock of ReportService interface.
+type MockReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportServiceMockRecorder
+}
+
+// MockReportServiceMockRecorder is the mock recorder for MockReportService.
+type MockReportServiceMockRecorder struct {
+	mock *MockReportService
+}
+
+// NewMockReportService creates a new mock instance.
+func NewMockReportService(ctrl *gomock.Controller) *MockReportService {
+	mock := &MockReportService{ctrl: ctrl}
+	mock.recorder = &MockReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportService) EXPECT() *MockReportServiceMockRecorder {
+	return m.recorder
+}
+
+// Discounts mocks base method.
+func (m *MockReportService) Discounts(ctx context.Context, filter *models.ReportFilter) ([]models.DiscountReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Discounts", ctx, filter)
+	ret0, _ := ret[0].([]models.DiscountReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Discounts indicates an expected call of Discounts.
+func (mr *MockReportServiceMockRecorder) Discounts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Discounts", reflect.TypeOf((*MockReportService)(nil).Discounts), ctx, filter)
+}
+
+// RevenueByPeriod mocks base method.
+func (m *MockReportService) RevenueByPeriod(ctx context.Context, filter *models.ReportFilter, period models.ReportPeriod) ([]models.RevenueReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevenueByPeriod", ctx, filter, period)
+	ret0, _ := ret[0].([]models.RevenueReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevenueByPeriod indicates an expected call of RevenueByPeriod.
+func (mr *MockReportServiceMockRecorder) RevenueByPeriod(ctx, filter, period any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevenueByPeriod", reflect.TypeOf((*MockReportService)(nil).RevenueByPeriod), ctx, filter, period)
+}
+
+// SalesByCashier mocks base method.
+func (m *MockReportService) SalesByCashier(ctx context.Context, filter *models.ReportFilter) ([]models.CashierSalesReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesByCashier", ctx, filter)
+	ret0, _ := ret[0].([]models.CashierSalesReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesByCashier indicates an expected call of SalesByCashier.
+func (mr *MockReportServiceMockRecorder) SalesByCashier(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesByCashier", reflect.TypeOf((*MockReportService)(nil).SalesByCashier), ctx, filter)
+}
+
+// SalesByPaymentMethod mocks base method.
+func (m *MockReportService) SalesByPaymentMethod(ctx context.Context, filter *models.ReportFilter) ([]models.PaymentMethodReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SalesByPaymentMethod", ctx, filter)
+	ret0, _ := ret[0].([]models.PaymentMethodReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SalesByPaymentMethod indicates an expected call of SalesByPaymentMethod.
+func (mr *MockReportServiceMockRecorder) SalesByPaymentMethod(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SalesByPaymentMethod", reflect.TypeOf((*MockReportService)(nil).SalesByPaymentMethod), ctx, filter)
+}
+
+// TopProducts mocks base method.
+func (m *MockReportService) TopProducts(ctx context.Context, filter *models.ReportFilter, limit uint64) ([]models.ProductSalesReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopProducts", ctx, filter, limit)
+	ret0, _ := ret[0].([]models.ProductSalesReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopProducts indicates an expected call of TopProducts.
+func (mr *MockReportServiceMockRecorder) TopProducts(ctx, filter, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopProducts", reflect.TypeOf((*MockReportService)(nil).TopProducts), ctx, filter, limit)
+}
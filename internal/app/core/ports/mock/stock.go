@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stock.go
+//
+// Generated by this command:
+//
+//	mockgen -source=stock.go -destination=mock/stock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockRepository is a mock of StockRepository interface.
+type MockStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockRepositoryMockRecorder
+}
+
+// MockStockRepositoryMockRecorder is the mock recorder for MockStockRepository.
+type MockStockRepositoryMockRecorder struct {
+	mock *MockStockRepository
+}
+
+// NewMockStockRepository creates a new mock instance.
+func NewMockStockRepository(ctrl *gomock.Controller) *MockStockRepository {
+	mock := &MockStockRepository{ctrl: ctrl}
+	mock.recorder = &MockStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockRepository) EXPECT() *MockStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListMovements mocks base method.
+func (m *MockStockRepository) ListMovements(ctx context.Context, filter *models.StockMovementFilter, skip, limit uint64) ([]models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", ctx, filter, skip, limit)
+	ret0, _ := ret[0].([]models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockStockRepositoryMockRecorder) ListMovements(ctx, filter, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockStockRepository)(nil).ListMovements), ctx, filter, skip, limit)
+}
+
+// RecordMovement mocks base method.
+func (m *MockStockRepository) RecordMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordMovement", ctx, movement)
+	ret0, _ := ret[0].(*models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordMovement indicates an expected call of RecordMovement.
+func (mr *MockStockRepositoryMockRecorder) RecordMovement(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMovement", reflect.TypeOf((*MockStockRepository)(nil).RecordMovement), ctx, movement)
+}
+
+// TransferStock mocks base method.
+func (m *MockStockRepository) TransferStock(ctx context.Context, transfer *models.StockMovement) (*models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferStock", ctx, transfer)
+	ret0, _ := ret[0].(*models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferStock indicates an expected call of TransferStock.
+func (mr *MockStockRepositoryMockRecorder) TransferStock(ctx, transfer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferStock", reflect.TypeOf((*MockStockRepository)(nil).TransferStock), ctx, transfer)
+}
+
+// MockStockService is a mock of StockService interface.
+type MockStockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockServiceMockRecorder
+}
+
+// MockStockServiceMockRecorder is the mock recorder for MockStockService.
+type MockStockServiceMockRecorder struct {
+	mock *MockStockService
+}
+
+// NewMockStockService creates a new mock instance.
+func NewMockStockService(ctrl *gomock.Controller) *MockStockService {
+	mock := &MockStockService{ctrl: ctrl}
+	mock.recorder = &MockStockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockService) EXPECT() *MockStockServiceMockRecorder {
+	return m.recorder
+}
+
+// ListMovements mocks base method.
+func (m *MockStockService) ListMovements(ctx context.Context, filter *models.StockMovementFilter, skip, limit uint64) ([]models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", ctx, filter, skip, limit)
+	ret0, _ := ret[0].([]models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockStockServiceMockRecorder) ListMovements(ctx, filter, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockStockService)(nil).ListMovements), ctx, filter, skip, limit)
+}
+
+// RecordMovement mocks base method.
+func (m *MockStockService) RecordMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordMovement", ctx, movement)
+	ret0, _ := ret[0].(*models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordMovement indicates an expected call of RecordMovement.
+func (mr *MockStockServiceMockRecorder) RecordMovement(ctx, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMovement", reflect.TypeOf((*MockStockService)(nil).RecordMovement), ctx, movement)
+}
+
+// MockStockAlerter is a mock of StockAlerter interface.
+type MockStockAlerter struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockAlerterMockRecorder
+}
+
+// MockStockAlerterMockRecorder is the mock recorder for MockStockAlerter.
+type MockStockAlerterMockRecorder struct {
+	mock *MockStockAlerter
+}
+
+// NewMockStockAlerter creates a new mock instance.
+func NewMockStockAlerter(ctrl *gomock.Controller) *MockStockAlerter {
+	mock := &MockStockAlerter{ctrl: ctrl}
+	mock.recorder = &MockStockAlerterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockAlerter) EXPECT() *MockStockAlerterMockRecorder {
+	return m.recorder
+}
+
+// LowStock mocks base method.
+func (m *MockStockAlerter) LowStock(ctx context.Context, product *models.Product, movement *models.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LowStock", ctx, product, movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LowStock indicates an expected call of LowStock.
+func (mr *MockStockAlerterMockRecorder) LowStock(ctx, product, movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LowStock", reflect.TypeOf((*MockStockAlerter)(nil).LowStock), ctx, product, movement)
+}
@@ -0,0 +1,298 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=store.go -destination=mock/store.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStoreRepository is a mock of StoreRepository interface.
+type MockStoreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreRepositoryMockRecorder
+}
+
+// MockStoreRepositoryMockRecorder is the mock recorder for MockStoreRepository.
+type MockStoreRepositoryMockRecorder struct {
+	mock *MockStoreRepository
+}
+
+// NewMockStoreRepository creates a new mock instance.
+func NewMockStoreRepository(ctrl *gomock.Controller) *MockStoreRepository {
+	mock := &MockStoreRepository{ctrl: ctrl}
+	mock.recorder = &MockStoreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreRepository) EXPECT() *MockStoreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AssignUser mocks base method.
+func (m *MockStoreRepository) AssignUser(ctx context.Context, storeID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignUser", ctx, storeID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignUser indicates an expected call of AssignUser.
+func (mr *MockStoreRepositoryMockRecorder) AssignUser(ctx, storeID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUser", reflect.TypeOf((*MockStoreRepository)(nil).AssignUser), ctx, storeID, userID)
+}
+
+// CreateStore mocks base method.
+func (m *MockStoreRepository) CreateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStore", ctx, store)
+	ret0, _ := ret[0].(*models.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStore indicates an expected call of CreateStore.
+func (mr *MockStoreRepositoryMockRecorder) CreateStore(ctx, store any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStore", reflect.TypeOf((*MockStoreRepository)(nil).CreateStore), ctx, store)
+}
+
+// DeleteStore mocks base method.
+func (m *MockStoreRepository) DeleteStore(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStore indicates an expected call of DeleteStore.
+func (mr *MockStoreRepositoryMockRecorder) DeleteStore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStore", reflect.TypeOf((*MockStoreRepository)(nil).DeleteStore), ctx, id)
+}
+
+// GetStoreByID mocks base method.
+func (m *MockStoreRepository) GetStoreByID(ctx context.Context, id uint64) (*models.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStoreByID", ctx, id)
+	ret0, _ := ret[0].(*models.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStoreByID indicates an expected call of GetStoreByID.
+func (mr *MockStoreRepositoryMockRecorder) GetStoreByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoreByID", reflect.TypeOf((*MockStoreRepository)(nil).GetStoreByID), ctx, id)
+}
+
+// ListStores mocks base method.
+func (m *MockStoreRepository) ListStores(ctx context.Context, skip, limit uint64) ([]models.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStores", ctx, skip, limit)
+	ret0, _ := ret[0].([]models.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStores indicates an expected call of ListStores.
+func (mr *MockStoreRepositoryMockRecorder) ListStores(ctx, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStores", reflect.TypeOf((*MockStoreRepository)(nil).ListStores), ctx, skip, limit)
+}
+
+// ListUsers mocks base method.
+func (m *MockStoreRepository) ListUsers(ctx context.Context, storeID, skip, limit uint64) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, storeID, skip, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreRepositoryMockRecorder) ListUsers(ctx, storeID, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStoreRepository)(nil).ListUsers), ctx, storeID, skip, limit)
+}
+
+// UnassignUser mocks base method.
+func (m *MockStoreRepository) UnassignUser(ctx context.Context, storeID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignUser", ctx, storeID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignUser indicates an expected call of UnassignUser.
+func (mr *MockStoreRepositoryMockRecorder) UnassignUser(ctx, storeID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignUser", reflect.TypeOf((*MockStoreRepository)(nil).UnassignUser), ctx, storeID, userID)
+}
+
+// UpdateStore mocks base method.
+func (m *MockStoreRepository) UpdateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStore", ctx, store)
+	ret0, _ := ret[0].(*models.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStore indicates an expected call of UpdateStore.
+func (mr *MockStoreRepositoryMockRecorder) UpdateStore(ctx, store any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStore", reflect.TypeOf((*MockStoreRepository)(nil).UpdateStore), ctx, store)
+}
+
+// MockStoreService is a mock of StoreService interface.
+type MockStoreService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreServiceMockRecorder
+}
+
+// MockStoreServiceMockRecorder is the mock recorder for MockStoreService.
+type MockStoreServiceMockRecorder struct {
+	mock *MockStoreService
+}
+
+// NewMockStoreService creates a new mock instance.
+func NewMockStoreService(ctrl *gomock.Controller) *MockStoreService {
+	mock := &MockStoreService{ctrl: ctrl}
+	mock.recorder = &MockStoreServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStoreService) EXPECT() *MockStoreServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignUser mocks base method.
+func (m *MockStoreService) AssignUser(ctx context.Context, storeID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignUser", ctx, storeID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignUser indicates an expected call of AssignUser.
+func (mr *MockStoreServiceMockRecorder) AssignUser(ctx, storeID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignUser", reflect.TypeOf((*MockStoreService)(nil).AssignUser), ctx, storeID, userID)
+}
+
+// CreateStore mocks base method.
+func (m *MockStoreService) CreateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStore", ctx, store)
+	ret0, _ := ret[0].(*models.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStore indicates an expected call of CreateStore.
+func (mr *MockStoreServiceMockRecorder) CreateStore(ctx, store any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStore", reflect.TypeOf((*MockStoreService)(nil).CreateStore), ctx, store)
+}
+
+// DeleteStore mocks base method.
+func (m *MockStoreService) DeleteStore(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStore indicates an expected call of DeleteStore.
+func (mr *MockStoreServiceMockRecorder) DeleteStore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStore", reflect.TypeOf((*MockStoreService)(nil).DeleteStore), ctx, id)
+}
+
+// GetStore mocks base method.
+func (m *MockStoreService) GetStore(ctx context.Context, id uint64) (*models.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStore", ctx, id)
+	ret0, _ := ret[0].(*models.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStore indicates an expected call of GetStore.
+func (mr *MockStoreServiceMockRecorder) GetStore(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStore", reflect.TypeOf((*MockStoreService)(nil).GetStore), ctx, id)
+}
+
+// ListStores mocks base method.
+func (m *MockStoreService) ListStores(ctx context.Context, skip, limit uint64) ([]models.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStores", ctx, skip, limit)
+	ret0, _ := ret[0].([]models.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStores indicates an expected call of ListStores.
+func (mr *MockStoreServiceMockRecorder) ListStores(ctx, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStores", reflect.TypeOf((*MockStoreService)(nil).ListStores), ctx, skip, limit)
+}
+
+// ListUsers mocks base method.
+func (m *MockStoreService) ListUsers(ctx context.Context, storeID, skip, limit uint64) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, storeID, skip, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreServiceMockRecorder) ListUsers(ctx, storeID, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStoreService)(nil).ListUsers), ctx, storeID, skip, limit)
+}
+
+// UnassignUser mocks base method.
+func (m *MockStoreService) UnassignUser(ctx context.Context, storeID, userID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignUser", ctx, storeID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignUser indicates an expected call of UnassignUser.
+func (mr *MockStoreServiceMockRecorder) UnassignUser(ctx, storeID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignUser", reflect.TypeOf((*MockStoreService)(nil).UnassignUser), ctx, storeID, userID)
+}
+
+// UpdateStore mocks base method.
+func (m *MockStoreService) UpdateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStore", ctx, store)
+	ret0, _ := ret[0].(*models.Store)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStore indicates an expected call of UpdateStore.
+func (mr *MockStoreServiceMockRecorder) UpdateStore(ctx, store any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStore", reflect.TypeOf((*MockStoreService)(nil).UpdateStore), ctx, store)
+}
@@ -0,0 +1,375 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tax.go
+//
+// Generated by this command:
+//
+//	mockgen -source=tax.go -destination=mock/tax.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxRepository is a mock of TaxRepository interface.
+type MockTaxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRepositoryMockRecorder
+}
+
+// MockTaxRepositoryMockRecorder is the mock recorder for MockTaxRepository.
+type MockTaxRepositoryMockRecorder struct {
+	mock *MockTaxRepository
+}
+
+// NewMockTaxRepository creates a new mock instance.
+func NewMockTaxRepository(ctrl *gomock.Controller) *MockTaxRepository {
+	mock := &MockTaxRepository{ctrl: ctrl}
+	mock.recorder = &MockTaxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRepository) EXPECT() *MockTaxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxClass mocks base method.
+func (m *MockTaxRepository) CreateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxClass", ctx, class)
+	ret0, _ := ret[0].(*models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxClass indicates an expected call of CreateTaxClass.
+func (mr *MockTaxRepositoryMockRecorder) CreateTaxClass(ctx, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxClass", reflect.TypeOf((*MockTaxRepository)(nil).CreateTaxClass), ctx, class)
+}
+
+// CreateTaxRate mocks base method.
+func (m *MockTaxRepository) CreateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRate", ctx, rate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRate indicates an expected call of CreateTaxRate.
+func (mr *MockTaxRepositoryMockRecorder) CreateTaxRate(ctx, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRate", reflect.TypeOf((*MockTaxRepository)(nil).CreateTaxRate), ctx, rate)
+}
+
+// DeleteTaxClass mocks base method.
+func (m *MockTaxRepository) DeleteTaxClass(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxClass", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaxClass indicates an expected call of DeleteTaxClass.
+func (mr *MockTaxRepositoryMockRecorder) DeleteTaxClass(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxClass", reflect.TypeOf((*MockTaxRepository)(nil).DeleteTaxClass), ctx, id)
+}
+
+// DeleteTaxRate mocks base method.
+func (m *MockTaxRepository) DeleteTaxRate(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxRate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaxRate indicates an expected call of DeleteTaxRate.
+func (mr *MockTaxRepositoryMockRecorder) DeleteTaxRate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxRate", reflect.TypeOf((*MockTaxRepository)(nil).DeleteTaxRate), ctx, id)
+}
+
+// GetTaxClassByID mocks base method.
+func (m *MockTaxRepository) GetTaxClassByID(ctx context.Context, id uint64) (*models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxClassByID", ctx, id)
+	ret0, _ := ret[0].(*models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxClassByID indicates an expected call of GetTaxClassByID.
+func (mr *MockTaxRepositoryMockRecorder) GetTaxClassByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxClassByID", reflect.TypeOf((*MockTaxRepository)(nil).GetTaxClassByID), ctx, id)
+}
+
+// GetTaxRateByID mocks base method.
+func (m *MockTaxRepository) GetTaxRateByID(ctx context.Context, id uint64) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRateByID", ctx, id)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRateByID indicates an expected call of GetTaxRateByID.
+func (mr *MockTaxRepositoryMockRecorder) GetTaxRateByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRateByID", reflect.TypeOf((*MockTaxRepository)(nil).GetTaxRateByID), ctx, id)
+}
+
+// ListStoreTaxRates mocks base method.
+func (m *MockTaxRepository) ListStoreTaxRates(ctx context.Context, store *models.Store) ([]models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStoreTaxRates", ctx, store)
+	ret0, _ := ret[0].([]models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStoreTaxRates indicates an expected call of ListStoreTaxRates.
+func (mr *MockTaxRepositoryMockRecorder) ListStoreTaxRates(ctx, store any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStoreTaxRates", reflect.TypeOf((*MockTaxRepository)(nil).ListStoreTaxRates), ctx, store)
+}
+
+// ListTaxClasses mocks base method.
+func (m *MockTaxRepository) ListTaxClasses(ctx context.Context, skip, limit uint64) ([]models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaxClasses", ctx, skip, limit)
+	ret0, _ := ret[0].([]models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaxClasses indicates an expected call of ListTaxClasses.
+func (mr *MockTaxRepositoryMockRecorder) ListTaxClasses(ctx, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaxClasses", reflect.TypeOf((*MockTaxRepository)(nil).ListTaxClasses), ctx, skip, limit)
+}
+
+// ListTaxRates mocks base method.
+func (m *MockTaxRepository) ListTaxRates(ctx context.Context, taxClassID, skip, limit uint64) ([]models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaxRates", ctx, taxClassID, skip, limit)
+	ret0, _ := ret[0].([]models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaxRates indicates an expected call of ListTaxRates.
+func (mr *MockTaxRepositoryMockRecorder) ListTaxRates(ctx, taxClassID, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaxRates", reflect.TypeOf((*MockTaxRepository)(nil).ListTaxRates), ctx, taxClassID, skip, limit)
+}
+
+// UpdateTaxClass mocks base method.
+func (m *MockTaxRepository) UpdateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxClass", ctx, class)
+	ret0, _ := ret[0].(*models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaxClass indicates an expected call of UpdateTaxClass.
+func (mr *MockTaxRepositoryMockRecorder) UpdateTaxClass(ctx, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxClass", reflect.TypeOf((*MockTaxRepository)(nil).UpdateTaxClass), ctx, class)
+}
+
+// UpdateTaxRate mocks base method.
+func (m *MockTaxRepository) UpdateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRate", ctx, rate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaxRate indicates an expected call of UpdateTaxRate.
+func (mr *MockTaxRepositoryMockRecorder) UpdateTaxRate(ctx, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRate", reflect.TypeOf((*MockTaxRepository)(nil).UpdateTaxRate), ctx, rate)
+}
+
+// MockTaxService is a mock of TaxService interface.
+type MockTaxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxServiceMockRecorder
+}
+
+// MockTaxServiceMockRecorder is the mock recorder for MockTaxService.
+type MockTaxServiceMockRecorder struct {
+	mock *MockTaxService
+}
+
+// NewMockTaxService creates a new mock instance.
+func NewMockTaxService(ctrl *gomock.Controller) *MockTaxService {
+	mock := &MockTaxService{ctrl: ctrl}
+	mock.recorder = &MockTaxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxService) EXPECT() *MockTaxServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxClass mocks base method.
+func (m *MockTaxService) CreateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxClass", ctx, class)
+	ret0, _ := ret[0].(*models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxClass indicates an expected call of CreateTaxClass.
+func (mr *MockTaxServiceMockRecorder) CreateTaxClass(ctx, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxClass", reflect.TypeOf((*MockTaxService)(nil).CreateTaxClass), ctx, class)
+}
+
+// CreateTaxRate mocks base method.
+func (m *MockTaxService) CreateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRate", ctx, rate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRate indicates an expected call of CreateTaxRate.
+func (mr *MockTaxServiceMockRecorder) CreateTaxRate(ctx, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRate", reflect.TypeOf((*MockTaxService)(nil).CreateTaxRate), ctx, rate)
+}
+
+// DeleteTaxClass mocks base method.
+func (m *MockTaxService) DeleteTaxClass(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxClass", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaxClass indicates an expected call of DeleteTaxClass.
+func (mr *MockTaxServiceMockRecorder) DeleteTaxClass(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxClass", reflect.TypeOf((*MockTaxService)(nil).DeleteTaxClass), ctx, id)
+}
+
+// DeleteTaxRate mocks base method.
+func (m *MockTaxService) DeleteTaxRate(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaxRate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaxRate indicates an expected call of DeleteTaxRate.
+func (mr *MockTaxServiceMockRecorder) DeleteTaxRate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaxRate", reflect.TypeOf((*MockTaxService)(nil).DeleteTaxRate), ctx, id)
+}
+
+// GetTaxClass mocks base method.
+func (m *MockTaxService) GetTaxClass(ctx context.Context, id uint64) (*models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxClass", ctx, id)
+	ret0, _ := ret[0].(*models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxClass indicates an expected call of GetTaxClass.
+func (mr *MockTaxServiceMockRecorder) GetTaxClass(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxClass", reflect.TypeOf((*MockTaxService)(nil).GetTaxClass), ctx, id)
+}
+
+// GetTaxRate mocks base method.
+func (m *MockTaxService) GetTaxRate(ctx context.Context, id uint64) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRate", ctx, id)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRate indicates an expected call of GetTaxRate.
+func (mr *MockTaxServiceMockRecorder) GetTaxRate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRate", reflect.TypeOf((*MockTaxService)(nil).GetTaxRate), ctx, id)
+}
+
+// ListTaxClasses mocks base method.
+func (m *MockTaxService) ListTaxClasses(ctx context.Context, skip, limit uint64) ([]models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaxClasses", ctx, skip, limit)
+	ret0, _ := ret[0].([]models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaxClasses indicates an expected call of ListTaxClasses.
+func (mr *MockTaxServiceMockRecorder) ListTaxClasses(ctx, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaxClasses", reflect.TypeOf((*MockTaxService)(nil).ListTaxClasses), ctx, skip, limit)
+}
+
+// ListTaxRates mocks base method.
+func (m *MockTaxService) ListTaxRates(ctx context.Context, taxClassID, skip, limit uint64) ([]models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaxRates", ctx, taxClassID, skip, limit)
+	ret0, _ := ret[0].([]models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaxRates indicates an expected call of ListTaxRates.
+func (mr *MockTaxServiceMockRecorder) ListTaxRates(ctx, taxClassID, skip, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaxRates", reflect.TypeOf((*MockTaxService)(nil).ListTaxRates), ctx, taxClassID, skip, limit)
+}
+
+// UpdateTaxClass mocks base method.
+func (m *MockTaxService) UpdateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxClass", ctx, class)
+	ret0, _ := ret[0].(*models.TaxClass)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaxClass indicates an expected call of UpdateTaxClass.
+func (mr *MockTaxServiceMockRecorder) UpdateTaxClass(ctx, class any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxClass", reflect.TypeOf((*MockTaxService)(nil).UpdateTaxClass), ctx, class)
+}
+
+// UpdateTaxRate mocks base method.
+func (m *MockTaxService) UpdateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTaxRate", ctx, rate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTaxRate indicates an expected call of UpdateTaxRate.
+func (mr *MockTaxServiceMockRecorder) UpdateTaxRate(ctx, rate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaxRate", reflect.TypeOf((*MockTaxService)(nil).UpdateTaxRate), ctx, rate)
+}
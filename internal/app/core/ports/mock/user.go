@@ -11,9 +11,9 @@ package mock
 
 import (
 	context "context"
-	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
 	reflect "reflect"
 
+	models "github.com/bagashiz/go_hexagonal/internal/app/core/models"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -114,6 +114,21 @@ func (mr *MockUserRepositoryMockRecorder) ListUsers(ctx, skip, limit any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserRepository)(nil).ListUsers), ctx, skip, limit)
 }
 
+// PatchUser mocks base method.
+func (m *MockUserRepository) PatchUser(ctx context.Context, id, version uint64, changes []models.UserChange) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchUser", ctx, id, version, changes)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchUser indicates an expected call of PatchUser.
+func (mr *MockUserRepositoryMockRecorder) PatchUser(ctx, id, version, changes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUser", reflect.TypeOf((*MockUserRepository)(nil).PatchUser), ctx, id, version, changes)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserRepository) UpdateUser(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +211,21 @@ func (mr *MockUserServiceMockRecorder) ListUsers(ctx, skip, limit any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserService)(nil).ListUsers), ctx, skip, limit)
 }
 
+// PatchUser mocks base method.
+func (m *MockUserService) PatchUser(ctx context.Context, patch *models.UserPatch) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchUser", ctx, patch)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchUser indicates an expected call of PatchUser.
+func (mr *MockUserServiceMockRecorder) PatchUser(ctx, patch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUser", reflect.TypeOf((*MockUserService)(nil).PatchUser), ctx, patch)
+}
+
 // Register mocks base method.
 func (m *MockUserService) Register(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -212,16 +242,16 @@ func (mr *MockUserServiceMockRecorder) Register(ctx, user any) *gomock.Call {
 }
 
 // UpdateUser mocks base method.
-func (m *MockUserService) UpdateUser(ctx context.Context, user *models.User) (*models.User, error) {
+func (m *MockUserService) UpdateUser(ctx context.Context, user *models.User, currentPassword string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", ctx, user)
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, user, currentPassword)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserServiceMockRecorder) UpdateUser(ctx, user any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) UpdateUser(ctx, user, currentPassword any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserService)(nil).UpdateUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserService)(nil).UpdateUser), ctx, user, currentPassword)
 }
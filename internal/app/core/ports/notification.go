@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=notification.go -destination=mock/notification.go -package=mock
+
+// NotificationService is an interface for delivering notifications to users
+type NotificationService interface {
+	// Send delivers a notification to its recipient
+	Send(ctx context.Context, notification *models.Notification) error
+}
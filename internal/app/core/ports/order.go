@@ -0,0 +1,38 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=order.go -destination=mock/order.go -package=mock
+
+// OrderRepository is an interface for interacting with order-related data
+type OrderRepository interface {
+	// CreateOrder locks the ordered products, inserts the order with its items and discounts,
+	// records a sale movement for each item, counts a use of each applied promotion and
+	// records the points the customer redeemed and earned, all in a single transaction.
+	// It fails with ErrInsufficientPoints if the customer no longer has the redeemed points
+	CreateOrder(ctx context.Context, order *models.Order) (*models.Order, []models.StockMovement, error)
+	// SettleOrder locks an open order and updates it with its payment, recording the points its customer
+	// earns. It fails with ErrOrderNotOpen if the order was paid for or voided in the meantime
+	SettleOrder(ctx context.Context, order *models.Order) (*models.Order, error)
+	// GetOrderByID selects an order with its items by id
+	GetOrderByID(ctx context.Context, id uint64) (*models.Order, error)
+	// ListOrders selects a list of orders with their items with pagination, of a store unless storeID is zero
+	ListOrders(ctx context.Context, storeID, skip, limit uint64) ([]models.Order, error)
+	// ListCustomerOrders selects a list of orders of a customer with their items with pagination, newest first
+	ListCustomerOrders(ctx context.Context, customerID, skip, limit uint64) ([]models.Order, error)
+}
+
+// OrderService is an interface for interacting with order-related business logic
+type OrderService interface {
+	// CreateOrder prices the items, applies the promotions, checks the payment and places a new order
+	CreateOrder(ctx context.Context, order *models.Order) (*models.Order, error)
+	// SettleOrder takes the payment of an open order
+	SettleOrder(ctx context.Context, payment *models.Order) (*models.Order, error)
+	// GetOrder returns an order by id, if it was placed at the store unless storeID is zero
+	GetOrder(ctx context.Context, storeID, id uint64) (*models.Order, error)
+	// ListOrders returns a list of orders with pagination, of a store unless storeID is zero
+	ListOrders(ctx context.Context, storeID, skip, limit uint64) ([]models.Order, error)
+}
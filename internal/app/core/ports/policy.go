@@ -0,0 +1,43 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=policy.go -destination=mock/policy.go -package=mock
+
+// PolicyService is an interface for keeping the authorization policy in sync with the domain,
+// the grouping rules are kept in the casbin domain of the tenant of the context
+type PolicyService interface {
+	// AddUserRole adds a grouping rule that assigns a role to a user
+	AddUserRole(ctx context.Context, userID uint64, role models.UserRole) error
+	// UpdateUserRole replaces the grouping rule of a user from one role to another
+	UpdateUserRole(ctx context.Context, userID uint64, from, to models.UserRole) error
+	// DeleteUser removes every grouping rule of a user
+	DeleteUser(ctx context.Context, userID uint64) error
+	// DeleteRole removes a role with all of its policies and grouping rules
+	DeleteRole(ctx context.Context, role models.UserRole) error
+	// AddGroupMember adds a grouping rule that makes a user a member of a group
+	AddGroupMember(ctx context.Context, groupID, userID uint64) error
+	// DeleteGroupMember removes the grouping rule between a user and a group
+	DeleteGroupMember(ctx context.Context, groupID, userID uint64) error
+	// UpdateGroupParent replaces the grouping rule that nests a group inside a parent group
+	UpdateGroupParent(ctx context.Context, groupID uint64, from, to *uint64) error
+	// AddGroupRole adds a grouping rule that assigns a role to every member of a group
+	AddGroupRole(ctx context.Context, groupID uint64, role models.UserRole) error
+	// DeleteGroupRole removes the grouping rule between a group and a role
+	DeleteGroupRole(ctx context.Context, groupID uint64, role models.UserRole) error
+	// DeleteGroup removes every grouping rule a group takes part in
+	DeleteGroup(ctx context.Context, groupID uint64) error
+	// ListRules returns the rules of the filter's type, empty filter values match everything
+	ListRules(filter *models.PolicyRule) ([]models.PolicyRule, error)
+	// AddRule adds a rule, reporting false when it was already there
+	AddRule(rule *models.PolicyRule) (bool, error)
+	// RemoveRule removes a rule, reporting false when it was not there
+	RemoveRule(rule *models.PolicyRule) (bool, error)
+	// GetSubjectRoles returns the roles a subject has in a domain, directly and through other roles or groups
+	GetSubjectRoles(subject, domain string) (*models.SubjectRoles, error)
+	// Explain decides on a request as the router would, and returns the rules that allowed it
+	Explain(request *models.AuthzRequest) (*models.AuthzDecision, error)
+}
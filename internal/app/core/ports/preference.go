@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=preference.go -destination=mock/preference.go -package=mock
+
+// PreferenceRepository is an interface for interacting with preference-related data
+type PreferenceRepository interface {
+	// GetPreferences selects the preferences a user has set, without defaults
+	GetPreferences(ctx context.Context, userID uint64) (models.Preferences, error)
+	// SavePreferences stores the given preferences and deletes the reset ones in a single transaction
+	SavePreferences(ctx context.Context, userID uint64, set models.Preferences, reset []models.PreferenceKey) error
+}
+
+// PreferenceService is an interface for interacting with preference-related business logic
+type PreferenceService interface {
+	// GetPreferences returns all preferences of a user, with defaults for the ones not set
+	GetPreferences(ctx context.Context, userID uint64) (models.Preferences, error)
+	// ReplacePreferences sets all preferences of a user, the ones left out are reset to their defaults
+	ReplacePreferences(ctx context.Context, userID uint64, preferences models.Preferences) (models.Preferences, error)
+	// UpdatePreferences sets the given preferences of a user, leaving the others as they are
+	UpdatePreferences(ctx context.Context, userID uint64, preferences models.Preferences) (models.Preferences, error)
+}
@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"time"
+)
+
+//go:generate mockgen -source=promotion.go -destination=mock/promotion.go -package=mock
+
+// PromotionRepository is an interface for interacting with promotion-related data
+type PromotionRepository interface {
+	// CreatePromotion inserts a new promotion into the database
+	CreatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error)
+	// GetPromotionByID selects a promotion by id
+	GetPromotionByID(ctx context.Context, id uint64) (*models.Promotion, error)
+	// GetPromotionByCouponCode selects a promotion by its coupon code
+	GetPromotionByCouponCode(ctx context.Context, code string) (*models.Promotion, error)
+	// ListPromotions selects a list of promotions with pagination
+	ListPromotions(ctx context.Context, skip, limit uint64) ([]models.Promotion, error)
+	// ListAutomaticPromotions selects the active promotions without a coupon code
+	// whose validity window contains the given time
+	ListAutomaticPromotions(ctx context.Context, at time.Time) ([]models.Promotion, error)
+	// UpdatePromotion updates a promotion
+	UpdatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error)
+	// DeletePromotion deletes a promotion
+	DeletePromotion(ctx context.Context, id uint64) error
+}
+
+// PromotionService is an interface for interacting with promotion-related business logic
+type PromotionService interface {
+	// CreatePromotion creates a new promotion
+	CreatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error)
+	// GetPromotion returns a promotion by id
+	GetPromotion(ctx context.Context, id uint64) (*models.Promotion, error)
+	// ListPromotions returns a list of promotions with pagination
+	ListPromotions(ctx context.Context, skip, limit uint64) ([]models.Promotion, error)
+	// UpdatePromotion updates a promotion
+	UpdatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error)
+	// DeletePromotion deletes a promotion
+	DeletePromotion(ctx context.Context, id uint64) error
+}
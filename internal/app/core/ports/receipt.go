@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=receipt.go -destination=mock/receipt.go -package=mock
+
+// ReceiptRenderer is an interface for rendering receipts into documents
+type ReceiptRenderer interface {
+	// Render renders a receipt in its format with the configured branding and tax lines
+	Render(ctx context.Context, receipt *models.Receipt) ([]byte, error)
+}
+
+// ReceiptService is an interface for interacting with receipt-related business logic
+type ReceiptService interface {
+	// GetReceipt renders the receipt of an order, if it was placed at the store unless storeID is zero
+	GetReceipt(ctx context.Context, storeID, orderID uint64, format models.ReceiptFormat, width models.ReceiptPaperWidth) ([]byte, error)
+}
@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=refund.go -destination=mock/refund.go -package=mock
+
+// RefundRepository is an interface for interacting with refund-related data
+type RefundRepository interface {
+	// CreateRefund locks the order, inserts the refund with its items, records a return
+	// movement for each of them and records its points in the ledger of the customer, all in
+	// a single transaction. It fails with ErrVersionConflict unless the order still has as
+	// many refunds as the given count
+	CreateRefund(ctx context.Context, refund *models.Refund, previousRefunds int) (*models.Refund, error)
+	// GetRefundByID selects a refund with its items by id
+	GetRefundByID(ctx context.Context, id uint64) (*models.Refund, error)
+	// ListRefunds selects a list of refunds with their items with pagination,
+	// of a store unless storeID is zero and of an order unless orderID is zero
+	ListRefunds(ctx context.Context, storeID, orderID, skip, limit uint64) ([]models.Refund, error)
+	// ListOrderRefunds selects every refund of an order with its items
+	ListOrderRefunds(ctx context.Context, orderID uint64) ([]models.Refund, error)
+}
+
+// RefundService is an interface for interacting with refund-related business logic
+type RefundService interface {
+	// RefundOrder pays back some or all of what is left of a paid order and puts the items back into stock
+	RefundOrder(ctx context.Context, refund *models.Refund) (*models.Refund, error)
+	// VoidOrder cancels an open order and puts its items back into stock
+	VoidOrder(ctx context.Context, refund *models.Refund) (*models.Refund, error)
+	// GetRefund returns a refund by id, if it was made at the store unless storeID is zero
+	GetRefund(ctx context.Context, storeID, id uint64) (*models.Refund, error)
+	// ListRefunds returns a list of refunds with pagination,
+	// of a store unless storeID is zero and of an order unless orderID is zero
+	ListRefunds(ctx context.Context, storeID, orderID, skip, limit uint64) ([]models.Refund, error)
+}
@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=report.go -destination=mock/report.go -package=mock
+
+// ReportRepository is an interface for aggregating sales data
+type ReportRepository interface {
+	// RevenueByPeriod sums the orders of each period that has any, in order
+	RevenueByPeriod(ctx context.Context, filter *models.ReportFilter, period models.ReportPeriod) ([]models.RevenueReport, error)
+	// TopProducts sums the sales of each product, the highest revenue first
+	TopProducts(ctx context.Context, filter *models.ReportFilter, limit uint64) ([]models.ProductSalesReport, error)
+	// SalesByCashier sums the orders of each cashier, the highest revenue first
+	SalesByCashier(ctx context.Context, filter *models.ReportFilter) ([]models.CashierSalesReport, error)
+	// SalesByPaymentMethod sums the orders of each payment method
+	SalesByPaymentMethod(ctx context.Context, filter *models.ReportFilter) ([]models.PaymentMethodReport, error)
+	// Discounts sums the discounts of each promotion, the highest amount first
+	Discounts(ctx context.Context, filter *models.ReportFilter) ([]models.DiscountReport, error)
+}
+
+// ReportService is an interface for interacting with sales reports
+type ReportService interface {
+	// RevenueByPeriod returns the takings of every period in the range, including the ones without sales
+	RevenueByPeriod(ctx context.Context, filter *models.ReportFilter, period models.ReportPeriod) ([]models.RevenueReport, error)
+	// TopProducts returns the best selling products by revenue
+	TopProducts(ctx context.Context, filter *models.ReportFilter, limit uint64) ([]models.ProductSalesReport, error)
+	// SalesByCashier returns the takings of each cashier
+	SalesByCashier(ctx context.Context, filter *models.ReportFilter) ([]models.CashierSalesReport, error)
+	// SalesByPaymentMethod returns the takings of each payment method
+	SalesByPaymentMethod(ctx context.Context, filter *models.ReportFilter) ([]models.PaymentMethodReport, error)
+	// Discounts returns how much each promotion took off the orders
+	Discounts(ctx context.Context, filter *models.ReportFilter) ([]models.DiscountReport, error)
+}
@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=role.go -destination=mock/role.go -package=mock
+
+// RoleRepository is an interface for interacting with role-related data
+type RoleRepository interface {
+	// CreateRole inserts a new role into the database
+	CreateRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	// GetRoleByID selects a role by id
+	GetRoleByID(ctx context.Context, id uint64) (*models.Role, error)
+	// GetRoleByName selects a role by name
+	GetRoleByName(ctx context.Context, name models.UserRole) (*models.Role, error)
+	// ListRoles selects a list of roles with pagination
+	ListRoles(ctx context.Context, skip, limit uint64) ([]models.Role, error)
+	// UpdateRole updates a role
+	UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	// DeleteRole deletes a role
+	DeleteRole(ctx context.Context, id uint64) error
+	// CountUsersByRole counts the users assigned to a role
+	CountUsersByRole(ctx context.Context, name models.UserRole) (uint64, error)
+}
+
+// RoleService is an interface for interacting with role-related business logic
+type RoleService interface {
+	// CreateRole creates a new role
+	CreateRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	// GetRole returns a role by id
+	GetRole(ctx context.Context, id uint64) (*models.Role, error)
+	// ListRoles returns a list of roles with pagination
+	ListRoles(ctx context.Context, skip, limit uint64) ([]models.Role, error)
+	// UpdateRole updates a role
+	UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	// DeleteRole deletes a role that is no longer assigned to any user
+	DeleteRole(ctx context.Context, id uint64) error
+}
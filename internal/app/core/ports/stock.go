@@ -0,0 +1,34 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=stock.go -destination=mock/stock.go -package=mock
+
+// StockRepository is an interface for interacting with the stock ledger
+type StockRepository interface {
+	// RecordMovement locks the product, appends a movement to its ledger and
+	// applies it to the product stock, all in a single transaction
+	RecordMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error)
+	// TransferStock appends the movements out of one store and into another to the ledger and applies
+	// them to the stock, all in a single transaction, and returns the one out of the store
+	TransferStock(ctx context.Context, transfer *models.StockMovement) (*models.StockMovement, error)
+	// ListMovements selects a list of stock movements matching a filter with pagination, the newest first
+	ListMovements(ctx context.Context, filter *models.StockMovementFilter, skip, limit uint64) ([]models.StockMovement, error)
+}
+
+// StockService is an interface for interacting with stock-related business logic
+type StockService interface {
+	// RecordMovement records a manual stock movement, such as a restock or an adjustment
+	RecordMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error)
+	// ListMovements returns a list of stock movements matching a filter with pagination
+	ListMovements(ctx context.Context, filter *models.StockMovementFilter, skip, limit uint64) ([]models.StockMovement, error)
+}
+
+// StockAlerter is an interface for raising alerts about product stock
+type StockAlerter interface {
+	// LowStock is called when a movement takes the stock of a product down to its low-stock threshold
+	LowStock(ctx context.Context, product *models.Product, movement *models.StockMovement) error
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=storage.go -destination=mock/storage.go -package=mock
+
+// FileRepository is an interface for interacting with stored files
+type FileRepository interface {
+	// Put stores a file under the given key, replacing any file already there
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Delete removes the file stored under the given key, a missing file is not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public url of the file stored under the given key
+	URL(key string) string
+}
@@ -0,0 +1,48 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=store.go -destination=mock/store.go -package=mock
+
+// StoreRepository is an interface for interacting with store-related data
+type StoreRepository interface {
+	// CreateStore inserts a new store into the database
+	CreateStore(ctx context.Context, store *models.Store) (*models.Store, error)
+	// GetStoreByID selects a store by id
+	GetStoreByID(ctx context.Context, id uint64) (*models.Store, error)
+	// ListStores selects a list of stores with pagination
+	ListStores(ctx context.Context, skip, limit uint64) ([]models.Store, error)
+	// UpdateStore updates a store
+	UpdateStore(ctx context.Context, store *models.Store) (*models.Store, error)
+	// DeleteStore deletes a store
+	DeleteStore(ctx context.Context, id uint64) error
+	// AssignUser assigns a user to a store, in place of the store they were assigned to
+	AssignUser(ctx context.Context, storeID, userID uint64) error
+	// ListUsers selects the users assigned to a store with pagination
+	ListUsers(ctx context.Context, storeID, skip, limit uint64) ([]models.User, error)
+	// UnassignUser removes a user from a store
+	UnassignUser(ctx context.Context, storeID, userID uint64) error
+}
+
+// StoreService is an interface for interacting with store-related business logic
+type StoreService interface {
+	// CreateStore creates a new store
+	CreateStore(ctx context.Context, store *models.Store) (*models.Store, error)
+	// GetStore returns a store by id
+	GetStore(ctx context.Context, id uint64) (*models.Store, error)
+	// ListStores returns a list of stores with pagination
+	ListStores(ctx context.Context, skip, limit uint64) ([]models.Store, error)
+	// UpdateStore updates a store
+	UpdateStore(ctx context.Context, store *models.Store) (*models.Store, error)
+	// DeleteStore deletes a store
+	DeleteStore(ctx context.Context, id uint64) error
+	// AssignUser assigns a user to a store
+	AssignUser(ctx context.Context, storeID, userID uint64) error
+	// ListUsers returns the users assigned to a store with pagination
+	ListUsers(ctx context.Context, storeID, skip, limit uint64) ([]models.User, error)
+	// UnassignUser removes a user from a store
+	UnassignUser(ctx context.Context, storeID, userID uint64) error
+}
@@ -0,0 +1,58 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=tax.go -destination=mock/tax.go -package=mock
+
+// TaxRepository is an interface for interacting with tax-related data
+type TaxRepository interface {
+	// CreateTaxClass inserts a new tax class into the database
+	CreateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error)
+	// GetTaxClassByID selects a tax class by id
+	GetTaxClassByID(ctx context.Context, id uint64) (*models.TaxClass, error)
+	// ListTaxClasses selects a list of tax classes with pagination
+	ListTaxClasses(ctx context.Context, skip, limit uint64) ([]models.TaxClass, error)
+	// UpdateTaxClass updates a tax class
+	UpdateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error)
+	// DeleteTaxClass deletes a tax class with its rates
+	DeleteTaxClass(ctx context.Context, id uint64) error
+	// CreateTaxRate inserts a new tax rate into the database
+	CreateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error)
+	// GetTaxRateByID selects a tax rate by id
+	GetTaxRateByID(ctx context.Context, id uint64) (*models.TaxRate, error)
+	// ListTaxRates selects a list of tax rates with pagination, of a tax class unless taxClassID is zero
+	ListTaxRates(ctx context.Context, taxClassID, skip, limit uint64) ([]models.TaxRate, error)
+	// UpdateTaxRate updates a tax rate
+	UpdateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error)
+	// DeleteTaxRate deletes a tax rate
+	DeleteTaxRate(ctx context.Context, id uint64) error
+	// ListStoreTaxRates selects every tax rate of a store, of its region or of neither
+	ListStoreTaxRates(ctx context.Context, store *models.Store) ([]models.TaxRate, error)
+}
+
+// TaxService is an interface for interacting with tax-related business logic
+type TaxService interface {
+	// CreateTaxClass creates a new tax class
+	CreateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error)
+	// GetTaxClass returns a tax class by id
+	GetTaxClass(ctx context.Context, id uint64) (*models.TaxClass, error)
+	// ListTaxClasses returns a list of tax classes with pagination
+	ListTaxClasses(ctx context.Context, skip, limit uint64) ([]models.TaxClass, error)
+	// UpdateTaxClass updates a tax class
+	UpdateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error)
+	// DeleteTaxClass deletes a tax class that no longer has products, with its rates
+	DeleteTaxClass(ctx context.Context, id uint64) error
+	// CreateTaxRate validates and creates a new tax rate
+	CreateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error)
+	// GetTaxRate returns a tax rate by id
+	GetTaxRate(ctx context.Context, id uint64) (*models.TaxRate, error)
+	// ListTaxRates returns a list of tax rates with pagination, of a tax class unless taxClassID is zero
+	ListTaxRates(ctx context.Context, taxClassID, skip, limit uint64) ([]models.TaxRate, error)
+	// UpdateTaxRate validates and replaces a tax rate
+	UpdateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error)
+	// DeleteTaxRate deletes a tax rate
+	DeleteTaxRate(ctx context.Context, id uint64) error
+}
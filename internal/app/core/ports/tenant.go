@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+)
+
+//go:generate mockgen -source=tenant.go -destination=mock/tenant.go -package=mock
+
+// TenantRepository is an interface for interacting with tenant-related data
+type TenantRepository interface {
+	// CreateTenant inserts a new tenant into the database
+	CreateTenant(ctx context.Context, tenant *models.Tenant) (*models.Tenant, error)
+	// GetTenantByID selects a tenant by id
+	GetTenantByID(ctx context.Context, id uint64) (*models.Tenant, error)
+	// ListTenants selects a list of tenants with pagination
+	ListTenants(ctx context.Context, skip, limit uint64) ([]models.Tenant, error)
+	// DeleteTenant deletes a tenant
+	DeleteTenant(ctx context.Context, id uint64) error
+}
+
+// TenantService is an interface for interacting with tenant-related business logic
+type TenantService interface {
+	// CreateTenant creates a new tenant and registers its first admin
+	CreateTenant(ctx context.Context, tenant *models.Tenant, admin *models.User) (*models.Tenant, *models.User, error)
+	// GetTenant returns a tenant by id, to the users of that tenant or of the default one
+	GetTenant(ctx context.Context, id uint64) (*models.Tenant, error)
+	// LookupTenant returns a tenant by id to the middleware, before the request acts on any tenant
+	LookupTenant(ctx context.Context, id uint64) (*models.Tenant, error)
+	// ListTenants returns a list of tenants with pagination
+	ListTenants(ctx context.Context, skip, limit uint64) ([]models.Tenant, error)
+}
@@ -19,6 +19,8 @@ type UserRepository interface {
 	ListUsers(ctx context.Context, skip, limit uint64) ([]models.User, error)
 	// UpdateUser updates a user
 	UpdateUser(ctx context.Context, user *models.User) (*models.User, error)
+	// PatchUser applies a change set to a user if its version is still current
+	PatchUser(ctx context.Context, id, version uint64, changes []models.UserChange) (*models.User, error)
 	// DeleteUser deletes a user
 	DeleteUser(ctx context.Context, id uint64) error
 }
@@ -31,8 +33,10 @@ type UserService interface {
 	GetUser(ctx context.Context, id uint64) (*models.User, error)
 	// ListUsers returns a list of users with pagination
 	ListUsers(ctx context.Context, skip, limit uint64) ([]models.User, error)
-	// UpdateUser updates a user
-	UpdateUser(ctx context.Context, user *models.User) (*models.User, error)
+	// UpdateUser updates a user, users changing their own password give the current one
+	UpdateUser(ctx context.Context, user *models.User, currentPassword string) (*models.User, error)
+	// PatchUser partially updates a user with a merge patch
+	PatchUser(ctx context.Context, patch *models.UserPatch) (*models.User, error)
 	// DeleteUser deletes a user
 	DeleteUser(ctx context.Context, id uint64) error
 }
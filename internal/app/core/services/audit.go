@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+)
+
+/**
+ * AuditService implements ports.AuditService interface
+ * and provides an access to the audit repository
+ */
+type AuditService struct {
+	repo ports.AuditRepository
+}
+
+// NewAuditService creates a new audit services instance
+func NewAuditService(repo ports.AuditRepository) *AuditService {
+	return &AuditService{
+		repo,
+	}
+}
+
+// RecordUserChange appends an audit record of a user change
+func (as *AuditService) RecordUserChange(ctx context.Context, action models.AuditAction, before, after *models.User) error {
+	record := &models.AuditRecord{
+		Action:  action,
+		Changes: diffUser(before, after),
+	}
+
+	if after != nil {
+		record.TargetID = after.ID
+	} else if before != nil {
+		record.TargetID = before.ID
+	}
+
+	return as.createRecord(ctx, record)
+}
+
+// RecordPolicyChange appends an audit record of a policy rule being added or removed, it
+// is part of the history of the user the rule is about, if the rule is about a single user
+func (as *AuditService) RecordPolicyChange(ctx context.Context, action models.AuditAction, rule *models.PolicyRule) error {
+	record := &models.AuditRecord{
+		Action:  action,
+		Changes: diffPolicyRule(action, rule),
+	}
+
+	if userID, ok := models.ParseUserSubject(rule.Subject); ok {
+		record.TargetID = userID
+	}
+
+	return as.createRecord(ctx, record)
+}
+
+// createRecord appends an audit record, the actor and the origin of the change are taken
+// from the request context when it has them
+func (as *AuditService) createRecord(ctx context.Context, record *models.AuditRecord) error {
+	if payload, ok := _constant.Payload(ctx); ok {
+		record.ActorID = &payload.UserID
+	}
+
+	if meta, ok := ctx.Value(_constant.RequestMetaKey).(*models.RequestMeta); ok {
+		record.IP = meta.IP
+		record.RequestID = meta.ID
+	}
+
+	_, err := as.repo.CreateAuditRecord(ctx, record)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// ListUserHistory lists the audit records of a user, newest first
+func (as *AuditService) ListUserHistory(ctx context.Context, filter *models.AuditFilter, skip, limit uint64) ([]models.AuditRecord, error) {
+	records, err := as.repo.ListAuditRecords(ctx, filter, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return records, nil
+}
+
+// diffPolicyRule lists the values of a rule, as the values after it was added or before it was removed
+func diffPolicyRule(action models.AuditAction, rule *models.PolicyRule) []models.AuditChange {
+	changes := []models.AuditChange{}
+
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"type", string(rule.Type)},
+		{"subject", rule.Subject},
+		{"domain", rule.Domain},
+		{"object", rule.Object},
+		{"action", rule.Action},
+		{"role", rule.Role},
+	}
+
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+
+		change := models.AuditChange{Field: field.name}
+		if action == models.AuditPolicyRemove {
+			change.Before = field.value
+		} else {
+			change.After = field.value
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// diffUser lists the fields that differ between two states of a user, with password hashes redacted
+func diffUser(before, after *models.User) []models.AuditChange {
+	changes := []models.AuditChange{}
+
+	var from, to models.User
+	if before != nil {
+		from = *before
+	}
+	if after != nil {
+		to = *after
+	}
+
+	fields := []struct {
+		name     string
+		from, to any
+	}{
+		{string(models.UserNameField), from.Name, to.Name},
+		{string(models.UserEmailField), from.Email, to.Email},
+		{string(models.UserRoleField), from.Role, to.Role},
+		{string(models.UserAvatarField), from.Avatar, to.Avatar},
+	}
+
+	for _, field := range fields {
+		if field.from == field.to {
+			continue
+		}
+
+		change := models.AuditChange{Field: field.name}
+		if before != nil {
+			change.Before = field.from
+		}
+		if after != nil {
+			change.After = field.to
+		}
+		changes = append(changes, change)
+	}
+
+	// only the fact that the password changed is recorded, never its hash
+	if from.Password != to.Password {
+		change := models.AuditChange{Field: string(models.UserPasswordField)}
+		if before != nil {
+			change.Before = models.RedactedValue
+		}
+		if after != nil {
+			change.After = models.RedactedValue
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
@@ -0,0 +1,222 @@
+package services_test
+
+import (
+	"context"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type recordUserChangeTestedInput struct {
+	ctx    context.Context
+	action models.AuditAction
+	before *models.User
+	after  *models.User
+}
+
+type recordUserChangeExpectedOutput struct {
+	err error
+}
+
+func TestAuditService_RecordUserChange(t *testing.T) {
+	actorID := gofakeit.Uint64()
+	meta := &models.RequestMeta{
+		ID: gofakeit.UUID(),
+		IP: gofakeit.IPv4Address(),
+	}
+
+	ctx := context.Background()
+	ctx = _constant.WithPayload(ctx, &models.TokenPayload{UserID: actorID})
+	ctx = context.WithValue(ctx, _constant.RequestMetaKey, meta)
+
+	before := &models.User{
+		ID:       gofakeit.Uint64(),
+		Name:     gofakeit.Name(),
+		Email:    gofakeit.Email(),
+		Password: "$2a$10$old",
+		Role:     models.Cashier,
+	}
+	after := &models.User{
+		ID:       before.ID,
+		Name:     before.Name,
+		Email:    before.Email,
+		Password: "$2a$10$new",
+		Role:     models.Admin,
+	}
+
+	testCases := []struct {
+		desc     string
+		mocks    func(auditRepo *mock2.MockAuditRepository)
+		input    recordUserChangeTestedInput
+		expected recordUserChangeExpectedOutput
+	}{
+		{
+			desc: "Success_Update",
+			mocks: func(auditRepo *mock2.MockAuditRepository) {
+				auditRepo.EXPECT().
+					CreateAuditRecord(gomock.Any(), gomock.Eq(&models.AuditRecord{
+						ActorID:  &actorID,
+						TargetID: before.ID,
+						Action:   models.AuditUserUpdate,
+						Changes: []models.AuditChange{
+							{Field: "role", Before: models.Cashier, After: models.Admin},
+							{Field: "password", Before: models.RedactedValue, After: models.RedactedValue},
+						},
+						IP:        meta.IP,
+						RequestID: meta.ID,
+					})).
+					Return(&models.AuditRecord{}, nil)
+			},
+			input: recordUserChangeTestedInput{
+				ctx:    ctx,
+				action: models.AuditUserUpdate,
+				before: before,
+				after:  after,
+			},
+			expected: recordUserChangeExpectedOutput{
+				err: nil,
+			},
+		},
+		{
+			desc: "Success_CreateWithoutActor",
+			mocks: func(auditRepo *mock2.MockAuditRepository) {
+				auditRepo.EXPECT().
+					CreateAuditRecord(gomock.Any(), gomock.Eq(&models.AuditRecord{
+						TargetID: after.ID,
+						Action:   models.AuditUserCreate,
+						Changes: []models.AuditChange{
+							{Field: "name", After: after.Name},
+							{Field: "email", After: after.Email},
+							{Field: "role", After: after.Role},
+							{Field: "password", After: models.RedactedValue},
+						},
+					})).
+					Return(&models.AuditRecord{}, nil)
+			},
+			input: recordUserChangeTestedInput{
+				ctx:    context.Background(),
+				action: models.AuditUserCreate,
+				after:  after,
+			},
+			expected: recordUserChangeExpectedOutput{
+				err: nil,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(auditRepo *mock2.MockAuditRepository) {
+				auditRepo.EXPECT().
+					CreateAuditRecord(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrInternal)
+			},
+			input: recordUserChangeTestedInput{
+				ctx:    ctx,
+				action: models.AuditUserDelete,
+				before: before,
+			},
+			expected: recordUserChangeExpectedOutput{
+				err: models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditRepo := mock2.NewMockAuditRepository(ctrl)
+
+			tc.mocks(auditRepo)
+
+			auditService := services.NewAuditService(auditRepo)
+
+			err := auditService.RecordUserChange(tc.input.ctx, tc.input.action, tc.input.before, tc.input.after)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+		})
+	}
+}
+
+func TestAuditService_RecordPolicyChange(t *testing.T) {
+	actorID := gofakeit.Uint64()
+	userID := gofakeit.Uint64()
+
+	ctx := context.Background()
+	ctx = _constant.WithPayload(ctx, &models.TokenPayload{UserID: actorID})
+
+	testCases := []struct {
+		desc     string
+		action   models.AuditAction
+		rule     *models.PolicyRule
+		expected *models.AuditRecord
+	}{
+		{
+			// a rule about a single user is part of that user's history
+			desc:   "Success_AddUserGrouping",
+			action: models.AuditPolicyAdd,
+			rule: &models.PolicyRule{
+				Type:    models.PolicyGrouping,
+				Subject: models.UserSubject(userID),
+				Role:    string(models.Manager),
+			},
+			expected: &models.AuditRecord{
+				ActorID:  &actorID,
+				TargetID: userID,
+				Action:   models.AuditPolicyAdd,
+				Changes: []models.AuditChange{
+					{Field: "type", After: "g"},
+					{Field: "subject", After: models.UserSubject(userID)},
+					{Field: "role", After: "manager"},
+				},
+			},
+		},
+		{
+			desc:   "Success_RemoveRolePolicy",
+			action: models.AuditPolicyRemove,
+			rule: &models.PolicyRule{
+				Type:    models.PolicyPermission,
+				Subject: string(models.Cashier),
+				Object:  "/v1/products/",
+				Action:  "POST",
+			},
+			expected: &models.AuditRecord{
+				ActorID:  &actorID,
+				TargetID: 0,
+				Action:   models.AuditPolicyRemove,
+				Changes: []models.AuditChange{
+					{Field: "type", Before: "p"},
+					{Field: "subject", Before: "cashier"},
+					{Field: "object", Before: "/v1/products/"},
+					{Field: "action", Before: "POST"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			auditRepo := mock2.NewMockAuditRepository(ctrl)
+			auditRepo.EXPECT().
+				CreateAuditRecord(gomock.Any(), gomock.Eq(tc.expected)).
+				Return(&models.AuditRecord{}, nil)
+
+			auditService := services.NewAuditService(auditRepo)
+
+			err := auditService.RecordPolicyChange(ctx, tc.action, tc.rule)
+			assert.NoError(t, err, "Error mismatch")
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"net/http"
+	"strings"
+)
+
+// policyValueMaxLength is the length of the casbin_rule value columns
+const policyValueMaxLength = 100
+
+// anyPolicyAction is the action of a permission rule that allows every method
+const anyPolicyAction = ".*"
+
+// policyActions are the actions a permission rule may allow, the HTTP methods the router
+// enforces and the action that gives access to every store. A rule may allow several of
+// them as "GET|POST", which the matcher treats as an anchored regex
+var policyActions = map[string]bool{
+	http.MethodGet:         true,
+	http.MethodPost:        true,
+	http.MethodPut:         true,
+	http.MethodPatch:       true,
+	http.MethodDelete:      true,
+	models.AllStoresAction: true,
+}
+
+/**
+ * AuthzService implements ports.AuthzService interface
+ * and provides an access to the policy and audit services
+ */
+type AuthzService struct {
+	policy ports.PolicyService
+	audit  ports.AuditService
+}
+
+// NewAuthzService creates a new authz services instance
+func NewAuthzService(policy ports.PolicyService, audit ports.AuditService) *AuthzService {
+	return &AuthzService{
+		policy,
+		audit,
+	}
+}
+
+// ListRules lists the permission or grouping rules of the tenant matching the filter, with the permission
+// rules of every tenant first. The enforcer keeps the whole policy in memory so the page is taken from
+// every matching rule
+func (as *AuthzService) ListRules(ctx context.Context, filter *models.PolicyRule, skip, limit uint64) ([]models.PolicyRule, error) {
+	if filter.Type != models.PolicyPermission && filter.Type != models.PolicyGrouping {
+		return nil, models.ErrInvalidPolicy
+	}
+
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := []string{dom}
+	if filter.Type == models.PolicyPermission {
+		domains = []string{models.AnyDomain, dom}
+	}
+
+	var rules []models.PolicyRule
+	for _, domain := range domains {
+		domainFilter := *filter
+		domainFilter.Domain = domain
+
+		domainRules, err := as.policy.ListRules(&domainFilter)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		rules = append(rules, domainRules...)
+	}
+
+	if skip > 0 {
+		skip--
+	}
+	start := min(skip*limit, uint64(len(rules)))
+	end := min(start+limit, uint64(len(rules)))
+
+	return rules[start:end], nil
+}
+
+// AddRule adds a permission or grouping rule to the tenant, which takes effect on the next request.
+// Only the admins of the default tenant grant permissions on what every tenant shares. The rule is
+// taken out again when it cannot be audited, so no change goes unrecorded
+func (as *AuthzService) AddRule(ctx context.Context, rule *models.PolicyRule) (*models.PolicyRule, error) {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rule.Domain = dom
+
+	normalizePolicyRule(rule)
+	if !validPolicyRule(rule) {
+		return nil, models.ErrInvalidPolicy
+	}
+
+	if rule.Type == models.PolicyPermission && !inDefaultTenant(ctx) && sharedPolicyObject(rule.Object) {
+		return nil, models.ErrForbidden
+	}
+
+	added, err := as.policy.AddRule(rule)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+	if !added {
+		return nil, models.ErrConflictingData
+	}
+
+	err = as.audit.RecordPolicyChange(ctx, models.AuditPolicyAdd, rule)
+	if err != nil {
+		_, undoErr := as.policy.RemoveRule(rule)
+		if undoErr != nil {
+			return nil, models.ErrInternal
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// RemoveRule removes a permission or grouping rule of the tenant, the rules of every tenant are left
+// as they are. The admin role's permissions on the authz API and the admin's own admin role are
+// protected, removing them would lock everyone out of the policy. The rule is put back when its
+// removal cannot be audited
+func (as *AuthzService) RemoveRule(ctx context.Context, rule *models.PolicyRule) error {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return err
+	}
+	rule.Domain = dom
+
+	normalizePolicyRule(rule)
+	if !validPolicyRule(rule) {
+		return models.ErrInvalidPolicy
+	}
+
+	if protectedPolicyRule(ctx, rule) {
+		return models.ErrProtectedPolicy
+	}
+
+	removed, err := as.policy.RemoveRule(rule)
+	if err != nil {
+		return models.ErrInternal
+	}
+	if !removed {
+		return models.ErrDataNotFound
+	}
+
+	err = as.audit.RecordPolicyChange(ctx, models.AuditPolicyRemove, rule)
+	if err != nil {
+		_, undoErr := as.policy.AddRule(rule)
+		if undoErr != nil {
+			return models.ErrInternal
+		}
+		return err
+	}
+
+	return nil
+}
+
+// protectedPolicyRule reports whether a rule lets the admin of the request administer the policy,
+// the admin role's permissions on the authz API or the grouping that gives the admin that role
+func protectedPolicyRule(ctx context.Context, rule *models.PolicyRule) bool {
+	switch rule.Type {
+	case models.PolicyPermission:
+		return rule.Subject == string(models.Admin) &&
+			strings.HasPrefix(rule.Object, models.AuthzObjectPrefix)
+	case models.PolicyGrouping:
+		if rule.Role != string(models.Admin) {
+			return false
+		}
+		// without the admin of the request, every admin grouping is kept as it may be theirs
+		payload, ok := _constant.Payload(ctx)
+		return !ok || rule.Subject == models.UserSubject(payload.UserID)
+	default:
+		return false
+	}
+}
+
+// GetSubjectRoles gets the roles a subject has in the tenant, directly and through other roles or groups
+func (as *AuthzService) GetSubjectRoles(ctx context.Context, subject string) (*models.SubjectRoles, error) {
+	subject = strings.TrimSpace(subject)
+	if !validPolicyValue(subject) {
+		return nil, models.ErrInvalidPolicy
+	}
+
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := as.policy.GetSubjectRoles(subject, dom)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return roles, nil
+}
+
+// CheckRequest decides on a request of a subject in the tenant as the router would, with the rule that
+// allowed it and the roles of the subject, to tell which rule a denied request is missing
+func (as *AuthzService) CheckRequest(ctx context.Context, request *models.AuthzRequest) (*models.AuthzDecision, error) {
+	dom, err := _constant.TenantDomain(ctx)
+	if err != nil {
+		return nil, err
+	}
+	request.Domain = dom
+
+	request.Subject = strings.TrimSpace(request.Subject)
+	request.Object = strings.TrimSpace(request.Object)
+	request.Action = strings.TrimSpace(request.Action)
+	request.Owner = strings.TrimSpace(request.Owner)
+	if upper := strings.ToUpper(request.Action); policyActions[upper] {
+		request.Action = upper
+	}
+
+	// a request acts in one way, the router never enforces alternatives or regexes
+	if !validPolicyValue(request.Subject) ||
+		!validPolicyValue(request.Object) ||
+		!policyActions[request.Action] ||
+		(request.Owner != "" && !validPolicyValue(request.Owner)) {
+		return nil, models.ErrInvalidPolicy
+	}
+
+	decision, err := as.policy.Explain(request)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return decision, nil
+}
+
+// sharedObjectPrefixes are the paths of what every tenant shares
+var sharedObjectPrefixes = []string{models.TenantsObjectPrefix, models.RolesObjectPrefix}
+
+// sharedPolicyObject reports whether a rule's object may match a path of what every tenant shares.
+// A pattern is taken up to its first wildcard or parameter, which could stand for any of them
+func sharedPolicyObject(object string) bool {
+	literal, _, isPattern := strings.Cut(object, "*")
+	if i := strings.Index(literal, ":"); i >= 0 {
+		literal, isPattern = literal[:i], true
+	}
+
+	for _, prefix := range sharedObjectPrefixes {
+		if strings.HasPrefix(object, prefix) || (isPattern && strings.HasPrefix(prefix, literal)) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePolicyRule trims the values of a rule and upper-cases the HTTP methods it allows
+func normalizePolicyRule(rule *models.PolicyRule) {
+	rule.Subject = strings.TrimSpace(rule.Subject)
+	rule.Object = strings.TrimSpace(rule.Object)
+	rule.Role = strings.TrimSpace(rule.Role)
+
+	actions := strings.Split(strings.TrimSpace(rule.Action), "|")
+	for i, action := range actions {
+		if upper := strings.ToUpper(action); policyActions[upper] {
+			actions[i] = upper
+		}
+	}
+	rule.Action = strings.Join(actions, "|")
+}
+
+// validPolicyRule reports whether a rule has exactly the values of its type, each of them valid.
+// The owner subject is resolved per request, so it cannot be granted to nor given roles
+func validPolicyRule(rule *models.PolicyRule) bool {
+	switch rule.Type {
+	case models.PolicyPermission:
+		return rule.Role == "" &&
+			validPolicyValue(rule.Subject) &&
+			validPolicyValue(rule.Object) &&
+			validPolicyAction(rule.Action)
+	case models.PolicyGrouping:
+		return rule.Object == "" && rule.Action == "" &&
+			validPolicyValue(rule.Subject) &&
+			validPolicyValue(rule.Role) &&
+			rule.Subject != rule.Role &&
+			rule.Subject != models.OwnerSubject &&
+			rule.Role != models.OwnerSubject
+	default:
+		return false
+	}
+}
+
+// validPolicyAction reports whether an action allows every method, or only known actions.
+// Other regexes are refused, as the matcher would run them on every request
+func validPolicyAction(action string) bool {
+	if action == anyPolicyAction {
+		return true
+	}
+
+	for _, part := range strings.Split(action, "|") {
+		if !policyActions[part] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validPolicyValue reports whether a value fits in a casbin_rule column and has no separators
+func validPolicyValue(value string) bool {
+	return value != "" &&
+		len(value) <= policyValueMaxLength &&
+		!strings.ContainsAny(value, ", \t\r\n")
+}
@@ -0,0 +1,754 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthzService_ListRules(t *testing.T) {
+	ctx := _constant.WithTenantID(context.Background(), 1)
+
+	filter := &models.PolicyRule{
+		Type:    models.PolicyPermission,
+		Subject: "manager",
+	}
+	rules := make([]models.PolicyRule, 12)
+	for i := range rules {
+		rules[i] = models.PolicyRule{Type: models.PolicyPermission, Subject: "manager", Object: "/v1/x/", Action: "GET"}
+	}
+
+	testCases := []struct {
+		desc     string
+		skip     uint64
+		limit    uint64
+		expected int
+	}{
+		{desc: "Success_FirstPage", skip: 1, limit: 5, expected: 5},
+		{desc: "Success_LastPage", skip: 3, limit: 5, expected: 2},
+		{desc: "Success_PastTheEnd", skip: 4, limit: 5, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			policy := mock2.NewMockPolicyService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			authzService := services.NewAuthzService(policy, audit)
+
+			// the permissions of every tenant come before the tenant's own
+			policy.EXPECT().
+				ListRules(gomock.Eq(&models.PolicyRule{Type: filter.Type, Subject: filter.Subject, Domain: models.AnyDomain})).
+				Return(rules[:8], nil)
+			policy.EXPECT().
+				ListRules(gomock.Eq(&models.PolicyRule{Type: filter.Type, Subject: filter.Subject, Domain: "tenant:1"})).
+				Return(rules[8:], nil)
+
+			page, err := authzService.ListRules(ctx, filter, tc.skip, tc.limit)
+			assert.NoError(t, err, "Error mismatch")
+			assert.Len(t, page, tc.expected, "Rules mismatch")
+		})
+	}
+}
+
+func TestAuthzService_AddRule(t *testing.T) {
+	ctx := _constant.WithTenantID(context.Background(), 1)
+
+	type expectedOutput struct {
+		rule *models.PolicyRule
+		err  error
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			policy *mock2.MockPolicyService,
+			audit *mock2.MockAuditService,
+		)
+		tenantID uint64
+		input    *models.PolicyRule
+		expected expectedOutput
+	}{
+		{
+			desc: "Success_Policy",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				rule := &models.PolicyRule{
+					Type:    models.PolicyPermission,
+					Subject: "manager",
+					Domain:  "tenant:1",
+					Object:  "/v1/products/",
+					Action:  "POST",
+				}
+				policy.EXPECT().
+					AddRule(gomock.Eq(rule)).
+					Return(true, nil)
+				audit.EXPECT().
+					RecordPolicyChange(gomock.Any(), gomock.Eq(models.AuditPolicyAdd), gomock.Eq(rule)).
+					Return(nil)
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyPermission,
+				Subject: " manager",
+				Object:  "/v1/products/ ",
+				Action:  "post",
+			},
+			expected: expectedOutput{
+				rule: &models.PolicyRule{
+					Type:    models.PolicyPermission,
+					Subject: "manager",
+					Domain:  "tenant:1",
+					Object:  "/v1/products/",
+					Action:  "POST",
+				},
+				err: nil,
+			},
+		},
+		{
+			desc: "Success_PatternPolicy",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					AddRule(gomock.Any()).
+					Return(true, nil)
+				audit.EXPECT().
+					RecordPolicyChange(gomock.Any(), gomock.Eq(models.AuditPolicyAdd), gomock.Any()).
+					Return(nil)
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyPermission,
+				Subject: "manager",
+				Object:  "/v1/products/:id",
+				Action:  "get|Put",
+			},
+			expected: expectedOutput{
+				rule: &models.PolicyRule{
+					Type:    models.PolicyPermission,
+					Subject: "manager",
+					Domain:  "tenant:1",
+					Object:  "/v1/products/:id",
+					Action:  "GET|PUT",
+				},
+				err: nil,
+			},
+		},
+		{
+			desc: "Success_Grouping",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					AddRule(gomock.Any()).
+					Return(true, nil)
+				audit.EXPECT().
+					RecordPolicyChange(gomock.Any(), gomock.Eq(models.AuditPolicyAdd), gomock.Any()).
+					Return(nil)
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyGrouping,
+				Subject: "user:1",
+				Role:    "manager",
+			},
+			expected: expectedOutput{
+				rule: &models.PolicyRule{
+					Type:    models.PolicyGrouping,
+					Subject: "user:1",
+					Role:    "manager",
+					Domain:  "tenant:1",
+				},
+				err: nil,
+			},
+		},
+		{
+			desc: "Fail_UnknownAction",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyPermission,
+				Subject: "manager",
+				Object:  "/v1/products/",
+				Action:  "FETCH",
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrInvalidPolicy,
+			},
+		},
+		{
+			// only alternatives of known actions are accepted as regexes
+			desc: "Fail_ActionRegex",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyPermission,
+				Subject: "manager",
+				Object:  "/v1/products/:id",
+				Action:  "(G|P)+",
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrInvalidPolicy,
+			},
+		},
+		{
+			// casbin separates values with commas in its text formats
+			desc: "Fail_Separator",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyGrouping,
+				Subject: "user:1,user:2",
+				Role:    "manager",
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrInvalidPolicy,
+			},
+		},
+		{
+			desc: "Fail_GroupingToItself",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyGrouping,
+				Subject: "manager",
+				Role:    "manager",
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrInvalidPolicy,
+			},
+		},
+		{
+			// the owner of a resource is resolved per request, granting it would apply everywhere
+			desc: "Fail_GroupingToOwner",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyGrouping,
+				Subject: "user:1",
+				Role:    models.OwnerSubject,
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrInvalidPolicy,
+			},
+		},
+		{
+			// a rule that cannot be audited is taken out again
+			desc: "Fail_AuditError",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				rule := &models.PolicyRule{
+					Type:    models.PolicyGrouping,
+					Subject: "user:1",
+					Role:    "manager",
+					Domain:  "tenant:1",
+				}
+				policy.EXPECT().
+					AddRule(gomock.Eq(rule)).
+					Return(true, nil)
+				audit.EXPECT().
+					RecordPolicyChange(gomock.Any(), gomock.Eq(models.AuditPolicyAdd), gomock.Eq(rule)).
+					Return(models.ErrInternal)
+				policy.EXPECT().
+					RemoveRule(gomock.Eq(rule)).
+					Return(true, nil)
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyGrouping,
+				Subject: "user:1",
+				Role:    "manager",
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_AlreadyExists",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					AddRule(gomock.Any()).
+					Return(false, nil)
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyGrouping,
+				Subject: "user:1",
+				Role:    "manager",
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					AddRule(gomock.Any()).
+					Return(false, errors.New("connection refused"))
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyGrouping,
+				Subject: "user:1",
+				Role:    "manager",
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrInternal,
+			},
+		},
+		{
+			// the admins of the default tenant grant the rules on what every tenant shares
+			desc: "Success_SharedObject",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				rule := &models.PolicyRule{
+					Type:    models.PolicyPermission,
+					Subject: "auditor",
+					Domain:  "tenant:1",
+					Object:  "/v1/tenants/:id",
+					Action:  "GET",
+				}
+				policy.EXPECT().
+					AddRule(gomock.Eq(rule)).
+					Return(true, nil)
+				audit.EXPECT().
+					RecordPolicyChange(gomock.Any(), gomock.Eq(models.AuditPolicyAdd), gomock.Eq(rule)).
+					Return(nil)
+			},
+			input: &models.PolicyRule{
+				Type:    models.PolicyPermission,
+				Subject: "auditor",
+				Object:  "/v1/tenants/:id",
+				Action:  "GET",
+			},
+			expected: expectedOutput{
+				rule: &models.PolicyRule{
+					Type:    models.PolicyPermission,
+					Subject: "auditor",
+					Domain:  "tenant:1",
+					Object:  "/v1/tenants/:id",
+					Action:  "GET",
+				},
+				err: nil,
+			},
+		},
+		{
+			// the admins of the other tenants would reach the records of every tenant
+			desc: "Fail_SharedObject",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			tenantID: 2,
+			input: &models.PolicyRule{
+				Type:    models.PolicyPermission,
+				Subject: "admin",
+				Object:  "/v1/tenants/:id",
+				Action:  "GET",
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrForbidden,
+			},
+		},
+		{
+			// a pattern that could stand for a shared path is refused too
+			desc: "Fail_SharedObjectPattern",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			tenantID: 2,
+			input: &models.PolicyRule{
+				Type:    models.PolicyPermission,
+				Subject: "admin",
+				Object:  "/v1/*",
+				Action:  ".*",
+			},
+			expected: expectedOutput{
+				rule: nil,
+				err:  models.ErrForbidden,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			policy := mock2.NewMockPolicyService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(policy, audit)
+
+			authzService := services.NewAuthzService(policy, audit)
+
+			reqCtx := ctx
+			if tc.tenantID != 0 {
+				reqCtx = _constant.WithTenantID(ctx, tc.tenantID)
+			}
+
+			rule, err := authzService.AddRule(reqCtx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.rule, rule, "Rule mismatch")
+		})
+	}
+}
+
+func TestAuthzService_RemoveRule(t *testing.T) {
+	ctx := _constant.WithTenantID(context.Background(), 1)
+	ctx = _constant.WithPayload(ctx, &models.TokenPayload{UserID: 1, Role: models.Admin, TenantID: 1})
+
+	permission := func(subject, object string) *models.PolicyRule {
+		return &models.PolicyRule{
+			Type:    models.PolicyPermission,
+			Subject: subject,
+			Domain:  "tenant:1",
+			Object:  object,
+			Action:  "DELETE",
+		}
+	}
+	grouping := func(subject, role string) *models.PolicyRule {
+		return &models.PolicyRule{
+			Type:    models.PolicyGrouping,
+			Subject: subject,
+			Domain:  "tenant:1",
+			Role:    role,
+		}
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			policy *mock2.MockPolicyService,
+			audit *mock2.MockAuditService,
+		)
+		input    *models.PolicyRule
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					RemoveRule(gomock.Eq(permission("manager", "/v1/products/"))).
+					Return(true, nil)
+				audit.EXPECT().
+					RecordPolicyChange(gomock.Any(), gomock.Eq(models.AuditPolicyRemove), gomock.Eq(permission("manager", "/v1/products/"))).
+					Return(nil)
+			},
+			input:    permission("manager", "/v1/products/"),
+			expected: nil,
+		},
+		{
+			// another admin can take the role away, the last one keeps it
+			desc: "Success_OtherAdmin",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					RemoveRule(gomock.Eq(grouping("user:2", "admin"))).
+					Return(true, nil)
+				audit.EXPECT().
+					RecordPolicyChange(gomock.Any(), gomock.Eq(models.AuditPolicyRemove), gomock.Eq(grouping("user:2", "admin"))).
+					Return(nil)
+			},
+			input:    grouping("user:2", "admin"),
+			expected: nil,
+		},
+		{
+			// without it, nobody could put the rules back through the API
+			desc: "Fail_ProtectedPolicy",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input:    permission("admin", "/v1/authz/policies"),
+			expected: models.ErrProtectedPolicy,
+		},
+		{
+			// the admin would lose the permissions to give the role back
+			desc: "Fail_ProtectedOwnAdmin",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input:    grouping("user:1", "admin"),
+			expected: models.ErrProtectedPolicy,
+		},
+		{
+			// a removal that cannot be audited is put back
+			desc: "Fail_AuditError",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					RemoveRule(gomock.Eq(permission("manager", "/v1/products/"))).
+					Return(true, nil)
+				audit.EXPECT().
+					RecordPolicyChange(gomock.Any(), gomock.Eq(models.AuditPolicyRemove), gomock.Eq(permission("manager", "/v1/products/"))).
+					Return(models.ErrInternal)
+				policy.EXPECT().
+					AddRule(gomock.Eq(permission("manager", "/v1/products/"))).
+					Return(true, nil)
+			},
+			input:    permission("manager", "/v1/products/"),
+			expected: models.ErrInternal,
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					RemoveRule(gomock.Any()).
+					Return(false, nil)
+			},
+			input:    permission("manager", "/v1/products/"),
+			expected: models.ErrDataNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			policy := mock2.NewMockPolicyService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(policy, audit)
+
+			authzService := services.NewAuthzService(policy, audit)
+
+			err := authzService.RemoveRule(ctx, tc.input)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+
+	// without the admin of the request, any admin grouping may be their own and is kept
+	t.Run("Fail_ProtectedNoPayload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		authzService := services.NewAuthzService(mock2.NewMockPolicyService(ctrl), mock2.NewMockAuditService(ctrl))
+
+		err := authzService.RemoveRule(_constant.WithTenantID(context.Background(), 1), grouping("user:2", "admin"))
+		assert.Equal(t, models.ErrProtectedPolicy, err, "Error mismatch")
+	})
+}
+
+func TestAuthzService_CheckRequest(t *testing.T) {
+	ctx := _constant.WithTenantID(context.Background(), 1)
+
+	request := &models.AuthzRequest{
+		Subject: "user:1",
+		Domain:  "tenant:1",
+		Object:  "/v1/products/42",
+		Action:  "PUT",
+	}
+	decision := &models.AuthzDecision{
+		Request: *request,
+		Allowed: true,
+		Rules: []models.PolicyRule{
+			{Type: models.PolicyPermission, Subject: "manager", Domain: models.AnyDomain, Object: "/v1/products/:id", Action: "GET|PUT"},
+		},
+		Roles: []string{"manager"},
+	}
+
+	type expectedOutput struct {
+		decision *models.AuthzDecision
+		err      error
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			policy *mock2.MockPolicyService,
+			audit *mock2.MockAuditService,
+		)
+		input    *models.AuthzRequest
+		expected expectedOutput
+	}{
+		{
+			// the request is decided in the tenant it is made in, whatever domain it names
+			desc: "Success",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					Explain(gomock.Eq(request)).
+					Return(decision, nil)
+			},
+			input: &models.AuthzRequest{
+				Subject: "user:1 ",
+				Domain:  "tenant:2",
+				Object:  "/v1/products/42",
+				Action:  "put",
+			},
+			expected: expectedOutput{
+				decision: decision,
+				err:      nil,
+			},
+		},
+		{
+			desc: "Success_AllStores",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					Explain(gomock.Eq(&models.AuthzRequest{
+						Subject: "user:1",
+						Domain:  "tenant:1",
+						Object:  models.AllStoresObject,
+						Action:  models.AllStoresAction,
+					})).
+					Return(&models.AuthzDecision{}, nil)
+			},
+			input: &models.AuthzRequest{
+				Subject: "user:1",
+				Object:  models.AllStoresObject,
+				Action:  models.AllStoresAction,
+			},
+			expected: expectedOutput{
+				decision: &models.AuthzDecision{},
+				err:      nil,
+			},
+		},
+		{
+			// the router enforces one method at a time
+			desc: "Fail_ActionAlternatives",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: &models.AuthzRequest{
+				Subject: "user:1",
+				Object:  "/v1/products/42",
+				Action:  "GET|PUT",
+			},
+			expected: expectedOutput{
+				decision: nil,
+				err:      models.ErrInvalidPolicy,
+			},
+		},
+		{
+			desc: "Fail_EmptySubject",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: &models.AuthzRequest{
+				Object: "/v1/products/42",
+				Action: "GET",
+			},
+			expected: expectedOutput{
+				decision: nil,
+				err:      models.ErrInvalidPolicy,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				policy *mock2.MockPolicyService,
+				audit *mock2.MockAuditService,
+			) {
+				policy.EXPECT().
+					Explain(gomock.Any()).
+					Return(nil, errors.New("invalid regex"))
+			},
+			input: &models.AuthzRequest{
+				Subject: "user:1",
+				Object:  "/v1/products/42",
+				Action:  "GET",
+			},
+			expected: expectedOutput{
+				decision: nil,
+				err:      models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			policy := mock2.NewMockPolicyService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(policy, audit)
+
+			authzService := services.NewAuthzService(policy, audit)
+
+			decision, err := authzService.CheckRequest(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.decision, decision, "Decision mismatch")
+		})
+	}
+}
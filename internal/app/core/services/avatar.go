@@ -0,0 +1,218 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"net/http"
+	"path"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * AvatarService implements ports.AvatarService interface
+ * and provides an access to the user and file repositories,
+ * cache and audit services
+ */
+type AvatarService struct {
+	repo    ports.UserRepository
+	storage ports.FileRepository
+	cache   ports.CacheRepository
+	audit   ports.AuditService
+}
+
+// NewAvatarService creates a new avatar services instance
+func NewAvatarService(
+	repo ports.UserRepository,
+	storage ports.FileRepository,
+	cache ports.CacheRepository,
+	audit ports.AuditService,
+) *AvatarService {
+	return &AvatarService{
+		repo,
+		storage,
+		cache,
+		audit,
+	}
+}
+
+// UploadAvatar validates an image, stores its square thumbnails and sets it as the user's avatar,
+// the thumbnails of the previous avatar are removed afterwards
+func (as *AvatarService) UploadAvatar(ctx context.Context, id uint64, data []byte) (*models.User, error) {
+	if len(data) > models.AvatarMaxSize {
+		return nil, models.ErrFileTooLarge
+	}
+
+	// the content is sniffed rather than trusting the type declared by the client
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(models.AvatarContentTypes, contentType) {
+		return nil, models.ErrUnsupportedMediaType
+	}
+
+	// dimensions are checked before decoding so a small file cannot claim a huge canvas
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, models.ErrUnsupportedMediaType
+	}
+	if config.Width > models.AvatarMaxDimension || config.Height > models.AvatarMaxDimension {
+		return nil, models.ErrFileTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, models.ErrUnsupportedMediaType
+	}
+
+	existingUser, err := as.repo.GetUserByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	avatarID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	key := avatarKey(id, avatarID.String())
+
+	for _, size := range models.AvatarSizes {
+		var buf bytes.Buffer
+
+		err = png.Encode(&buf, utils.Thumbnail(img, size))
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+
+		err = as.storage.Put(ctx, thumbnailKey(key, size), "image/png", buf.Bytes())
+		if err != nil {
+			as.deleteThumbnails(ctx, key)
+			return nil, models.ErrInternal
+		}
+	}
+
+	user, err := as.repo.PatchUser(ctx, id, existingUser.Version, []models.UserChange{{
+		Field: models.UserAvatarField,
+		From:  existingUser.Avatar,
+		To:    as.storage.URL(key),
+	}})
+	if err != nil {
+		as.deleteThumbnails(ctx, key)
+		if err == models.ErrVersionConflict {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	err = as.refreshCache(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingUser.Avatar != "" {
+		as.deleteThumbnails(ctx, avatarKey(id, path.Base(existingUser.Avatar)))
+	}
+
+	err = as.audit.RecordUserChange(ctx, models.AuditUserUpdate, existingUser, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteAvatar clears the user's avatar and removes its thumbnails
+func (as *AvatarService) DeleteAvatar(ctx context.Context, id uint64) (*models.User, error) {
+	existingUser, err := as.repo.GetUserByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	if existingUser.Avatar == "" {
+		return nil, models.ErrDataNotFound
+	}
+
+	user, err := as.repo.PatchUser(ctx, id, existingUser.Version, []models.UserChange{{
+		Field: models.UserAvatarField,
+		From:  existingUser.Avatar,
+	}})
+	if err != nil {
+		if err == models.ErrVersionConflict {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	err = as.refreshCache(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	as.deleteThumbnails(ctx, avatarKey(id, path.Base(existingUser.Avatar)))
+
+	err = as.audit.RecordUserChange(ctx, models.AuditUserUpdate, existingUser, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// refreshCache replaces the cached user and invalidates the cached user lists
+func (as *AvatarService) refreshCache(ctx context.Context, user *models.User) error {
+	cacheKey := utils.GenerateCacheKey("user", user.ID)
+
+	err := as.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	userSerialized, err := utils.Serialize(user)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = as.cache.Set(ctx, cacheKey, userSerialized, 0)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = as.cache.DeleteByPrefix(ctx, "users:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// deleteThumbnails removes every thumbnail of an avatar. It is best effort,
+// a file left behind is unreachable from any user and only wastes storage
+func (as *AvatarService) deleteThumbnails(ctx context.Context, key string) {
+	for _, size := range models.AvatarSizes {
+		_ = as.storage.Delete(ctx, thumbnailKey(key, size))
+	}
+}
+
+// avatarKey returns the storage key prefix of one uploaded avatar of a user
+func avatarKey(userID uint64, avatarID string) string {
+	return fmt.Sprintf("avatars/%d/%s", userID, avatarID)
+}
+
+// thumbnailKey returns the storage key of an avatar thumbnail, matching models.User.AvatarURL
+func thumbnailKey(key string, size int) string {
+	return fmt.Sprintf("%s/%d.png", key, size)
+}
@@ -0,0 +1,514 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"image"
+	"image/color"
+	"image/png"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// encodeTestImage encodes a solid image of the given dimensions as png
+func encodeTestImage(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 80, B: 40, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	assert.NoError(t, err)
+
+	return buf.Bytes()
+}
+
+// isThumbnail matches a png thumbnail stored under an avatar key of the user
+func isThumbnail(userID uint64, size int) gomock.Matcher {
+	pattern := regexp.MustCompile(fmt.Sprintf(`^avatars/%d/[0-9a-f-]{36}/%d\.png$`, userID, size))
+	return gomock.Cond(func(key string) bool {
+		return pattern.MatchString(key)
+	})
+}
+
+// isSquarePNG matches png data of a square image with the given edge length
+func isSquarePNG(size int) gomock.Matcher {
+	return gomock.Cond(func(data []byte) bool {
+		config, err := png.DecodeConfig(bytes.NewReader(data))
+		return err == nil && config.Width == size && config.Height == size
+	})
+}
+
+type uploadAvatarTestedInput struct {
+	id   uint64
+	data []byte
+}
+
+type avatarExpectedOutput struct {
+	user *models.User
+	err  error
+}
+
+func TestAvatarService_UploadAvatar(t *testing.T) {
+	ctx := context.Background()
+	data := encodeTestImage(t, 300, 200)
+
+	user := &models.User{
+		ID:      gofakeit.Uint64(),
+		Name:    gofakeit.Name(),
+		Email:   gofakeit.Email(),
+		Role:    models.Cashier,
+		Version: 3,
+	}
+	userWithAvatar := &models.User{
+		ID:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: 3,
+		Avatar:  fmt.Sprintf("http://cdn.example.com/avatars/%d/old-avatar", user.ID),
+	}
+	updatedUser := &models.User{
+		ID:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: 4,
+		Avatar:  fmt.Sprintf("http://cdn.example.com/avatars/%d/new-avatar", user.ID),
+	}
+
+	cacheKey := util2.GenerateCacheKey("user", user.ID)
+	userSerialized, _ := util2.Serialize(updatedUser)
+	ttl := time.Duration(0)
+
+	storedThumbnails := func(storage *mock2.MockFileRepository) {
+		for _, size := range models.AvatarSizes {
+			storage.EXPECT().
+				Put(gomock.Any(), isThumbnail(user.ID, size), gomock.Eq("image/png"), isSquarePNG(size)).
+				Return(nil)
+		}
+		storage.EXPECT().
+			URL(gomock.Any()).
+			DoAndReturn(func(key string) string {
+				return "http://cdn.example.com/" + key
+			})
+	}
+	refreshedCache := func(cache *mock2.MockCacheRepository) {
+		cache.EXPECT().
+			Delete(gomock.Any(), gomock.Eq(cacheKey)).
+			Return(nil)
+		cache.EXPECT().
+			Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(userSerialized), gomock.Eq(ttl)).
+			Return(nil)
+		cache.EXPECT().
+			DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+			Return(nil)
+	}
+	isAvatarChange := func(from string) gomock.Matcher {
+		return gomock.Cond(func(changes []models.UserChange) bool {
+			return len(changes) == 1 &&
+				changes[0].Field == models.UserAvatarField &&
+				changes[0].From == from &&
+				regexp.MustCompile(fmt.Sprintf(`^http://cdn\.example\.com/avatars/%d/[0-9a-f-]{36}$`, user.ID)).
+					MatchString(changes[0].To.(string))
+		})
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock2.MockUserRepository,
+			storage *mock2.MockFileRepository,
+			cache *mock2.MockCacheRepository,
+			audit *mock2.MockAuditService,
+		)
+		input    uploadAvatarTestedInput
+		expected avatarExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(user, nil)
+				storedThumbnails(storage)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(user.Version), isAvatarChange("")).
+					Return(updatedUser, nil)
+				refreshedCache(cache)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserUpdate), gomock.Eq(user), gomock.Eq(updatedUser)).
+					Return(nil)
+			},
+			input: uploadAvatarTestedInput{
+				id:   user.ID,
+				data: data,
+			},
+			expected: avatarExpectedOutput{
+				user: updatedUser,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Success_ReplacesPrevious",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(userWithAvatar, nil)
+				storedThumbnails(storage)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(user.Version), isAvatarChange(userWithAvatar.Avatar)).
+					Return(updatedUser, nil)
+				refreshedCache(cache)
+				for _, size := range models.AvatarSizes {
+					storage.EXPECT().
+						Delete(gomock.Any(), gomock.Eq(fmt.Sprintf("avatars/%d/old-avatar/%d.png", user.ID, size))).
+						Return(nil)
+				}
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserUpdate), gomock.Eq(userWithAvatar), gomock.Eq(updatedUser)).
+					Return(nil)
+			},
+			input: uploadAvatarTestedInput{
+				id:   user.ID,
+				data: data,
+			},
+			expected: avatarExpectedOutput{
+				user: updatedUser,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_TooLarge",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: uploadAvatarTestedInput{
+				id:   user.ID,
+				data: make([]byte, models.AvatarMaxSize+1),
+			},
+			expected: avatarExpectedOutput{
+				user: nil,
+				err:  models.ErrFileTooLarge,
+			},
+		},
+		{
+			desc: "Fail_DimensionsTooLarge",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: uploadAvatarTestedInput{
+				id:   user.ID,
+				data: encodeTestImage(t, models.AvatarMaxDimension+1, 1),
+			},
+			expected: avatarExpectedOutput{
+				user: nil,
+				err:  models.ErrFileTooLarge,
+			},
+		},
+		{
+			desc: "Fail_UnsupportedType",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: uploadAvatarTestedInput{
+				id:   user.ID,
+				data: []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"),
+			},
+			expected: avatarExpectedOutput{
+				user: nil,
+				err:  models.ErrUnsupportedMediaType,
+			},
+		},
+		{
+			desc: "Fail_CorruptImage",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: uploadAvatarTestedInput{
+				id:   user.ID,
+				data: append([]byte("\x89PNG\r\n\x1a\n"), []byte("not really a png")...),
+			},
+			expected: avatarExpectedOutput{
+				user: nil,
+				err:  models.ErrUnsupportedMediaType,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: uploadAvatarTestedInput{
+				id:   user.ID,
+				data: data,
+			},
+			expected: avatarExpectedOutput{
+				user: nil,
+				err:  models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_StorageError",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(user, nil)
+				storage.EXPECT().
+					Put(gomock.Any(), isThumbnail(user.ID, models.AvatarSizes[0]), gomock.Any(), gomock.Any()).
+					Return(errors.New("storage unavailable"))
+				storage.EXPECT().
+					Delete(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(len(models.AvatarSizes))
+			},
+			input: uploadAvatarTestedInput{
+				id:   user.ID,
+				data: data,
+			},
+			expected: avatarExpectedOutput{
+				user: nil,
+				err:  models.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_StaleVersion",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(user, nil)
+				storedThumbnails(storage)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(user.Version), isAvatarChange("")).
+					Return(nil, models.ErrVersionConflict)
+				for _, size := range models.AvatarSizes {
+					storage.EXPECT().
+						Delete(gomock.Any(), isThumbnail(user.ID, size)).
+						Return(nil)
+				}
+			},
+			input: uploadAvatarTestedInput{
+				id:   user.ID,
+				data: data,
+			},
+			expected: avatarExpectedOutput{
+				user: nil,
+				err:  models.ErrVersionConflict,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			storage := mock2.NewMockFileRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(userRepo, storage, cache, audit)
+
+			avatarService := services.NewAvatarService(userRepo, storage, cache, audit)
+
+			user, err := avatarService.UploadAvatar(ctx, tc.input.id, tc.input.data)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.user, user, "User mismatch")
+		})
+	}
+}
+
+func TestAvatarService_DeleteAvatar(t *testing.T) {
+	ctx := context.Background()
+
+	user := &models.User{
+		ID:      gofakeit.Uint64(),
+		Name:    gofakeit.Name(),
+		Email:   gofakeit.Email(),
+		Role:    models.Cashier,
+		Version: 4,
+		Avatar:  "http://cdn.example.com/avatars/1/old-avatar",
+	}
+	userWithoutAvatar := &models.User{
+		ID:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		Role:    user.Role,
+		Version: 5,
+	}
+
+	cacheKey := util2.GenerateCacheKey("user", user.ID)
+	userSerialized, _ := util2.Serialize(userWithoutAvatar)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock2.MockUserRepository,
+			storage *mock2.MockFileRepository,
+			cache *mock2.MockCacheRepository,
+			audit *mock2.MockAuditService,
+		)
+		input    uint64
+		expected avatarExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(user, nil)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(user.Version), gomock.Eq([]models.UserChange{
+						{Field: models.UserAvatarField, From: user.Avatar},
+					})).
+					Return(userWithoutAvatar, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(userSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				for _, size := range models.AvatarSizes {
+					storage.EXPECT().
+						Delete(gomock.Any(), gomock.Eq(fmt.Sprintf("avatars/%d/old-avatar/%d.png", user.ID, size))).
+						Return(nil)
+				}
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserUpdate), gomock.Eq(user), gomock.Eq(userWithoutAvatar)).
+					Return(nil)
+			},
+			input: user.ID,
+			expected: avatarExpectedOutput{
+				user: userWithoutAvatar,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_NoAvatar",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(userWithoutAvatar, nil)
+			},
+			input: user.ID,
+			expected: avatarExpectedOutput{
+				user: nil,
+				err:  models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				storage *mock2.MockFileRepository,
+				cache *mock2.MockCacheRepository,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(nil, models.ErrInternal)
+			},
+			input: user.ID,
+			expected: avatarExpectedOutput{
+				user: nil,
+				err:  models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			storage := mock2.NewMockFileRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(userRepo, storage, cache, audit)
+
+			avatarService := services.NewAvatarService(userRepo, storage, cache, audit)
+
+			user, err := avatarService.DeleteAvatar(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.user, user, "User mismatch")
+		})
+	}
+}
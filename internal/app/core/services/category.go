@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+)
+
+/**
+ * CategoryService implements ports.CategoryService interface
+ * and provides an access to the category repositories
+ * and cache service
+ */
+type CategoryService struct {
+	repo  ports.CategoryRepository
+	cache ports.CacheRepository
+}
+
+// NewCategoryService creates a new category services instance
+func NewCategoryService(repo ports.CategoryRepository, cache ports.CacheRepository) *CategoryService {
+	return &CategoryService{
+		repo,
+		cache,
+	}
+}
+
+// CreateCategory creates a new category
+func (cs *CategoryService) CreateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	category, err := cs.repo.CreateCategory(ctx, category)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("category", category.ID)
+	categorySerialized, err := utils.Serialize(category)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cs.cache.Set(ctx, cacheKey, categorySerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cs.cache.DeleteByPrefix(ctx, "categories:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return category, nil
+}
+
+// GetCategory gets a category by ID
+func (cs *CategoryService) GetCategory(ctx context.Context, id uint64) (*models.Category, error) {
+	var category *models.Category
+
+	cacheKey := utils.GenerateCacheKey("category", id)
+	cachedCategory, err := cs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedCategory, &category)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return category, nil
+	}
+
+	category, err = cs.repo.GetCategoryByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	categorySerialized, err := utils.Serialize(category)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cs.cache.Set(ctx, cacheKey, categorySerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return category, nil
+}
+
+// ListCategories lists all categories
+func (cs *CategoryService) ListCategories(ctx context.Context, skip, limit uint64) ([]models.Category, error) {
+	var categories []models.Category
+
+	params := utils.GenerateCacheKeyParams(skip, limit)
+	cacheKey := utils.GenerateCacheKey("categories", params)
+
+	cachedCategories, err := cs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedCategories, &categories)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return categories, nil
+	}
+
+	categories, err = cs.repo.ListCategories(ctx, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	categoriesSerialized, err := utils.Serialize(categories)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cs.cache.Set(ctx, cacheKey, categoriesSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return categories, nil
+}
+
+// UpdateCategory renames a category
+func (cs *CategoryService) UpdateCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	existingCategory, err := cs.repo.GetCategoryByID(ctx, category.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	emptyData := category.Name == ""
+	sameData := existingCategory.Name == category.Name
+	if emptyData || sameData {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	category, err = cs.repo.UpdateCategory(ctx, category)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("category", category.ID)
+
+	err = cs.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cs.cache.DeleteByPrefix(ctx, "categories:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return category, nil
+}
+
+// DeleteCategory deletes a category by ID, refusing categories that still have products
+func (cs *CategoryService) DeleteCategory(ctx context.Context, id uint64) error {
+	_, err := cs.repo.GetCategoryByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = cs.repo.DeleteCategory(ctx, id)
+	if err != nil {
+		if err == models.ErrCategoryInUse {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("category", id)
+
+	err = cs.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = cs.cache.DeleteByPrefix(ctx, "categories:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
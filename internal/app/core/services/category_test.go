@@ -0,0 +1,329 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type categoryExpectedOutput struct {
+	category *models.Category
+	err      error
+}
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	ctx := context.Background()
+	categoryInput := &models.Category{
+		Name: gofakeit.ProductCategory(),
+	}
+	categoryOutput := &models.Category{
+		ID:        gofakeit.Uint64(),
+		Name:      categoryInput.Name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	cacheKey := util2.GenerateCacheKey("category", categoryOutput.ID)
+	categorySerialized, _ := util2.Serialize(categoryOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			categoryRepo *mock2.MockCategoryRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected categoryExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Eq(categoryInput)).
+					Return(categoryOutput, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(categorySerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("categories:*")).
+					Return(nil)
+			},
+			expected: categoryExpectedOutput{
+				category: categoryOutput,
+				err:      nil,
+			},
+		},
+		{
+			desc: "Fail_DuplicateData",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Eq(categoryInput)).
+					Return(nil, models.ErrConflictingData)
+			},
+			expected: categoryExpectedOutput{
+				category: nil,
+				err:      models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					CreateCategory(gomock.Any(), gomock.Eq(categoryInput)).
+					Return(nil, errors.New("connection refused"))
+			},
+			expected: categoryExpectedOutput{
+				category: nil,
+				err:      models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			categoryRepo := mock2.NewMockCategoryRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(categoryRepo, cache)
+
+			categoryService := services.NewCategoryService(categoryRepo, cache)
+
+			category, err := categoryService.CreateCategory(ctx, categoryInput)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.category, category, "Category mismatch")
+		})
+	}
+}
+
+func TestCategoryService_UpdateCategory(t *testing.T) {
+	ctx := context.Background()
+	categoryID := gofakeit.Uint64()
+	existingCategory := &models.Category{
+		ID:   categoryID,
+		Name: "Beverages",
+	}
+	categoryOutput := &models.Category{
+		ID:   categoryID,
+		Name: "Drinks",
+	}
+
+	cacheKey := util2.GenerateCacheKey("category", categoryID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			categoryRepo *mock2.MockCategoryRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    *models.Category
+		expected categoryExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Return(categoryOutput, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("categories:*")).
+					Return(nil)
+			},
+			input: &models.Category{ID: categoryID, Name: "Drinks"},
+			expected: categoryExpectedOutput{
+				category: categoryOutput,
+				err:      nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: &models.Category{ID: categoryID, Name: "Drinks"},
+			expected: categoryExpectedOutput{
+				category: nil,
+				err:      models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_SameName",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Return(existingCategory, nil)
+			},
+			input: &models.Category{ID: categoryID, Name: "Beverages"},
+			expected: categoryExpectedOutput{
+				category: nil,
+				err:      models.ErrNoUpdatedData,
+			},
+		},
+		{
+			desc: "Fail_DuplicateData",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrConflictingData)
+			},
+			input: &models.Category{ID: categoryID, Name: "Snacks"},
+			expected: categoryExpectedOutput{
+				category: nil,
+				err:      models.ErrConflictingData,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			categoryRepo := mock2.NewMockCategoryRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(categoryRepo, cache)
+
+			categoryService := services.NewCategoryService(categoryRepo, cache)
+
+			category, err := categoryService.UpdateCategory(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.category, category, "Category mismatch")
+		})
+	}
+}
+
+func TestCategoryService_DeleteCategory(t *testing.T) {
+	ctx := context.Background()
+	categoryID := gofakeit.Uint64()
+	existingCategory := &models.Category{
+		ID:   categoryID,
+		Name: gofakeit.ProductCategory(),
+	}
+
+	cacheKey := util2.GenerateCacheKey("category", categoryID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			categoryRepo *mock2.MockCategoryRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("categories:*")).
+					Return(nil)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			expected: models.ErrDataNotFound,
+		},
+		{
+			desc: "Fail_CategoryInUse",
+			mocks: func(
+				categoryRepo *mock2.MockCategoryRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				categoryRepo.EXPECT().
+					GetCategoryByID(gomock.Any(), gomock.Eq(categoryID)).
+					Return(existingCategory, nil)
+				categoryRepo.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(models.ErrCategoryInUse)
+			},
+			expected: models.ErrCategoryInUse,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			categoryRepo := mock2.NewMockCategoryRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(categoryRepo, cache)
+
+			categoryService := services.NewCategoryService(categoryRepo, cache)
+
+			err := categoryService.DeleteCategory(ctx, categoryID)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
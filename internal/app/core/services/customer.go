@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"strings"
+)
+
+/**
+ * CustomerService implements ports.CustomerService interface
+ * and provides an access to the customer and order repositories
+ * and cache service
+ */
+type CustomerService struct {
+	repo      ports.CustomerRepository
+	orderRepo ports.OrderRepository
+	cache     ports.CacheRepository
+}
+
+// NewCustomerService creates a new customer services instance
+func NewCustomerService(repo ports.CustomerRepository, orderRepo ports.OrderRepository, cache ports.CacheRepository) *CustomerService {
+	return &CustomerService{
+		repo,
+		orderRepo,
+		cache,
+	}
+}
+
+// CreateCustomer creates a new customer without any points
+func (cs *CustomerService) CreateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	normalizeCustomer(customer)
+	if customer.Name == "" {
+		return nil, models.ErrInvalidCustomer
+	}
+
+	customer, err := cs.repo.CreateCustomer(ctx, customer)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("customer", customer.ID)
+	customerSerialized, err := utils.Serialize(customer)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cs.cache.Set(ctx, cacheKey, customerSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cs.cache.DeleteByPrefix(ctx, "customers:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return customer, nil
+}
+
+// GetCustomer gets a customer by ID
+func (cs *CustomerService) GetCustomer(ctx context.Context, id uint64) (*models.Customer, error) {
+	var customer *models.Customer
+
+	cacheKey := utils.GenerateCacheKey("customer", id)
+	cachedCustomer, err := cs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedCustomer, &customer)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return customer, nil
+	}
+
+	customer, err = cs.repo.GetCustomerByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	customerSerialized, err := utils.Serialize(customer)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cs.cache.Set(ctx, cacheKey, customerSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return customer, nil
+}
+
+// ListCustomers lists the customers matching the search on name, email or phone
+func (cs *CustomerService) ListCustomers(ctx context.Context, search string, skip, limit uint64) ([]models.Customer, error) {
+	var customers []models.Customer
+
+	search = strings.TrimSpace(search)
+	params := utils.GenerateCacheKeyParams(skip, limit, search)
+	cacheKey := utils.GenerateCacheKey("customers", params)
+
+	cachedCustomers, err := cs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedCustomers, &customers)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return customers, nil
+	}
+
+	customers, err = cs.repo.ListCustomers(ctx, search, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	customersSerialized, err := utils.Serialize(customers)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cs.cache.Set(ctx, cacheKey, customersSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return customers, nil
+}
+
+// UpdateCustomer replaces the contact details of a customer, their points only change through the ledger
+func (cs *CustomerService) UpdateCustomer(ctx context.Context, customer *models.Customer) (*models.Customer, error) {
+	normalizeCustomer(customer)
+
+	existingCustomer, err := cs.repo.GetCustomerByID(ctx, customer.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	emptyData := customer.Name == ""
+	sameData := existingCustomer.Name == customer.Name &&
+		existingCustomer.Email == customer.Email &&
+		existingCustomer.Phone == customer.Phone
+	if emptyData || sameData {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	customer, err = cs.repo.UpdateCustomer(ctx, customer)
+	if err != nil {
+		if err == models.ErrConflictingData || err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	err = invalidateCustomerCache(ctx, cs.cache, customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// DeleteCustomer deletes a customer by ID, refusing customers that have placed orders or have
+// loyalty points history, which is kept for auditing
+func (cs *CustomerService) DeleteCustomer(ctx context.Context, id uint64) error {
+	_, err := cs.repo.GetCustomerByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = cs.repo.DeleteCustomer(ctx, id)
+	if err != nil {
+		if err == models.ErrCustomerInUse {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	return invalidateCustomerCache(ctx, cs.cache, id)
+}
+
+// ListOrders lists the orders of a customer, the newest first
+func (cs *CustomerService) ListOrders(ctx context.Context, customerID, skip, limit uint64) ([]models.Order, error) {
+	_, err := cs.repo.GetCustomerByID(ctx, customerID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	orders, err := cs.orderRepo.ListCustomerOrders(ctx, customerID, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return orders, nil
+}
+
+// AdjustPoints adds points to or takes points from a customer by hand, which needs a reason
+// and cannot take more points than the customer has
+func (cs *CustomerService) AdjustPoints(ctx context.Context, entry *models.LoyaltyEntry) (*models.LoyaltyEntry, error) {
+	entry.Type = models.LoyaltyAdjustment
+	entry.Reason = strings.TrimSpace(entry.Reason)
+	entry.OrderID = nil
+	if entry.Points == 0 || entry.Reason == "" {
+		return nil, models.ErrInvalidLoyaltyEntry
+	}
+
+	_, err := cs.repo.GetCustomerByID(ctx, entry.CustomerID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	entry, err = cs.repo.CreateLoyaltyEntry(ctx, entry)
+	if err != nil {
+		if err == models.ErrInsufficientPoints {
+			return nil, err
+		}
+		// the customer was deleted since it was read
+		if err == models.ErrInvalidCustomer {
+			return nil, models.ErrDataNotFound
+		}
+		return nil, models.ErrInternal
+	}
+
+	err = invalidateCustomerCache(ctx, cs.cache, entry.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ListLoyaltyEntries lists the loyalty ledger of a customer, the newest first
+func (cs *CustomerService) ListLoyaltyEntries(ctx context.Context, customerID, skip, limit uint64) ([]models.LoyaltyEntry, error) {
+	_, err := cs.repo.GetCustomerByID(ctx, customerID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	entries, err := cs.repo.ListLoyaltyEntries(ctx, customerID, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return entries, nil
+}
+
+// normalizeCustomer trims the contact details of a customer, emails are compared case-insensitively
+func normalizeCustomer(customer *models.Customer) {
+	customer.Name = strings.TrimSpace(customer.Name)
+	customer.Email = strings.ToLower(strings.TrimSpace(customer.Email))
+	customer.Phone = strings.TrimSpace(customer.Phone)
+}
+
+// invalidateCustomerCache removes a customer from the cache, along with every cached list of
+// customers, after their details or their points have changed
+func invalidateCustomerCache(ctx context.Context, cache ports.CacheRepository, customerID uint64) error {
+	err := cache.Delete(ctx, utils.GenerateCacheKey("customer", customerID))
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = cache.DeleteByPrefix(ctx, "customers:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,420 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type customerExpectedOutput struct {
+	customer *models.Customer
+	err      error
+}
+
+func TestCustomerService_CreateCustomer(t *testing.T) {
+	ctx := context.Background()
+
+	customerInput := func() *models.Customer {
+		return &models.Customer{
+			Name:  " Jane Doe ",
+			Email: "Jane@Example.com ",
+			Phone: " +6281234567890",
+		}
+	}
+	customerOutput := &models.Customer{
+		ID:        gofakeit.Uint64(),
+		Name:      "Jane Doe",
+		Email:     "jane@example.com",
+		Phone:     "+6281234567890",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	cacheKey := util2.GenerateCacheKey("customer", customerOutput.ID)
+	customerSerialized, _ := util2.Serialize(customerOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			customerRepo *mock2.MockCustomerRepository,
+			orderRepo *mock2.MockOrderRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    *models.Customer
+		expected customerExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				customerRepo.EXPECT().
+					CreateCustomer(gomock.Any(), gomock.Eq(&models.Customer{
+						Name:  "Jane Doe",
+						Email: "jane@example.com",
+						Phone: "+6281234567890",
+					})).
+					Return(customerOutput, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(customerSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("customers:*")).
+					Return(nil)
+			},
+			input: customerInput(),
+			expected: customerExpectedOutput{
+				customer: customerOutput,
+				err:      nil,
+			},
+		},
+		{
+			desc: "Fail_NoName",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: &models.Customer{Name: " ", Email: "jane@example.com"},
+			expected: customerExpectedOutput{
+				customer: nil,
+				err:      models.ErrInvalidCustomer,
+			},
+		},
+		{
+			// another customer has the email or phone
+			desc: "Fail_DuplicateContact",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				customerRepo.EXPECT().
+					CreateCustomer(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrConflictingData)
+			},
+			input: customerInput(),
+			expected: customerExpectedOutput{
+				customer: nil,
+				err:      models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				customerRepo.EXPECT().
+					CreateCustomer(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("connection refused"))
+			},
+			input: customerInput(),
+			expected: customerExpectedOutput{
+				customer: nil,
+				err:      models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			customerRepo := mock2.NewMockCustomerRepository(ctrl)
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(customerRepo, orderRepo, cache)
+
+			customerService := services.NewCustomerService(customerRepo, orderRepo, cache)
+
+			customer, err := customerService.CreateCustomer(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.customer, customer, "Customer mismatch")
+		})
+	}
+}
+
+func TestCustomerService_DeleteCustomer(t *testing.T) {
+	ctx := context.Background()
+	customerID := gofakeit.Uint64()
+	existingCustomer := &models.Customer{
+		ID:   customerID,
+		Name: "Jane Doe",
+	}
+
+	cacheKey := util2.GenerateCacheKey("customer", customerID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			customerRepo *mock2.MockCustomerRepository,
+			orderRepo *mock2.MockOrderRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(existingCustomer, nil)
+				customerRepo.EXPECT().
+					DeleteCustomer(gomock.Any(), gomock.Eq(customerID)).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("customers:*")).
+					Return(nil)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			expected: models.ErrDataNotFound,
+		},
+		{
+			// the orders and points history of a customer are kept for auditing
+			desc: "Fail_CustomerInUse",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(existingCustomer, nil)
+				customerRepo.EXPECT().
+					DeleteCustomer(gomock.Any(), gomock.Eq(customerID)).
+					Return(models.ErrCustomerInUse)
+			},
+			expected: models.ErrCustomerInUse,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			customerRepo := mock2.NewMockCustomerRepository(ctrl)
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(customerRepo, orderRepo, cache)
+
+			customerService := services.NewCustomerService(customerRepo, orderRepo, cache)
+
+			err := customerService.DeleteCustomer(ctx, customerID)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
+
+func TestCustomerService_AdjustPoints(t *testing.T) {
+	ctx := context.Background()
+	customerID := gofakeit.Uint64()
+	userID := gofakeit.Uint64()
+	orderID := gofakeit.Uint64()
+	existingCustomer := &models.Customer{
+		ID:     customerID,
+		Name:   "Jane Doe",
+		Points: 120,
+	}
+
+	input := func(points int64, reason string) *models.LoyaltyEntry {
+		return &models.LoyaltyEntry{
+			CustomerID: customerID,
+			Type:       models.LoyaltyEarn,
+			Points:     points,
+			Reason:     reason,
+			OrderID:    &orderID,
+			UserID:     &userID,
+		}
+	}
+	entryOutput := &models.LoyaltyEntry{
+		ID:           gofakeit.Uint64(),
+		CustomerID:   customerID,
+		Type:         models.LoyaltyAdjustment,
+		Points:       50,
+		BalanceAfter: 170,
+		Reason:       "goodwill",
+		UserID:       &userID,
+		CreatedAt:    time.Now(),
+	}
+
+	cacheKey := util2.GenerateCacheKey("customer", customerID)
+
+	type expectedOutput struct {
+		entry *models.LoyaltyEntry
+		err   error
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			customerRepo *mock2.MockCustomerRepository,
+			orderRepo *mock2.MockOrderRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    *models.LoyaltyEntry
+		expected expectedOutput
+	}{
+		{
+			// an adjustment is never of an order
+			desc: "Success",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(existingCustomer, nil)
+				customerRepo.EXPECT().
+					CreateLoyaltyEntry(gomock.Any(), gomock.Eq(&models.LoyaltyEntry{
+						CustomerID: customerID,
+						Type:       models.LoyaltyAdjustment,
+						Points:     50,
+						Reason:     "goodwill",
+						UserID:     &userID,
+					})).
+					Return(entryOutput, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("customers:*")).
+					Return(nil)
+			},
+			input: input(50, " goodwill "),
+			expected: expectedOutput{
+				entry: entryOutput,
+				err:   nil,
+			},
+		},
+		{
+			desc: "Fail_NoPoints",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: input(0, "goodwill"),
+			expected: expectedOutput{
+				entry: nil,
+				err:   models.ErrInvalidLoyaltyEntry,
+			},
+		},
+		{
+			desc: "Fail_NoReason",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: input(50, " "),
+			expected: expectedOutput{
+				entry: nil,
+				err:   models.ErrInvalidLoyaltyEntry,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: input(50, "goodwill"),
+			expected: expectedOutput{
+				entry: nil,
+				err:   models.ErrDataNotFound,
+			},
+		},
+		{
+			// the balance is checked under lock by the repository
+			desc: "Fail_InsufficientPoints",
+			mocks: func(
+				customerRepo *mock2.MockCustomerRepository,
+				orderRepo *mock2.MockOrderRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(existingCustomer, nil)
+				customerRepo.EXPECT().
+					CreateLoyaltyEntry(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrInsufficientPoints)
+			},
+			input: input(-121, "expired"),
+			expected: expectedOutput{
+				entry: nil,
+				err:   models.ErrInsufficientPoints,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			customerRepo := mock2.NewMockCustomerRepository(ctrl)
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(customerRepo, orderRepo, cache)
+
+			customerService := services.NewCustomerService(customerRepo, orderRepo, cache)
+
+			entry, err := customerService.AdjustPoints(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.entry, entry, "Loyalty entry mismatch")
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * EmailChangeService implements ports.EmailChangeService interface
+ * and provides an access to the email change and user repositories,
+ * cache, link, notification and audit services
+ */
+type EmailChangeService struct {
+	repo     ports.EmailChangeRepository
+	userRepo ports.UserRepository
+	cache    ports.CacheRepository
+	link     ports.LinkService
+	notifier ports.NotificationService
+	audit    ports.AuditService
+}
+
+// NewEmailChangeService creates a new email change services instance
+func NewEmailChangeService(
+	repo ports.EmailChangeRepository,
+	userRepo ports.UserRepository,
+	cache ports.CacheRepository,
+	link ports.LinkService,
+	notifier ports.NotificationService,
+	audit ports.AuditService,
+) *EmailChangeService {
+	return &EmailChangeService{
+		repo,
+		userRepo,
+		cache,
+		link,
+		notifier,
+		audit,
+	}
+}
+
+// RequestEmailChange stores the new email as pending, sends a confirmation link to it
+// and a notice with a revert link to the current email
+func (ecs *EmailChangeService) RequestEmailChange(ctx context.Context, user *models.User, email string) (*models.EmailChange, error) {
+	if email == user.Email {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	_, err := ecs.userRepo.GetUserByEmail(ctx, email)
+	if err == nil {
+		return nil, models.ErrConflictingData
+	}
+	if err != models.ErrDataNotFound {
+		return nil, models.ErrInternal
+	}
+
+	// a new request supersedes any earlier one that was never confirmed
+	err = ecs.repo.DeletePendingEmailChanges(ctx, user.ID)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	tokenID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+	revertTokenID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	confirmLink, expiresAt, err := ecs.link.CreateLink(ctx, models.LinkEmailConfirm, tokenID.String())
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+	revertLink, _, err := ecs.link.CreateLink(ctx, models.LinkEmailRevert, revertTokenID.String())
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	change := &models.EmailChange{
+		UserID:        user.ID,
+		OldEmail:      user.Email,
+		NewEmail:      email,
+		TokenID:       tokenID.String(),
+		RevertTokenID: revertTokenID.String(),
+		ExpiresAt:     expiresAt,
+	}
+
+	change, err = ecs.repo.CreateEmailChange(ctx, change)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	confirmation := &models.Notification{
+		Recipient: change.NewEmail,
+		Subject:   "Confirm your new email",
+		Body: fmt.Sprintf(
+			"Confirm this address as the new email of your account before %s:\n%s\n",
+			change.ExpiresAt.Format(time.RFC1123),
+			confirmLink,
+		),
+	}
+
+	err = ecs.notifier.Send(ctx, confirmation)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	notice := &models.Notification{
+		Recipient: change.OldEmail,
+		Subject:   "Your email is being changed",
+		Body: fmt.Sprintf(
+			"A change of your account email to %s was requested.\n\nIf this was not you, revert it:\n%s\n",
+			change.NewEmail,
+			revertLink,
+		),
+	}
+
+	err = ecs.notifier.Send(ctx, notice)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	// the requested email is recorded as the after state, the user keeps the current one until confirmed
+	requested := *user
+	requested.Email = change.NewEmail
+
+	err = ecs.audit.RecordUserChange(ctx, models.AuditUserEmailRequest, user, &requested)
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// ConfirmEmailChange verifies the confirmation link and moves the user to the new email
+func (ecs *EmailChangeService) ConfirmEmailChange(ctx context.Context, token string) (*models.User, error) {
+	tokenID, tenantID, err := ecs.link.VerifyLink(models.LinkEmailConfirm, token)
+	if err != nil {
+		if err == models.ErrExpiredLink {
+			return nil, err
+		}
+		return nil, models.ErrInvalidLink
+	}
+	ctx = _constant.WithTenantID(ctx, tenantID)
+
+	change, err := ecs.repo.GetEmailChangeByTokenID(ctx, tokenID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, models.ErrInvalidLink
+		}
+		return nil, models.ErrInternal
+	}
+
+	if change.ConfirmedAt != nil || change.RevertedAt != nil {
+		return nil, models.ErrInvalidLink
+	}
+	if !change.IsPending(time.Now()) {
+		return nil, models.ErrExpiredLink
+	}
+
+	user, err := ecs.moveEmail(ctx, models.AuditUserEmailConfirm, change.UserID, change.OldEmail, change.NewEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ecs.repo.ConfirmEmailChange(ctx, change.ID)
+	if err != nil {
+		if err == models.ErrInvalidLink {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	return user, nil
+}
+
+// RevertEmailChange verifies the revert link, then cancels the change if it is still pending
+// or moves the user back to the old email if it was already confirmed
+func (ecs *EmailChangeService) RevertEmailChange(ctx context.Context, token string) (*models.User, error) {
+	tokenID, tenantID, err := ecs.link.VerifyLink(models.LinkEmailRevert, token)
+	if err != nil {
+		if err == models.ErrExpiredLink {
+			return nil, err
+		}
+		return nil, models.ErrInvalidLink
+	}
+	ctx = _constant.WithTenantID(ctx, tenantID)
+
+	change, err := ecs.repo.GetEmailChangeByRevertTokenID(ctx, tokenID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, models.ErrInvalidLink
+		}
+		return nil, models.ErrInternal
+	}
+
+	if change.RevertedAt != nil {
+		return nil, models.ErrInvalidLink
+	}
+
+	var user *models.User
+
+	if change.ConfirmedAt == nil {
+		user, err = ecs.userRepo.GetUserByID(ctx, change.UserID)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+	} else {
+		user, err = ecs.moveEmail(ctx, models.AuditUserEmailRevert, change.UserID, change.NewEmail, change.OldEmail)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = ecs.repo.RevertEmailChange(ctx, change.ID)
+	if err != nil {
+		if err == models.ErrInvalidLink {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	return user, nil
+}
+
+// moveEmail switches a user from one email to another, as long as the user still has
+// the former and no other user took the latter in the meantime
+func (ecs *EmailChangeService) moveEmail(ctx context.Context, action models.AuditAction, userID uint64, from, to string) (*models.User, error) {
+	existingUser, err := ecs.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, models.ErrInvalidLink
+		}
+		return nil, models.ErrInternal
+	}
+
+	if existingUser.Email != from {
+		return nil, models.ErrInvalidLink
+	}
+
+	_, err = ecs.userRepo.GetUserByEmail(ctx, to)
+	if err == nil {
+		return nil, models.ErrConflictingData
+	}
+	if err != models.ErrDataNotFound {
+		return nil, models.ErrInternal
+	}
+
+	changes := []models.UserChange{
+		{Field: models.UserEmailField, From: from, To: to},
+	}
+
+	user, err := ecs.userRepo.PatchUser(ctx, existingUser.ID, existingUser.Version, changes)
+	if err != nil {
+		if err == models.ErrConflictingData || err == models.ErrVersionConflict {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("user", user.ID)
+
+	err = ecs.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ecs.cache.DeleteByPrefix(ctx, "users:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ecs.audit.RecordUserChange(ctx, action, existingUser, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,528 @@
+package services_test
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type requestEmailChangeTestedInput struct {
+	user  *models.User
+	email string
+}
+
+type requestEmailChangeExpectedOutput struct {
+	change *models.EmailChange
+	err    error
+}
+
+func TestEmailChangeService_RequestEmailChange(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	user := &models.User{
+		ID:    gofakeit.Uint64(),
+		Email: gofakeit.Email(),
+	}
+	newEmail := gofakeit.Email()
+
+	change := &models.EmailChange{
+		ID:        gofakeit.Uint64(),
+		UserID:    user.ID,
+		OldEmail:  user.Email,
+		NewEmail:  newEmail,
+		ExpiresAt: expiresAt,
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			emailChangeRepo *mock2.MockEmailChangeRepository,
+			userRepo *mock2.MockUserRepository,
+			cache *mock2.MockCacheRepository,
+			link *mock2.MockLinkService,
+			notifier *mock2.MockNotificationService,
+			audit *mock2.MockAuditService,
+		)
+		input    requestEmailChangeTestedInput
+		expected requestEmailChangeExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				emailChangeRepo *mock2.MockEmailChangeRepository,
+				userRepo *mock2.MockUserRepository,
+				cache *mock2.MockCacheRepository,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(newEmail)).
+					Return(nil, models.ErrDataNotFound)
+				emailChangeRepo.EXPECT().
+					DeletePendingEmailChanges(gomock.Any(), gomock.Eq(user.ID)).
+					Return(nil)
+				link.EXPECT().
+					CreateLink(gomock.Any(), gomock.Eq(models.LinkEmailConfirm), gomock.Any()).
+					Return("http://localhost:8080/email/confirm?token=v4.local.x", expiresAt, nil)
+				link.EXPECT().
+					CreateLink(gomock.Any(), gomock.Eq(models.LinkEmailRevert), gomock.Any()).
+					Return("http://localhost:8080/email/revert?token=v4.local.y", expiresAt, nil)
+				emailChangeRepo.EXPECT().
+					CreateEmailChange(gomock.Any(), gomock.Any()).
+					Return(change, nil)
+				gomock.InOrder(
+					notifier.EXPECT().
+						Send(gomock.Any(), gomock.Cond(func(n *models.Notification) bool {
+							return n.Recipient == newEmail
+						})).
+						Return(nil),
+					notifier.EXPECT().
+						Send(gomock.Any(), gomock.Cond(func(n *models.Notification) bool {
+							return n.Recipient == user.Email
+						})).
+						Return(nil),
+				)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserEmailRequest), gomock.Eq(user), gomock.Any()).
+					Return(nil)
+			},
+			input: requestEmailChangeTestedInput{
+				user:  user,
+				email: newEmail,
+			},
+			expected: requestEmailChangeExpectedOutput{
+				change: change,
+				err:    nil,
+			},
+		},
+		{
+			desc: "Fail_SameEmail",
+			mocks: func(
+				emailChangeRepo *mock2.MockEmailChangeRepository,
+				userRepo *mock2.MockUserRepository,
+				cache *mock2.MockCacheRepository,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+				audit *mock2.MockAuditService,
+			) {
+			},
+			input: requestEmailChangeTestedInput{
+				user:  user,
+				email: user.Email,
+			},
+			expected: requestEmailChangeExpectedOutput{
+				change: nil,
+				err:    models.ErrNoUpdatedData,
+			},
+		},
+		{
+			desc: "Fail_EmailTaken",
+			mocks: func(
+				emailChangeRepo *mock2.MockEmailChangeRepository,
+				userRepo *mock2.MockUserRepository,
+				cache *mock2.MockCacheRepository,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(newEmail)).
+					Return(&models.User{Email: newEmail}, nil)
+			},
+			input: requestEmailChangeTestedInput{
+				user:  user,
+				email: newEmail,
+			},
+			expected: requestEmailChangeExpectedOutput{
+				change: nil,
+				err:    models.ErrConflictingData,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			emailChangeRepo := mock2.NewMockEmailChangeRepository(ctrl)
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			link := mock2.NewMockLinkService(ctrl)
+			notifier := mock2.NewMockNotificationService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(emailChangeRepo, userRepo, cache, link, notifier, audit)
+
+			emailChangeService := services.NewEmailChangeService(emailChangeRepo, userRepo, cache, link, notifier, audit)
+
+			change, err := emailChangeService.RequestEmailChange(ctx, tc.input.user, tc.input.email)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.change, change, "Email change mismatch")
+		})
+	}
+}
+
+type emailChangeLinkTestedInput struct {
+	token string
+}
+
+type emailChangeLinkExpectedOutput struct {
+	user *models.User
+	err  error
+}
+
+func TestEmailChangeService_ConfirmEmailChange(t *testing.T) {
+	ctx := context.Background()
+	token := "v4.local.x"
+	tokenID := gofakeit.UUID()
+	confirmedAt := time.Now()
+
+	user := &models.User{
+		ID:      gofakeit.Uint64(),
+		Email:   gofakeit.Email(),
+		Version: 2,
+	}
+	change := &models.EmailChange{
+		ID:        gofakeit.Uint64(),
+		UserID:    user.ID,
+		OldEmail:  user.Email,
+		NewEmail:  gofakeit.Email(),
+		TokenID:   tokenID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	movedUser := &models.User{
+		ID:      user.ID,
+		Email:   change.NewEmail,
+		Version: 3,
+	}
+
+	cacheKey := util2.GenerateCacheKey("user", user.ID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			emailChangeRepo *mock2.MockEmailChangeRepository,
+			userRepo *mock2.MockUserRepository,
+			cache *mock2.MockCacheRepository,
+			link *mock2.MockLinkService,
+			notifier *mock2.MockNotificationService,
+			audit *mock2.MockAuditService,
+		)
+		input    emailChangeLinkTestedInput
+		expected emailChangeLinkExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				emailChangeRepo *mock2.MockEmailChangeRepository,
+				userRepo *mock2.MockUserRepository,
+				cache *mock2.MockCacheRepository,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+				audit *mock2.MockAuditService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkEmailConfirm), gomock.Eq(token)).
+					Return(tokenID, models.DefaultTenantID, nil)
+				emailChangeRepo.EXPECT().
+					GetEmailChangeByTokenID(gomock.Any(), gomock.Eq(tokenID)).
+					Return(change, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(user, nil)
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(change.NewEmail)).
+					Return(nil, models.ErrDataNotFound)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(user.ID), gomock.Eq(user.Version), gomock.Eq([]models.UserChange{
+						{Field: models.UserEmailField, From: change.OldEmail, To: change.NewEmail},
+					})).
+					Return(movedUser, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				emailChangeRepo.EXPECT().
+					ConfirmEmailChange(gomock.Any(), gomock.Eq(change.ID)).
+					Return(nil)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserEmailConfirm), gomock.Eq(user), gomock.Eq(movedUser)).
+					Return(nil)
+			},
+			input: emailChangeLinkTestedInput{
+				token: token,
+			},
+			expected: emailChangeLinkExpectedOutput{
+				user: movedUser,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_EmailTakenMeanwhile",
+			mocks: func(
+				emailChangeRepo *mock2.MockEmailChangeRepository,
+				userRepo *mock2.MockUserRepository,
+				cache *mock2.MockCacheRepository,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+				audit *mock2.MockAuditService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkEmailConfirm), gomock.Eq(token)).
+					Return(tokenID, models.DefaultTenantID, nil)
+				emailChangeRepo.EXPECT().
+					GetEmailChangeByTokenID(gomock.Any(), gomock.Eq(tokenID)).
+					Return(change, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Return(user, nil)
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(change.NewEmail)).
+					Return(&models.User{Email: change.NewEmail}, nil)
+			},
+			input: emailChangeLinkTestedInput{
+				token: token,
+			},
+			expected: emailChangeLinkExpectedOutput{
+				user: nil,
+				err:  models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_AlreadyConfirmed",
+			mocks: func(
+				emailChangeRepo *mock2.MockEmailChangeRepository,
+				userRepo *mock2.MockUserRepository,
+				cache *mock2.MockCacheRepository,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+				audit *mock2.MockAuditService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkEmailConfirm), gomock.Eq(token)).
+					Return(tokenID, models.DefaultTenantID, nil)
+				emailChangeRepo.EXPECT().
+					GetEmailChangeByTokenID(gomock.Any(), gomock.Eq(tokenID)).
+					Return(&models.EmailChange{
+						ID:          change.ID,
+						UserID:      user.ID,
+						ExpiresAt:   change.ExpiresAt,
+						ConfirmedAt: &confirmedAt,
+					}, nil)
+			},
+			input: emailChangeLinkTestedInput{
+				token: token,
+			},
+			expected: emailChangeLinkExpectedOutput{
+				user: nil,
+				err:  models.ErrInvalidLink,
+			},
+		},
+		{
+			desc: "Fail_ExpiredLink",
+			mocks: func(
+				emailChangeRepo *mock2.MockEmailChangeRepository,
+				userRepo *mock2.MockUserRepository,
+				cache *mock2.MockCacheRepository,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+				audit *mock2.MockAuditService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkEmailConfirm), gomock.Eq(token)).
+					Return("", uint64(0), models.ErrExpiredLink)
+			},
+			input: emailChangeLinkTestedInput{
+				token: token,
+			},
+			expected: emailChangeLinkExpectedOutput{
+				user: nil,
+				err:  models.ErrExpiredLink,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			emailChangeRepo := mock2.NewMockEmailChangeRepository(ctrl)
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			link := mock2.NewMockLinkService(ctrl)
+			notifier := mock2.NewMockNotificationService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(emailChangeRepo, userRepo, cache, link, notifier, audit)
+
+			emailChangeService := services.NewEmailChangeService(emailChangeRepo, userRepo, cache, link, notifier, audit)
+
+			user, err := emailChangeService.ConfirmEmailChange(ctx, tc.input.token)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.user, user, "User mismatch")
+		})
+	}
+}
+
+func TestEmailChangeService_RevertEmailChange(t *testing.T) {
+	ctx := context.Background()
+	token := "v4.local.y"
+	revertTokenID := gofakeit.UUID()
+	confirmedAt := time.Now()
+
+	oldEmail := gofakeit.Email()
+	takenOver := &models.User{
+		ID:      gofakeit.Uint64(),
+		Email:   gofakeit.Email(),
+		Version: 5,
+	}
+	change := &models.EmailChange{
+		ID:            gofakeit.Uint64(),
+		UserID:        takenOver.ID,
+		OldEmail:      oldEmail,
+		NewEmail:      takenOver.Email,
+		RevertTokenID: revertTokenID,
+		ExpiresAt:     time.Now().Add(time.Hour),
+		ConfirmedAt:   &confirmedAt,
+	}
+	restoredUser := &models.User{
+		ID:      takenOver.ID,
+		Email:   oldEmail,
+		Version: 6,
+	}
+
+	cacheKey := util2.GenerateCacheKey("user", takenOver.ID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			emailChangeRepo *mock2.MockEmailChangeRepository,
+			userRepo *mock2.MockUserRepository,
+			cache *mock2.MockCacheRepository,
+			link *mock2.MockLinkService,
+			notifier *mock2.MockNotificationService,
+			audit *mock2.MockAuditService,
+		)
+		input    emailChangeLinkTestedInput
+		expected emailChangeLinkExpectedOutput
+	}{
+		{
+			desc: "Success_AfterConfirmation",
+			mocks: func(
+				emailChangeRepo *mock2.MockEmailChangeRepository,
+				userRepo *mock2.MockUserRepository,
+				cache *mock2.MockCacheRepository,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+				audit *mock2.MockAuditService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkEmailRevert), gomock.Eq(token)).
+					Return(revertTokenID, models.DefaultTenantID, nil)
+				emailChangeRepo.EXPECT().
+					GetEmailChangeByRevertTokenID(gomock.Any(), gomock.Eq(revertTokenID)).
+					Return(change, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(takenOver.ID)).
+					Return(takenOver, nil)
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(oldEmail)).
+					Return(nil, models.ErrDataNotFound)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(takenOver.ID), gomock.Eq(takenOver.Version), gomock.Eq([]models.UserChange{
+						{Field: models.UserEmailField, From: takenOver.Email, To: oldEmail},
+					})).
+					Return(restoredUser, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				emailChangeRepo.EXPECT().
+					RevertEmailChange(gomock.Any(), gomock.Eq(change.ID)).
+					Return(nil)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserEmailRevert), gomock.Eq(takenOver), gomock.Eq(restoredUser)).
+					Return(nil)
+			},
+			input: emailChangeLinkTestedInput{
+				token: token,
+			},
+			expected: emailChangeLinkExpectedOutput{
+				user: restoredUser,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_EmailChangedAgain",
+			mocks: func(
+				emailChangeRepo *mock2.MockEmailChangeRepository,
+				userRepo *mock2.MockUserRepository,
+				cache *mock2.MockCacheRepository,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+				audit *mock2.MockAuditService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkEmailRevert), gomock.Eq(token)).
+					Return(revertTokenID, models.DefaultTenantID, nil)
+				emailChangeRepo.EXPECT().
+					GetEmailChangeByRevertTokenID(gomock.Any(), gomock.Eq(revertTokenID)).
+					Return(change, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(takenOver.ID)).
+					Return(&models.User{ID: takenOver.ID, Email: gofakeit.Email()}, nil)
+			},
+			input: emailChangeLinkTestedInput{
+				token: token,
+			},
+			expected: emailChangeLinkExpectedOutput{
+				user: nil,
+				err:  models.ErrInvalidLink,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			emailChangeRepo := mock2.NewMockEmailChangeRepository(ctrl)
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			link := mock2.NewMockLinkService(ctrl)
+			notifier := mock2.NewMockNotificationService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(emailChangeRepo, userRepo, cache, link, notifier, audit)
+
+			emailChangeService := services.NewEmailChangeService(emailChangeRepo, userRepo, cache, link, notifier, audit)
+
+			user, err := emailChangeService.RevertEmailChange(ctx, tc.input.token)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.user, user, "User mismatch")
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package services
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+)
+
+/**
+ * GroupService implements ports.GroupService interface
+ * and provides an access to the group, user and role repositories,
+ * cache and policy services
+ */
+type GroupService struct {
+	repo     ports.GroupRepository
+	userRepo ports.UserRepository
+	roleRepo ports.RoleRepository
+	cache    ports.CacheRepository
+	policy   ports.PolicyService
+}
+
+// NewGroupService creates a new group services instance
+func NewGroupService(
+	repo ports.GroupRepository,
+	userRepo ports.UserRepository,
+	roleRepo ports.RoleRepository,
+	cache ports.CacheRepository,
+	policy ports.PolicyService,
+) *GroupService {
+	return &GroupService{
+		repo,
+		userRepo,
+		roleRepo,
+		cache,
+		policy,
+	}
+}
+
+// CreateGroup creates a new group, optionally nested inside a parent group
+func (gs *GroupService) CreateGroup(ctx context.Context, group *models.Group) (*models.Group, error) {
+	if group.ParentID != nil {
+		_, err := gs.repo.GetGroupByID(ctx, *group.ParentID)
+		if err != nil {
+			if err == models.ErrDataNotFound {
+				return nil, err
+			}
+			return nil, models.ErrInternal
+		}
+	}
+
+	group, err := gs.repo.CreateGroup(ctx, group)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	if group.ParentID != nil {
+		err = gs.policy.UpdateGroupParent(ctx, group.ID, nil, group.ParentID)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+	}
+
+	err = gs.cache.DeleteByPrefix(ctx, "groups:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return group, nil
+}
+
+// GetGroup gets a group by ID
+func (gs *GroupService) GetGroup(ctx context.Context, id uint64) (*models.Group, error) {
+	var group *models.Group
+
+	cacheKey := utils.GenerateCacheKey("group", id)
+	cachedGroup, err := gs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedGroup, &group)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return group, nil
+	}
+
+	group, err = gs.repo.GetGroupByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	groupSerialized, err := utils.Serialize(group)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = gs.cache.Set(ctx, cacheKey, groupSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return group, nil
+}
+
+// ListGroups lists all groups
+func (gs *GroupService) ListGroups(ctx context.Context, skip, limit uint64) ([]models.Group, error) {
+	var groups []models.Group
+
+	params := utils.GenerateCacheKeyParams(skip, limit)
+	cacheKey := utils.GenerateCacheKey("groups", params)
+
+	cachedGroups, err := gs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedGroups, &groups)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return groups, nil
+	}
+
+	groups, err = gs.repo.ListGroups(ctx, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	groupsSerialized, err := utils.Serialize(groups)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = gs.cache.Set(ctx, cacheKey, groupsSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return groups, nil
+}
+
+// UpdateGroup updates a group's name, description, and parent.
+// A nil parent keeps the current one, a parent of 0 detaches the group.
+func (gs *GroupService) UpdateGroup(ctx context.Context, group *models.Group) (*models.Group, error) {
+	existingGroup, err := gs.repo.GetGroupByID(ctx, group.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	parentID := existingGroup.ParentID
+	if group.ParentID != nil {
+		parentID = group.ParentID
+		if *parentID == 0 {
+			parentID = nil
+		}
+	}
+
+	parentChanged := !sameGroupParent(existingGroup.ParentID, parentID)
+	emptyData := group.Name == "" &&
+		group.Description == "" &&
+		group.ParentID == nil
+	sameData := (group.Name == "" || existingGroup.Name == group.Name) &&
+		(group.Description == "" || existingGroup.Description == group.Description) &&
+		!parentChanged
+	if emptyData || sameData {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	if parentChanged && parentID != nil {
+		if *parentID == group.ID {
+			return nil, models.ErrGroupCycle
+		}
+
+		_, err := gs.repo.GetGroupByID(ctx, *parentID)
+		if err != nil {
+			if err == models.ErrDataNotFound {
+				return nil, err
+			}
+			return nil, models.ErrInternal
+		}
+
+		ancestorIDs, err := gs.repo.ListAncestorIDs(ctx, *parentID)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+
+		for _, ancestorID := range ancestorIDs {
+			if ancestorID == group.ID {
+				return nil, models.ErrGroupCycle
+			}
+		}
+	}
+
+	group.ParentID = parentID
+
+	group, err = gs.repo.UpdateGroup(ctx, group)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	if parentChanged {
+		err = gs.policy.UpdateGroupParent(ctx, group.ID, existingGroup.ParentID, parentID)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+	}
+
+	cacheKey := utils.GenerateCacheKey("group", group.ID)
+
+	err = gs.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = gs.cache.DeleteByPrefix(ctx, "groups:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return group, nil
+}
+
+// DeleteGroup deletes a group by ID, its child groups are detached
+func (gs *GroupService) DeleteGroup(ctx context.Context, id uint64) error {
+	_, err := gs.repo.GetGroupByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = gs.repo.DeleteGroup(ctx, id)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = gs.policy.DeleteGroup(ctx, id)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	// child groups lost their parent, so every cached group may be stale
+	err = gs.cache.DeleteByPrefix(ctx, "group:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = gs.cache.DeleteByPrefix(ctx, "groups:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// AddMember adds a user to a group
+func (gs *GroupService) AddMember(ctx context.Context, groupID, userID uint64) (*models.GroupMember, error) {
+	_, err := gs.repo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	_, err = gs.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	member := &models.GroupMember{
+		GroupID: groupID,
+		UserID:  userID,
+	}
+
+	member, err = gs.repo.AddMember(ctx, member)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	err = gs.policy.AddGroupMember(ctx, groupID, userID)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return member, nil
+}
+
+// ListMembers lists the users of a group
+func (gs *GroupService) ListMembers(ctx context.Context, groupID, skip, limit uint64) ([]models.User, error) {
+	_, err := gs.repo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	users, err := gs.repo.ListMembers(ctx, groupID, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return users, nil
+}
+
+// RemoveMember removes a user from a group
+func (gs *GroupService) RemoveMember(ctx context.Context, groupID, userID uint64) error {
+	err := gs.repo.DeleteMember(ctx, groupID, userID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = gs.policy.DeleteGroupMember(ctx, groupID, userID)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// AssignRole assigns a role to every member of a group
+func (gs *GroupService) AssignRole(ctx context.Context, groupID uint64, role models.UserRole) error {
+	_, err := gs.repo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	_, err = gs.roleRepo.GetRoleByName(ctx, role)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return models.ErrInvalidRole
+		}
+		return models.ErrInternal
+	}
+
+	err = gs.repo.AddRole(ctx, groupID, role)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = gs.policy.AddGroupRole(ctx, groupID, role)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// ListRoles lists the roles assigned to a group
+func (gs *GroupService) ListRoles(ctx context.Context, groupID uint64) ([]models.UserRole, error) {
+	_, err := gs.repo.GetGroupByID(ctx, groupID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	roles, err := gs.repo.ListRoles(ctx, groupID)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return roles, nil
+}
+
+// UnassignRole removes a role from a group
+func (gs *GroupService) UnassignRole(ctx context.Context, groupID uint64, role models.UserRole) error {
+	err := gs.repo.DeleteRole(ctx, groupID, role)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = gs.policy.DeleteGroupRole(ctx, groupID, role)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// sameGroupParent reports whether two optional parent ids point to the same group
+func sameGroupParent(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,545 @@
+package services_test
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type updateGroupTestedInput struct {
+	group *models.Group
+}
+
+type updateGroupExpectedOutput struct {
+	group *models.Group
+	err   error
+}
+
+func TestGroupService_UpdateGroup(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		storeID    uint64 = 1
+		shiftID    uint64 = 2
+		subShiftID uint64 = 3
+		detach     uint64 = 0
+	)
+
+	// store -> shift -> sub shift
+	shift := &models.Group{
+		ID:       shiftID,
+		Name:     "Store 1 - Morning shift",
+		ParentID: &storeID,
+	}
+	store := &models.Group{
+		ID:   storeID,
+		Name: "Store 1",
+	}
+
+	cacheKey := util2.GenerateCacheKey("group", shiftID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			groupRepo *mock2.MockGroupRepository,
+			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
+			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+		)
+		input    updateGroupTestedInput
+		expected updateGroupExpectedOutput
+	}{
+		{
+			desc: "Success_Rename",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(shiftID)).
+					Return(shift, nil)
+				groupRepo.EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, group *models.Group) (*models.Group, error) {
+						return group, nil
+					})
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("groups:*")).
+					Return(nil)
+			},
+			input: updateGroupTestedInput{
+				group: &models.Group{
+					ID:   shiftID,
+					Name: "Store 1 - Evening shift",
+				},
+			},
+			expected: updateGroupExpectedOutput{
+				group: &models.Group{
+					ID:       shiftID,
+					Name:     "Store 1 - Evening shift",
+					ParentID: &storeID,
+				},
+				err: nil,
+			},
+		},
+		{
+			desc: "Success_Detach",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(shiftID)).
+					Return(shift, nil)
+				groupRepo.EXPECT().
+					UpdateGroup(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, group *models.Group) (*models.Group, error) {
+						return group, nil
+					})
+				policy.EXPECT().
+					UpdateGroupParent(gomock.Any(), gomock.Eq(shiftID), gomock.Eq(&storeID), gomock.Nil()).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("groups:*")).
+					Return(nil)
+			},
+			input: updateGroupTestedInput{
+				group: &models.Group{
+					ID:       shiftID,
+					ParentID: &detach,
+				},
+			},
+			expected: updateGroupExpectedOutput{
+				group: &models.Group{
+					ID: shiftID,
+				},
+				err: nil,
+			},
+		},
+		{
+			desc: "Fail_SameData",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(shiftID)).
+					Return(shift, nil)
+			},
+			input: updateGroupTestedInput{
+				group: &models.Group{
+					ID:       shiftID,
+					Name:     shift.Name,
+					ParentID: &storeID,
+				},
+			},
+			expected: updateGroupExpectedOutput{
+				group: nil,
+				err:   models.ErrNoUpdatedData,
+			},
+		},
+		{
+			desc: "Fail_SelfParent",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(store, nil)
+			},
+			input: updateGroupTestedInput{
+				group: &models.Group{
+					ID:       storeID,
+					ParentID: &storeID,
+				},
+			},
+			expected: updateGroupExpectedOutput{
+				group: nil,
+				err:   models.ErrGroupCycle,
+			},
+		},
+		{
+			desc: "Fail_DescendantParent",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(store, nil)
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(subShiftID)).
+					Return(&models.Group{ID: subShiftID, ParentID: &shiftID}, nil)
+				groupRepo.EXPECT().
+					ListAncestorIDs(gomock.Any(), gomock.Eq(subShiftID)).
+					Return([]uint64{shiftID, storeID}, nil)
+			},
+			input: updateGroupTestedInput{
+				group: &models.Group{
+					ID:       storeID,
+					ParentID: &subShiftID,
+				},
+			},
+			expected: updateGroupExpectedOutput{
+				group: nil,
+				err:   models.ErrGroupCycle,
+			},
+		},
+		{
+			desc: "Fail_ParentNotFound",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(store, nil)
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(subShiftID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: updateGroupTestedInput{
+				group: &models.Group{
+					ID:       storeID,
+					ParentID: &subShiftID,
+				},
+			},
+			expected: updateGroupExpectedOutput{
+				group: nil,
+				err:   models.ErrDataNotFound,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			groupRepo := mock2.NewMockGroupRepository(ctrl)
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+
+			tc.mocks(groupRepo, userRepo, roleRepo, cache, policy)
+
+			groupService := services.NewGroupService(groupRepo, userRepo, roleRepo, cache, policy)
+
+			group, err := groupService.UpdateGroup(ctx, tc.input.group)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.group, group, "Group mismatch")
+		})
+	}
+}
+
+type addMemberTestedInput struct {
+	groupID uint64
+	userID  uint64
+}
+
+type addMemberExpectedOutput struct {
+	member *models.GroupMember
+	err    error
+}
+
+func TestGroupService_AddMember(t *testing.T) {
+	ctx := context.Background()
+	groupID := gofakeit.Uint64()
+	userID := gofakeit.Uint64()
+
+	member := &models.GroupMember{
+		GroupID: groupID,
+		UserID:  userID,
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			groupRepo *mock2.MockGroupRepository,
+			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
+			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+		)
+		input    addMemberTestedInput
+		expected addMemberExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(groupID)).
+					Return(&models.Group{ID: groupID}, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(&models.User{ID: userID}, nil)
+				groupRepo.EXPECT().
+					AddMember(gomock.Any(), gomock.Eq(member)).
+					Return(member, nil)
+				policy.EXPECT().
+					AddGroupMember(gomock.Any(), gomock.Eq(groupID), gomock.Eq(userID)).
+					Return(nil)
+			},
+			input: addMemberTestedInput{
+				groupID: groupID,
+				userID:  userID,
+			},
+			expected: addMemberExpectedOutput{
+				member: member,
+				err:    nil,
+			},
+		},
+		{
+			desc: "Fail_UserNotFound",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(groupID)).
+					Return(&models.Group{ID: groupID}, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: addMemberTestedInput{
+				groupID: groupID,
+				userID:  userID,
+			},
+			expected: addMemberExpectedOutput{
+				member: nil,
+				err:    models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_AlreadyMember",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(groupID)).
+					Return(&models.Group{ID: groupID}, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(&models.User{ID: userID}, nil)
+				groupRepo.EXPECT().
+					AddMember(gomock.Any(), gomock.Eq(member)).
+					Return(nil, models.ErrConflictingData)
+			},
+			input: addMemberTestedInput{
+				groupID: groupID,
+				userID:  userID,
+			},
+			expected: addMemberExpectedOutput{
+				member: nil,
+				err:    models.ErrConflictingData,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			groupRepo := mock2.NewMockGroupRepository(ctrl)
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+
+			tc.mocks(groupRepo, userRepo, roleRepo, cache, policy)
+
+			groupService := services.NewGroupService(groupRepo, userRepo, roleRepo, cache, policy)
+
+			member, err := groupService.AddMember(ctx, tc.input.groupID, tc.input.userID)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.member, member, "Member mismatch")
+		})
+	}
+}
+
+type assignGroupRoleTestedInput struct {
+	groupID uint64
+	role    models.UserRole
+}
+
+type assignGroupRoleExpectedOutput struct {
+	err error
+}
+
+func TestGroupService_AssignRole(t *testing.T) {
+	ctx := context.Background()
+	groupID := gofakeit.Uint64()
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			groupRepo *mock2.MockGroupRepository,
+			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
+			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+		)
+		input    assignGroupRoleTestedInput
+		expected assignGroupRoleExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(groupID)).
+					Return(&models.Group{ID: groupID}, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
+				groupRepo.EXPECT().
+					AddRole(gomock.Any(), gomock.Eq(groupID), gomock.Eq(models.Cashier)).
+					Return(nil)
+				policy.EXPECT().
+					AddGroupRole(gomock.Any(), gomock.Eq(groupID), gomock.Eq(models.Cashier)).
+					Return(nil)
+			},
+			input: assignGroupRoleTestedInput{
+				groupID: groupID,
+				role:    models.Cashier,
+			},
+			expected: assignGroupRoleExpectedOutput{
+				err: nil,
+			},
+		},
+		{
+			desc: "Fail_InvalidRole",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(groupID)).
+					Return(&models.Group{ID: groupID}, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.UserRole("ghost"))).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: assignGroupRoleTestedInput{
+				groupID: groupID,
+				role:    "ghost",
+			},
+			expected: assignGroupRoleExpectedOutput{
+				err: models.ErrInvalidRole,
+			},
+		},
+		{
+			desc: "Fail_SyncPolicy",
+			mocks: func(
+				groupRepo *mock2.MockGroupRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				groupRepo.EXPECT().
+					GetGroupByID(gomock.Any(), gomock.Eq(groupID)).
+					Return(&models.Group{ID: groupID}, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
+				groupRepo.EXPECT().
+					AddRole(gomock.Any(), gomock.Eq(groupID), gomock.Eq(models.Cashier)).
+					Return(nil)
+				policy.EXPECT().
+					AddGroupRole(gomock.Any(), gomock.Eq(groupID), gomock.Eq(models.Cashier)).
+					Return(models.ErrInternal)
+			},
+			input: assignGroupRoleTestedInput{
+				groupID: groupID,
+				role:    models.Cashier,
+			},
+			expected: assignGroupRoleExpectedOutput{
+				err: models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			groupRepo := mock2.NewMockGroupRepository(ctrl)
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+
+			tc.mocks(groupRepo, userRepo, roleRepo, cache, policy)
+
+			groupService := services.NewGroupService(groupRepo, userRepo, roleRepo, cache, policy)
+
+			err := groupService.AssignRole(ctx, tc.input.groupID, tc.input.role)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * InvitationService implements ports.InvitationService interface
+ * and provides an access to the invitation, user and role repositories,
+ * user, link and notification services
+ */
+type InvitationService struct {
+	repo     ports.InvitationRepository
+	userRepo ports.UserRepository
+	roleRepo ports.RoleRepository
+	userSvc  ports.UserService
+	link     ports.LinkService
+	notifier ports.NotificationService
+}
+
+// NewInvitationService creates a new invitation services instance
+func NewInvitationService(
+	repo ports.InvitationRepository,
+	userRepo ports.UserRepository,
+	roleRepo ports.RoleRepository,
+	userSvc ports.UserService,
+	link ports.LinkService,
+	notifier ports.NotificationService,
+) *InvitationService {
+	return &InvitationService{
+		repo,
+		userRepo,
+		roleRepo,
+		userSvc,
+		link,
+		notifier,
+	}
+}
+
+// Invite creates an invitation with a pre-assigned role and emails its link to the invitee
+func (is *InvitationService) Invite(ctx context.Context, invitation *models.Invitation) (*models.Invitation, error) {
+	_, err := is.roleRepo.GetRoleByName(ctx, invitation.Role)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, models.ErrInvalidRole
+		}
+		return nil, models.ErrInternal
+	}
+
+	_, err = is.userRepo.GetUserByEmail(ctx, invitation.Email)
+	if err == nil {
+		return nil, models.ErrConflictingData
+	}
+	if err != models.ErrDataNotFound {
+		return nil, models.ErrInternal
+	}
+
+	pending, err := is.repo.GetPendingInvitationByEmail(ctx, invitation.Email)
+	if err != nil && err != models.ErrDataNotFound {
+		return nil, models.ErrInternal
+	}
+	if err == nil {
+		if pending.IsPending(time.Now()) {
+			return nil, models.ErrConflictingData
+		}
+
+		err = is.repo.RevokeInvitation(ctx, pending.ID)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+	}
+
+	tokenID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	link, expiresAt, err := is.link.CreateLink(ctx, models.LinkInvitation, tokenID.String())
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	invitation.TokenID = tokenID.String()
+	invitation.ExpiresAt = expiresAt
+
+	invitation, err = is.repo.CreateInvitation(ctx, invitation)
+	if err != nil {
+		if err == models.ErrInvalidRole {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	notification := &models.Notification{
+		Recipient: invitation.Email,
+		Subject:   "You have been invited",
+		Body: fmt.Sprintf(
+			"You have been invited to join as %s.\n\nAccept the invitation before %s:\n%s\n",
+			invitation.Role,
+			invitation.ExpiresAt.Format(time.RFC1123),
+			link,
+		),
+	}
+
+	err = is.notifier.Send(ctx, notification)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return invitation, nil
+}
+
+// ListInvitations lists all invitations
+func (is *InvitationService) ListInvitations(ctx context.Context, skip, limit uint64) ([]models.Invitation, error) {
+	invitations, err := is.repo.ListInvitations(ctx, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return invitations, nil
+}
+
+// RevokeInvitation revokes a pending invitation so its link can no longer be used
+func (is *InvitationService) RevokeInvitation(ctx context.Context, id uint64) error {
+	invitation, err := is.repo.GetInvitationByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	if invitation.AcceptedAt != nil || invitation.RevokedAt != nil {
+		return models.ErrInvalidLink
+	}
+
+	err = is.repo.RevokeInvitation(ctx, id)
+	if err != nil {
+		if err == models.ErrInvalidLink {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// AcceptInvitation verifies the invitation link and registers the invitee with the invited role
+func (is *InvitationService) AcceptInvitation(ctx context.Context, token string, user *models.User) (*models.User, error) {
+	tokenID, tenantID, err := is.link.VerifyLink(models.LinkInvitation, token)
+	if err != nil {
+		if err == models.ErrExpiredLink {
+			return nil, err
+		}
+		return nil, models.ErrInvalidLink
+	}
+	ctx = _constant.WithTenantID(ctx, tenantID)
+
+	invitation, err := is.repo.GetInvitationByTokenID(ctx, tokenID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, models.ErrInvalidLink
+		}
+		return nil, models.ErrInternal
+	}
+
+	if invitation.AcceptedAt != nil || invitation.RevokedAt != nil {
+		return nil, models.ErrInvalidLink
+	}
+	if !invitation.IsPending(time.Now()) {
+		return nil, models.ErrExpiredLink
+	}
+
+	user.Email = invitation.Email
+	user.Role = invitation.Role
+
+	user, err = is.userSvc.Register(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = is.repo.AcceptInvitation(ctx, invitation.ID)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return user, nil
+}
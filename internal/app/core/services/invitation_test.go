@@ -0,0 +1,391 @@
+package services_test
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type inviteTestedInput struct {
+	invitation *models.Invitation
+}
+
+type inviteExpectedOutput struct {
+	invitation *models.Invitation
+	err        error
+}
+
+func TestInvitationService_Invite(t *testing.T) {
+	ctx := context.Background()
+	email := gofakeit.Email()
+	expiresAt := time.Now().Add(72 * time.Hour)
+
+	invitation := &models.Invitation{
+		ID:        gofakeit.Uint64(),
+		Email:     email,
+		Role:      models.Cashier,
+		InvitedBy: gofakeit.Uint64(),
+		ExpiresAt: expiresAt,
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			invitationRepo *mock2.MockInvitationRepository,
+			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
+			userSvc *mock2.MockUserService,
+			link *mock2.MockLinkService,
+			notifier *mock2.MockNotificationService,
+		)
+		input    inviteTestedInput
+		expected inviteExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				invitationRepo *mock2.MockInvitationRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				userSvc *mock2.MockUserService,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Return(nil, models.ErrDataNotFound)
+				invitationRepo.EXPECT().
+					GetPendingInvitationByEmail(gomock.Any(), gomock.Eq(email)).
+					Return(nil, models.ErrDataNotFound)
+				link.EXPECT().
+					CreateLink(gomock.Any(), gomock.Eq(models.LinkInvitation), gomock.Any()).
+					Return("http://localhost:8080/invitation?token=v4.local.x", expiresAt, nil)
+				invitationRepo.EXPECT().
+					CreateInvitation(gomock.Any(), gomock.Any()).
+					Return(invitation, nil)
+				notifier.EXPECT().
+					Send(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			input: inviteTestedInput{
+				invitation: &models.Invitation{
+					Email:     email,
+					Role:      models.Cashier,
+					InvitedBy: invitation.InvitedBy,
+				},
+			},
+			expected: inviteExpectedOutput{
+				invitation: invitation,
+				err:        nil,
+			},
+		},
+		{
+			desc: "Fail_InvalidRole",
+			mocks: func(
+				invitationRepo *mock2.MockInvitationRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				userSvc *mock2.MockUserService,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.UserRole("manager"))).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: inviteTestedInput{
+				invitation: &models.Invitation{
+					Email: email,
+					Role:  "manager",
+				},
+			},
+			expected: inviteExpectedOutput{
+				invitation: nil,
+				err:        models.ErrInvalidRole,
+			},
+		},
+		{
+			desc: "Fail_ExistingUser",
+			mocks: func(
+				invitationRepo *mock2.MockInvitationRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				userSvc *mock2.MockUserService,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Return(&models.User{Email: email}, nil)
+			},
+			input: inviteTestedInput{
+				invitation: &models.Invitation{
+					Email: email,
+					Role:  models.Cashier,
+				},
+			},
+			expected: inviteExpectedOutput{
+				invitation: nil,
+				err:        models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_PendingInvitation",
+			mocks: func(
+				invitationRepo *mock2.MockInvitationRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				userSvc *mock2.MockUserService,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
+				userRepo.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(email)).
+					Return(nil, models.ErrDataNotFound)
+				invitationRepo.EXPECT().
+					GetPendingInvitationByEmail(gomock.Any(), gomock.Eq(email)).
+					Return(invitation, nil)
+			},
+			input: inviteTestedInput{
+				invitation: &models.Invitation{
+					Email: email,
+					Role:  models.Cashier,
+				},
+			},
+			expected: inviteExpectedOutput{
+				invitation: nil,
+				err:        models.ErrConflictingData,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			invitationRepo := mock2.NewMockInvitationRepository(ctrl)
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			userSvc := mock2.NewMockUserService(ctrl)
+			link := mock2.NewMockLinkService(ctrl)
+			notifier := mock2.NewMockNotificationService(ctrl)
+
+			tc.mocks(invitationRepo, userRepo, roleRepo, userSvc, link, notifier)
+
+			invitationService := services.NewInvitationService(invitationRepo, userRepo, roleRepo, userSvc, link, notifier)
+
+			invitation, err := invitationService.Invite(ctx, tc.input.invitation)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.invitation, invitation, "Invitation mismatch")
+		})
+	}
+}
+
+type acceptInvitationTestedInput struct {
+	token string
+	user  *models.User
+}
+
+type acceptInvitationExpectedOutput struct {
+	user *models.User
+	err  error
+}
+
+func TestInvitationService_AcceptInvitation(t *testing.T) {
+	ctx := context.Background()
+	token := "v4.local.x"
+	tokenID := gofakeit.UUID()
+	email := gofakeit.Email()
+	name := gofakeit.Name()
+	password := gofakeit.Password(true, true, true, true, false, 8)
+	acceptedAt := time.Now()
+
+	invitation := &models.Invitation{
+		ID:        gofakeit.Uint64(),
+		Email:     email,
+		Role:      models.Cashier,
+		TokenID:   tokenID,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	registered := &models.User{
+		ID:    gofakeit.Uint64(),
+		Name:  name,
+		Email: email,
+		Role:  models.Cashier,
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			invitationRepo *mock2.MockInvitationRepository,
+			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
+			userSvc *mock2.MockUserService,
+			link *mock2.MockLinkService,
+			notifier *mock2.MockNotificationService,
+		)
+		input    acceptInvitationTestedInput
+		expected acceptInvitationExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				invitationRepo *mock2.MockInvitationRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				userSvc *mock2.MockUserService,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkInvitation), gomock.Eq(token)).
+					Return(tokenID, models.DefaultTenantID, nil)
+				invitationRepo.EXPECT().
+					GetInvitationByTokenID(gomock.Any(), gomock.Eq(tokenID)).
+					Return(invitation, nil)
+				userSvc.EXPECT().
+					Register(gomock.Any(), gomock.Eq(&models.User{
+						Name:     name,
+						Email:    email,
+						Password: password,
+						Role:     models.Cashier,
+					})).
+					Return(registered, nil)
+				invitationRepo.EXPECT().
+					AcceptInvitation(gomock.Any(), gomock.Eq(invitation.ID)).
+					Return(nil)
+			},
+			input: acceptInvitationTestedInput{
+				token: token,
+				user: &models.User{
+					Name:     name,
+					Password: password,
+				},
+			},
+			expected: acceptInvitationExpectedOutput{
+				user: registered,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_ExpiredLink",
+			mocks: func(
+				invitationRepo *mock2.MockInvitationRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				userSvc *mock2.MockUserService,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkInvitation), gomock.Eq(token)).
+					Return("", uint64(0), models.ErrExpiredLink)
+			},
+			input: acceptInvitationTestedInput{
+				token: token,
+				user:  &models.User{Name: name, Password: password},
+			},
+			expected: acceptInvitationExpectedOutput{
+				user: nil,
+				err:  models.ErrExpiredLink,
+			},
+		},
+		{
+			desc: "Fail_TamperedLink",
+			mocks: func(
+				invitationRepo *mock2.MockInvitationRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				userSvc *mock2.MockUserService,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkInvitation), gomock.Eq(token)).
+					Return("", uint64(0), models.ErrInternal)
+			},
+			input: acceptInvitationTestedInput{
+				token: token,
+				user:  &models.User{Name: name, Password: password},
+			},
+			expected: acceptInvitationExpectedOutput{
+				user: nil,
+				err:  models.ErrInvalidLink,
+			},
+		},
+		{
+			desc: "Fail_AlreadyAccepted",
+			mocks: func(
+				invitationRepo *mock2.MockInvitationRepository,
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				userSvc *mock2.MockUserService,
+				link *mock2.MockLinkService,
+				notifier *mock2.MockNotificationService,
+			) {
+				link.EXPECT().
+					VerifyLink(gomock.Eq(models.LinkInvitation), gomock.Eq(token)).
+					Return(tokenID, models.DefaultTenantID, nil)
+				invitationRepo.EXPECT().
+					GetInvitationByTokenID(gomock.Any(), gomock.Eq(tokenID)).
+					Return(&models.Invitation{
+						ID:         invitation.ID,
+						TokenID:    tokenID,
+						ExpiresAt:  invitation.ExpiresAt,
+						AcceptedAt: &acceptedAt,
+					}, nil)
+			},
+			input: acceptInvitationTestedInput{
+				token: token,
+				user:  &models.User{Name: name, Password: password},
+			},
+			expected: acceptInvitationExpectedOutput{
+				user: nil,
+				err:  models.ErrInvalidLink,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			invitationRepo := mock2.NewMockInvitationRepository(ctrl)
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			userSvc := mock2.NewMockUserService(ctrl)
+			link := mock2.NewMockLinkService(ctrl)
+			notifier := mock2.NewMockNotificationService(ctrl)
+
+			tc.mocks(invitationRepo, userRepo, roleRepo, userSvc, link, notifier)
+
+			invitationService := services.NewInvitationService(invitationRepo, userRepo, roleRepo, userSvc, link, notifier)
+
+			user, err := invitationService.AcceptInvitation(ctx, tc.input.token, tc.input.user)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.user, user, "User mismatch")
+		})
+	}
+}
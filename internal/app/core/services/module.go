@@ -10,5 +10,25 @@ var Module = fx.Module(
 	fx.Provide(
 		fx.Annotate(NewUserService, fx.As(new(ports.UserService))),
 		fx.Annotate(NewAuthService, fx.As(new(ports.AuthService))),
+		fx.Annotate(NewRoleService, fx.As(new(ports.RoleService))),
+		fx.Annotate(NewGroupService, fx.As(new(ports.GroupService))),
+		fx.Annotate(NewInvitationService, fx.As(new(ports.InvitationService))),
+		fx.Annotate(NewEmailChangeService, fx.As(new(ports.EmailChangeService))),
+		fx.Annotate(NewAuditService, fx.As(new(ports.AuditService))),
+		fx.Annotate(NewAvatarService, fx.As(new(ports.AvatarService))),
+		fx.Annotate(NewPreferenceService, fx.As(new(ports.PreferenceService))),
+		fx.Annotate(NewCategoryService, fx.As(new(ports.CategoryService))),
+		fx.Annotate(NewProductService, fx.As(new(ports.ProductService))),
+		fx.Annotate(NewOrderService, fx.As(new(ports.OrderService))),
+		fx.Annotate(NewStockService, fx.As(new(ports.StockService))),
+		fx.Annotate(NewReportService, fx.As(new(ports.ReportService))),
+		fx.Annotate(NewPromotionService, fx.As(new(ports.PromotionService))),
+		fx.Annotate(NewRefundService, fx.As(new(ports.RefundService))),
+		fx.Annotate(NewStoreService, fx.As(new(ports.StoreService))),
+		fx.Annotate(NewReceiptService, fx.As(new(ports.ReceiptService))),
+		fx.Annotate(NewTaxService, fx.As(new(ports.TaxService))),
+		fx.Annotate(NewCustomerService, fx.As(new(ports.CustomerService))),
+		fx.Annotate(NewAuthzService, fx.As(new(ports.AuthzService))),
+		fx.Annotate(NewTenantService, fx.As(new(ports.TenantService))),
 	),
 )
@@ -0,0 +1,479 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"slices"
+	"time"
+)
+
+/**
+ * OrderService implements ports.OrderService interface
+ * and provides an access to the order, product, promotion, store,
+ * tax and customer repositories, cache service, stock alerter
+ * and loyalty program
+ */
+type OrderService struct {
+	orderRepo     ports.OrderRepository
+	productRepo   ports.ProductRepository
+	promotionRepo ports.PromotionRepository
+	storeRepo     ports.StoreRepository
+	taxRepo       ports.TaxRepository
+	customerRepo  ports.CustomerRepository
+	cache         ports.CacheRepository
+	alerter       ports.StockAlerter
+	loyalty       ports.LoyaltyProgram
+}
+
+// NewOrderService creates a new order services instance
+func NewOrderService(
+	orderRepo ports.OrderRepository,
+	productRepo ports.ProductRepository,
+	promotionRepo ports.PromotionRepository,
+	storeRepo ports.StoreRepository,
+	taxRepo ports.TaxRepository,
+	customerRepo ports.CustomerRepository,
+	cache ports.CacheRepository,
+	alerter ports.StockAlerter,
+	loyalty ports.LoyaltyProgram,
+) *OrderService {
+	return &OrderService{
+		orderRepo,
+		productRepo,
+		promotionRepo,
+		storeRepo,
+		taxRepo,
+		customerRepo,
+		cache,
+		alerter,
+		loyalty,
+	}
+}
+
+// CreateOrder prices the items at the current product prices, applies the available promotions
+// and the coupon, if any, taxes the discounted items at the rates of the store, checks the payment
+// covers the total unless the order is opened to be paid later and places the order, decrementing
+// the stock of the products it sells in the store of the order. An order paid with points is paid in
+// full with the points of its customer, any other paid order of a customer earns them points
+func (ors *OrderService) CreateOrder(ctx context.Context, order *models.Order) (*models.Order, error) {
+	if order.StoreID == 0 {
+		return nil, models.ErrStoreRequired
+	}
+
+	// an open order is paid for when it is settled, points would pay for it at once
+	if order.Status == models.OrderOpen {
+		if order.TotalPaid != 0 || order.PaymentMethod == models.PaymentPoints {
+			return nil, models.ErrInvalidPayment
+		}
+	} else {
+		order.Status = models.OrderPaid
+	}
+
+	var customer *models.Customer
+	if order.CustomerID != nil {
+		var err error
+		customer, err = ors.customerRepo.GetCustomerByID(ctx, *order.CustomerID)
+		if err != nil {
+			if err == models.ErrDataNotFound {
+				return nil, models.ErrInvalidCustomer
+			}
+			return nil, models.ErrInternal
+		}
+	}
+	if order.PaymentMethod == models.PaymentPoints && customer == nil {
+		return nil, models.ErrCustomerRequired
+	}
+
+	order.Items = mergeOrderItems(order.Items)
+	order.CouponCode = normalizeCouponCode(order.CouponCode)
+	order.TotalPrice = 0
+	order.TotalDiscount = 0
+	order.TotalTax = 0
+	order.PointsEarned = 0
+	order.PointsRedeemed = 0
+
+	products := make([]*models.Product, len(order.Items))
+	for i := range order.Items {
+		item := &order.Items[i]
+
+		product, err := ors.productRepo.GetProductByID(ctx, item.ProductID)
+		if err != nil {
+			if err == models.ErrDataNotFound {
+				return nil, models.ErrInvalidProduct
+			}
+			return nil, models.ErrInternal
+		}
+
+		// the stock across every store, checked again in the store under lock by the repository,
+		// this only fails early
+		if product.Stock < item.Quantity {
+			return nil, models.ErrInsufficientStock
+		}
+
+		products[i] = product
+		item.SKU = product.SKU
+		item.Name = product.Name
+		item.Price = product.Price
+		item.TotalPrice = product.Price * item.Quantity
+		order.TotalPrice += item.TotalPrice
+	}
+
+	promotions, err := ors.availablePromotions(ctx, order.CouponCode, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	discounts, amounts := applyPromotions(order.Items, products, promotions)
+	order.Discounts = discounts
+
+	couponApplied := false
+	for _, discount := range order.Discounts {
+		order.TotalDiscount += discount.Amount
+		if discount.CouponCode != "" {
+			couponApplied = true
+		}
+	}
+	if order.CouponCode != "" && !couponApplied {
+		return nil, models.ErrInvalidCoupon
+	}
+	order.TotalPrice -= order.TotalDiscount
+
+	order.Taxes, err = ors.orderTaxes(ctx, order.StoreID, products, amounts)
+	if err != nil {
+		return nil, err
+	}
+
+	// inclusive taxes are already in the prices, exclusive ones are added on top
+	for _, tax := range order.Taxes {
+		order.TotalTax += tax.Amount
+		if !tax.Inclusive {
+			order.TotalPrice += tax.Amount
+		}
+	}
+
+	// points pay the total exactly, the balance is checked again under lock by the repository
+	if order.PaymentMethod == models.PaymentPoints {
+		order.PointsRedeemed = ors.loyalty.RedeemCost(order.TotalPrice)
+		if customer.Points < order.PointsRedeemed {
+			return nil, models.ErrInsufficientPoints
+		}
+		order.TotalPaid = order.TotalPrice
+	}
+
+	// an open order is settled later, and is voided if it never is
+	if !order.IsOpen() {
+		if order.TotalPaid < order.TotalPrice {
+			return nil, models.ErrInsufficientPayment
+		}
+		order.TotalChange = order.TotalPaid - order.TotalPrice
+
+		if customer != nil && order.PaymentMethod != models.PaymentPoints {
+			order.PointsEarned = ors.loyalty.PointsEarned(order.TotalPrice)
+		}
+	}
+
+	order, movements, err := ors.orderRepo.CreateOrder(ctx, order)
+	if err != nil {
+		if err == models.ErrInsufficientStock || err == models.ErrInvalidProduct ||
+			err == models.ErrPromotionExhausted || err == models.ErrInvalidStore ||
+			err == models.ErrInvalidCustomer || err == models.ErrInsufficientPoints {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("order", order.ID)
+	orderSerialized, err := utils.Serialize(order)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ors.cache.Set(ctx, cacheKey, orderSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ors.cache.DeleteByPrefix(ctx, "orders:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	productIDs := make([]uint64, len(order.Items))
+	for i, item := range order.Items {
+		productIDs[i] = item.ProductID
+	}
+
+	err = invalidateStockCache(ctx, ors.cache, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// the usage counts of the applied promotions have changed
+	if len(order.Discounts) > 0 {
+		err = invalidatePromotionCache(ctx, ors.cache, order.Discounts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if order.PointsEarned > 0 || order.PointsRedeemed > 0 {
+		err = invalidateCustomerCache(ctx, ors.cache, *order.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the movements are in the same order as the items
+	for i := range movements {
+		alertLowStock(ctx, ors.alerter, products[i], &movements[i])
+	}
+
+	return order, nil
+}
+
+// SettleOrder takes the payment of an open order placed at the store of the payment, or at any store
+// when it has none. The payment has to cover the total, and the customer of the order earns points for it
+func (ors *OrderService) SettleOrder(ctx context.Context, payment *models.Order) (*models.Order, error) {
+	order, err := ors.orderRepo.GetOrderByID(ctx, payment.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	if !inStore(order.StoreID, payment.StoreID) {
+		return nil, models.ErrDataNotFound
+	}
+	if !order.IsOpen() {
+		return nil, models.ErrOrderNotOpen
+	}
+	if payment.PaymentMethod == models.PaymentPoints {
+		return nil, models.ErrInvalidPayment
+	}
+	if payment.TotalPaid < order.TotalPrice {
+		return nil, models.ErrInsufficientPayment
+	}
+
+	order.PaymentMethod = payment.PaymentMethod
+	order.TotalPaid = payment.TotalPaid
+	order.TotalChange = order.TotalPaid - order.TotalPrice
+	if order.CustomerID != nil {
+		order.PointsEarned = ors.loyalty.PointsEarned(order.TotalPrice)
+	}
+
+	order, err = ors.orderRepo.SettleOrder(ctx, order)
+	if err != nil {
+		if err == models.ErrDataNotFound || err == models.ErrOrderNotOpen {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("order", order.ID)
+	orderSerialized, err := utils.Serialize(order)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ors.cache.Set(ctx, cacheKey, orderSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ors.cache.DeleteByPrefix(ctx, "orders:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	if order.PointsEarned > 0 {
+		err = invalidateCustomerCache(ctx, ors.cache, *order.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// GetOrder gets an order by ID, an order of another store than the given one is not found
+func (ors *OrderService) GetOrder(ctx context.Context, storeID, id uint64) (*models.Order, error) {
+	var order *models.Order
+
+	cacheKey := utils.GenerateCacheKey("order", id)
+	cachedOrder, err := ors.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedOrder, &order)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		if !inStore(order.StoreID, storeID) {
+			return nil, models.ErrDataNotFound
+		}
+		return order, nil
+	}
+
+	order, err = ors.orderRepo.GetOrderByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	orderSerialized, err := utils.Serialize(order)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ors.cache.Set(ctx, cacheKey, orderSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	if !inStore(order.StoreID, storeID) {
+		return nil, models.ErrDataNotFound
+	}
+
+	return order, nil
+}
+
+// ListOrders lists the orders of a store, or of every store when storeID is zero
+func (ors *OrderService) ListOrders(ctx context.Context, storeID, skip, limit uint64) ([]models.Order, error) {
+	var orders []models.Order
+
+	params := utils.GenerateCacheKeyParams(skip, limit, storeID)
+	cacheKey := utils.GenerateCacheKey("orders", params)
+
+	cachedOrders, err := ors.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedOrders, &orders)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return orders, nil
+	}
+
+	orders, err = ors.orderRepo.ListOrders(ctx, storeID, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	ordersSerialized, err := utils.Serialize(orders)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ors.cache.Set(ctx, cacheKey, ordersSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return orders, nil
+}
+
+// availablePromotions returns the automatic promotions that can be applied at the given time
+// and, when a coupon code is given, the promotion it unlocks
+func (ors *OrderService) availablePromotions(ctx context.Context, couponCode string, at time.Time) ([]models.Promotion, error) {
+	promotions, err := ors.promotionRepo.ListAutomaticPromotions(ctx, at)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	promotions = slices.DeleteFunc(promotions, func(p models.Promotion) bool {
+		return p.IsExhausted()
+	})
+
+	if couponCode == "" {
+		return promotions, nil
+	}
+
+	coupon, err := ors.promotionRepo.GetPromotionByCouponCode(ctx, couponCode)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, models.ErrInvalidCoupon
+		}
+		return nil, models.ErrInternal
+	}
+
+	if !coupon.IsAvailable(at) {
+		return nil, models.ErrInvalidCoupon
+	}
+	if coupon.IsExhausted() {
+		return nil, models.ErrPromotionExhausted
+	}
+
+	return append(promotions, *coupon), nil
+}
+
+// orderTaxes works out the taxes of the discounted items of an order at the rates of its store,
+// an order of untaxed products needs neither the store nor its rates
+func (ors *OrderService) orderTaxes(ctx context.Context, storeID uint64, products []*models.Product, amounts []int64) ([]models.OrderTax, error) {
+	taxed := slices.ContainsFunc(products, func(p *models.Product) bool {
+		return p.TaxClassID != nil
+	})
+	if !taxed {
+		return nil, nil
+	}
+
+	store, err := ors.storeRepo.GetStoreByID(ctx, storeID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, models.ErrInvalidStore
+		}
+		return nil, models.ErrInternal
+	}
+
+	rates, err := ors.taxRepo.ListStoreTaxRates(ctx, store)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return calculateTaxes(products, amounts, rates, store.TaxRounding), nil
+}
+
+// invalidatePromotionCache removes the cached promotions an order was discounted by
+func invalidatePromotionCache(ctx context.Context, cache ports.CacheRepository, discounts []models.OrderDiscount) error {
+	for _, discount := range discounts {
+		err := cache.Delete(ctx, utils.GenerateCacheKey("promotion", discount.PromotionID))
+		if err != nil {
+			return models.ErrInternal
+		}
+	}
+
+	err := cache.DeleteByPrefix(ctx, "promotions:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// mergeOrderItems adds up the quantities of the items selling the same product and sorts them
+// by product, so concurrent orders lock the product rows in the same order and cannot deadlock
+func mergeOrderItems(items []models.OrderItem) []models.OrderItem {
+	var merged []models.OrderItem
+
+	for _, item := range items {
+		i := slices.IndexFunc(merged, func(m models.OrderItem) bool {
+			return m.ProductID == item.ProductID
+		})
+		if i == -1 {
+			merged = append(merged, models.OrderItem{
+				ProductID: item.ProductID,
+				Quantity:  item.Quantity,
+			})
+			continue
+		}
+		merged[i].Quantity += item.Quantity
+	}
+
+	slices.SortFunc(merged, func(a, b models.OrderItem) int {
+		return cmp.Compare(a.ProductID, b.ProductID)
+	})
+
+	return merged
+}
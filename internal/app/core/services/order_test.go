@@ -0,0 +1,1585 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type orderExpectedOutput struct {
+	order *models.Order
+	err   error
+}
+
+func TestOrderService_CreateOrder(t *testing.T) {
+	ctx := context.Background()
+	userID := gofakeit.Uint64()
+
+	cola := &models.Product{ID: 1, SKU: "BEV-COLA-330", Name: "Cola 330ml", Price: 1500, Stock: 10, LowStockThreshold: 5}
+	chips := &models.Product{ID: 2, SKU: "SNK-CHIPS-80", Name: "Chips 80g", Price: 2250, Stock: 1}
+
+	// the priced order the repository is asked to place, cola is ordered twice and merged
+	pricedOrder := &models.Order{
+		UserID:        userID,
+		Status:        models.OrderPaid,
+		StoreID:       1,
+		PaymentMethod: models.PaymentCash,
+		TotalPrice:    3*1500 + 2250,
+		TotalPaid:     10000,
+		TotalChange:   10000 - (3*1500 + 2250),
+		Items: []models.OrderItem{
+			{ProductID: 1, SKU: cola.SKU, Name: cola.Name, Quantity: 3, Price: 1500, TotalPrice: 4500},
+			{ProductID: 2, SKU: chips.SKU, Name: chips.Name, Quantity: 1, Price: 2250, TotalPrice: 2250},
+		},
+	}
+	orderOutput := *pricedOrder
+	orderOutput.ID = gofakeit.Uint64()
+	orderOutput.CreatedAt = time.Now()
+	orderOutput.UpdatedAt = time.Now()
+
+	// cola stays above its threshold, chips sell out and reach theirs
+	movements := []models.StockMovement{
+		{ID: 1, ProductID: 1, Type: models.StockSale, Quantity: -3, StockAfter: 7, OrderID: &orderOutput.ID, UserID: &userID},
+		{ID: 2, ProductID: 2, Type: models.StockSale, Quantity: -1, StockAfter: 0, OrderID: &orderOutput.ID, UserID: &userID},
+	}
+
+	cacheKey := util2.GenerateCacheKey("order", orderOutput.ID)
+	orderSerialized, _ := util2.Serialize(&orderOutput)
+	ttl := time.Duration(0)
+
+	openOrder := &models.Order{
+		UserID:        userID,
+		Status:        models.OrderOpen,
+		StoreID:       1,
+		PaymentMethod: models.PaymentCash,
+		TotalPrice:    1500,
+		Items: []models.OrderItem{
+			{ProductID: 1, SKU: cola.SKU, Name: cola.Name, Quantity: 1, Price: 1500, TotalPrice: 1500},
+		},
+	}
+	openOutput := *openOrder
+	openOutput.ID = gofakeit.Uint64()
+	openMovements := []models.StockMovement{
+		{ID: 3, ProductID: 1, Type: models.StockSale, Quantity: -1, StockAfter: 9, OrderID: &openOutput.ID, UserID: &userID},
+	}
+	openSerialized, _ := util2.Serialize(&openOutput)
+
+	input := func(paid int64, items ...models.OrderItem) *models.Order {
+		return &models.Order{
+			UserID:        userID,
+			StoreID:       1,
+			PaymentMethod: models.PaymentCash,
+			TotalPaid:     paid,
+			Items:         items,
+		}
+	}
+	opened := func(order *models.Order) *models.Order {
+		order.Status = models.OrderOpen
+		return order
+	}
+	items := []models.OrderItem{
+		{ProductID: 2, Quantity: 1},
+		{ProductID: 1, Quantity: 1},
+		{ProductID: 1, Quantity: 2},
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			orderRepo *mock2.MockOrderRepository,
+			productRepo *mock2.MockProductRepository,
+			promotionRepo *mock2.MockPromotionRepository,
+			storeRepo *mock2.MockStoreRepository,
+			taxRepo *mock2.MockTaxRepository,
+			customerRepo *mock2.MockCustomerRepository,
+			cache *mock2.MockCacheRepository,
+			alerter *mock2.MockStockAlerter,
+			loyalty *mock2.MockLoyaltyProgram,
+		)
+		input    *models.Order
+		expected orderExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(1))).
+					Return(cola, nil)
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(2))).
+					Return(chips, nil)
+				promotionRepo.EXPECT().
+					ListAutomaticPromotions(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Eq(pricedOrder)).
+					Return(&orderOutput, movements, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(orderSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("orders:*")).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("product", 1))).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("product", 2))).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("stock-movements:*")).
+					Return(nil)
+				alerter.EXPECT().
+					LowStock(gomock.Any(), gomock.Eq(&models.Product{
+						ID: 2, SKU: chips.SKU, Name: chips.Name, Price: chips.Price, Stock: 0,
+					}), gomock.Eq(&movements[1])).
+					Return(nil)
+			},
+			input: input(10000, items...),
+			expected: orderExpectedOutput{
+				order: &orderOutput,
+				err:   nil,
+			},
+		},
+		{
+			desc: "Fail_InvalidProduct",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(1))).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: input(10000, items...),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrInvalidProduct,
+			},
+		},
+		{
+			desc: "Fail_InsufficientStock",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(2))).
+					Return(chips, nil)
+			},
+			input: input(10000, models.OrderItem{ProductID: 2, Quantity: 2}),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrInsufficientStock,
+			},
+		},
+		{
+			desc: "Fail_InsufficientStockWhenLocked",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				// the last one sold before the row was locked
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(2))).
+					Return(chips, nil)
+				promotionRepo.EXPECT().
+					ListAutomaticPromotions(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Return(nil, nil, models.ErrInsufficientStock)
+			},
+			input: input(10000, models.OrderItem{ProductID: 2, Quantity: 1}),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrInsufficientStock,
+			},
+		},
+		{
+			desc: "Fail_NoStore",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+			},
+			input: func() *models.Order {
+				order := input(10000, items...)
+				order.StoreID = 0
+				return order
+			}(),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrStoreRequired,
+			},
+		},
+		{
+			desc: "Fail_InsufficientPayment",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(1))).
+					Return(cola, nil)
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(2))).
+					Return(chips, nil)
+				promotionRepo.EXPECT().
+					ListAutomaticPromotions(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+			},
+			input: input(6749, items...),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrInsufficientPayment,
+			},
+		},
+		{
+			// only an order opened to be paid later is placed with nothing paid
+			desc: "Fail_NothingPaid",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(1))).
+					Return(cola, nil)
+				promotionRepo.EXPECT().
+					ListAutomaticPromotions(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+			},
+			input: input(0, models.OrderItem{ProductID: 1, Quantity: 1}),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrInsufficientPayment,
+			},
+		},
+		{
+			desc: "Fail_OpenPaid",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+			},
+			input: opened(input(1500, models.OrderItem{ProductID: 1, Quantity: 1})),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrInvalidPayment,
+			},
+		},
+		{
+			// nothing paid yet, the order is settled or voided later
+			desc: "Success_Open",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(1))).
+					Return(cola, nil)
+				promotionRepo.EXPECT().
+					ListAutomaticPromotions(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Eq(openOrder)).
+					Return(&openOutput, openMovements, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("order", openOutput.ID)), gomock.Eq(openSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("orders:*")).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("product", 1))).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("stock-movements:*")).
+					Return(nil)
+			},
+			input: opened(input(0, models.OrderItem{ProductID: 1, Quantity: 1})),
+			expected: orderExpectedOutput{
+				order: &openOutput,
+				err:   nil,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(1))).
+					Return(cola, nil)
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(uint64(2))).
+					Return(chips, nil)
+				promotionRepo.EXPECT().
+					ListAutomaticPromotions(gomock.Any(), gomock.Any()).
+					Return(nil, nil)
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Return(nil, nil, errors.New("connection refused"))
+			},
+			input: input(10000, items...),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			promotionRepo := mock2.NewMockPromotionRepository(ctrl)
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			taxRepo := mock2.NewMockTaxRepository(ctrl)
+			customerRepo := mock2.NewMockCustomerRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			alerter := mock2.NewMockStockAlerter(ctrl)
+			loyalty := mock2.NewMockLoyaltyProgram(ctrl)
+
+			tc.mocks(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			orderService := services.NewOrderService(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			order, err := orderService.CreateOrder(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.order, order, "Order mismatch")
+		})
+	}
+}
+
+func TestOrderService_CreateOrderWithPromotions(t *testing.T) {
+	ctx := context.Background()
+
+	cola := &models.Product{ID: 1, CategoryID: 1, SKU: "BEV-COLA-330", Name: "Cola 330ml", Price: 1500, Stock: 10}
+	chips := &models.Product{ID: 2, CategoryID: 2, SKU: "SNK-CHIPS-80", Name: "Chips 80g", Price: 2250, Stock: 10}
+
+	colaID := cola.ID
+	beverages := cola.CategoryID
+	snacks := chips.CategoryID
+	yesterday := time.Now().Add(-24 * time.Hour)
+
+	// three colas and a bag of chips, 4500 + 2250
+	input := func(couponCode string) *models.Order {
+		return &models.Order{
+			StoreID:       1,
+			PaymentMethod: models.PaymentCash,
+			TotalPaid:     10000,
+			CouponCode:    couponCode,
+			Items: []models.OrderItem{
+				{ProductID: 1, Quantity: 3},
+				{ProductID: 2, Quantity: 1},
+			},
+		}
+	}
+
+	beveragesOff := models.Promotion{ID: 3, Name: "Beverages 10% off", Type: models.PromotionPercentage, Value: 10, CategoryID: &beverages, Active: true}
+	threeForTwo := models.Promotion{ID: 4, Name: "Cola 3 for 2", Type: models.PromotionBuyXGetY, ProductID: &colaID, BuyQuantity: 2, GetQuantity: 1, Active: true}
+	orderOff := models.Promotion{ID: 1, Name: "1000 off", Type: models.PromotionFixed, Value: 1000, Active: true}
+	usedUp := models.Promotion{ID: 5, Name: "Used up", Type: models.PromotionFixed, Value: 500, UsageLimit: 10, UsageCount: 10, Active: true}
+	coupon := models.Promotion{ID: 6, Name: "Save 5", Type: models.PromotionFixed, Value: 500, CouponCode: "SAVE5", Active: true}
+	expiredCoupon := coupon
+	expiredCoupon.EndsAt = &yesterday
+	exhaustedCoupon := coupon
+	exhaustedCoupon.UsageLimit = 1
+	exhaustedCoupon.UsageCount = 1
+	snacksCoupon := models.Promotion{ID: 7, Name: "Snacks 2 for 1", Type: models.PromotionBuyXGetY, CategoryID: &snacks, BuyQuantity: 1, GetQuantity: 1, CouponCode: "SAVE5", Active: true}
+
+	type expectedOutput struct {
+		discounts  []models.OrderDiscount
+		totalPrice int64
+		err        error
+	}
+
+	testCases := []struct {
+		desc       string
+		automatic  []models.Promotion
+		coupon     *models.Promotion
+		couponErr  error
+		couponCode string
+		repoErr    error
+		expected   expectedOutput
+	}{
+		{
+			desc:      "Success_CategoryPercentage",
+			automatic: []models.Promotion{beveragesOff},
+			expected: expectedOutput{
+				discounts:  []models.OrderDiscount{{PromotionID: 3, Name: beveragesOff.Name, Amount: 450}},
+				totalPrice: 6750 - 450,
+			},
+		},
+		{
+			desc:      "Success_BuyXGetY",
+			automatic: []models.Promotion{threeForTwo},
+			expected: expectedOutput{
+				discounts:  []models.OrderDiscount{{PromotionID: 4, Name: threeForTwo.Name, Amount: 1500}},
+				totalPrice: 6750 - 1500,
+			},
+		},
+		{
+			// the order-wide promotion is created first but applies last
+			desc:      "Success_ProductPromotionsBeforeOrderWide",
+			automatic: []models.Promotion{orderOff, threeForTwo, beveragesOff},
+			expected: expectedOutput{
+				discounts: []models.OrderDiscount{
+					{PromotionID: 3, Name: beveragesOff.Name, Amount: 450},
+					{PromotionID: 4, Name: threeForTwo.Name, Amount: 1500},
+					{PromotionID: 1, Name: orderOff.Name, Amount: 1000},
+				},
+				totalPrice: 6750 - 450 - 1500 - 1000,
+			},
+		},
+		{
+			desc:      "Success_SkipsExhausted",
+			automatic: []models.Promotion{usedUp},
+			expected: expectedOutput{
+				totalPrice: 6750,
+			},
+		},
+		{
+			desc:       "Success_Coupon",
+			coupon:     &coupon,
+			couponCode: " save5 ",
+			expected: expectedOutput{
+				discounts:  []models.OrderDiscount{{PromotionID: 6, Name: coupon.Name, CouponCode: "SAVE5", Amount: 500}},
+				totalPrice: 6750 - 500,
+			},
+		},
+		{
+			desc:       "Fail_CouponNotFound",
+			couponErr:  models.ErrDataNotFound,
+			couponCode: "SAVE5",
+			expected: expectedOutput{
+				err: models.ErrInvalidCoupon,
+			},
+		},
+		{
+			desc:       "Fail_CouponExpired",
+			coupon:     &expiredCoupon,
+			couponCode: "SAVE5",
+			expected: expectedOutput{
+				err: models.ErrInvalidCoupon,
+			},
+		},
+		{
+			desc:       "Fail_CouponExhausted",
+			coupon:     &exhaustedCoupon,
+			couponCode: "SAVE5",
+			expected: expectedOutput{
+				err: models.ErrPromotionExhausted,
+			},
+		},
+		{
+			// a single bag of chips does not earn a free one
+			desc:       "Fail_CouponDoesNotApply",
+			coupon:     &snacksCoupon,
+			couponCode: "SAVE5",
+			expected: expectedOutput{
+				err: models.ErrInvalidCoupon,
+			},
+		},
+		{
+			desc:      "Fail_ExhaustedWhenPlaced",
+			automatic: []models.Promotion{beveragesOff},
+			repoErr:   models.ErrPromotionExhausted,
+			expected: expectedOutput{
+				err: models.ErrPromotionExhausted,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			promotionRepo := mock2.NewMockPromotionRepository(ctrl)
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			taxRepo := mock2.NewMockTaxRepository(ctrl)
+			customerRepo := mock2.NewMockCustomerRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			alerter := mock2.NewMockStockAlerter(ctrl)
+			loyalty := mock2.NewMockLoyaltyProgram(ctrl)
+
+			orderService := services.NewOrderService(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			productRepo.EXPECT().
+				GetProductByID(gomock.Any(), gomock.Eq(uint64(1))).
+				Return(cola, nil)
+			productRepo.EXPECT().
+				GetProductByID(gomock.Any(), gomock.Eq(uint64(2))).
+				Return(chips, nil)
+			promotionRepo.EXPECT().
+				ListAutomaticPromotions(gomock.Any(), gomock.Any()).
+				Return(tc.automatic, nil)
+			if tc.couponCode != "" {
+				promotionRepo.EXPECT().
+					GetPromotionByCouponCode(gomock.Any(), gomock.Eq("SAVE5")).
+					Return(tc.coupon, tc.couponErr)
+			}
+			// the discounts are what is checked here, not caching
+			orderRepo.EXPECT().
+				CreateOrder(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, []models.StockMovement, error) {
+					if tc.repoErr != nil {
+						return nil, nil, tc.repoErr
+					}
+					return order, nil, nil
+				}).
+				MaxTimes(1)
+			cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			cache.EXPECT().DeleteByPrefix(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			order, err := orderService.CreateOrder(ctx, input(tc.couponCode))
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			if tc.expected.err != nil {
+				assert.Nil(t, order, "Order mismatch")
+				return
+			}
+
+			var totalDiscount int64
+			for _, discount := range tc.expected.discounts {
+				totalDiscount += discount.Amount
+			}
+			assert.Equal(t, tc.expected.discounts, order.Discounts, "Discounts mismatch")
+			assert.Equal(t, totalDiscount, order.TotalDiscount, "Total discount mismatch")
+			assert.Equal(t, tc.expected.totalPrice, order.TotalPrice, "Total price mismatch")
+			assert.Equal(t, order.TotalPaid-tc.expected.totalPrice, order.TotalChange, "Total change mismatch")
+		})
+	}
+}
+
+func TestOrderService_CreateOrderWithTaxes(t *testing.T) {
+	ctx := context.Background()
+
+	beverages := uint64(1)
+	food := uint64(2)
+	cola := &models.Product{ID: 1, SKU: "BEV-COLA-330", Name: "Cola 330ml", Price: 1500, Stock: 10, TaxClassID: &beverages}
+	chips := &models.Product{ID: 2, SKU: "SNK-CHIPS-80", Name: "Chips 80g", Price: 2250, Stock: 10, TaxClassID: &food}
+	gum := &models.Product{ID: 3, SKU: "SNK-GUM-10", Name: "Gum", Price: 120, Stock: 10, TaxClassID: &beverages}
+
+	storeID := uint64(1)
+	store := &models.Store{ID: storeID, Name: "Main store", TaxRounding: models.TaxRoundingLine}
+	regionStore := &models.Store{ID: storeID, Name: "Main store", Region: "ID-JK", TaxRounding: models.TaxRoundingLine}
+	invoiceStore := &models.Store{ID: storeID, Name: "Main store", Region: "ID-JK", TaxRounding: models.TaxRoundingInvoice}
+
+	vat := models.TaxRate{ID: 1, TaxClassID: beverages, Name: "VAT", Rate: 110000, Inclusive: true}
+	foodVAT := models.TaxRate{ID: 2, TaxClassID: food, Name: "VAT", Rate: 50000, Inclusive: true}
+	salesTax := models.TaxRate{ID: 3, TaxClassID: beverages, Name: "Sales tax", Rate: 87500, Region: "ID-JK"}
+	storeVAT := models.TaxRate{ID: 4, TaxClassID: beverages, Name: "VAT", Rate: 100000, Inclusive: true, StoreID: &storeID}
+	cityTax := models.TaxRate{ID: 5, TaxClassID: beverages, Name: "City tax", Rate: 10000}
+
+	orderOff := models.Promotion{ID: 1, Name: "1000 off", Type: models.PromotionFixed, Value: 1000, Active: true}
+
+	// three colas, a bag of chips and a pack of gum, 4500 + 2250 + 120
+	input := func(paid int64) *models.Order {
+		return &models.Order{
+			StoreID:       storeID,
+			PaymentMethod: models.PaymentCash,
+			TotalPaid:     paid,
+			Items: []models.OrderItem{
+				{ProductID: 1, Quantity: 3},
+				{ProductID: 2, Quantity: 1},
+				{ProductID: 3, Quantity: 1},
+			},
+		}
+	}
+
+	type expectedOutput struct {
+		taxes      []models.OrderTax
+		totalTax   int64
+		totalPrice int64
+		err        error
+	}
+
+	testCases := []struct {
+		desc       string
+		store      *models.Store
+		storeErr   error
+		rates      []models.TaxRate
+		promotions []models.Promotion
+		paid       int64
+		expected   expectedOutput
+	}{
+		{
+			// 4500 * 11 / 111 and 120 * 11 / 111 rounded on each line, 2250 * 5 / 105
+			desc:  "Success_Inclusive",
+			store: store,
+			rates: []models.TaxRate{vat, foodVAT},
+			paid:  10000,
+			expected: expectedOutput{
+				taxes: []models.OrderTax{
+					{TaxRateID: 1, Name: "VAT", Rate: 110000, Inclusive: true, TaxableAmount: 4054 + 108, Amount: 446 + 12},
+					{TaxRateID: 2, Name: "VAT", Rate: 50000, Inclusive: true, TaxableAmount: 2143, Amount: 107},
+				},
+				totalTax:   565,
+				totalPrice: 6870,
+			},
+		},
+		{
+			// the exclusive tax is a share of the price without the inclusive one
+			desc:  "Success_InclusiveAndExclusive",
+			store: store,
+			rates: []models.TaxRate{vat, cityTax},
+			paid:  10000,
+			expected: expectedOutput{
+				taxes: []models.OrderTax{
+					{TaxRateID: 1, Name: "VAT", Rate: 110000, Inclusive: true, TaxableAmount: 4162, Amount: 458},
+					{TaxRateID: 5, Name: "City tax", Rate: 10000, Inclusive: false, TaxableAmount: 4162, Amount: 41 + 1},
+				},
+				totalTax:   500,
+				totalPrice: 6870 + 42,
+			},
+		},
+		{
+			// 393.75 and 10.5 are rounded up on each line
+			desc:  "Success_RegionReplacesGlobal",
+			store: regionStore,
+			rates: []models.TaxRate{vat, salesTax},
+			paid:  10000,
+			expected: expectedOutput{
+				taxes: []models.OrderTax{
+					{TaxRateID: 3, Name: "Sales tax", Rate: 87500, Inclusive: false, TaxableAmount: 4620, Amount: 394 + 11},
+				},
+				totalTax:   405,
+				totalPrice: 6870 + 405,
+			},
+		},
+		{
+			// 404.25 is rounded once
+			desc:  "Success_InvoiceRounding",
+			store: invoiceStore,
+			rates: []models.TaxRate{vat, salesTax},
+			paid:  10000,
+			expected: expectedOutput{
+				taxes: []models.OrderTax{
+					{TaxRateID: 3, Name: "Sales tax", Rate: 87500, Inclusive: false, TaxableAmount: 4620, Amount: 404},
+				},
+				totalTax:   404,
+				totalPrice: 6870 + 404,
+			},
+		},
+		{
+			desc:  "Success_StoreReplacesRegion",
+			store: regionStore,
+			rates: []models.TaxRate{salesTax, storeVAT, cityTax},
+			paid:  10000,
+			expected: expectedOutput{
+				taxes: []models.OrderTax{
+					{TaxRateID: 4, Name: "VAT", Rate: 100000, Inclusive: true, TaxableAmount: 4091 + 109, Amount: 409 + 11},
+				},
+				totalTax:   420,
+				totalPrice: 6870,
+			},
+		},
+		{
+			// the colas are taxed on the 3500 left once discounted
+			desc:       "Success_TaxedAfterDiscount",
+			store:      regionStore,
+			rates:      []models.TaxRate{salesTax},
+			promotions: []models.Promotion{orderOff},
+			paid:       10000,
+			expected: expectedOutput{
+				taxes: []models.OrderTax{
+					{TaxRateID: 3, Name: "Sales tax", Rate: 87500, Inclusive: false, TaxableAmount: 3620, Amount: 306 + 11},
+				},
+				totalTax:   317,
+				totalPrice: 6870 - 1000 + 317,
+			},
+		},
+		{
+			desc:     "Fail_StoreNotFound",
+			storeErr: models.ErrDataNotFound,
+			paid:     10000,
+			expected: expectedOutput{
+				err: models.ErrInvalidStore,
+			},
+		},
+		{
+			// the exclusive tax is paid for as well
+			desc:  "Fail_InsufficientPayment",
+			store: regionStore,
+			rates: []models.TaxRate{salesTax},
+			paid:  6870,
+			expected: expectedOutput{
+				err: models.ErrInsufficientPayment,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			promotionRepo := mock2.NewMockPromotionRepository(ctrl)
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			taxRepo := mock2.NewMockTaxRepository(ctrl)
+			customerRepo := mock2.NewMockCustomerRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			alerter := mock2.NewMockStockAlerter(ctrl)
+			loyalty := mock2.NewMockLoyaltyProgram(ctrl)
+
+			orderService := services.NewOrderService(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			for _, product := range []*models.Product{cola, chips, gum} {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(product.ID)).
+					Return(product, nil)
+			}
+			promotionRepo.EXPECT().
+				ListAutomaticPromotions(gomock.Any(), gomock.Any()).
+				Return(tc.promotions, nil)
+			storeRepo.EXPECT().
+				GetStoreByID(gomock.Any(), gomock.Eq(storeID)).
+				Return(tc.store, tc.storeErr)
+			if tc.storeErr == nil {
+				taxRepo.EXPECT().
+					ListStoreTaxRates(gomock.Any(), gomock.Eq(tc.store)).
+					Return(tc.rates, nil)
+			}
+			// the taxes are what is checked here, not caching
+			orderRepo.EXPECT().
+				CreateOrder(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, []models.StockMovement, error) {
+					return order, nil, nil
+				}).
+				MaxTimes(1)
+			cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			cache.EXPECT().DeleteByPrefix(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			order, err := orderService.CreateOrder(ctx, input(tc.paid))
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			if tc.expected.err != nil {
+				assert.Nil(t, order, "Order mismatch")
+				return
+			}
+
+			assert.Equal(t, tc.expected.taxes, order.Taxes, "Taxes mismatch")
+			assert.Equal(t, tc.expected.totalTax, order.TotalTax, "Total tax mismatch")
+			assert.Equal(t, tc.expected.totalPrice, order.TotalPrice, "Total price mismatch")
+			assert.Equal(t, order.TotalPaid-tc.expected.totalPrice, order.TotalChange, "Total change mismatch")
+		})
+	}
+}
+
+func TestOrderService_CreateOrderWithPoints(t *testing.T) {
+	ctx := context.Background()
+
+	cola := &models.Product{ID: 1, SKU: "BEV-COLA-330", Name: "Cola 330ml", Price: 1500, Stock: 10}
+	customerID := gofakeit.Uint64()
+	customerKey := util2.GenerateCacheKey("customer", customerID)
+
+	customer := func(points int64) *models.Customer {
+		return &models.Customer{ID: customerID, Name: "Jane Doe", Points: points}
+	}
+
+	type expectedOutput struct {
+		pointsEarned   int64
+		pointsRedeemed int64
+		totalPaid      int64
+		totalChange    int64
+		err            error
+	}
+
+	// two colas for 3000, a point is earned for every 100 spent and pays 1
+	testCases := []struct {
+		desc  string
+		mocks func(
+			orderRepo *mock2.MockOrderRepository,
+			productRepo *mock2.MockProductRepository,
+			promotionRepo *mock2.MockPromotionRepository,
+			storeRepo *mock2.MockStoreRepository,
+			taxRepo *mock2.MockTaxRepository,
+			customerRepo *mock2.MockCustomerRepository,
+			cache *mock2.MockCacheRepository,
+			alerter *mock2.MockStockAlerter,
+			loyalty *mock2.MockLoyaltyProgram,
+		)
+		paymentMethod models.PaymentMethod
+		paid          int64
+		open          bool
+		withCustomer  bool
+		expected      expectedOutput
+	}{
+		{
+			desc: "Success_Earn",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(customer(0), nil)
+				loyalty.EXPECT().
+					PointsEarned(gomock.Eq(int64(3000))).
+					Return(int64(30))
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(customerKey)).
+					Return(nil)
+			},
+			paymentMethod: models.PaymentCash,
+			paid:          5000,
+			withCustomer:  true,
+			expected: expectedOutput{
+				pointsEarned: 30,
+				totalPaid:    5000,
+				totalChange:  2000,
+			},
+		},
+		{
+			// points pay the total exactly, whatever was handed over, and earn none
+			desc: "Success_Redeem",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(customer(3500), nil)
+				loyalty.EXPECT().
+					RedeemCost(gomock.Eq(int64(3000))).
+					Return(int64(3000))
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(customerKey)).
+					Return(nil)
+			},
+			paymentMethod: models.PaymentPoints,
+			paid:          0,
+			withCustomer:  true,
+			expected: expectedOutput{
+				pointsRedeemed: 3000,
+				totalPaid:      3000,
+			},
+		},
+		{
+			// an open order may be voided, so it earns nothing until it is settled
+			desc: "Success_OpenEarnsNothing",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(customer(0), nil)
+			},
+			paymentMethod: models.PaymentCash,
+			paid:          0,
+			open:          true,
+			withCustomer:  true,
+			expected:      expectedOutput{},
+		},
+		{
+			desc: "Success_WithoutCustomer",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+			},
+			paymentMethod: models.PaymentCard,
+			paid:          3000,
+			expected: expectedOutput{
+				totalPaid: 3000,
+			},
+		},
+		{
+			desc: "Fail_CustomerNotFound",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			paymentMethod: models.PaymentCash,
+			paid:          5000,
+			withCustomer:  true,
+			expected: expectedOutput{
+				err: models.ErrInvalidCustomer,
+			},
+		},
+		{
+			desc: "Fail_CustomerRequired",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+			},
+			paymentMethod: models.PaymentPoints,
+			paid:          0,
+			expected: expectedOutput{
+				err: models.ErrCustomerRequired,
+			},
+		},
+		{
+			desc: "Fail_InsufficientPoints",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(customer(2999), nil)
+				loyalty.EXPECT().
+					RedeemCost(gomock.Eq(int64(3000))).
+					Return(int64(3000))
+			},
+			paymentMethod: models.PaymentPoints,
+			paid:          0,
+			withCustomer:  true,
+			expected: expectedOutput{
+				err: models.ErrInsufficientPoints,
+			},
+		},
+		{
+			// the points were spent by another order since the customer was read
+			desc: "Fail_PointsSpentMeanwhile",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				customerRepo.EXPECT().
+					GetCustomerByID(gomock.Any(), gomock.Eq(customerID)).
+					Return(customer(3000), nil)
+				loyalty.EXPECT().
+					RedeemCost(gomock.Eq(int64(3000))).
+					Return(int64(3000))
+				orderRepo.EXPECT().
+					CreateOrder(gomock.Any(), gomock.Any()).
+					Return(nil, nil, models.ErrInsufficientPoints)
+			},
+			paymentMethod: models.PaymentPoints,
+			paid:          0,
+			withCustomer:  true,
+			expected: expectedOutput{
+				err: models.ErrInsufficientPoints,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			promotionRepo := mock2.NewMockPromotionRepository(ctrl)
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			taxRepo := mock2.NewMockTaxRepository(ctrl)
+			customerRepo := mock2.NewMockCustomerRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			alerter := mock2.NewMockStockAlerter(ctrl)
+			loyalty := mock2.NewMockLoyaltyProgram(ctrl)
+
+			tc.mocks(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			orderService := services.NewOrderService(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			// the points are what is checked here, not pricing nor caching
+			productRepo.EXPECT().
+				GetProductByID(gomock.Any(), gomock.Eq(cola.ID)).
+				Return(cola, nil).
+				MaxTimes(1)
+			promotionRepo.EXPECT().
+				ListAutomaticPromotions(gomock.Any(), gomock.Any()).
+				Return(nil, nil).
+				MaxTimes(1)
+			orderRepo.EXPECT().
+				CreateOrder(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, []models.StockMovement, error) {
+					return order, nil, nil
+				}).
+				MaxTimes(1)
+			cache.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			cache.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			cache.EXPECT().DeleteByPrefix(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			input := &models.Order{
+				StoreID:       1,
+				PaymentMethod: tc.paymentMethod,
+				TotalPaid:     tc.paid,
+				Items:         []models.OrderItem{{ProductID: cola.ID, Quantity: 2}},
+			}
+			if tc.open {
+				input.Status = models.OrderOpen
+			}
+			if tc.withCustomer {
+				input.CustomerID = &customerID
+			}
+
+			order, err := orderService.CreateOrder(ctx, input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			if tc.expected.err != nil {
+				assert.Nil(t, order, "Order mismatch")
+				return
+			}
+
+			assert.Equal(t, tc.expected.pointsEarned, order.PointsEarned, "Points earned mismatch")
+			assert.Equal(t, tc.expected.pointsRedeemed, order.PointsRedeemed, "Points redeemed mismatch")
+			assert.Equal(t, tc.expected.totalPaid, order.TotalPaid, "Total paid mismatch")
+			assert.Equal(t, tc.expected.totalChange, order.TotalChange, "Total change mismatch")
+		})
+	}
+}
+
+func TestOrderService_SettleOrder(t *testing.T) {
+	ctx := context.Background()
+	customerID := gofakeit.Uint64()
+	userID := gofakeit.Uint64()
+
+	// two colas for 3000, rung up for a customer and left open
+	openOrder := func() *models.Order {
+		return &models.Order{
+			ID:            7,
+			UserID:        userID,
+			Status:        models.OrderOpen,
+			StoreID:       1,
+			PaymentMethod: models.PaymentCash,
+			TotalPrice:    3000,
+			CustomerID:    &customerID,
+			Items: []models.OrderItem{
+				{ID: 11, OrderID: 7, ProductID: 1, Quantity: 2, Price: 1500, TotalPrice: 3000},
+			},
+		}
+	}
+	settledOrder := openOrder()
+	settledOrder.Status = models.OrderPaid
+	settledOrder.PaymentMethod = models.PaymentCard
+	settledOrder.TotalPaid = 5000
+	settledOrder.TotalChange = 2000
+	settledOrder.PointsEarned = 30
+
+	settledSerialized, _ := util2.Serialize(settledOrder)
+	ttl := time.Duration(0)
+
+	payment := func(storeID uint64, paid int64) *models.Order {
+		return &models.Order{
+			ID:            7,
+			StoreID:       storeID,
+			PaymentMethod: models.PaymentCard,
+			TotalPaid:     paid,
+		}
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			orderRepo *mock2.MockOrderRepository,
+			productRepo *mock2.MockProductRepository,
+			promotionRepo *mock2.MockPromotionRepository,
+			storeRepo *mock2.MockStoreRepository,
+			taxRepo *mock2.MockTaxRepository,
+			customerRepo *mock2.MockCustomerRepository,
+			cache *mock2.MockCacheRepository,
+			alerter *mock2.MockStockAlerter,
+			loyalty *mock2.MockLoyaltyProgram,
+		)
+		input    *models.Order
+		expected orderExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(uint64(7))).
+					Return(openOrder(), nil)
+				loyalty.EXPECT().
+					PointsEarned(gomock.Eq(int64(3000))).
+					Return(int64(30))
+				orderRepo.EXPECT().
+					SettleOrder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, error) {
+						order.Status = models.OrderPaid
+						return order, nil
+					})
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("order", uint64(7))), gomock.Eq(settledSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("orders:*")).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("customer", customerID))).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("customers:*")).
+					Return(nil)
+			},
+			input: payment(1, 5000),
+			expected: orderExpectedOutput{
+				order: settledOrder,
+				err:   nil,
+			},
+		},
+		{
+			desc: "Fail_InsufficientPayment",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(uint64(7))).
+					Return(openOrder(), nil)
+			},
+			input: payment(1, 2999),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrInsufficientPayment,
+			},
+		},
+		{
+			desc: "Fail_AlreadyPaid",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				paid := *settledOrder
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(uint64(7))).
+					Return(&paid, nil)
+			},
+			input: payment(1, 5000),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrOrderNotOpen,
+			},
+		},
+		{
+			// the order was voided since it was read
+			desc: "Fail_VoidedMeanwhile",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(uint64(7))).
+					Return(openOrder(), nil)
+				loyalty.EXPECT().
+					PointsEarned(gomock.Eq(int64(3000))).
+					Return(int64(30))
+				orderRepo.EXPECT().
+					SettleOrder(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrOrderNotOpen)
+			},
+			input: payment(1, 5000),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrOrderNotOpen,
+			},
+		},
+		{
+			desc: "Fail_OtherStore",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(uint64(7))).
+					Return(openOrder(), nil)
+			},
+			input: payment(2, 5000),
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrDataNotFound,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			promotionRepo := mock2.NewMockPromotionRepository(ctrl)
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			taxRepo := mock2.NewMockTaxRepository(ctrl)
+			customerRepo := mock2.NewMockCustomerRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			alerter := mock2.NewMockStockAlerter(ctrl)
+			loyalty := mock2.NewMockLoyaltyProgram(ctrl)
+
+			tc.mocks(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			orderService := services.NewOrderService(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			order, err := orderService.SettleOrder(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.order, order, "Order mismatch")
+		})
+	}
+}
+
+func TestOrderService_GetOrder(t *testing.T) {
+	ctx := context.Background()
+	orderOutput := &models.Order{
+		ID:            gofakeit.Uint64(),
+		UserID:        gofakeit.Uint64(),
+		StoreID:       1,
+		PaymentMethod: models.PaymentCard,
+		TotalPrice:    1500,
+		TotalPaid:     1500,
+		Items: []models.OrderItem{
+			{ID: 1, ProductID: 1, SKU: "BEV-COLA-330", Name: "Cola 330ml", Quantity: 1, Price: 1500, TotalPrice: 1500},
+		},
+	}
+
+	cacheKey := util2.GenerateCacheKey("order", orderOutput.ID)
+	orderSerialized, _ := util2.Serialize(orderOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			orderRepo *mock2.MockOrderRepository,
+			productRepo *mock2.MockProductRepository,
+			promotionRepo *mock2.MockPromotionRepository,
+			storeRepo *mock2.MockStoreRepository,
+			taxRepo *mock2.MockTaxRepository,
+			customerRepo *mock2.MockCustomerRepository,
+			cache *mock2.MockCacheRepository,
+			alerter *mock2.MockStockAlerter,
+			loyalty *mock2.MockLoyaltyProgram,
+		)
+		storeID  uint64
+		expected orderExpectedOutput
+	}{
+		{
+			desc: "Success_FromCache",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(orderSerialized, nil)
+			},
+			storeID: 1,
+			expected: orderExpectedOutput{
+				order: orderOutput,
+				err:   nil,
+			},
+		},
+		{
+			// a request acting on every store reaches the orders of each
+			desc: "Success_FromRepository",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(orderOutput.ID)).
+					Return(orderOutput, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(orderSerialized), gomock.Eq(ttl)).
+					Return(nil)
+			},
+			expected: orderExpectedOutput{
+				order: orderOutput,
+				err:   nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(orderOutput.ID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			storeID: 1,
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_OtherStore",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				productRepo *mock2.MockProductRepository,
+				promotionRepo *mock2.MockPromotionRepository,
+				storeRepo *mock2.MockStoreRepository,
+				taxRepo *mock2.MockTaxRepository,
+				customerRepo *mock2.MockCustomerRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+				loyalty *mock2.MockLoyaltyProgram,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(orderSerialized, nil)
+			},
+			storeID: 2,
+			expected: orderExpectedOutput{
+				order: nil,
+				err:   models.ErrDataNotFound,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			promotionRepo := mock2.NewMockPromotionRepository(ctrl)
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			taxRepo := mock2.NewMockTaxRepository(ctrl)
+			customerRepo := mock2.NewMockCustomerRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			alerter := mock2.NewMockStockAlerter(ctrl)
+			loyalty := mock2.NewMockLoyaltyProgram(ctrl)
+
+			tc.mocks(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			orderService := services.NewOrderService(orderRepo, productRepo, promotionRepo, storeRepo, taxRepo, customerRepo, cache, alerter, loyalty)
+
+			order, err := orderService.GetOrder(ctx, tc.storeID, orderOutput.ID)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.order, order, "Order mismatch")
+		})
+	}
+}
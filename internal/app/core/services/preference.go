@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"slices"
+)
+
+/**
+ * PreferenceService implements ports.PreferenceService interface
+ * and provides an access to the preference repository
+ * and cache service
+ */
+type PreferenceService struct {
+	repo  ports.PreferenceRepository
+	cache ports.CacheRepository
+}
+
+// NewPreferenceService creates a new preference services instance
+func NewPreferenceService(repo ports.PreferenceRepository, cache ports.CacheRepository) *PreferenceService {
+	return &PreferenceService{
+		repo,
+		cache,
+	}
+}
+
+// GetPreferences returns all preferences of a user, with defaults for the ones not set
+func (ps *PreferenceService) GetPreferences(ctx context.Context, userID uint64) (models.Preferences, error) {
+	var preferences models.Preferences
+
+	cacheKey := utils.GenerateCacheKey("preferences", userID)
+	cachedPreferences, err := ps.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedPreferences, &preferences)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return preferences, nil
+	}
+
+	stored, err := ps.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return ps.cachePreferences(ctx, userID, stored)
+}
+
+// ReplacePreferences sets all preferences of a user, the ones left out are reset to their defaults
+func (ps *PreferenceService) ReplacePreferences(ctx context.Context, userID uint64, preferences models.Preferences) (models.Preferences, error) {
+	err := validatePreferences(preferences)
+	if err != nil {
+		return nil, err
+	}
+
+	set, reset := splitPreferences(preferences)
+	for key := range models.PreferenceSchemas {
+		if _, ok := preferences[key]; !ok {
+			reset = append(reset, key)
+		}
+	}
+	slices.Sort(reset)
+
+	return ps.savePreferences(ctx, userID, set, reset)
+}
+
+// UpdatePreferences sets the given preferences of a user, leaving the others as they are
+func (ps *PreferenceService) UpdatePreferences(ctx context.Context, userID uint64, preferences models.Preferences) (models.Preferences, error) {
+	if len(preferences) == 0 {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	err := validatePreferences(preferences)
+	if err != nil {
+		return nil, err
+	}
+
+	set, reset := splitPreferences(preferences)
+
+	return ps.savePreferences(ctx, userID, set, reset)
+}
+
+// savePreferences writes a change to the repository and caches the preferences that result from it
+func (ps *PreferenceService) savePreferences(ctx context.Context, userID uint64, set models.Preferences, reset []models.PreferenceKey) (models.Preferences, error) {
+	err := ps.repo.SavePreferences(ctx, userID, set, reset)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	// read back rather than merging in memory, another request may have changed other keys meanwhile
+	stored, err := ps.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return ps.cachePreferences(ctx, userID, stored)
+}
+
+// cachePreferences fills in the defaults of the stored preferences and caches the result
+func (ps *PreferenceService) cachePreferences(ctx context.Context, userID uint64, stored models.Preferences) (models.Preferences, error) {
+	preferences := models.DefaultPreferences()
+	for key, value := range stored {
+		// a key dropped from the schema may still be stored
+		if _, ok := models.PreferenceSchemas[key]; ok {
+			preferences[key] = value
+		}
+	}
+
+	cacheKey := utils.GenerateCacheKey("preferences", userID)
+	preferencesSerialized, err := utils.Serialize(preferences)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, preferencesSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return preferences, nil
+}
+
+// validatePreferences checks every preference against its schema, a nil value is always valid
+func validatePreferences(preferences models.Preferences) error {
+	for key, value := range preferences {
+		schema, ok := models.PreferenceSchemas[key]
+		if !ok {
+			return models.ErrUnknownPreference
+		}
+		if value == nil {
+			continue
+		}
+
+		switch schema.Type {
+		case models.PreferenceBool:
+			if _, ok := value.(bool); !ok {
+				return models.ErrInvalidPreference
+			}
+		case models.PreferenceString:
+			str, ok := value.(string)
+			if !ok {
+				return models.ErrInvalidPreference
+			}
+			if len(schema.Allowed) > 0 && !slices.Contains(schema.Allowed, str) {
+				return models.ErrInvalidPreference
+			}
+			if schema.Valid != nil && !schema.Valid(str) {
+				return models.ErrInvalidPreference
+			}
+		}
+	}
+
+	return nil
+}
+
+// splitPreferences separates the preferences to store from the ones to reset, which are
+// the nil ones and the ones equal to their default, so users follow later default changes
+func splitPreferences(preferences models.Preferences) (models.Preferences, []models.PreferenceKey) {
+	set := models.Preferences{}
+	var reset []models.PreferenceKey
+
+	for key, value := range preferences {
+		if value == nil || value == models.PreferenceSchemas[key].Default {
+			reset = append(reset, key)
+			continue
+		}
+		set[key] = value
+	}
+	slices.Sort(reset)
+
+	return set, reset
+}
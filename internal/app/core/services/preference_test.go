@@ -0,0 +1,424 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// withPreferences returns the default preferences overridden by the given ones
+func withPreferences(overrides models.Preferences) models.Preferences {
+	preferences := models.DefaultPreferences()
+	for key, value := range overrides {
+		preferences[key] = value
+	}
+	return preferences
+}
+
+type preferencesExpectedOutput struct {
+	preferences models.Preferences
+	err         error
+}
+
+func TestPreferenceService_GetPreferences(t *testing.T) {
+	ctx := context.Background()
+	userID := gofakeit.Uint64()
+	cacheKey := util2.GenerateCacheKey("preferences", userID)
+	ttl := time.Duration(0)
+
+	stored := models.Preferences{
+		models.PreferenceTheme:    "dark",
+		models.PreferenceTimezone: "Asia/Jakarta",
+	}
+	preferences := withPreferences(stored)
+	preferencesSerialized, _ := util2.Serialize(preferences)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			preferenceRepo *mock2.MockPreferenceRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected preferencesExpectedOutput
+	}{
+		{
+			desc: "Success_FromCache",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(preferencesSerialized, nil)
+			},
+			expected: preferencesExpectedOutput{
+				preferences: preferences,
+				err:         nil,
+			},
+		},
+		{
+			desc: "Success_FromRepository",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				preferenceRepo.EXPECT().
+					GetPreferences(gomock.Any(), gomock.Eq(userID)).
+					Return(stored, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(preferencesSerialized), gomock.Eq(ttl)).
+					Return(nil)
+			},
+			expected: preferencesExpectedOutput{
+				preferences: preferences,
+				err:         nil,
+			},
+		},
+		{
+			desc: "Success_DropsUndefinedKeys",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				preferenceRepo.EXPECT().
+					GetPreferences(gomock.Any(), gomock.Eq(userID)).
+					Return(models.Preferences{
+						models.PreferenceTheme:    "dark",
+						models.PreferenceTimezone: "Asia/Jakarta",
+						"removed.preference":      true,
+					}, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(preferencesSerialized), gomock.Eq(ttl)).
+					Return(nil)
+			},
+			expected: preferencesExpectedOutput{
+				preferences: preferences,
+				err:         nil,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				preferenceRepo.EXPECT().
+					GetPreferences(gomock.Any(), gomock.Eq(userID)).
+					Return(nil, errors.New("connection refused"))
+			},
+			expected: preferencesExpectedOutput{
+				preferences: nil,
+				err:         models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			preferenceRepo := mock2.NewMockPreferenceRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(preferenceRepo, cache)
+
+			preferenceService := services.NewPreferenceService(preferenceRepo, cache)
+
+			preferences, err := preferenceService.GetPreferences(ctx, userID)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.preferences, preferences, "Preferences mismatch")
+		})
+	}
+}
+
+func TestPreferenceService_UpdatePreferences(t *testing.T) {
+	ctx := context.Background()
+	userID := gofakeit.Uint64()
+	cacheKey := util2.GenerateCacheKey("preferences", userID)
+	ttl := time.Duration(0)
+
+	stored := models.Preferences{
+		models.PreferenceTheme:                  "dark",
+		models.PreferenceNotificationsMarketing: true,
+	}
+	preferences := withPreferences(stored)
+	preferencesSerialized, _ := util2.Serialize(preferences)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			preferenceRepo *mock2.MockPreferenceRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    models.Preferences
+		expected preferencesExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				preferenceRepo.EXPECT().
+					SavePreferences(gomock.Any(), gomock.Eq(userID), gomock.Eq(stored), gomock.Eq([]models.PreferenceKey{
+						models.PreferenceLanguage,
+						models.PreferenceTimezone,
+					})).
+					Return(nil)
+				preferenceRepo.EXPECT().
+					GetPreferences(gomock.Any(), gomock.Eq(userID)).
+					Return(stored, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(preferencesSerialized), gomock.Eq(ttl)).
+					Return(nil)
+			},
+			input: models.Preferences{
+				models.PreferenceTheme:                  "dark",
+				models.PreferenceNotificationsMarketing: true,
+				// null and a value equal to the default both reset
+				models.PreferenceLanguage: nil,
+				models.PreferenceTimezone: "UTC",
+			},
+			expected: preferencesExpectedOutput{
+				preferences: preferences,
+				err:         nil,
+			},
+		},
+		{
+			desc: "Fail_Empty",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: models.Preferences{},
+			expected: preferencesExpectedOutput{
+				preferences: nil,
+				err:         models.ErrNoUpdatedData,
+			},
+		},
+		{
+			desc: "Fail_UnknownPreference",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: models.Preferences{
+				"font.size": "large",
+			},
+			expected: preferencesExpectedOutput{
+				preferences: nil,
+				err:         models.ErrUnknownPreference,
+			},
+		},
+		{
+			desc: "Fail_WrongType",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: models.Preferences{
+				models.PreferenceNotificationsEmail: "yes",
+			},
+			expected: preferencesExpectedOutput{
+				preferences: nil,
+				err:         models.ErrInvalidPreference,
+			},
+		},
+		{
+			desc: "Fail_NotAllowed",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: models.Preferences{
+				models.PreferenceTheme: "sepia",
+			},
+			expected: preferencesExpectedOutput{
+				preferences: nil,
+				err:         models.ErrInvalidPreference,
+			},
+		},
+		{
+			desc: "Fail_InvalidTimezone",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: models.Preferences{
+				models.PreferenceTimezone: "Mars/Olympus_Mons",
+			},
+			expected: preferencesExpectedOutput{
+				preferences: nil,
+				err:         models.ErrInvalidPreference,
+			},
+		},
+		{
+			desc: "Fail_InvalidLanguage",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: models.Preferences{
+				models.PreferenceLanguage: "english",
+			},
+			expected: preferencesExpectedOutput{
+				preferences: nil,
+				err:         models.ErrInvalidPreference,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				preferenceRepo.EXPECT().
+					SavePreferences(gomock.Any(), gomock.Eq(userID), gomock.Any(), gomock.Any()).
+					Return(errors.New("connection refused"))
+			},
+			input: models.Preferences{
+				models.PreferenceTheme: "dark",
+			},
+			expected: preferencesExpectedOutput{
+				preferences: nil,
+				err:         models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			preferenceRepo := mock2.NewMockPreferenceRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(preferenceRepo, cache)
+
+			preferenceService := services.NewPreferenceService(preferenceRepo, cache)
+
+			preferences, err := preferenceService.UpdatePreferences(ctx, userID, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.preferences, preferences, "Preferences mismatch")
+		})
+	}
+}
+
+func TestPreferenceService_ReplacePreferences(t *testing.T) {
+	ctx := context.Background()
+	userID := gofakeit.Uint64()
+	cacheKey := util2.GenerateCacheKey("preferences", userID)
+	ttl := time.Duration(0)
+
+	stored := models.Preferences{
+		models.PreferenceLanguage: "id",
+	}
+	preferences := withPreferences(stored)
+	preferencesSerialized, _ := util2.Serialize(preferences)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			preferenceRepo *mock2.MockPreferenceRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    models.Preferences
+		expected preferencesExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				preferenceRepo.EXPECT().
+					SavePreferences(gomock.Any(), gomock.Eq(userID), gomock.Eq(stored), gomock.Eq([]models.PreferenceKey{
+						models.PreferenceNotificationsEmail,
+						models.PreferenceNotificationsMarketing,
+						models.PreferenceNotificationsSecurity,
+						models.PreferenceTheme,
+						models.PreferenceTimezone,
+					})).
+					Return(nil)
+				preferenceRepo.EXPECT().
+					GetPreferences(gomock.Any(), gomock.Eq(userID)).
+					Return(stored, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(preferencesSerialized), gomock.Eq(ttl)).
+					Return(nil)
+			},
+			input: models.Preferences{
+				models.PreferenceLanguage: "id",
+			},
+			expected: preferencesExpectedOutput{
+				preferences: preferences,
+				err:         nil,
+			},
+		},
+		{
+			desc: "Fail_InvalidPreference",
+			mocks: func(
+				preferenceRepo *mock2.MockPreferenceRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: models.Preferences{
+				models.PreferenceLanguage: 42.0,
+			},
+			expected: preferencesExpectedOutput{
+				preferences: nil,
+				err:         models.ErrInvalidPreference,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			preferenceRepo := mock2.NewMockPreferenceRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(preferenceRepo, cache)
+
+			preferenceService := services.NewPreferenceService(preferenceRepo, cache)
+
+			preferences, err := preferenceService.ReplacePreferences(ctx, userID, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.preferences, preferences, "Preferences mismatch")
+		})
+	}
+}
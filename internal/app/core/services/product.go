@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+)
+
+/**
+ * ProductService implements ports.ProductService interface
+ * and provides an access to the product repositories
+ * and cache service
+ */
+type ProductService struct {
+	repo  ports.ProductRepository
+	cache ports.CacheRepository
+}
+
+// NewProductService creates a new product services instance
+func NewProductService(repo ports.ProductRepository, cache ports.CacheRepository) *ProductService {
+	return &ProductService{
+		repo,
+		cache,
+	}
+}
+
+// CreateProduct creates a new product
+func (ps *ProductService) CreateProduct(ctx context.Context, product *models.Product) (*models.Product, error) {
+	product, err := ps.repo.CreateProduct(ctx, product)
+	if err != nil {
+		if err == models.ErrConflictingData || err == models.ErrInvalidCategory || err == models.ErrInvalidTaxClass {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("product", product.ID)
+	productSerialized, err := utils.Serialize(product)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, productSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return product, nil
+}
+
+// GetProduct gets a product by ID
+func (ps *ProductService) GetProduct(ctx context.Context, id uint64) (*models.Product, error) {
+	var product *models.Product
+
+	cacheKey := utils.GenerateCacheKey("product", id)
+	cachedProduct, err := ps.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedProduct, &product)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return product, nil
+	}
+
+	product, err = ps.repo.GetProductByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	productSerialized, err := utils.Serialize(product)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, productSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return product, nil
+}
+
+// ListProducts lists the products matching a filter
+func (ps *ProductService) ListProducts(ctx context.Context, filter *models.ProductFilter, skip, limit uint64) ([]models.Product, error) {
+	var products []models.Product
+
+	params := utils.GenerateCacheKeyParams(skip, limit, filter.CategoryID, filter.Search, filter.StoreID)
+	cacheKey := utils.GenerateCacheKey("products", params)
+
+	cachedProducts, err := ps.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedProducts, &products)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return products, nil
+	}
+
+	products, err = ps.repo.ListProducts(ctx, filter, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	productsSerialized, err := utils.Serialize(products)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, productsSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return products, nil
+}
+
+// UpdateProduct replaces a product's details, its stock is only changed through stock movements
+func (ps *ProductService) UpdateProduct(ctx context.Context, product *models.Product) (*models.Product, error) {
+	existingProduct, err := ps.repo.GetProductByID(ctx, product.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	sameData := existingProduct.CategoryID == product.CategoryID &&
+		existingProduct.SKU == product.SKU &&
+		existingProduct.Name == product.Name &&
+		existingProduct.Image == product.Image &&
+		existingProduct.Price == product.Price &&
+		existingProduct.LowStockThreshold == product.LowStockThreshold &&
+		sameID(existingProduct.TaxClassID, product.TaxClassID)
+	if sameData {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	product, err = ps.repo.UpdateProduct(ctx, product)
+	if err != nil {
+		if err == models.ErrConflictingData || err == models.ErrInvalidCategory || err == models.ErrInvalidTaxClass {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("product", product.ID)
+
+	err = ps.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	productSerialized, err := utils.Serialize(product)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, productSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return product, nil
+}
+
+// DeleteProduct deletes a product by ID
+func (ps *ProductService) DeleteProduct(ctx context.Context, id uint64) error {
+	_, err := ps.repo.GetProductByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("product", id)
+
+	err = ps.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = ps.repo.DeleteProduct(ctx, id)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
@@ -0,0 +1,378 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type productExpectedOutput struct {
+	product *models.Product
+	err     error
+}
+
+func TestProductService_CreateProduct(t *testing.T) {
+	ctx := context.Background()
+	productInput := &models.Product{
+		CategoryID:        gofakeit.Uint64(),
+		SKU:               gofakeit.UUID(),
+		Name:              gofakeit.ProductName(),
+		Price:             1500,
+		LowStockThreshold: 10,
+	}
+	productOutput := &models.Product{
+		ID:                gofakeit.Uint64(),
+		CategoryID:        productInput.CategoryID,
+		SKU:               productInput.SKU,
+		Name:              productInput.Name,
+		Price:             productInput.Price,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+		LowStockThreshold: productInput.LowStockThreshold,
+	}
+
+	cacheKey := util2.GenerateCacheKey("product", productOutput.ID)
+	productSerialized, _ := util2.Serialize(productOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			productRepo *mock2.MockProductRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected productExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					CreateProduct(gomock.Any(), gomock.Eq(productInput)).
+					Return(productOutput, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(productSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Return(nil)
+			},
+			expected: productExpectedOutput{
+				product: productOutput,
+				err:     nil,
+			},
+		},
+		{
+			desc: "Fail_DuplicateSKU",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					CreateProduct(gomock.Any(), gomock.Eq(productInput)).
+					Return(nil, models.ErrConflictingData)
+			},
+			expected: productExpectedOutput{
+				product: nil,
+				err:     models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InvalidCategory",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					CreateProduct(gomock.Any(), gomock.Eq(productInput)).
+					Return(nil, models.ErrInvalidCategory)
+			},
+			expected: productExpectedOutput{
+				product: nil,
+				err:     models.ErrInvalidCategory,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					CreateProduct(gomock.Any(), gomock.Eq(productInput)).
+					Return(nil, errors.New("connection refused"))
+			},
+			expected: productExpectedOutput{
+				product: nil,
+				err:     models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(productRepo, cache)
+
+			productService := services.NewProductService(productRepo, cache)
+
+			product, err := productService.CreateProduct(ctx, productInput)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.product, product, "Product mismatch")
+		})
+	}
+}
+
+func TestProductService_ListProducts(t *testing.T) {
+	ctx := context.Background()
+	filter := &models.ProductFilter{
+		CategoryID: gofakeit.Uint64(),
+		Search:     "cola",
+	}
+	skip, limit := uint64(0), uint64(10)
+
+	var products []models.Product
+	for i := 0; i < 3; i++ {
+		products = append(products, models.Product{
+			ID:         gofakeit.Uint64(),
+			CategoryID: filter.CategoryID,
+			SKU:        gofakeit.UUID(),
+			Name:       gofakeit.ProductName(),
+			Price:      int64(gofakeit.Number(100, 10000)),
+			Stock:      int64(gofakeit.Number(0, 100)),
+		})
+	}
+
+	params := util2.GenerateCacheKeyParams(skip, limit, filter.CategoryID, filter.Search, filter.StoreID)
+	cacheKey := util2.GenerateCacheKey("products", params)
+	productsSerialized, _ := util2.Serialize(products)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			productRepo *mock2.MockProductRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected []models.Product
+		err      error
+	}{
+		{
+			desc: "Success_FromCache",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(productsSerialized, nil)
+			},
+			expected: products,
+			err:      nil,
+		},
+		{
+			desc: "Success_FromRepository",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				productRepo.EXPECT().
+					ListProducts(gomock.Any(), gomock.Eq(filter), gomock.Eq(skip), gomock.Eq(limit)).
+					Return(products, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(productsSerialized), gomock.Eq(ttl)).
+					Return(nil)
+			},
+			expected: products,
+			err:      nil,
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				productRepo.EXPECT().
+					ListProducts(gomock.Any(), gomock.Eq(filter), gomock.Eq(skip), gomock.Eq(limit)).
+					Return(nil, errors.New("connection refused"))
+			},
+			expected: nil,
+			err:      models.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(productRepo, cache)
+
+			productService := services.NewProductService(productRepo, cache)
+
+			products, err := productService.ListProducts(ctx, filter, skip, limit)
+			assert.Equal(t, tc.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected, products, "Products mismatch")
+		})
+	}
+}
+
+func TestProductService_UpdateProduct(t *testing.T) {
+	ctx := context.Background()
+	existingProduct := &models.Product{
+		ID:         gofakeit.Uint64(),
+		CategoryID: gofakeit.Uint64(),
+		SKU:        gofakeit.UUID(),
+		Name:       gofakeit.ProductName(),
+		Price:      1500,
+		Stock:      100,
+	}
+	productInput := *existingProduct
+	productInput.Price = 1750
+	productOutput := productInput
+	productOutput.UpdatedAt = time.Now()
+
+	cacheKey := util2.GenerateCacheKey("product", existingProduct.ID)
+	productSerialized, _ := util2.Serialize(&productOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			productRepo *mock2.MockProductRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    models.Product
+		expected productExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(existingProduct.ID)).
+					Return(existingProduct, nil)
+				productRepo.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(&productInput)).
+					Return(&productOutput, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(productSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+					Return(nil)
+			},
+			input: productInput,
+			expected: productExpectedOutput{
+				product: &productOutput,
+				err:     nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(existingProduct.ID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: productInput,
+			expected: productExpectedOutput{
+				product: nil,
+				err:     models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_NoUpdatedData",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(existingProduct.ID)).
+					Return(existingProduct, nil)
+			},
+			input: *existingProduct,
+			expected: productExpectedOutput{
+				product: nil,
+				err:     models.ErrNoUpdatedData,
+			},
+		},
+		{
+			desc: "Fail_InvalidCategory",
+			mocks: func(
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(existingProduct.ID)).
+					Return(existingProduct, nil)
+				productRepo.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(&productInput)).
+					Return(nil, models.ErrInvalidCategory)
+			},
+			input: productInput,
+			expected: productExpectedOutput{
+				product: nil,
+				err:     models.ErrInvalidCategory,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(productRepo, cache)
+
+			productService := services.NewProductService(productRepo, cache)
+
+			input := tc.input
+			product, err := productService.UpdateProduct(ctx, &input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.product, product, "Product mismatch")
+		})
+	}
+}
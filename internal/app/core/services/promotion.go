@@ -0,0 +1,390 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"slices"
+	"strings"
+	"time"
+)
+
+/**
+ * PromotionService implements ports.PromotionService interface
+ * and provides an access to the promotion repositories
+ * and cache service
+ */
+type PromotionService struct {
+	repo  ports.PromotionRepository
+	cache ports.CacheRepository
+}
+
+// NewPromotionService creates a new promotion services instance
+func NewPromotionService(repo ports.PromotionRepository, cache ports.CacheRepository) *PromotionService {
+	return &PromotionService{
+		repo,
+		cache,
+	}
+}
+
+// CreatePromotion validates and creates a new promotion
+func (ps *PromotionService) CreatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	promotion.CouponCode = normalizeCouponCode(promotion.CouponCode)
+
+	err := validatePromotion(promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	promotion, err = ps.repo.CreatePromotion(ctx, promotion)
+	if err != nil {
+		if err == models.ErrConflictingData || err == models.ErrInvalidPromotion {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("promotion", promotion.ID)
+	promotionSerialized, err := utils.Serialize(promotion)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, promotionSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "promotions:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return promotion, nil
+}
+
+// GetPromotion gets a promotion by ID
+func (ps *PromotionService) GetPromotion(ctx context.Context, id uint64) (*models.Promotion, error) {
+	var promotion *models.Promotion
+
+	cacheKey := utils.GenerateCacheKey("promotion", id)
+	cachedPromotion, err := ps.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedPromotion, &promotion)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return promotion, nil
+	}
+
+	promotion, err = ps.repo.GetPromotionByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	promotionSerialized, err := utils.Serialize(promotion)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, promotionSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return promotion, nil
+}
+
+// ListPromotions lists all promotions
+func (ps *PromotionService) ListPromotions(ctx context.Context, skip, limit uint64) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+
+	params := utils.GenerateCacheKeyParams(skip, limit)
+	cacheKey := utils.GenerateCacheKey("promotions", params)
+
+	cachedPromotions, err := ps.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedPromotions, &promotions)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return promotions, nil
+	}
+
+	promotions, err = ps.repo.ListPromotions(ctx, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	promotionsSerialized, err := utils.Serialize(promotions)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, promotionsSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return promotions, nil
+}
+
+// UpdatePromotion validates and replaces a promotion's rule, its usage count is kept
+func (ps *PromotionService) UpdatePromotion(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	promotion.CouponCode = normalizeCouponCode(promotion.CouponCode)
+
+	err := validatePromotion(promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	existingPromotion, err := ps.repo.GetPromotionByID(ctx, promotion.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	if samePromotion(existingPromotion, promotion) {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	promotion, err = ps.repo.UpdatePromotion(ctx, promotion)
+	if err != nil {
+		if err == models.ErrConflictingData || err == models.ErrInvalidPromotion {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("promotion", promotion.ID)
+
+	err = ps.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	promotionSerialized, err := utils.Serialize(promotion)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.Set(ctx, cacheKey, promotionSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "promotions:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return promotion, nil
+}
+
+// DeletePromotion deletes a promotion by ID, the orders it was applied to keep their discounts
+func (ps *PromotionService) DeletePromotion(ctx context.Context, id uint64) error {
+	_, err := ps.repo.GetPromotionByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("promotion", id)
+
+	err = ps.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = ps.cache.DeleteByPrefix(ctx, "promotions:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = ps.repo.DeletePromotion(ctx, id)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// normalizeCouponCode makes coupon codes case-insensitive
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// validatePromotion checks the value and quantities of a promotion match its type,
+// that it covers a single product or category at most and ends after it starts
+func validatePromotion(promotion *models.Promotion) error {
+	switch promotion.Type {
+	case models.PromotionPercentage:
+		if promotion.Value < 1 || promotion.Value > 100 || promotion.BuyQuantity != 0 || promotion.GetQuantity != 0 {
+			return models.ErrInvalidPromotion
+		}
+	case models.PromotionFixed:
+		if promotion.Value < 1 || promotion.BuyQuantity != 0 || promotion.GetQuantity != 0 {
+			return models.ErrInvalidPromotion
+		}
+	case models.PromotionBuyXGetY:
+		// giving items away across the whole order would make any product free
+		if promotion.Value != 0 || promotion.BuyQuantity < 1 || promotion.GetQuantity < 1 || promotion.IsOrderWide() {
+			return models.ErrInvalidPromotion
+		}
+	default:
+		return models.ErrInvalidPromotion
+	}
+
+	if promotion.ProductID != nil && promotion.CategoryID != nil {
+		return models.ErrInvalidPromotion
+	}
+
+	if promotion.UsageLimit < 0 {
+		return models.ErrInvalidPromotion
+	}
+
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return models.ErrInvalidPromotion
+	}
+
+	return nil
+}
+
+// samePromotion checks whether an update would leave the rule of a promotion unchanged
+func samePromotion(a, b *models.Promotion) bool {
+	return a.Name == b.Name &&
+		a.Type == b.Type &&
+		a.Value == b.Value &&
+		sameID(a.ProductID, b.ProductID) &&
+		sameID(a.CategoryID, b.CategoryID) &&
+		a.BuyQuantity == b.BuyQuantity &&
+		a.GetQuantity == b.GetQuantity &&
+		a.CouponCode == b.CouponCode &&
+		a.UsageLimit == b.UsageLimit &&
+		sameTime(a.StartsAt, b.StartsAt) &&
+		sameTime(a.EndsAt, b.EndsAt) &&
+		a.Active == b.Active
+}
+
+// sameID compares two optional ids
+func sameID(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameTime compares two optional instants
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// applyPromotions works out the discounts the promotions give to the priced items of an order.
+// The promotions covering products are applied before the order-wide ones and, within each
+// group, in the order they were created, each to what the previous ones left to pay for the
+// items, so the same order always gets the same discounts and never goes below zero.
+// The products are those of the items, in the same order, and so is what is left to pay
+// for each item once discounted
+func applyPromotions(items []models.OrderItem, products []*models.Product, promotions []models.Promotion) ([]models.OrderDiscount, []int64) {
+	var discounts []models.OrderDiscount
+
+	remaining := make([]int64, len(items))
+	for i, item := range items {
+		remaining[i] = item.TotalPrice
+	}
+
+	promotions = slices.Clone(promotions)
+	slices.SortFunc(promotions, func(a, b models.Promotion) int {
+		if a.IsOrderWide() != b.IsOrderWide() {
+			if a.IsOrderWide() {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	for i := range promotions {
+		promotion := &promotions[i]
+
+		var covered []int
+		for j, product := range products {
+			if promotion.Covers(product) {
+				covered = append(covered, j)
+			}
+		}
+
+		var amount int64
+		switch promotion.Type {
+		case models.PromotionPercentage:
+			for _, j := range covered {
+				discount := remaining[j] * promotion.Value / 100
+				remaining[j] -= discount
+				amount += discount
+			}
+		case models.PromotionFixed:
+			left := promotion.Value
+			for _, j := range covered {
+				discount := min(left, remaining[j])
+				remaining[j] -= discount
+				left -= discount
+				amount += discount
+			}
+		case models.PromotionBuyXGetY:
+			amount = applyBuyXGetY(promotion, items, covered, remaining)
+		}
+
+		if amount > 0 {
+			discounts = append(discounts, models.OrderDiscount{
+				PromotionID: promotion.ID,
+				Name:        promotion.Name,
+				CouponCode:  promotion.CouponCode,
+				Amount:      amount,
+			})
+		}
+	}
+
+	return discounts, remaining
+}
+
+// applyBuyXGetY gives away the free units of the covered items, the cheapest first,
+// and returns how much they were worth
+func applyBuyXGetY(promotion *models.Promotion, items []models.OrderItem, covered []int, remaining []int64) int64 {
+	var units, amount int64
+	for _, j := range covered {
+		units += items[j].Quantity
+	}
+
+	free := units / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+
+	covered = slices.Clone(covered)
+	slices.SortStableFunc(covered, func(a, b int) int {
+		return cmp.Compare(items[a].Price, items[b].Price)
+	})
+
+	for _, j := range covered {
+		if free == 0 {
+			break
+		}
+
+		quantity := min(free, items[j].Quantity)
+		discount := min(quantity*items[j].Price, remaining[j])
+		remaining[j] -= discount
+		free -= quantity
+		amount += discount
+	}
+
+	return amount
+}
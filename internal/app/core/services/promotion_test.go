@@ -0,0 +1,364 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type promotionExpectedOutput struct {
+	promotion *models.Promotion
+	err       error
+}
+
+func TestPromotionService_CreatePromotion(t *testing.T) {
+	ctx := context.Background()
+	productID := gofakeit.Uint64()
+	categoryID := gofakeit.Uint64()
+	startsAt := time.Now()
+	endsAt := startsAt.Add(-time.Hour)
+
+	// the coupon code is matched case-insensitively
+	input := func(change func(p *models.Promotion)) *models.Promotion {
+		promotion := &models.Promotion{
+			Name:       "Summer sale",
+			Type:       models.PromotionPercentage,
+			Value:      10,
+			CouponCode: " summer10 ",
+			Active:     true,
+		}
+		if change != nil {
+			change(promotion)
+		}
+		return promotion
+	}
+	normalized := input(func(p *models.Promotion) { p.CouponCode = "SUMMER10" })
+	promotionOutput := input(func(p *models.Promotion) {
+		p.ID = gofakeit.Uint64()
+		p.CouponCode = "SUMMER10"
+		p.CreatedAt = time.Now()
+		p.UpdatedAt = time.Now()
+	})
+
+	cacheKey := util2.GenerateCacheKey("promotion", promotionOutput.ID)
+	promotionSerialized, _ := util2.Serialize(promotionOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			promotionRepo *mock2.MockPromotionRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    *models.Promotion
+		expected promotionExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				promotionRepo.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Eq(normalized)).
+					Return(promotionOutput, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(promotionSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("promotions:*")).
+					Return(nil)
+			},
+			input: input(nil),
+			expected: promotionExpectedOutput{
+				promotion: promotionOutput,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Fail_PercentageAboveHundred",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: input(func(p *models.Promotion) { p.Value = 101 }),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrInvalidPromotion,
+			},
+		},
+		{
+			desc: "Fail_FixedWithoutValue",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: input(func(p *models.Promotion) {
+				p.Type = models.PromotionFixed
+				p.Value = 0
+			}),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrInvalidPromotion,
+			},
+		},
+		{
+			desc: "Fail_BuyXGetYOnWholeOrder",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: input(func(p *models.Promotion) {
+				p.Type = models.PromotionBuyXGetY
+				p.Value = 0
+				p.BuyQuantity = 2
+				p.GetQuantity = 1
+			}),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrInvalidPromotion,
+			},
+		},
+		{
+			desc: "Fail_ProductAndCategory",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: input(func(p *models.Promotion) {
+				p.ProductID = &productID
+				p.CategoryID = &categoryID
+			}),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrInvalidPromotion,
+			},
+		},
+		{
+			desc: "Fail_EndsBeforeStart",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: input(func(p *models.Promotion) {
+				p.StartsAt = &startsAt
+				p.EndsAt = &endsAt
+			}),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrInvalidPromotion,
+			},
+		},
+		{
+			desc: "Fail_DuplicateCouponCode",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				promotionRepo.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Eq(normalized)).
+					Return(nil, models.ErrConflictingData)
+			},
+			input: input(nil),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				promotionRepo.EXPECT().
+					CreatePromotion(gomock.Any(), gomock.Eq(normalized)).
+					Return(nil, errors.New("connection refused"))
+			},
+			input: input(nil),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			promotionRepo := mock2.NewMockPromotionRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(promotionRepo, cache)
+
+			promotionService := services.NewPromotionService(promotionRepo, cache)
+
+			promotion, err := promotionService.CreatePromotion(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.promotion, promotion, "Promotion mismatch")
+		})
+	}
+}
+
+func TestPromotionService_UpdatePromotion(t *testing.T) {
+	ctx := context.Background()
+	id := gofakeit.Uint64()
+	endsAt := time.Now().Add(24 * time.Hour)
+
+	existingPromotion := &models.Promotion{
+		ID:         id,
+		Name:       "Summer sale",
+		Type:       models.PromotionPercentage,
+		Value:      10,
+		UsageCount: 42,
+		EndsAt:     &endsAt,
+		Active:     true,
+	}
+
+	input := func(change func(p *models.Promotion)) *models.Promotion {
+		// a request does not carry the usage count
+		promotion := *existingPromotion
+		promotion.UsageCount = 0
+		if change != nil {
+			change(&promotion)
+		}
+		return &promotion
+	}
+	deactivated := input(func(p *models.Promotion) { p.Active = false })
+	promotionOutput := input(func(p *models.Promotion) {
+		p.Active = false
+		p.UsageCount = 42
+	})
+
+	cacheKey := util2.GenerateCacheKey("promotion", id)
+	promotionSerialized, _ := util2.Serialize(promotionOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			promotionRepo *mock2.MockPromotionRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    *models.Promotion
+		expected promotionExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				promotionRepo.EXPECT().
+					GetPromotionByID(gomock.Any(), gomock.Eq(id)).
+					Return(existingPromotion, nil)
+				promotionRepo.EXPECT().
+					UpdatePromotion(gomock.Any(), gomock.Eq(deactivated)).
+					Return(promotionOutput, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(promotionSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("promotions:*")).
+					Return(nil)
+			},
+			input: input(func(p *models.Promotion) { p.Active = false }),
+			expected: promotionExpectedOutput{
+				promotion: promotionOutput,
+				err:       nil,
+			},
+		},
+		{
+			desc: "Fail_NoUpdatedData",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				promotionRepo.EXPECT().
+					GetPromotionByID(gomock.Any(), gomock.Eq(id)).
+					Return(existingPromotion, nil)
+			},
+			input: input(nil),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrNoUpdatedData,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				promotionRepo.EXPECT().
+					GetPromotionByID(gomock.Any(), gomock.Eq(id)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: input(nil),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_UsageLimitBelowUsageCount",
+			mocks: func(
+				promotionRepo *mock2.MockPromotionRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				promotionRepo.EXPECT().
+					GetPromotionByID(gomock.Any(), gomock.Eq(id)).
+					Return(existingPromotion, nil)
+				promotionRepo.EXPECT().
+					UpdatePromotion(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrInvalidPromotion)
+			},
+			input: input(func(p *models.Promotion) { p.UsageLimit = 10 }),
+			expected: promotionExpectedOutput{
+				promotion: nil,
+				err:       models.ErrInvalidPromotion,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			promotionRepo := mock2.NewMockPromotionRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(promotionRepo, cache)
+
+			promotionService := services.NewPromotionService(promotionRepo, cache)
+
+			promotion, err := promotionService.UpdatePromotion(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.promotion, promotion, "Promotion mismatch")
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+)
+
+/**
+ * ReceiptService implements ports.ReceiptService interface
+ * and provides an access to the order, store and user repositories
+ * and the receipt renderer
+ */
+type ReceiptService struct {
+	orderRepo ports.OrderRepository
+	storeRepo ports.StoreRepository
+	userRepo  ports.UserRepository
+	renderer  ports.ReceiptRenderer
+}
+
+// NewReceiptService creates a new receipt services instance
+func NewReceiptService(
+	orderRepo ports.OrderRepository,
+	storeRepo ports.StoreRepository,
+	userRepo ports.UserRepository,
+	renderer ports.ReceiptRenderer,
+) *ReceiptService {
+	return &ReceiptService{
+		orderRepo,
+		storeRepo,
+		userRepo,
+		renderer,
+	}
+}
+
+// GetReceipt renders the receipt of an order with the store it was placed at and the cashier who placed it,
+// an order of another store than the given one is not found
+func (rs *ReceiptService) GetReceipt(ctx context.Context, storeID, orderID uint64, format models.ReceiptFormat, width models.ReceiptPaperWidth) ([]byte, error) {
+	order, err := rs.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	if !inStore(order.StoreID, storeID) {
+		return nil, models.ErrDataNotFound
+	}
+
+	store, err := rs.storeRepo.GetStoreByID(ctx, order.StoreID)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	receipt := &models.Receipt{
+		Order:      order,
+		Store:      store,
+		Format:     format,
+		PaperWidth: width,
+	}
+
+	user, err := rs.userRepo.GetUserByID(ctx, order.UserID)
+	switch err {
+	case nil:
+		receipt.Cashier = user.Name
+	case models.ErrDataNotFound:
+		// the receipt is still printed once the cashier is deleted
+	default:
+		return nil, models.ErrInternal
+	}
+
+	data, err := rs.renderer.Render(ctx, receipt)
+	if err != nil {
+		if err == models.ErrNotAcceptable {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	return data, nil
+}
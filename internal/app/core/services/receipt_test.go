@@ -0,0 +1,205 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type receiptExpectedOutput struct {
+	data []byte
+	err  error
+}
+
+func TestReceiptService_GetReceipt(t *testing.T) {
+	ctx := context.Background()
+	order := &models.Order{
+		ID:            7,
+		UserID:        gofakeit.Uint64(),
+		PaymentMethod: models.PaymentCard,
+		TotalPrice:    2250,
+		TotalPaid:     2250,
+		StoreID:       1,
+	}
+	store := &models.Store{
+		ID:   1,
+		Name: "Main store",
+	}
+	cashier := &models.User{
+		ID:   order.UserID,
+		Name: gofakeit.Name(),
+	}
+	pdf := []byte("%PDF-1.4")
+
+	receipt := func(cashier string, width models.ReceiptPaperWidth) *models.Receipt {
+		return &models.Receipt{
+			Order:      order,
+			Store:      store,
+			Cashier:    cashier,
+			Format:     models.ReceiptPDF,
+			PaperWidth: width,
+		}
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			orderRepo *mock2.MockOrderRepository,
+			storeRepo *mock2.MockStoreRepository,
+			userRepo *mock2.MockUserRepository,
+			renderer *mock2.MockReceiptRenderer,
+		)
+		storeID  uint64
+		width    models.ReceiptPaperWidth
+		expected receiptExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				storeRepo *mock2.MockStoreRepository,
+				userRepo *mock2.MockUserRepository,
+				renderer *mock2.MockReceiptRenderer,
+			) {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(order.ID)).
+					Return(order, nil)
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(store.ID)).
+					Return(store, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(order.UserID)).
+					Return(cashier, nil)
+				renderer.EXPECT().
+					Render(gomock.Any(), gomock.Eq(receipt(cashier.Name, models.ReceiptPaper58))).
+					Return(pdf, nil)
+			},
+			storeID: 1,
+			width:   models.ReceiptPaper58,
+			expected: receiptExpectedOutput{
+				data: pdf,
+				err:  nil,
+			},
+		},
+		{
+			// a request acting on every store reaches the orders of each
+			desc: "Success_CashierDeleted",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				storeRepo *mock2.MockStoreRepository,
+				userRepo *mock2.MockUserRepository,
+				renderer *mock2.MockReceiptRenderer,
+			) {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(order.ID)).
+					Return(order, nil)
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(store.ID)).
+					Return(store, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(order.UserID)).
+					Return(nil, models.ErrDataNotFound)
+				renderer.EXPECT().
+					Render(gomock.Any(), gomock.Eq(receipt("", 0))).
+					Return(pdf, nil)
+			},
+			storeID: 0,
+			expected: receiptExpectedOutput{
+				data: pdf,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				storeRepo *mock2.MockStoreRepository,
+				userRepo *mock2.MockUserRepository,
+				renderer *mock2.MockReceiptRenderer,
+			) {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(order.ID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			storeID: 1,
+			expected: receiptExpectedOutput{
+				data: nil,
+				err:  models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_OtherStore",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				storeRepo *mock2.MockStoreRepository,
+				userRepo *mock2.MockUserRepository,
+				renderer *mock2.MockReceiptRenderer,
+			) {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(order.ID)).
+					Return(order, nil)
+			},
+			storeID: 2,
+			expected: receiptExpectedOutput{
+				data: nil,
+				err:  models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_RenderError",
+			mocks: func(
+				orderRepo *mock2.MockOrderRepository,
+				storeRepo *mock2.MockStoreRepository,
+				userRepo *mock2.MockUserRepository,
+				renderer *mock2.MockReceiptRenderer,
+			) {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(order.ID)).
+					Return(order, nil)
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(store.ID)).
+					Return(store, nil)
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(order.UserID)).
+					Return(cashier, nil)
+				renderer.EXPECT().
+					Render(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("template error"))
+			},
+			storeID: 1,
+			expected: receiptExpectedOutput{
+				data: nil,
+				err:  models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			renderer := mock2.NewMockReceiptRenderer(ctrl)
+
+			tc.mocks(orderRepo, storeRepo, userRepo, renderer)
+
+			receiptService := services.NewReceiptService(orderRepo, storeRepo, userRepo, renderer)
+
+			data, err := receiptService.GetReceipt(ctx, tc.storeID, order.ID, models.ReceiptPDF, tc.width)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.data, data, "Receipt mismatch")
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"slices"
+)
+
+/**
+ * RefundService implements ports.RefundService interface
+ * and provides an access to the refund and order repositories
+ * and cache service
+ */
+type RefundService struct {
+	refundRepo ports.RefundRepository
+	orderRepo  ports.OrderRepository
+	cache      ports.CacheRepository
+}
+
+// NewRefundService creates a new refund services instance
+func NewRefundService(refundRepo ports.RefundRepository, orderRepo ports.OrderRepository, cache ports.CacheRepository) *RefundService {
+	return &RefundService{
+		refundRepo,
+		orderRepo,
+		cache,
+	}
+}
+
+// RefundOrder pays back the given items of a paid order, or everything left of it when no item is given,
+// with the payment method of the order unless another is given, and puts the items back into stock.
+// The store of the refund is the one the request acts on, and is taken from the order. Points are
+// paid back in points only, and the points the order earned are taken back with what is refunded
+func (rs *RefundService) RefundOrder(ctx context.Context, refund *models.Refund) (*models.Refund, error) {
+	order, refunds, err := rs.orderWithRefunds(ctx, refund.StoreID, refund.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// an open order is voided instead
+	if order.IsOpen() {
+		return nil, models.ErrInvalidRefund
+	}
+
+	refunded := refundedQuantities(refunds)
+
+	items, err := refundItems(order, refunded, refund.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	if refund.PaymentMethod == "" {
+		refund.PaymentMethod = order.PaymentMethod
+	}
+	if (refund.PaymentMethod == models.PaymentPoints) != (order.PaymentMethod == models.PaymentPoints) {
+		return nil, models.ErrInvalidRefund
+	}
+
+	var amount, pointsRefunded, pointsReversed int64
+	for _, previous := range refunds {
+		amount += previous.Amount
+		pointsRefunded += previous.PointsRefunded
+		pointsReversed += previous.PointsReversed
+	}
+
+	refund.Type = models.RefundTypeRefund
+	refund.StoreID = order.StoreID
+	refund.Items = items
+	refund.Amount = refundShare(order, refunded, items, order.TotalPrice, amount)
+	refund.PointsRefunded = refundShare(order, refunded, items, order.PointsRedeemed, pointsRefunded)
+	refund.PointsReversed = refundShare(order, refunded, items, order.PointsEarned, pointsReversed)
+
+	refund, err = rs.createRefund(ctx, refund, len(refunds))
+	if err != nil {
+		return nil, err
+	}
+
+	if refund.PointsRefunded > 0 || refund.PointsReversed > 0 {
+		err = invalidateCustomerCache(ctx, rs.cache, *order.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return refund, nil
+}
+
+// VoidOrder cancels an open order as a whole and puts its items back into stock
+func (rs *RefundService) VoidOrder(ctx context.Context, refund *models.Refund) (*models.Refund, error) {
+	order, refunds, err := rs.orderWithRefunds(ctx, refund.StoreID, refund.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.IsOpen() || len(refunds) > 0 {
+		return nil, models.ErrInvalidRefund
+	}
+
+	items, err := refundItems(order, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	refund.Type = models.RefundTypeVoid
+	refund.StoreID = order.StoreID
+	refund.Items = items
+	refund.Amount = 0
+	refund.PaymentMethod = ""
+
+	return rs.createRefund(ctx, refund, 0)
+}
+
+// GetRefund gets a refund by ID, a refund of another store than the given one is not found
+func (rs *RefundService) GetRefund(ctx context.Context, storeID, id uint64) (*models.Refund, error) {
+	var refund *models.Refund
+
+	cacheKey := utils.GenerateCacheKey("refund", id)
+	cachedRefund, err := rs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedRefund, &refund)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		if !inStore(refund.StoreID, storeID) {
+			return nil, models.ErrDataNotFound
+		}
+		return refund, nil
+	}
+
+	refund, err = rs.refundRepo.GetRefundByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	refundSerialized, err := utils.Serialize(refund)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = rs.cache.Set(ctx, cacheKey, refundSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	if !inStore(refund.StoreID, storeID) {
+		return nil, models.ErrDataNotFound
+	}
+
+	return refund, nil
+}
+
+// ListRefunds lists the refunds, of a store unless storeID is zero and of an order unless orderID is zero
+func (rs *RefundService) ListRefunds(ctx context.Context, storeID, orderID, skip, limit uint64) ([]models.Refund, error) {
+	var refunds []models.Refund
+
+	params := utils.GenerateCacheKeyParams(skip, limit, storeID, orderID)
+	cacheKey := utils.GenerateCacheKey("refunds", params)
+
+	cachedRefunds, err := rs.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedRefunds, &refunds)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return refunds, nil
+	}
+
+	refunds, err = rs.refundRepo.ListRefunds(ctx, storeID, orderID, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	refundsSerialized, err := utils.Serialize(refunds)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = rs.cache.Set(ctx, cacheKey, refundsSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return refunds, nil
+}
+
+// orderWithRefunds gets an order with the refunds it already has, an order of another store
+// than the given one is not found
+func (rs *RefundService) orderWithRefunds(ctx context.Context, storeID, orderID uint64) (*models.Order, []models.Refund, error) {
+	order, err := rs.orderRepo.GetOrderByID(ctx, orderID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, nil, err
+		}
+		return nil, nil, models.ErrInternal
+	}
+
+	if !inStore(order.StoreID, storeID) {
+		return nil, nil, models.ErrDataNotFound
+	}
+
+	refunds, err := rs.refundRepo.ListOrderRefunds(ctx, orderID)
+	if err != nil {
+		return nil, nil, models.ErrInternal
+	}
+
+	return order, refunds, nil
+}
+
+// createRefund places a refund and invalidates the cache of the refunds and of the restocked products
+func (rs *RefundService) createRefund(ctx context.Context, refund *models.Refund, previousRefunds int) (*models.Refund, error) {
+	refund, err := rs.refundRepo.CreateRefund(ctx, refund, previousRefunds)
+	if err != nil {
+		if err == models.ErrVersionConflict {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("refund", refund.ID)
+	refundSerialized, err := utils.Serialize(refund)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = rs.cache.Set(ctx, cacheKey, refundSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(ctx, "refunds:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	var productIDs []uint64
+	for _, item := range refund.Items {
+		if item.ProductID != 0 {
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+
+	err = invalidateStockCache(ctx, rs.cache, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+// refundedQuantities adds up how much of each order item the refunds gave back
+func refundedQuantities(refunds []models.Refund) map[uint64]int64 {
+	refunded := map[uint64]int64{}
+	for _, refund := range refunds {
+		for _, item := range refund.Items {
+			refunded[item.OrderItemID] += item.Quantity
+		}
+	}
+	return refunded
+}
+
+// refundItems checks the requested items are of the order and no more than is left of them,
+// adding up the ones requested twice. Without any requested item, everything left is refunded
+func refundItems(order *models.Order, refunded map[uint64]int64, requested []models.RefundItem) ([]models.RefundItem, error) {
+	var items []models.RefundItem
+
+	if len(requested) == 0 {
+		for _, orderItem := range order.Items {
+			left := orderItem.Quantity - refunded[orderItem.ID]
+			if left > 0 {
+				requested = append(requested, models.RefundItem{OrderItemID: orderItem.ID, Quantity: left})
+			}
+		}
+	}
+
+	for _, item := range requested {
+		i := slices.IndexFunc(items, func(r models.RefundItem) bool {
+			return r.OrderItemID == item.OrderItemID
+		})
+		if i == -1 {
+			items = append(items, models.RefundItem{OrderItemID: item.OrderItemID})
+			i = len(items) - 1
+		}
+		items[i].Quantity += item.Quantity
+	}
+
+	if len(items) == 0 {
+		return nil, models.ErrInvalidRefund
+	}
+
+	for i := range items {
+		item := &items[i]
+
+		j := slices.IndexFunc(order.Items, func(o models.OrderItem) bool {
+			return o.ID == item.OrderItemID
+		})
+		if j == -1 || item.Quantity < 1 || item.Quantity > order.Items[j].Quantity-refunded[item.OrderItemID] {
+			return nil, models.ErrInvalidRefund
+		}
+
+		item.ProductID = order.Items[j].ProductID
+	}
+
+	// the products are locked in the same order as by orders, so they cannot deadlock
+	slices.SortFunc(items, func(a, b models.RefundItem) int {
+		return cmp.Compare(a.ProductID, b.ProductID)
+	})
+
+	return items, nil
+}
+
+// refundShare works out the share of a total of the order the items give back, which is how much to
+// pay back or how many points to refund or take back. The discounts and taxes of the order are shared
+// by its items in proportion to their price, and the refund giving back the last of the order gives
+// back whatever is left of the total after the previous refunds, so the refunds of an order always
+// add up to its total
+func refundShare(order *models.Order, refunded map[uint64]int64, items []models.RefundItem, total, previous int64) int64 {
+	var subtotal, gross, left int64
+
+	for _, orderItem := range order.Items {
+		subtotal += orderItem.TotalPrice
+		left += orderItem.Quantity - refunded[orderItem.ID]
+	}
+
+	for _, item := range items {
+		j := slices.IndexFunc(order.Items, func(o models.OrderItem) bool {
+			return o.ID == item.OrderItemID
+		})
+		gross += order.Items[j].Price * item.Quantity
+		left -= item.Quantity
+	}
+
+	if left == 0 {
+		return total - previous
+	}
+
+	if subtotal == 0 {
+		return 0
+	}
+
+	return gross * total / subtotal
+}
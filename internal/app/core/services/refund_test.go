@@ -0,0 +1,473 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type refundExpectedOutput struct {
+	refund *models.Refund
+	err    error
+}
+
+// expectRefundPlaced expects a refund to be placed, cached and the stock of its products invalidated
+func expectRefundPlaced(refundRepo *mock2.MockRefundRepository, cache *mock2.MockCacheRepository, refund *models.Refund, previousRefunds int) *models.Refund {
+	placed := *refund
+	placed.ID = gofakeit.Uint64()
+	placed.CreatedAt = time.Now()
+
+	refundSerialized, _ := util2.Serialize(&placed)
+	ttl := time.Duration(0)
+
+	refundRepo.EXPECT().
+		CreateRefund(gomock.Any(), gomock.Eq(refund), gomock.Eq(previousRefunds)).
+		Return(&placed, nil)
+	cache.EXPECT().
+		Set(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("refund", placed.ID)), gomock.Eq(refundSerialized), gomock.Eq(ttl)).
+		Return(nil)
+	cache.EXPECT().
+		DeleteByPrefix(gomock.Any(), gomock.Eq("refunds:*")).
+		Return(nil)
+	for _, item := range refund.Items {
+		cache.EXPECT().
+			Delete(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("product", item.ProductID))).
+			Return(nil)
+	}
+	cache.EXPECT().
+		DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+		Return(nil)
+	cache.EXPECT().
+		DeleteByPrefix(gomock.Any(), gomock.Eq("stock-movements:*")).
+		Return(nil)
+
+	return &placed
+}
+
+func TestRefundService_RefundOrder(t *testing.T) {
+	ctx := context.Background()
+	userID := gofakeit.Uint64()
+
+	// three colas and a bag of chips, 500 off and paid in cash
+	order := &models.Order{
+		ID:            7,
+		Status:        models.OrderPaid,
+		PaymentMethod: models.PaymentCash,
+		TotalPrice:    6750 - 500,
+		TotalPaid:     10000,
+		TotalChange:   10000 - 6250,
+		TotalDiscount: 500,
+		StoreID:       1,
+		Items: []models.OrderItem{
+			{ID: 11, OrderID: 7, ProductID: 1, Quantity: 3, Price: 1500, TotalPrice: 4500},
+			{ID: 12, OrderID: 7, ProductID: 2, Quantity: 1, Price: 2250, TotalPrice: 2250},
+		},
+	}
+	openOrder := *order
+	openOrder.Status = models.OrderOpen
+	openOrder.TotalPaid = 0
+	openOrder.TotalChange = 0
+
+	// the colas were refunded already, at their share of the discounted total
+	colasRefunded := []models.Refund{{
+		ID:      1,
+		OrderID: 7,
+		Type:    models.RefundTypeRefund,
+		Amount:  4500 * 6250 / 6750,
+		Items:   []models.RefundItem{{OrderItemID: 11, ProductID: 1, Quantity: 3}},
+	}}
+
+	input := func(paymentMethod models.PaymentMethod, items ...models.RefundItem) *models.Refund {
+		return &models.Refund{
+			OrderID:       7,
+			UserID:        userID,
+			PaymentMethod: paymentMethod,
+			Reason:        "damaged packaging",
+			Items:         items,
+			StoreID:       1,
+		}
+	}
+	refund := func(paymentMethod models.PaymentMethod, amount int64, items ...models.RefundItem) *models.Refund {
+		return &models.Refund{
+			OrderID:       7,
+			UserID:        userID,
+			Type:          models.RefundTypeRefund,
+			PaymentMethod: paymentMethod,
+			Amount:        amount,
+			Reason:        "damaged packaging",
+			Items:         items,
+			StoreID:       1,
+		}
+	}
+	otherStore := input("")
+	otherStore.StoreID = 2
+
+	testCases := []struct {
+		desc     string
+		refunds  []models.Refund
+		order    *models.Order
+		orderErr error
+		input    *models.Refund
+		placed   *models.Refund
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:   "Success_Everything",
+			input:  input(""),
+			placed: refund(models.PaymentCash, 6250, models.RefundItem{OrderItemID: 11, ProductID: 1, Quantity: 3}, models.RefundItem{OrderItemID: 12, ProductID: 2, Quantity: 1}),
+		},
+		{
+			// 1500 of 6750 is refunded at its share of 6250, rounded down
+			desc:   "Success_Partial",
+			input:  input(models.PaymentCard, models.RefundItem{OrderItemID: 11, Quantity: 1}),
+			placed: refund(models.PaymentCard, 1388, models.RefundItem{OrderItemID: 11, ProductID: 1, Quantity: 1}),
+		},
+		{
+			// the last of the order pays back what is left, so the refunds add up to what was paid
+			desc:    "Success_Remainder",
+			refunds: colasRefunded,
+			input:   input("", models.RefundItem{OrderItemID: 12, Quantity: 1}),
+			placed:  refund(models.PaymentCash, 6250-4166, models.RefundItem{OrderItemID: 12, ProductID: 2, Quantity: 1}),
+		},
+		{
+			desc:    "Fail_AlreadyRefunded",
+			refunds: colasRefunded,
+			input:   input("", models.RefundItem{OrderItemID: 11, Quantity: 1}),
+			err:     models.ErrInvalidRefund,
+		},
+		{
+			desc:  "Fail_ItemOfAnotherOrder",
+			input: input("", models.RefundItem{OrderItemID: 13, Quantity: 1}),
+			err:   models.ErrInvalidRefund,
+		},
+		{
+			desc:  "Fail_TooMany",
+			input: input("", models.RefundItem{OrderItemID: 11, Quantity: 2}, models.RefundItem{OrderItemID: 11, Quantity: 2}),
+			err:   models.ErrInvalidRefund,
+		},
+		{
+			desc:  "Fail_Open",
+			order: &openOrder,
+			input: input(""),
+			err:   models.ErrInvalidRefund,
+		},
+		{
+			// the order was placed at another store than the one refunding it
+			desc:  "Fail_OtherStore",
+			input: otherStore,
+			err:   models.ErrDataNotFound,
+		},
+		{
+			desc:     "Fail_OrderNotFound",
+			orderErr: models.ErrDataNotFound,
+			input:    input(""),
+			err:      models.ErrDataNotFound,
+		},
+		{
+			desc:    "Fail_ConcurrentRefund",
+			input:   input(""),
+			repoErr: models.ErrVersionConflict,
+			err:     models.ErrVersionConflict,
+		},
+		{
+			desc:    "Fail_InternalError",
+			input:   input(""),
+			repoErr: errors.New("connection refused"),
+			err:     models.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			refundRepo := mock2.NewMockRefundRepository(ctrl)
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			refundService := services.NewRefundService(refundRepo, orderRepo, cache)
+
+			expected := refundExpectedOutput{err: tc.err}
+
+			if tc.orderErr != nil {
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(uint64(7))).
+					Return(nil, tc.orderErr)
+			} else {
+				orderOutput := order
+				if tc.order != nil {
+					orderOutput = tc.order
+				}
+				orderRepo.EXPECT().
+					GetOrderByID(gomock.Any(), gomock.Eq(uint64(7))).
+					Return(orderOutput, nil)
+				if tc.input.StoreID == orderOutput.StoreID {
+					refundRepo.EXPECT().
+						ListOrderRefunds(gomock.Any(), gomock.Eq(uint64(7))).
+						Return(tc.refunds, nil)
+				}
+			}
+
+			switch {
+			case tc.placed != nil:
+				expected.refund = expectRefundPlaced(refundRepo, cache, tc.placed, len(tc.refunds))
+			case tc.repoErr != nil:
+				refundRepo.EXPECT().
+					CreateRefund(gomock.Any(), gomock.Any(), gomock.Eq(len(tc.refunds))).
+					Return(nil, tc.repoErr)
+			}
+
+			refund, err := refundService.RefundOrder(ctx, tc.input)
+			assert.Equal(t, expected.err, err, "Error mismatch")
+			assert.Equal(t, expected.refund, refund, "Refund mismatch")
+		})
+	}
+}
+
+func TestRefundService_RefundOrderWithPoints(t *testing.T) {
+	ctx := context.Background()
+	userID := gofakeit.Uint64()
+	customerID := gofakeit.Uint64()
+
+	// three colas and a bag of chips, 500 off, bought by a customer
+	order := func(paymentMethod models.PaymentMethod, earned, redeemed int64) *models.Order {
+		return &models.Order{
+			ID:             7,
+			PaymentMethod:  paymentMethod,
+			TotalPrice:     6250,
+			TotalPaid:      6250,
+			TotalDiscount:  500,
+			StoreID:        1,
+			CustomerID:     &customerID,
+			PointsEarned:   earned,
+			PointsRedeemed: redeemed,
+			Items: []models.OrderItem{
+				{ID: 11, OrderID: 7, ProductID: 1, Quantity: 3, Price: 1500, TotalPrice: 4500},
+				{ID: 12, OrderID: 7, ProductID: 2, Quantity: 1, Price: 2250, TotalPrice: 2250},
+			},
+		}
+	}
+	paidWithPoints := order(models.PaymentPoints, 0, 6250)
+	earnedPoints := order(models.PaymentCash, 62, 0)
+
+	// the colas were refunded already, taking back their share of the earned points
+	colasRefunded := []models.Refund{{
+		ID:             1,
+		OrderID:        7,
+		Type:           models.RefundTypeRefund,
+		Amount:         4166,
+		PointsReversed: 41,
+		Items:          []models.RefundItem{{OrderItemID: 11, ProductID: 1, Quantity: 3}},
+	}}
+
+	input := func(paymentMethod models.PaymentMethod, items ...models.RefundItem) *models.Refund {
+		return &models.Refund{
+			OrderID:       7,
+			UserID:        userID,
+			PaymentMethod: paymentMethod,
+			Reason:        "damaged packaging",
+			Items:         items,
+			StoreID:       1,
+		}
+	}
+	refund := func(paymentMethod models.PaymentMethod, amount, refunded, reversed int64, items ...models.RefundItem) *models.Refund {
+		return &models.Refund{
+			OrderID:        7,
+			UserID:         userID,
+			Type:           models.RefundTypeRefund,
+			PaymentMethod:  paymentMethod,
+			Amount:         amount,
+			Reason:         "damaged packaging",
+			Items:          items,
+			StoreID:        1,
+			PointsRefunded: refunded,
+			PointsReversed: reversed,
+		}
+	}
+	cola := models.RefundItem{OrderItemID: 11, ProductID: 1, Quantity: 1}
+	chips := models.RefundItem{OrderItemID: 12, ProductID: 2, Quantity: 1}
+
+	testCases := []struct {
+		desc    string
+		order   *models.Order
+		refunds []models.Refund
+		input   *models.Refund
+		placed  *models.Refund
+		err     error
+	}{
+		{
+			// 1500 of 6750 gives back its share of the 6250 points, rounded down
+			desc:   "Success_RefundPoints",
+			order:  paidWithPoints,
+			input:  input("", models.RefundItem{OrderItemID: 11, Quantity: 1}),
+			placed: refund(models.PaymentPoints, 1388, 1388, 0, cola),
+		},
+		{
+			desc:   "Success_ReverseEarned",
+			order:  earnedPoints,
+			input:  input(models.PaymentCard, models.RefundItem{OrderItemID: 11, Quantity: 1}),
+			placed: refund(models.PaymentCard, 1388, 0, 13, cola),
+		},
+		{
+			// the last of the order takes back whatever is left of the earned points
+			desc:    "Success_ReverseRemainder",
+			order:   earnedPoints,
+			refunds: colasRefunded,
+			input:   input("", models.RefundItem{OrderItemID: 12, Quantity: 1}),
+			placed:  refund(models.PaymentCash, 6250-4166, 0, 62-41, chips),
+		},
+		{
+			desc:  "Fail_PointsForPaidOrder",
+			order: earnedPoints,
+			input: input(models.PaymentPoints),
+			err:   models.ErrInvalidRefund,
+		},
+		{
+			// points are paid back in points only
+			desc:  "Fail_CashForPointsOrder",
+			order: paidWithPoints,
+			input: input(models.PaymentCash),
+			err:   models.ErrInvalidRefund,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			refundRepo := mock2.NewMockRefundRepository(ctrl)
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			refundService := services.NewRefundService(refundRepo, orderRepo, cache)
+
+			expected := refundExpectedOutput{err: tc.err}
+
+			orderRepo.EXPECT().
+				GetOrderByID(gomock.Any(), gomock.Eq(uint64(7))).
+				Return(tc.order, nil)
+			refundRepo.EXPECT().
+				ListOrderRefunds(gomock.Any(), gomock.Eq(uint64(7))).
+				Return(tc.refunds, nil)
+
+			if tc.placed != nil {
+				expected.refund = expectRefundPlaced(refundRepo, cache, tc.placed, len(tc.refunds))
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("customer", customerID))).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("customers:*")).
+					Return(nil)
+			}
+
+			refund, err := refundService.RefundOrder(ctx, tc.input)
+			assert.Equal(t, expected.err, err, "Error mismatch")
+			assert.Equal(t, expected.refund, refund, "Refund mismatch")
+		})
+	}
+}
+
+func TestRefundService_VoidOrder(t *testing.T) {
+	ctx := context.Background()
+	userID := gofakeit.Uint64()
+
+	openOrder := &models.Order{
+		ID:            7,
+		Status:        models.OrderOpen,
+		PaymentMethod: models.PaymentCash,
+		TotalPrice:    2250,
+		Items: []models.OrderItem{
+			{ID: 12, OrderID: 7, ProductID: 2, Quantity: 1, Price: 2250, TotalPrice: 2250},
+		},
+	}
+	paidOrder := *openOrder
+	paidOrder.Status = models.OrderPaid
+	paidOrder.TotalPaid = 2250
+
+	input := func() *models.Refund {
+		return &models.Refund{
+			OrderID: 7,
+			UserID:  userID,
+			Reason:  "customer walked out",
+		}
+	}
+	void := &models.Refund{
+		OrderID: 7,
+		UserID:  userID,
+		Type:    models.RefundTypeVoid,
+		Reason:  "customer walked out",
+		Items:   []models.RefundItem{{OrderItemID: 12, ProductID: 2, Quantity: 1}},
+	}
+
+	testCases := []struct {
+		desc    string
+		order   *models.Order
+		refunds []models.Refund
+		placed  *models.Refund
+		err     error
+	}{
+		{
+			desc:   "Success",
+			order:  openOrder,
+			placed: void,
+		},
+		{
+			desc:  "Fail_Paid",
+			order: &paidOrder,
+			err:   models.ErrInvalidRefund,
+		},
+		{
+			desc:    "Fail_AlreadyVoided",
+			order:   openOrder,
+			refunds: []models.Refund{*void},
+			err:     models.ErrInvalidRefund,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			refundRepo := mock2.NewMockRefundRepository(ctrl)
+			orderRepo := mock2.NewMockOrderRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			refundService := services.NewRefundService(refundRepo, orderRepo, cache)
+
+			expected := refundExpectedOutput{err: tc.err}
+
+			orderRepo.EXPECT().
+				GetOrderByID(gomock.Any(), gomock.Eq(uint64(7))).
+				Return(tc.order, nil)
+			refundRepo.EXPECT().
+				ListOrderRefunds(gomock.Any(), gomock.Eq(uint64(7))).
+				Return(tc.refunds, nil)
+			if tc.placed != nil {
+				expected.refund = expectRefundPlaced(refundRepo, cache, tc.placed, 0)
+			}
+
+			refund, err := refundService.VoidOrder(ctx, input())
+			assert.Equal(t, expected.err, err, "Error mismatch")
+			assert.Equal(t, expected.refund, refund, "Refund mismatch")
+		})
+	}
+}
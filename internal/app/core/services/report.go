@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"time"
+)
+
+/**
+ * ReportService implements ports.ReportService interface
+ * and provides an access to the report repository
+ * and cache service
+ */
+type ReportService struct {
+	repo  ports.ReportRepository
+	cache ports.CacheRepository
+}
+
+// NewReportService creates a new report services instance
+func NewReportService(repo ports.ReportRepository, cache ports.CacheRepository) *ReportService {
+	return &ReportService{
+		repo,
+		cache,
+	}
+}
+
+// RevenueByPeriod returns the takings of every period in the range, the periods without sales have zero takings
+func (rs *ReportService) RevenueByPeriod(ctx context.Context, filter *models.ReportFilter, period models.ReportPeriod) ([]models.RevenueReport, error) {
+	if period != models.ReportDay && period != models.ReportWeek && period != models.ReportMonth {
+		return nil, models.ErrInvalidReport
+	}
+
+	return cachedReport(ctx, rs.cache, reportCacheKey("revenue", filter, period), filter, func() ([]models.RevenueReport, error) {
+		revenue, err := rs.repo.RevenueByPeriod(ctx, filter, period)
+		if err != nil {
+			return nil, err
+		}
+		return fillRevenueGaps(revenue, filter, period), nil
+	})
+}
+
+// TopProducts returns the best selling products by revenue
+func (rs *ReportService) TopProducts(ctx context.Context, filter *models.ReportFilter, limit uint64) ([]models.ProductSalesReport, error) {
+	return cachedReport(ctx, rs.cache, reportCacheKey("top-products", filter, limit), filter, func() ([]models.ProductSalesReport, error) {
+		return rs.repo.TopProducts(ctx, filter, limit)
+	})
+}
+
+// SalesByCashier returns the takings of each cashier
+func (rs *ReportService) SalesByCashier(ctx context.Context, filter *models.ReportFilter) ([]models.CashierSalesReport, error) {
+	return cachedReport(ctx, rs.cache, reportCacheKey("cashiers", filter), filter, func() ([]models.CashierSalesReport, error) {
+		return rs.repo.SalesByCashier(ctx, filter)
+	})
+}
+
+// SalesByPaymentMethod returns the takings of each payment method
+func (rs *ReportService) SalesByPaymentMethod(ctx context.Context, filter *models.ReportFilter) ([]models.PaymentMethodReport, error) {
+	return cachedReport(ctx, rs.cache, reportCacheKey("payment-methods", filter), filter, func() ([]models.PaymentMethodReport, error) {
+		return rs.repo.SalesByPaymentMethod(ctx, filter)
+	})
+}
+
+// Discounts returns how much each promotion took off the orders
+func (rs *ReportService) Discounts(ctx context.Context, filter *models.ReportFilter) ([]models.DiscountReport, error) {
+	return cachedReport(ctx, rs.cache, reportCacheKey("discounts", filter), filter, func() ([]models.DiscountReport, error) {
+		return rs.repo.Discounts(ctx, filter)
+	})
+}
+
+// cachedReport validates the range of a report and returns it from the cache, or builds and caches it.
+// Reports are not invalidated by new orders, they expire after models.ReportCacheTTL instead
+func cachedReport[T any](
+	ctx context.Context,
+	cache ports.CacheRepository,
+	cacheKey string,
+	filter *models.ReportFilter,
+	build func() ([]T, error),
+) ([]T, error) {
+	var report []T
+
+	if !filter.From.Before(filter.To) || filter.To.Sub(filter.From) > models.ReportMaxRange {
+		return nil, models.ErrInvalidReport
+	}
+
+	cached, err := cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cached, &report)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return report, nil
+	}
+
+	report, err = build()
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	reportSerialized, err := utils.Serialize(report)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = cache.Set(ctx, cacheKey, reportSerialized, models.ReportCacheTTL)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return report, nil
+}
+
+// reportCacheKey generates the cache key of a report, the same dates in another timezone or store are another report
+func reportCacheKey(report string, filter *models.ReportFilter, params ...any) string {
+	params = append([]any{
+		report,
+		filter.From.Format(time.RFC3339),
+		filter.To.Format(time.RFC3339),
+		filter.Location().String(),
+		filter.StoreID,
+	}, params...)
+
+	return utils.GenerateCacheKey("reports", utils.GenerateCacheKeyParams(params...))
+}
+
+// fillRevenueGaps returns a row for every period from the one the range starts in up to the end of the range,
+// taking the rows with sales from the given ones, which must be in order
+func fillRevenueGaps(revenue []models.RevenueReport, filter *models.ReportFilter, period models.ReportPeriod) []models.RevenueReport {
+	var filled []models.RevenueReport
+
+	i := 0
+	for start := periodStart(filter.From, period); start.Before(filter.To); start = nextPeriod(start, period) {
+		if i < len(revenue) && revenue[i].Start.Equal(start) {
+			filled = append(filled, revenue[i])
+			i++
+			continue
+		}
+		filled = append(filled, models.RevenueReport{Start: start})
+	}
+
+	return filled
+}
+
+// periodStart returns the start of the period a time is in, weeks start on monday like postgres' date_trunc
+func periodStart(t time.Time, period models.ReportPeriod) time.Time {
+	year, month, day := t.Date()
+
+	switch period {
+	case models.ReportWeek:
+		// days since monday
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, t.Location())
+	case models.ReportMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// nextPeriod returns the start of the period after the one starting at start,
+// by calendar days so periods spanning a daylight saving change are still whole days
+func nextPeriod(start time.Time, period models.ReportPeriod) time.Time {
+	switch period {
+	case models.ReportWeek:
+		return start.AddDate(0, 0, 7)
+	case models.ReportMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,254 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReportService_RevenueByPeriod(t *testing.T) {
+	ctx := context.Background()
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	day := func(d int) time.Time {
+		return time.Date(2024, time.March, d, 0, 0, 0, 0, jakarta)
+	}
+
+	// wednesday 2024-03-06 to friday 2024-03-08, with sales on the thursday only
+	filter := &models.ReportFilter{From: day(6), To: day(9)}
+	sales := []models.RevenueReport{
+		{Start: day(7), Orders: 3, Revenue: 12500},
+	}
+	revenue := []models.RevenueReport{
+		{Start: day(6)},
+		{Start: day(7), Orders: 3, Revenue: 12500},
+		{Start: day(8)},
+	}
+
+	cacheKey := util2.GenerateCacheKey("reports", util2.GenerateCacheKeyParams(
+		"revenue", "2024-03-06T00:00:00+07:00", "2024-03-09T00:00:00+07:00", "Asia/Jakarta", uint64(0), models.ReportDay,
+	))
+	revenueSerialized, _ := util2.Serialize(revenue)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			reportRepo *mock2.MockReportRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		filter   *models.ReportFilter
+		period   models.ReportPeriod
+		expected []models.RevenueReport
+		err      error
+	}{
+		{
+			desc: "Success_FillsGaps",
+			mocks: func(
+				reportRepo *mock2.MockReportRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				reportRepo.EXPECT().
+					RevenueByPeriod(gomock.Any(), gomock.Eq(filter), gomock.Eq(models.ReportDay)).
+					Return(sales, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(revenueSerialized), gomock.Eq(models.ReportCacheTTL)).
+					Return(nil)
+			},
+			filter:   filter,
+			period:   models.ReportDay,
+			expected: revenue,
+			err:      nil,
+		},
+		{
+			desc: "Success_WeeksStartOnMonday",
+			mocks: func(
+				reportRepo *mock2.MockReportRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrDataNotFound)
+				reportRepo.EXPECT().
+					RevenueByPeriod(gomock.Any(), gomock.Any(), gomock.Eq(models.ReportWeek)).
+					Return(nil, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Eq(models.ReportCacheTTL)).
+					Return(nil)
+			},
+			filter: &models.ReportFilter{From: day(6), To: day(19)},
+			period: models.ReportWeek,
+			expected: []models.RevenueReport{
+				{Start: day(4)},
+				{Start: day(11)},
+				{Start: day(18)},
+			},
+			err: nil,
+		},
+		{
+			desc: "Fail_EndsBeforeStart",
+			mocks: func(
+				reportRepo *mock2.MockReportRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			filter:   &models.ReportFilter{From: day(9), To: day(6)},
+			period:   models.ReportDay,
+			expected: nil,
+			err:      models.ErrInvalidReport,
+		},
+		{
+			desc: "Fail_TooLong",
+			mocks: func(
+				reportRepo *mock2.MockReportRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			filter:   &models.ReportFilter{From: day(1), To: day(1).AddDate(2, 0, 0)},
+			period:   models.ReportMonth,
+			expected: nil,
+			err:      models.ErrInvalidReport,
+		},
+		{
+			desc: "Fail_UnknownPeriod",
+			mocks: func(
+				reportRepo *mock2.MockReportRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			filter:   filter,
+			period:   "quarter",
+			expected: nil,
+			err:      models.ErrInvalidReport,
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				reportRepo *mock2.MockReportRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				reportRepo.EXPECT().
+					RevenueByPeriod(gomock.Any(), gomock.Eq(filter), gomock.Eq(models.ReportDay)).
+					Return(nil, errors.New("connection refused"))
+			},
+			filter:   filter,
+			period:   models.ReportDay,
+			expected: nil,
+			err:      models.ErrInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reportRepo := mock2.NewMockReportRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(reportRepo, cache)
+
+			reportService := services.NewReportService(reportRepo, cache)
+
+			revenue, err := reportService.RevenueByPeriod(ctx, tc.filter, tc.period)
+			assert.Equal(t, tc.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected, revenue, "Revenue mismatch")
+		})
+	}
+}
+
+func TestReportService_TopProducts(t *testing.T) {
+	ctx := context.Background()
+	// march at the second store
+	filter := &models.ReportFilter{
+		From:    time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		StoreID: 2,
+	}
+	products := []models.ProductSalesReport{
+		{ProductID: 1, SKU: "BEV-COLA-330", Name: "Cola 330ml", Quantity: 120, Revenue: 180000},
+		{ProductID: 0, SKU: "SNK-CHIPS-80", Name: "Chips 80g", Quantity: 30, Revenue: 67500},
+	}
+
+	cacheKey := util2.GenerateCacheKey("reports", util2.GenerateCacheKeyParams(
+		"top-products", "2024-03-01T00:00:00Z", "2024-04-01T00:00:00Z", "UTC", uint64(2), 10,
+	))
+	productsSerialized, _ := util2.Serialize(products)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			reportRepo *mock2.MockReportRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected []models.ProductSalesReport
+		err      error
+	}{
+		{
+			desc: "Success_FromCache",
+			mocks: func(
+				reportRepo *mock2.MockReportRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(productsSerialized, nil)
+			},
+			expected: products,
+			err:      nil,
+		},
+		{
+			desc: "Success_FromRepository",
+			mocks: func(
+				reportRepo *mock2.MockReportRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				reportRepo.EXPECT().
+					TopProducts(gomock.Any(), gomock.Eq(filter), gomock.Eq(uint64(10))).
+					Return(products, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(productsSerialized), gomock.Eq(models.ReportCacheTTL)).
+					Return(nil)
+			},
+			expected: products,
+			err:      nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			reportRepo := mock2.NewMockReportRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(reportRepo, cache)
+
+			reportService := services.NewReportService(reportRepo, cache)
+
+			products, err := reportService.TopProducts(ctx, filter, 10)
+			assert.Equal(t, tc.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected, products, "Products mismatch")
+		})
+	}
+}
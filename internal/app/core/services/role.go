@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+)
+
+/**
+ * RoleService implements ports.RoleService interface
+ * and provides an access to the role repositories,
+ * cache and policy services
+ */
+type RoleService struct {
+	repo   ports.RoleRepository
+	cache  ports.CacheRepository
+	policy ports.PolicyService
+}
+
+// NewRoleService creates a new role services instance
+func NewRoleService(repo ports.RoleRepository, cache ports.CacheRepository, policy ports.PolicyService) *RoleService {
+	return &RoleService{
+		repo,
+		cache,
+		policy,
+	}
+}
+
+// CreateRole creates a new role, roles are shared by every tenant so only the default one changes them
+func (rs *RoleService) CreateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	if !inDefaultTenant(ctx) {
+		return nil, models.ErrForbidden
+	}
+
+	role, err := rs.repo.CreateRole(ctx, role)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("role", role.ID)
+	roleSerialized, err := utils.Serialize(role)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = rs.cache.Set(sharedCache(ctx), cacheKey, roleSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(sharedCache(ctx), "roles:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return role, nil
+}
+
+// GetRole gets a role by ID
+func (rs *RoleService) GetRole(ctx context.Context, id uint64) (*models.Role, error) {
+	var role *models.Role
+
+	cacheKey := utils.GenerateCacheKey("role", id)
+	cachedRole, err := rs.cache.Get(sharedCache(ctx), cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedRole, &role)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return role, nil
+	}
+
+	role, err = rs.repo.GetRoleByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	roleSerialized, err := utils.Serialize(role)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = rs.cache.Set(sharedCache(ctx), cacheKey, roleSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return role, nil
+}
+
+// ListRoles lists all roles
+func (rs *RoleService) ListRoles(ctx context.Context, skip, limit uint64) ([]models.Role, error) {
+	var roles []models.Role
+
+	params := utils.GenerateCacheKeyParams(skip, limit)
+	cacheKey := utils.GenerateCacheKey("roles", params)
+
+	cachedRoles, err := rs.cache.Get(sharedCache(ctx), cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedRoles, &roles)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return roles, nil
+	}
+
+	roles, err = rs.repo.ListRoles(ctx, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	rolesSerialized, err := utils.Serialize(roles)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = rs.cache.Set(sharedCache(ctx), cacheKey, rolesSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return roles, nil
+}
+
+// UpdateRole updates a role's description, the name is immutable once created
+func (rs *RoleService) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	if !inDefaultTenant(ctx) {
+		return nil, models.ErrForbidden
+	}
+
+	existingRole, err := rs.repo.GetRoleByID(ctx, role.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	emptyData := role.Description == ""
+	sameData := existingRole.Description == role.Description
+	if emptyData || sameData {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	role, err = rs.repo.UpdateRole(ctx, role)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("role", role.ID)
+
+	err = rs.cache.Delete(sharedCache(ctx), cacheKey)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(sharedCache(ctx), "roles:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return role, nil
+}
+
+// DeleteRole deletes a role by ID, refusing roles that still have users
+func (rs *RoleService) DeleteRole(ctx context.Context, id uint64) error {
+	if !inDefaultTenant(ctx) {
+		return models.ErrForbidden
+	}
+
+	role, err := rs.repo.GetRoleByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	count, err := rs.repo.CountUsersByRole(ctx, role.Name)
+	if err != nil {
+		return models.ErrInternal
+	}
+	if count > 0 {
+		return models.ErrRoleInUse
+	}
+
+	err = rs.repo.DeleteRole(ctx, id)
+	if err != nil {
+		if err == models.ErrRoleInUse {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = rs.policy.DeleteRole(ctx, role.Name)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("role", id)
+
+	err = rs.cache.Delete(sharedCache(ctx), cacheKey)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = rs.cache.DeleteByPrefix(sharedCache(ctx), "roles:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// sharedCache returns a context that caches outside of the tenant of the request, as roles are shared by every tenant
+func sharedCache(ctx context.Context) context.Context {
+	return _constant.WithTenantID(ctx, 0)
+}
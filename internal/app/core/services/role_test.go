@@ -0,0 +1,443 @@
+package services_test
+
+import (
+	"context"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type createRoleTestedInput struct {
+	role *models.Role
+}
+
+type createRoleExpectedOutput struct {
+	role *models.Role
+	err  error
+}
+
+func TestRoleService_CreateRole(t *testing.T) {
+	ctx := _constant.WithTenantID(context.Background(), models.DefaultTenantID)
+	roleInput := &models.Role{
+		Name:        "store_manager",
+		Description: gofakeit.Sentence(5),
+	}
+	roleOutput := &models.Role{
+		ID:          gofakeit.Uint64(),
+		Name:        roleInput.Name,
+		Description: roleInput.Description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	cacheKey := util2.GenerateCacheKey("role", roleOutput.ID)
+	roleSerialized, _ := util2.Serialize(roleOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			roleRepo *mock2.MockRoleRepository,
+			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+		)
+		input    createRoleTestedInput
+		expected createRoleExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				roleRepo.EXPECT().
+					CreateRole(gomock.Any(), gomock.Eq(roleInput)).
+					Return(roleOutput, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(roleSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("roles:*")).
+					Return(nil)
+			},
+			input: createRoleTestedInput{
+				role: roleInput,
+			},
+			expected: createRoleExpectedOutput{
+				role: roleOutput,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_DuplicateData",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				roleRepo.EXPECT().
+					CreateRole(gomock.Any(), gomock.Eq(roleInput)).
+					Return(nil, models.ErrConflictingData)
+			},
+			input: createRoleTestedInput{
+				role: roleInput,
+			},
+			expected: createRoleExpectedOutput{
+				role: nil,
+				err:  models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				roleRepo.EXPECT().
+					CreateRole(gomock.Any(), gomock.Eq(roleInput)).
+					Return(nil, models.ErrInternal)
+			},
+			input: createRoleTestedInput{
+				role: roleInput,
+			},
+			expected: createRoleExpectedOutput{
+				role: nil,
+				err:  models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+
+			tc.mocks(roleRepo, cache, policy)
+
+			roleService := services.NewRoleService(roleRepo, cache, policy)
+
+			role, err := roleService.CreateRole(ctx, tc.input.role)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.role, role, "Role mismatch")
+		})
+	}
+}
+
+type updateRoleTestedInput struct {
+	role *models.Role
+}
+
+type updateRoleExpectedOutput struct {
+	role *models.Role
+	err  error
+}
+
+func TestRoleService_UpdateRole(t *testing.T) {
+	ctx := _constant.WithTenantID(context.Background(), models.DefaultTenantID)
+	roleID := gofakeit.Uint64()
+
+	existingRole := &models.Role{
+		ID:          roleID,
+		Name:        models.Cashier,
+		Description: gofakeit.Sentence(5),
+	}
+	roleInput := &models.Role{
+		ID:          roleID,
+		Description: gofakeit.Sentence(6),
+	}
+	roleOutput := &models.Role{
+		ID:          roleID,
+		Name:        existingRole.Name,
+		Description: roleInput.Description,
+	}
+
+	cacheKey := util2.GenerateCacheKey("role", roleID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			roleRepo *mock2.MockRoleRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    updateRoleTestedInput
+		expected updateRoleExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(roleID)).
+					Return(existingRole, nil)
+				roleRepo.EXPECT().
+					UpdateRole(gomock.Any(), gomock.Eq(roleInput)).
+					Return(roleOutput, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("roles:*")).
+					Return(nil)
+			},
+			input: updateRoleTestedInput{
+				role: roleInput,
+			},
+			expected: updateRoleExpectedOutput{
+				role: roleOutput,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(roleID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: updateRoleTestedInput{
+				role: roleInput,
+			},
+			expected: updateRoleExpectedOutput{
+				role: nil,
+				err:  models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_SameData",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(roleID)).
+					Return(existingRole, nil)
+			},
+			input: updateRoleTestedInput{
+				role: &models.Role{
+					ID:          roleID,
+					Description: existingRole.Description,
+				},
+			},
+			expected: updateRoleExpectedOutput{
+				role: nil,
+				err:  models.ErrNoUpdatedData,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+
+			tc.mocks(roleRepo, cache)
+
+			roleService := services.NewRoleService(roleRepo, cache, policy)
+
+			role, err := roleService.UpdateRole(ctx, tc.input.role)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.role, role, "Role mismatch")
+		})
+	}
+}
+
+type deleteRoleTestedInput struct {
+	id uint64
+}
+
+type deleteRoleExpectedOutput struct {
+	err error
+}
+
+func TestRoleService_DeleteRole(t *testing.T) {
+	ctx := _constant.WithTenantID(context.Background(), models.DefaultTenantID)
+	roleID := gofakeit.Uint64()
+	role := &models.Role{
+		ID:   roleID,
+		Name: "store_manager",
+	}
+
+	cacheKey := util2.GenerateCacheKey("role", roleID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			roleRepo *mock2.MockRoleRepository,
+			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+		)
+		input    deleteRoleTestedInput
+		expected deleteRoleExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(roleID)).
+					Return(role, nil)
+				roleRepo.EXPECT().
+					CountUsersByRole(gomock.Any(), gomock.Eq(role.Name)).
+					Return(uint64(0), nil)
+				roleRepo.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq(roleID)).
+					Return(nil)
+				policy.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq(role.Name)).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("roles:*")).
+					Return(nil)
+			},
+			input: deleteRoleTestedInput{
+				id: roleID,
+			},
+			expected: deleteRoleExpectedOutput{
+				err: nil,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(roleID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: deleteRoleTestedInput{
+				id: roleID,
+			},
+			expected: deleteRoleExpectedOutput{
+				err: models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_RoleInUse",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(roleID)).
+					Return(role, nil)
+				roleRepo.EXPECT().
+					CountUsersByRole(gomock.Any(), gomock.Eq(role.Name)).
+					Return(uint64(3), nil)
+			},
+			input: deleteRoleTestedInput{
+				id: roleID,
+			},
+			expected: deleteRoleExpectedOutput{
+				err: models.ErrRoleInUse,
+			},
+		},
+		{
+			desc: "Fail_RoleInUseConstraint",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(roleID)).
+					Return(role, nil)
+				roleRepo.EXPECT().
+					CountUsersByRole(gomock.Any(), gomock.Eq(role.Name)).
+					Return(uint64(0), nil)
+				roleRepo.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq(roleID)).
+					Return(models.ErrRoleInUse)
+			},
+			input: deleteRoleTestedInput{
+				id: roleID,
+			},
+			expected: deleteRoleExpectedOutput{
+				err: models.ErrRoleInUse,
+			},
+		},
+		{
+			desc: "Fail_DeletePolicy",
+			mocks: func(
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+			) {
+				roleRepo.EXPECT().
+					GetRoleByID(gomock.Any(), gomock.Eq(roleID)).
+					Return(role, nil)
+				roleRepo.EXPECT().
+					CountUsersByRole(gomock.Any(), gomock.Eq(role.Name)).
+					Return(uint64(0), nil)
+				roleRepo.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq(roleID)).
+					Return(nil)
+				policy.EXPECT().
+					DeleteRole(gomock.Any(), gomock.Eq(role.Name)).
+					Return(models.ErrInternal)
+			},
+			input: deleteRoleTestedInput{
+				id: roleID,
+			},
+			expected: deleteRoleExpectedOutput{
+				err: models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+
+			tc.mocks(roleRepo, cache, policy)
+
+			roleService := services.NewRoleService(roleRepo, cache, policy)
+
+			err := roleService.DeleteRole(ctx, tc.input.id)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+		})
+	}
+}
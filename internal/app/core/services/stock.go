@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+)
+
+/**
+ * StockService implements ports.StockService interface
+ * and provides an access to the stock and product repositories,
+ * cache service and stock alerter
+ */
+type StockService struct {
+	repo        ports.StockRepository
+	productRepo ports.ProductRepository
+	cache       ports.CacheRepository
+	alerter     ports.StockAlerter
+}
+
+// NewStockService creates a new stock services instance
+func NewStockService(
+	repo ports.StockRepository,
+	productRepo ports.ProductRepository,
+	cache ports.CacheRepository,
+	alerter ports.StockAlerter,
+) *StockService {
+	return &StockService{
+		repo,
+		productRepo,
+		cache,
+		alerter,
+	}
+}
+
+// RecordMovement records a manual stock movement with a reason in the stock of a store. Sales are
+// only recorded by orders, and restocks and returns can only add stock. A transfer moves its
+// quantity into another store, recording the movements out of the one and into the other together
+func (ss *StockService) RecordMovement(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	if movement.StoreID == 0 {
+		return nil, models.ErrStoreRequired
+	}
+
+	switch movement.Type {
+	case models.StockRestock, models.StockReturn:
+		if movement.Quantity <= 0 {
+			return nil, models.ErrInvalidStockMovement
+		}
+	case models.StockAdjustment:
+		if movement.Quantity == 0 {
+			return nil, models.ErrInvalidStockMovement
+		}
+	case models.StockTransfer:
+		if movement.Quantity <= 0 || movement.ToStoreID == 0 || movement.ToStoreID == movement.StoreID {
+			return nil, models.ErrInvalidStockMovement
+		}
+	default:
+		return nil, models.ErrInvalidStockMovement
+	}
+	if movement.Reason == "" {
+		return nil, models.ErrInvalidStockMovement
+	}
+
+	product, err := ss.productRepo.GetProductByID(ctx, movement.ProductID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, models.ErrInvalidProduct
+		}
+		return nil, models.ErrInternal
+	}
+
+	recordMovement := ss.repo.RecordMovement
+	if movement.Type == models.StockTransfer {
+		recordMovement = ss.repo.TransferStock
+	}
+
+	movement, err = recordMovement(ctx, movement)
+	if err != nil {
+		if err == models.ErrInsufficientStock || err == models.ErrInvalidProduct || err == models.ErrInvalidStore {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	err = invalidateStockCache(ctx, ss.cache, []uint64{movement.ProductID})
+	if err != nil {
+		return nil, err
+	}
+
+	alertLowStock(ctx, ss.alerter, product, movement)
+
+	return movement, nil
+}
+
+// ListMovements lists the stock movements matching a filter
+func (ss *StockService) ListMovements(ctx context.Context, filter *models.StockMovementFilter, skip, limit uint64) ([]models.StockMovement, error) {
+	var movements []models.StockMovement
+
+	params := utils.GenerateCacheKeyParams(skip, limit, filter.ProductID, filter.Type, filter.StoreID)
+	cacheKey := utils.GenerateCacheKey("stock-movements", params)
+
+	cachedMovements, err := ss.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedMovements, &movements)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return movements, nil
+	}
+
+	movements, err = ss.repo.ListMovements(ctx, filter, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	movementsSerialized, err := utils.Serialize(movements)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ss.cache.Set(ctx, cacheKey, movementsSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return movements, nil
+}
+
+// invalidateStockCache removes the cached products whose stock has changed,
+// along with the cached product and stock movement lists
+func invalidateStockCache(ctx context.Context, cache ports.CacheRepository, productIDs []uint64) error {
+	for _, id := range productIDs {
+		err := cache.Delete(ctx, utils.GenerateCacheKey("product", id))
+		if err != nil {
+			return models.ErrInternal
+		}
+	}
+
+	err := cache.DeleteByPrefix(ctx, "products:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = cache.DeleteByPrefix(ctx, "stock-movements:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// alertLowStock raises an alert when a movement takes the stock of a product from above its
+// low-stock threshold down to it or below, so an alert is raised once rather than on every sale.
+// It is best effort, the movement is already recorded and a missed alert should not undo it
+func alertLowStock(ctx context.Context, alerter ports.StockAlerter, product *models.Product, movement *models.StockMovement) {
+	if movement.StockBefore() <= product.LowStockThreshold || movement.StockAfter > product.LowStockThreshold {
+		return
+	}
+
+	alerted := *product
+	alerted.Stock = movement.StockAfter
+	_ = alerter.LowStock(ctx, &alerted, movement)
+}
@@ -0,0 +1,346 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type stockMovementExpectedOutput struct {
+	movement *models.StockMovement
+	err      error
+}
+
+func TestStockService_RecordMovement(t *testing.T) {
+	ctx := context.Background()
+	userID := gofakeit.Uint64()
+	product := &models.Product{
+		ID:                gofakeit.Uint64(),
+		SKU:               "BEV-COLA-330",
+		Name:              "Cola 330ml",
+		Stock:             12,
+		LowStockThreshold: 10,
+	}
+
+	movement := func(movementType models.StockMovementType, quantity int64, reason string) *models.StockMovement {
+		return &models.StockMovement{
+			ProductID: product.ID,
+			Type:      movementType,
+			Quantity:  quantity,
+			Reason:    reason,
+			UserID:    &userID,
+			StoreID:   1,
+		}
+	}
+	recorded := func(input *models.StockMovement, stockAfter int64) *models.StockMovement {
+		output := *input
+		output.ID = gofakeit.Uint64()
+		output.StockAfter = stockAfter
+		output.CreatedAt = time.Now()
+		return &output
+	}
+
+	restock := movement(models.StockRestock, 24, "weekly delivery")
+	restocked := recorded(restock, 36)
+	damaged := movement(models.StockAdjustment, -2, "damaged in storage")
+	adjusted := recorded(damaged, 10)
+	lowStock := *product
+	lowStock.Stock = 10
+	transfer := movement(models.StockTransfer, 1, "moved to the warehouse")
+	transfer.ToStoreID = 2
+	// the movement out of the store is returned, the repository records the one into the other
+	transferred := recorded(transfer, 11)
+	transferred.Quantity = -1
+
+	expectInvalidation := func(cache *mock2.MockCacheRepository) {
+		cache.EXPECT().
+			Delete(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("product", product.ID))).
+			Return(nil)
+		cache.EXPECT().
+			DeleteByPrefix(gomock.Any(), gomock.Eq("products:*")).
+			Return(nil)
+		cache.EXPECT().
+			DeleteByPrefix(gomock.Any(), gomock.Eq("stock-movements:*")).
+			Return(nil)
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			stockRepo *mock2.MockStockRepository,
+			productRepo *mock2.MockProductRepository,
+			cache *mock2.MockCacheRepository,
+			alerter *mock2.MockStockAlerter,
+		)
+		input    *models.StockMovement
+		expected stockMovementExpectedOutput
+	}{
+		{
+			desc: "Success_Restock",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(product.ID)).
+					Return(product, nil)
+				stockRepo.EXPECT().
+					RecordMovement(gomock.Any(), gomock.Eq(restock)).
+					Return(restocked, nil)
+				expectInvalidation(cache)
+			},
+			input: restock,
+			expected: stockMovementExpectedOutput{
+				movement: restocked,
+				err:      nil,
+			},
+		},
+		{
+			desc: "Success_AdjustmentReachesThreshold",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(product.ID)).
+					Return(product, nil)
+				stockRepo.EXPECT().
+					RecordMovement(gomock.Any(), gomock.Eq(damaged)).
+					Return(adjusted, nil)
+				expectInvalidation(cache)
+				alerter.EXPECT().
+					LowStock(gomock.Any(), gomock.Eq(&lowStock), gomock.Eq(adjusted)).
+					Return(errors.New("smtp unavailable"))
+			},
+			input: damaged,
+			expected: stockMovementExpectedOutput{
+				movement: adjusted,
+				err:      nil,
+			},
+		},
+		{
+			desc: "Success_Transfer",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(product.ID)).
+					Return(product, nil)
+				stockRepo.EXPECT().
+					TransferStock(gomock.Any(), gomock.Eq(transfer)).
+					Return(transferred, nil)
+				expectInvalidation(cache)
+			},
+			input: transfer,
+			expected: stockMovementExpectedOutput{
+				movement: transferred,
+				err:      nil,
+			},
+		},
+		{
+			// a transfer takes the destination and moves stock out of the store, never into it
+			desc: "Fail_TransferNegative",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+			},
+			input: &models.StockMovement{
+				ProductID: product.ID,
+				Type:      models.StockTransfer,
+				Quantity:  -1,
+				Reason:    "moved to the warehouse",
+				UserID:    &userID,
+				StoreID:   1,
+				ToStoreID: 2,
+			},
+			expected: stockMovementExpectedOutput{
+				movement: nil,
+				err:      models.ErrInvalidStockMovement,
+			},
+		},
+		{
+			desc: "Fail_TransferSameStore",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+			},
+			input: &models.StockMovement{
+				ProductID: product.ID,
+				Type:      models.StockTransfer,
+				Quantity:  1,
+				Reason:    "moved to the warehouse",
+				UserID:    &userID,
+				StoreID:   1,
+				ToStoreID: 1,
+			},
+			expected: stockMovementExpectedOutput{
+				movement: nil,
+				err:      models.ErrInvalidStockMovement,
+			},
+		},
+		{
+			desc: "Fail_TransferNoDestination",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+			},
+			input: movement(models.StockTransfer, 1, "moved to the warehouse"),
+			expected: stockMovementExpectedOutput{
+				movement: nil,
+				err:      models.ErrInvalidStockMovement,
+			},
+		},
+		{
+			desc: "Fail_Sale",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+			},
+			input: movement(models.StockSale, -1, "sold"),
+			expected: stockMovementExpectedOutput{
+				movement: nil,
+				err:      models.ErrInvalidStockMovement,
+			},
+		},
+		{
+			desc: "Fail_NegativeRestock",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+			},
+			input: movement(models.StockRestock, -5, "weekly delivery"),
+			expected: stockMovementExpectedOutput{
+				movement: nil,
+				err:      models.ErrInvalidStockMovement,
+			},
+		},
+		{
+			desc: "Fail_NoReason",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+			},
+			input: movement(models.StockAdjustment, -1, ""),
+			expected: stockMovementExpectedOutput{
+				movement: nil,
+				err:      models.ErrInvalidStockMovement,
+			},
+		},
+		{
+			desc: "Fail_NoStore",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+			},
+			input: &models.StockMovement{
+				ProductID: product.ID,
+				Type:      models.StockRestock,
+				Quantity:  24,
+				Reason:    "weekly delivery",
+				UserID:    &userID,
+			},
+			expected: stockMovementExpectedOutput{
+				movement: nil,
+				err:      models.ErrStoreRequired,
+			},
+		},
+		{
+			desc: "Fail_InvalidProduct",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(product.ID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: transfer,
+			expected: stockMovementExpectedOutput{
+				movement: nil,
+				err:      models.ErrInvalidProduct,
+			},
+		},
+		{
+			desc: "Fail_InsufficientStock",
+			mocks: func(
+				stockRepo *mock2.MockStockRepository,
+				productRepo *mock2.MockProductRepository,
+				cache *mock2.MockCacheRepository,
+				alerter *mock2.MockStockAlerter,
+			) {
+				productRepo.EXPECT().
+					GetProductByID(gomock.Any(), gomock.Eq(product.ID)).
+					Return(product, nil)
+				stockRepo.EXPECT().
+					RecordMovement(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrInsufficientStock)
+			},
+			input: movement(models.StockAdjustment, -20, "stocktake"),
+			expected: stockMovementExpectedOutput{
+				movement: nil,
+				err:      models.ErrInsufficientStock,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stockRepo := mock2.NewMockStockRepository(ctrl)
+			productRepo := mock2.NewMockProductRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			alerter := mock2.NewMockStockAlerter(ctrl)
+
+			tc.mocks(stockRepo, productRepo, cache, alerter)
+
+			stockService := services.NewStockService(stockRepo, productRepo, cache, alerter)
+
+			movement, err := stockService.RecordMovement(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.movement, movement, "Stock movement mismatch")
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+)
+
+/**
+ * StoreService implements ports.StoreService interface
+ * and provides an access to the store repositories
+ * and cache service
+ */
+type StoreService struct {
+	repo  ports.StoreRepository
+	cache ports.CacheRepository
+}
+
+// NewStoreService creates a new store services instance
+func NewStoreService(repo ports.StoreRepository, cache ports.CacheRepository) *StoreService {
+	return &StoreService{
+		repo,
+		cache,
+	}
+}
+
+// CreateStore creates a new store, rounding taxes per line unless told otherwise
+func (ss *StoreService) CreateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	if store.TaxRounding == "" {
+		store.TaxRounding = models.TaxRoundingLine
+	}
+
+	store, err := ss.repo.CreateStore(ctx, store)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("store", store.ID)
+	storeSerialized, err := utils.Serialize(store)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ss.cache.Set(ctx, cacheKey, storeSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ss.cache.DeleteByPrefix(ctx, "stores:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return store, nil
+}
+
+// GetStore gets a store by ID
+func (ss *StoreService) GetStore(ctx context.Context, id uint64) (*models.Store, error) {
+	var store *models.Store
+
+	cacheKey := utils.GenerateCacheKey("store", id)
+	cachedStore, err := ss.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedStore, &store)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return store, nil
+	}
+
+	store, err = ss.repo.GetStoreByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	storeSerialized, err := utils.Serialize(store)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ss.cache.Set(ctx, cacheKey, storeSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return store, nil
+}
+
+// ListStores lists all stores
+func (ss *StoreService) ListStores(ctx context.Context, skip, limit uint64) ([]models.Store, error) {
+	var stores []models.Store
+
+	params := utils.GenerateCacheKeyParams(skip, limit)
+	cacheKey := utils.GenerateCacheKey("stores", params)
+
+	cachedStores, err := ss.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedStores, &stores)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return stores, nil
+	}
+
+	stores, err = ss.repo.ListStores(ctx, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	storesSerialized, err := utils.Serialize(stores)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ss.cache.Set(ctx, cacheKey, storesSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return stores, nil
+}
+
+// UpdateStore replaces the details of a store, rounding taxes per line unless told otherwise
+func (ss *StoreService) UpdateStore(ctx context.Context, store *models.Store) (*models.Store, error) {
+	if store.TaxRounding == "" {
+		store.TaxRounding = models.TaxRoundingLine
+	}
+
+	existingStore, err := ss.repo.GetStoreByID(ctx, store.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	emptyData := store.Name == ""
+	sameData := existingStore.Name == store.Name &&
+		existingStore.Address == store.Address &&
+		existingStore.Region == store.Region &&
+		existingStore.TaxRounding == store.TaxRounding
+	if emptyData || sameData {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	store, err = ss.repo.UpdateStore(ctx, store)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("store", store.ID)
+
+	err = ss.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ss.cache.DeleteByPrefix(ctx, "stores:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return store, nil
+}
+
+// DeleteStore deletes a store by ID, refusing stores that have placed orders or held stock
+func (ss *StoreService) DeleteStore(ctx context.Context, id uint64) error {
+	_, err := ss.repo.GetStoreByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = ss.repo.DeleteStore(ctx, id)
+	if err != nil {
+		if err == models.ErrStoreInUse {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("store", id)
+
+	err = ss.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = ss.cache.DeleteByPrefix(ctx, "stores:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	// the users of the store are left unassigned
+	err = ss.cache.DeleteByPrefix(ctx, "user:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = ss.cache.DeleteByPrefix(ctx, "users:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// AssignUser assigns a user to a store, moving them from the store they were assigned to.
+// The store of a user's token is the one they were assigned to when they logged in
+func (ss *StoreService) AssignUser(ctx context.Context, storeID, userID uint64) error {
+	_, err := ss.repo.GetStoreByID(ctx, storeID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = ss.repo.AssignUser(ctx, storeID, userID)
+	if err != nil {
+		if err == models.ErrDataNotFound || err == models.ErrInvalidStore {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	return invalidateUserCache(ctx, ss.cache, userID)
+}
+
+// ListUsers lists the users assigned to a store
+func (ss *StoreService) ListUsers(ctx context.Context, storeID, skip, limit uint64) ([]models.User, error) {
+	_, err := ss.repo.GetStoreByID(ctx, storeID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	users, err := ss.repo.ListUsers(ctx, storeID, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return users, nil
+}
+
+// UnassignUser removes a user from a store, leaving them without one
+func (ss *StoreService) UnassignUser(ctx context.Context, storeID, userID uint64) error {
+	err := ss.repo.UnassignUser(ctx, storeID, userID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	return invalidateUserCache(ctx, ss.cache, userID)
+}
+
+// invalidateUserCache removes a cached user along with the cached user lists
+func invalidateUserCache(ctx context.Context, cache ports.CacheRepository, userID uint64) error {
+	err := cache.Delete(ctx, utils.GenerateCacheKey("user", userID))
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = cache.DeleteByPrefix(ctx, "users:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// inStore checks whether something of a store can be reached by a request acting on the given
+// store, a request acting on no store is one allowed to reach every store
+func inStore(storeID, scope uint64) bool {
+	return scope == 0 || storeID == scope
+}
@@ -0,0 +1,320 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type storeExpectedOutput struct {
+	store *models.Store
+	err   error
+}
+
+func TestStoreService_CreateStore(t *testing.T) {
+	ctx := context.Background()
+	storeInput := &models.Store{
+		Name:        "Downtown",
+		Address:     gofakeit.Street(),
+		TaxRounding: models.TaxRoundingLine,
+	}
+	storeOutput := &models.Store{
+		ID:          gofakeit.Uint64(),
+		Name:        storeInput.Name,
+		Address:     storeInput.Address,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+		TaxRounding: storeInput.TaxRounding,
+	}
+
+	cacheKey := util2.GenerateCacheKey("store", storeOutput.ID)
+	storeSerialized, _ := util2.Serialize(storeOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			storeRepo *mock2.MockStoreRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected storeExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					CreateStore(gomock.Any(), gomock.Eq(storeInput)).
+					Return(storeOutput, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(storeSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("stores:*")).
+					Return(nil)
+			},
+			expected: storeExpectedOutput{
+				store: storeOutput,
+				err:   nil,
+			},
+		},
+		{
+			desc: "Fail_DuplicateData",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					CreateStore(gomock.Any(), gomock.Eq(storeInput)).
+					Return(nil, models.ErrConflictingData)
+			},
+			expected: storeExpectedOutput{
+				store: nil,
+				err:   models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					CreateStore(gomock.Any(), gomock.Eq(storeInput)).
+					Return(nil, errors.New("connection refused"))
+			},
+			expected: storeExpectedOutput{
+				store: nil,
+				err:   models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(storeRepo, cache)
+
+			storeService := services.NewStoreService(storeRepo, cache)
+
+			store, err := storeService.CreateStore(ctx, storeInput)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.store, store, "Store mismatch")
+		})
+	}
+}
+
+func TestStoreService_DeleteStore(t *testing.T) {
+	ctx := context.Background()
+	storeID := gofakeit.Uint64()
+	existingStore := &models.Store{
+		ID:   storeID,
+		Name: "Downtown",
+	}
+
+	cacheKey := util2.GenerateCacheKey("store", storeID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			storeRepo *mock2.MockStoreRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(existingStore, nil)
+				storeRepo.EXPECT().
+					DeleteStore(gomock.Any(), gomock.Eq(storeID)).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("stores:*")).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("user:*")).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			expected: models.ErrDataNotFound,
+		},
+		{
+			desc: "Fail_StoreInUse",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(existingStore, nil)
+				storeRepo.EXPECT().
+					DeleteStore(gomock.Any(), gomock.Eq(storeID)).
+					Return(models.ErrStoreInUse)
+			},
+			expected: models.ErrStoreInUse,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(storeRepo, cache)
+
+			storeService := services.NewStoreService(storeRepo, cache)
+
+			err := storeService.DeleteStore(ctx, storeID)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
+
+func TestStoreService_AssignUser(t *testing.T) {
+	ctx := context.Background()
+	storeID := gofakeit.Uint64()
+	userID := gofakeit.Uint64()
+	existingStore := &models.Store{
+		ID:   storeID,
+		Name: "Downtown",
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			storeRepo *mock2.MockStoreRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected error
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(existingStore, nil)
+				storeRepo.EXPECT().
+					AssignUser(gomock.Any(), gomock.Eq(storeID), gomock.Eq(userID)).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(util2.GenerateCacheKey("user", userID))).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_StoreNotFound",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			expected: models.ErrDataNotFound,
+		},
+		{
+			// the store was deleted since it was read
+			desc: "Fail_InvalidStore",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(existingStore, nil)
+				storeRepo.EXPECT().
+					AssignUser(gomock.Any(), gomock.Eq(storeID), gomock.Eq(userID)).
+					Return(models.ErrInvalidStore)
+			},
+			expected: models.ErrInvalidStore,
+		},
+		{
+			desc: "Fail_UserNotFound",
+			mocks: func(
+				storeRepo *mock2.MockStoreRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				storeRepo.EXPECT().
+					GetStoreByID(gomock.Any(), gomock.Eq(storeID)).
+					Return(existingStore, nil)
+				storeRepo.EXPECT().
+					AssignUser(gomock.Any(), gomock.Eq(storeID), gomock.Eq(userID)).
+					Return(models.ErrDataNotFound)
+			},
+			expected: models.ErrDataNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			storeRepo := mock2.NewMockStoreRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(storeRepo, cache)
+
+			storeService := services.NewStoreService(storeRepo, cache)
+
+			err := storeService.AssignUser(ctx, storeID, userID)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
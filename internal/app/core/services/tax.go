@@ -0,0 +1,512 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+/**
+ * TaxService implements ports.TaxService interface
+ * and provides an access to the tax repositories
+ * and cache service
+ */
+type TaxService struct {
+	repo  ports.TaxRepository
+	cache ports.CacheRepository
+}
+
+// NewTaxService creates a new tax services instance
+func NewTaxService(repo ports.TaxRepository, cache ports.CacheRepository) *TaxService {
+	return &TaxService{
+		repo,
+		cache,
+	}
+}
+
+// CreateTaxClass creates a new tax class
+func (ts *TaxService) CreateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error) {
+	class, err := ts.repo.CreateTaxClass(ctx, class)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("tax-class", class.ID)
+	classSerialized, err := utils.Serialize(class)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.Set(ctx, cacheKey, classSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.DeleteByPrefix(ctx, "tax-classes:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return class, nil
+}
+
+// GetTaxClass gets a tax class by ID
+func (ts *TaxService) GetTaxClass(ctx context.Context, id uint64) (*models.TaxClass, error) {
+	var class *models.TaxClass
+
+	cacheKey := utils.GenerateCacheKey("tax-class", id)
+	cachedClass, err := ts.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedClass, &class)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return class, nil
+	}
+
+	class, err = ts.repo.GetTaxClassByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	classSerialized, err := utils.Serialize(class)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.Set(ctx, cacheKey, classSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return class, nil
+}
+
+// ListTaxClasses lists all tax classes
+func (ts *TaxService) ListTaxClasses(ctx context.Context, skip, limit uint64) ([]models.TaxClass, error) {
+	var classes []models.TaxClass
+
+	params := utils.GenerateCacheKeyParams(skip, limit)
+	cacheKey := utils.GenerateCacheKey("tax-classes", params)
+
+	cachedClasses, err := ts.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedClasses, &classes)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return classes, nil
+	}
+
+	classes, err = ts.repo.ListTaxClasses(ctx, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	classesSerialized, err := utils.Serialize(classes)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.Set(ctx, cacheKey, classesSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return classes, nil
+}
+
+// UpdateTaxClass renames a tax class
+func (ts *TaxService) UpdateTaxClass(ctx context.Context, class *models.TaxClass) (*models.TaxClass, error) {
+	existingClass, err := ts.repo.GetTaxClassByID(ctx, class.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	emptyData := class.Name == ""
+	sameData := existingClass.Name == class.Name
+	if emptyData || sameData {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	class, err = ts.repo.UpdateTaxClass(ctx, class)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("tax-class", class.ID)
+
+	err = ts.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.DeleteByPrefix(ctx, "tax-classes:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return class, nil
+}
+
+// DeleteTaxClass deletes a tax class by ID with its rates, refusing classes that still have products
+func (ts *TaxService) DeleteTaxClass(ctx context.Context, id uint64) error {
+	_, err := ts.repo.GetTaxClassByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = ts.repo.DeleteTaxClass(ctx, id)
+	if err != nil {
+		if err == models.ErrTaxClassInUse {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("tax-class", id)
+
+	err = ts.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = ts.cache.DeleteByPrefix(ctx, "tax-classes:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	// the rates of the class are gone with it
+	err = ts.cache.DeleteByPrefix(ctx, "tax-rate:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = ts.cache.DeleteByPrefix(ctx, "tax-rates:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// CreateTaxRate creates a new tax rate
+func (ts *TaxService) CreateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	err := validateTaxRate(rate)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err = ts.repo.CreateTaxRate(ctx, rate)
+	if err != nil {
+		if err == models.ErrInvalidTaxClass || err == models.ErrInvalidStore || err == models.ErrInvalidTaxRate {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("tax-rate", rate.ID)
+	rateSerialized, err := utils.Serialize(rate)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.Set(ctx, cacheKey, rateSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.DeleteByPrefix(ctx, "tax-rates:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return rate, nil
+}
+
+// GetTaxRate gets a tax rate by ID
+func (ts *TaxService) GetTaxRate(ctx context.Context, id uint64) (*models.TaxRate, error) {
+	var rate *models.TaxRate
+
+	cacheKey := utils.GenerateCacheKey("tax-rate", id)
+	cachedRate, err := ts.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedRate, &rate)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return rate, nil
+	}
+
+	rate, err = ts.repo.GetTaxRateByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	rateSerialized, err := utils.Serialize(rate)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.Set(ctx, cacheKey, rateSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return rate, nil
+}
+
+// ListTaxRates lists the tax rates of a tax class, or of every class when taxClassID is zero
+func (ts *TaxService) ListTaxRates(ctx context.Context, taxClassID, skip, limit uint64) ([]models.TaxRate, error) {
+	var rates []models.TaxRate
+
+	params := utils.GenerateCacheKeyParams(skip, limit, taxClassID)
+	cacheKey := utils.GenerateCacheKey("tax-rates", params)
+
+	cachedRates, err := ts.cache.Get(ctx, cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedRates, &rates)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return rates, nil
+	}
+
+	rates, err = ts.repo.ListTaxRates(ctx, taxClassID, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	ratesSerialized, err := utils.Serialize(rates)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.Set(ctx, cacheKey, ratesSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return rates, nil
+}
+
+// UpdateTaxRate replaces a tax rate, the orders already placed keep the taxes they were charged
+func (ts *TaxService) UpdateTaxRate(ctx context.Context, rate *models.TaxRate) (*models.TaxRate, error) {
+	existingRate, err := ts.repo.GetTaxRateByID(ctx, rate.ID)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	err = validateTaxRate(rate)
+	if err != nil {
+		return nil, err
+	}
+
+	sameData := existingRate.TaxClassID == rate.TaxClassID &&
+		existingRate.Name == rate.Name &&
+		existingRate.Rate == rate.Rate &&
+		existingRate.Inclusive == rate.Inclusive &&
+		sameID(existingRate.StoreID, rate.StoreID) &&
+		existingRate.Region == rate.Region
+	if sameData {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	rate, err = ts.repo.UpdateTaxRate(ctx, rate)
+	if err != nil {
+		if err == models.ErrInvalidTaxClass || err == models.ErrInvalidStore || err == models.ErrInvalidTaxRate {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	err = invalidateTaxRateCache(ctx, ts.cache, rate.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+// DeleteTaxRate deletes a tax rate by ID, the orders already placed keep the taxes they were charged
+func (ts *TaxService) DeleteTaxRate(ctx context.Context, id uint64) error {
+	_, err := ts.repo.GetTaxRateByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return err
+		}
+		return models.ErrInternal
+	}
+
+	err = ts.repo.DeleteTaxRate(ctx, id)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return invalidateTaxRateCache(ctx, ts.cache, id)
+}
+
+// invalidateTaxRateCache removes a cached tax rate along with the cached rate lists
+func invalidateTaxRateCache(ctx context.Context, cache ports.CacheRepository, id uint64) error {
+	err := cache.Delete(ctx, utils.GenerateCacheKey("tax-rate", id))
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	err = cache.DeleteByPrefix(ctx, "tax-rates:*")
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return nil
+}
+
+// validateTaxRate checks a tax rate is named, is strictly between nothing and everything
+// and applies to a store or to a region, not both
+func validateTaxRate(rate *models.TaxRate) error {
+	rate.Name = strings.TrimSpace(rate.Name)
+	rate.Region = strings.TrimSpace(rate.Region)
+
+	if rate.Name == "" || rate.Rate <= 0 || rate.Rate >= models.TaxRateScale {
+		return models.ErrInvalidTaxRate
+	}
+
+	if rate.StoreID != nil && rate.Region != "" {
+		return models.ErrInvalidTaxRate
+	}
+
+	return nil
+}
+
+// applicableTaxRates groups the rates that apply at a store by tax class, keeping only the most
+// specific rates of each class, so a rate of the store replaces those of its region, which replace
+// those applying everywhere. The rates of a class are sorted by ID
+func applicableTaxRates(rates []models.TaxRate) map[uint64][]models.TaxRate {
+	classes := map[uint64][]models.TaxRate{}
+
+	for _, rate := range rates {
+		current := classes[rate.TaxClassID]
+
+		switch {
+		case len(current) == 0 || rate.MoreSpecific(&current[0]):
+			classes[rate.TaxClassID] = []models.TaxRate{rate}
+		case !current[0].MoreSpecific(&rate):
+			classes[rate.TaxClassID] = append(current, rate)
+		}
+	}
+
+	for _, classRates := range classes {
+		slices.SortFunc(classRates, func(a, b models.TaxRate) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+	}
+
+	return classes
+}
+
+// calculateTaxes works out the taxes of the lines of an order, what is left to pay for each line
+// once discounted being its taxable amount. The products are those of the lines, in the same order.
+//
+// The inclusive rates of a class are already in the amount of a line, so each tax of the class is
+// amount * rate / (scale + inclusive rates), the same share of the untaxed amount whether the tax
+// is inclusive or added on top. Line rounding rounds the tax of each line before adding them up,
+// invoice rounding adds up the amounts of the lines and rounds once. Everything stays in minor
+// units, rounded half up, and the taxes are sorted by rate
+func calculateTaxes(products []*models.Product, amounts []int64, rates []models.TaxRate, rounding models.TaxRounding) []models.OrderTax {
+	classes := applicableTaxRates(rates)
+
+	taxes := map[uint64]*models.OrderTax{}
+	bases := map[uint64]int64{}
+	denominators := map[uint64]int64{}
+
+	for i, product := range products {
+		if product.TaxClassID == nil || amounts[i] == 0 {
+			continue
+		}
+
+		classRates := classes[*product.TaxClassID]
+
+		denominator := int64(models.TaxRateScale)
+		for _, rate := range classRates {
+			if rate.Inclusive {
+				denominator += rate.Rate
+			}
+		}
+
+		for _, rate := range classRates {
+			tax, ok := taxes[rate.ID]
+			if !ok {
+				tax = &models.OrderTax{
+					TaxRateID: rate.ID,
+					Name:      rate.Name,
+					Rate:      rate.Rate,
+					Inclusive: rate.Inclusive,
+				}
+				taxes[rate.ID] = tax
+				denominators[rate.ID] = denominator
+			}
+
+			if rounding == models.TaxRoundingInvoice {
+				bases[rate.ID] += amounts[i]
+				continue
+			}
+
+			tax.TaxableAmount += mulDivRound(amounts[i], models.TaxRateScale, denominator)
+			tax.Amount += mulDivRound(amounts[i], rate.Rate, denominator)
+		}
+	}
+
+	orderTaxes := make([]models.OrderTax, 0, len(taxes))
+	for id, tax := range taxes {
+		if rounding == models.TaxRoundingInvoice {
+			tax.TaxableAmount = mulDivRound(bases[id], models.TaxRateScale, denominators[id])
+			tax.Amount = mulDivRound(bases[id], tax.Rate, denominators[id])
+		}
+		orderTaxes = append(orderTaxes, *tax)
+	}
+
+	slices.SortFunc(orderTaxes, func(a, b models.OrderTax) int {
+		return cmp.Compare(a.TaxRateID, b.TaxRateID)
+	})
+
+	return orderTaxes
+}
+
+// mulDivRound works out a * b / c of non-negative numbers rounded half up, the product is
+// kept in 128 bits so it cannot overflow. The result must fit, which it does as long as b <= c
+func mulDivRound(a, b, c int64) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	quotient, remainder := bits.Div64(hi, lo, uint64(c))
+
+	if remainder >= uint64(c)-remainder {
+		quotient++
+	}
+
+	return int64(quotient)
+}
@@ -0,0 +1,372 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type taxRateExpectedOutput struct {
+	rate *models.TaxRate
+	err  error
+}
+
+func TestTaxService_CreateTaxRate(t *testing.T) {
+	ctx := context.Background()
+	storeID := uint64(1)
+
+	rate := func(name string, value int64, storeID *uint64, region string) *models.TaxRate {
+		return &models.TaxRate{
+			TaxClassID: 1,
+			Name:       name,
+			Rate:       value,
+			StoreID:    storeID,
+			Region:     region,
+		}
+	}
+	rateOutput := &models.TaxRate{
+		ID:         gofakeit.Uint64(),
+		TaxClassID: 1,
+		Name:       "VAT",
+		Rate:       110000,
+		Region:     "ID-JK",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	cacheKey := util2.GenerateCacheKey("tax-rate", rateOutput.ID)
+	rateSerialized, _ := util2.Serialize(rateOutput)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			taxRepo *mock2.MockTaxRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    *models.TaxRate
+		expected taxRateExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				taxRepo.EXPECT().
+					CreateTaxRate(gomock.Any(), gomock.Eq(rate("VAT", 110000, nil, "ID-JK"))).
+					Return(rateOutput, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(rateSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("tax-rates:*")).
+					Return(nil)
+			},
+			input: rate(" VAT ", 110000, nil, "ID-JK "),
+			expected: taxRateExpectedOutput{
+				rate: rateOutput,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_NoName",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: rate(" ", 110000, nil, ""),
+			expected: taxRateExpectedOutput{
+				rate: nil,
+				err:  models.ErrInvalidTaxRate,
+			},
+		},
+		{
+			// a rate of a hundred percent would make the inclusive share undefined
+			desc: "Fail_WholeRate",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: rate("VAT", models.TaxRateScale, nil, ""),
+			expected: taxRateExpectedOutput{
+				rate: nil,
+				err:  models.ErrInvalidTaxRate,
+			},
+		},
+		{
+			desc: "Fail_StoreAndRegion",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+			},
+			input: rate("VAT", 110000, &storeID, "ID-JK"),
+			expected: taxRateExpectedOutput{
+				rate: nil,
+				err:  models.ErrInvalidTaxRate,
+			},
+		},
+		{
+			desc: "Fail_InvalidTaxClass",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				taxRepo.EXPECT().
+					CreateTaxRate(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrInvalidTaxClass)
+			},
+			input: rate("VAT", 110000, nil, ""),
+			expected: taxRateExpectedOutput{
+				rate: nil,
+				err:  models.ErrInvalidTaxClass,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				taxRepo.EXPECT().
+					CreateTaxRate(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("connection refused"))
+			},
+			input: rate("VAT", 110000, &storeID, ""),
+			expected: taxRateExpectedOutput{
+				rate: nil,
+				err:  models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taxRepo := mock2.NewMockTaxRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(taxRepo, cache)
+
+			taxService := services.NewTaxService(taxRepo, cache)
+
+			rate, err := taxService.CreateTaxRate(ctx, tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.rate, rate, "Tax rate mismatch")
+		})
+	}
+}
+
+func TestTaxService_UpdateTaxRate(t *testing.T) {
+	ctx := context.Background()
+	existingRate := &models.TaxRate{
+		ID:         gofakeit.Uint64(),
+		TaxClassID: 1,
+		Name:       "VAT",
+		Rate:       110000,
+		Inclusive:  true,
+	}
+	raisedRate := *existingRate
+	raisedRate.Rate = 120000
+
+	cacheKey := util2.GenerateCacheKey("tax-rate", existingRate.ID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			taxRepo *mock2.MockTaxRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		input    models.TaxRate
+		expected taxRateExpectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				taxRepo.EXPECT().
+					GetTaxRateByID(gomock.Any(), gomock.Eq(existingRate.ID)).
+					Return(existingRate, nil)
+				taxRepo.EXPECT().
+					UpdateTaxRate(gomock.Any(), gomock.Eq(&raisedRate)).
+					Return(&raisedRate, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("tax-rates:*")).
+					Return(nil)
+			},
+			input: raisedRate,
+			expected: taxRateExpectedOutput{
+				rate: &raisedRate,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_NoUpdatedData",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				taxRepo.EXPECT().
+					GetTaxRateByID(gomock.Any(), gomock.Eq(existingRate.ID)).
+					Return(existingRate, nil)
+			},
+			input: *existingRate,
+			expected: taxRateExpectedOutput{
+				rate: nil,
+				err:  models.ErrNoUpdatedData,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				taxRepo.EXPECT().
+					GetTaxRateByID(gomock.Any(), gomock.Eq(existingRate.ID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: raisedRate,
+			expected: taxRateExpectedOutput{
+				rate: nil,
+				err:  models.ErrDataNotFound,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taxRepo := mock2.NewMockTaxRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(taxRepo, cache)
+
+			taxService := services.NewTaxService(taxRepo, cache)
+
+			rate, err := taxService.UpdateTaxRate(ctx, &tc.input)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.rate, rate, "Tax rate mismatch")
+		})
+	}
+}
+
+func TestTaxService_DeleteTaxClass(t *testing.T) {
+	ctx := context.Background()
+	classID := gofakeit.Uint64()
+	existingClass := &models.TaxClass{
+		ID:   classID,
+		Name: "Food",
+	}
+
+	cacheKey := util2.GenerateCacheKey("tax-class", classID)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			taxRepo *mock2.MockTaxRepository,
+			cache *mock2.MockCacheRepository,
+		)
+		expected error
+	}{
+		{
+			// the rates of the class are deleted with it
+			desc: "Success",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				taxRepo.EXPECT().
+					GetTaxClassByID(gomock.Any(), gomock.Eq(classID)).
+					Return(existingClass, nil)
+				taxRepo.EXPECT().
+					DeleteTaxClass(gomock.Any(), gomock.Eq(classID)).
+					Return(nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("tax-classes:*")).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("tax-rate:*")).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("tax-rates:*")).
+					Return(nil)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				taxRepo.EXPECT().
+					GetTaxClassByID(gomock.Any(), gomock.Eq(classID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			expected: models.ErrDataNotFound,
+		},
+		{
+			desc: "Fail_TaxClassInUse",
+			mocks: func(
+				taxRepo *mock2.MockTaxRepository,
+				cache *mock2.MockCacheRepository,
+			) {
+				taxRepo.EXPECT().
+					GetTaxClassByID(gomock.Any(), gomock.Eq(classID)).
+					Return(existingClass, nil)
+				taxRepo.EXPECT().
+					DeleteTaxClass(gomock.Any(), gomock.Eq(classID)).
+					Return(models.ErrTaxClassInUse)
+			},
+			expected: models.ErrTaxClassInUse,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taxRepo := mock2.NewMockTaxRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+
+			tc.mocks(taxRepo, cache)
+
+			taxService := services.NewTaxService(taxRepo, cache)
+
+			err := taxService.DeleteTaxClass(ctx, classID)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+)
+
+/**
+ * TenantService implements ports.TenantService interface
+ * and provides an access to the tenant repositories,
+ * cache and user services
+ */
+type TenantService struct {
+	repo  ports.TenantRepository
+	cache ports.CacheRepository
+	users ports.UserService
+}
+
+// NewTenantService creates a new tenant services instance
+func NewTenantService(repo ports.TenantRepository, cache ports.CacheRepository, users ports.UserService) *TenantService {
+	return &TenantService{
+		repo,
+		cache,
+		users,
+	}
+}
+
+// CreateTenant creates a new tenant and registers its first admin in it, nobody else could sign in to it.
+// The tenant is deleted again when the admin cannot be registered, so that the request can be retried
+func (ts *TenantService) CreateTenant(ctx context.Context, tenant *models.Tenant, admin *models.User) (*models.Tenant, *models.User, error) {
+	if !inDefaultTenant(ctx) {
+		return nil, nil, models.ErrForbidden
+	}
+
+	tenant, err := ts.repo.CreateTenant(ctx, tenant)
+	if err != nil {
+		if err == models.ErrConflictingData {
+			return nil, nil, err
+		}
+		return nil, nil, models.ErrInternal
+	}
+
+	admin.Role = models.Admin
+	admin, err = ts.users.Register(_constant.WithTenantID(ctx, tenant.ID), admin)
+	if err != nil {
+		_ = ts.repo.DeleteTenant(ctx, tenant.ID)
+		return nil, nil, err
+	}
+
+	err = ts.cache.DeleteByPrefix(sharedCache(ctx), "tenants:*")
+	if err != nil {
+		return nil, nil, models.ErrInternal
+	}
+
+	return tenant, admin, nil
+}
+
+// GetTenant gets a tenant by ID, the users of other tenants than the default one only get their own
+func (ts *TenantService) GetTenant(ctx context.Context, id uint64) (*models.Tenant, error) {
+	tenantID, _ := _constant.TenantID(ctx)
+	if tenantID != id && !inDefaultTenant(ctx) {
+		return nil, models.ErrForbidden
+	}
+
+	return ts.LookupTenant(ctx, id)
+}
+
+// LookupTenant gets a tenant by ID whichever tenant the request acts on, it is looked up on every
+// request so it is cached
+func (ts *TenantService) LookupTenant(ctx context.Context, id uint64) (*models.Tenant, error) {
+	var tenant *models.Tenant
+
+	cacheKey := utils.GenerateCacheKey("tenant", id)
+	cachedTenant, err := ts.cache.Get(sharedCache(ctx), cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedTenant, &tenant)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return tenant, nil
+	}
+
+	tenant, err = ts.repo.GetTenantByID(ctx, id)
+	if err != nil {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	tenantSerialized, err := utils.Serialize(tenant)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.Set(sharedCache(ctx), cacheKey, tenantSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return tenant, nil
+}
+
+// ListTenants lists all tenants
+func (ts *TenantService) ListTenants(ctx context.Context, skip, limit uint64) ([]models.Tenant, error) {
+	if !inDefaultTenant(ctx) {
+		return nil, models.ErrForbidden
+	}
+
+	var tenants []models.Tenant
+
+	params := utils.GenerateCacheKeyParams(skip, limit)
+	cacheKey := utils.GenerateCacheKey("tenants", params)
+
+	cachedTenants, err := ts.cache.Get(sharedCache(ctx), cacheKey)
+	if err == nil {
+		err := utils.Deserialize(cachedTenants, &tenants)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+		return tenants, nil
+	}
+
+	tenants, err = ts.repo.ListTenants(ctx, skip, limit)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	tenantsSerialized, err := utils.Serialize(tenants)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = ts.cache.Set(sharedCache(ctx), cacheKey, tenantsSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	return tenants, nil
+}
+
+// inDefaultTenant reports whether a request acts on the default tenant, whose admins manage what every
+// tenant shares. The policy of the other tenants is theirs to change, so it cannot be relied on for that
+func inDefaultTenant(ctx context.Context) bool {
+	tenantID, _ := _constant.TenantID(ctx)
+	return tenantID == models.DefaultTenantID
+}
@@ -0,0 +1,341 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
+	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
+	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
+	util2 "github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// inTenant matches a context acting on the given tenant
+func inTenant(id uint64) gomock.Matcher {
+	return gomock.Cond(func(ctx context.Context) bool {
+		tenantID, _ := _constant.TenantID(ctx)
+		return tenantID == id
+	})
+}
+
+func TestTenantService_CreateTenant(t *testing.T) {
+	ctx := _constant.WithTenantID(context.Background(), models.DefaultTenantID)
+	tenantInput := &models.Tenant{
+		Name: gofakeit.Company(),
+	}
+	tenantOutput := &models.Tenant{
+		ID:        gofakeit.Uint64(),
+		Name:      tenantInput.Name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	adminOutput := &models.User{
+		ID:       gofakeit.Uint64(),
+		Name:     gofakeit.Name(),
+		Email:    gofakeit.Email(),
+		Role:     models.Admin,
+		TenantID: tenantOutput.ID,
+	}
+
+	type expectedOutput struct {
+		tenant *models.Tenant
+		admin  *models.User
+		err    error
+	}
+
+	testCases := []struct {
+		desc     string
+		tenantID uint64
+		mocks    func(
+			tenantRepo *mock2.MockTenantRepository,
+			cache *mock2.MockCacheRepository,
+			users *mock2.MockUserService,
+		)
+		expected expectedOutput
+	}{
+		{
+			desc: "Success",
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+				tenantRepo.EXPECT().
+					CreateTenant(gomock.Any(), gomock.Eq(tenantInput)).
+					Return(tenantOutput, nil)
+				// the admin is registered in the new tenant, not in the one of the request
+				users.EXPECT().
+					Register(inTenant(tenantOutput.ID), gomock.Any()).
+					Return(adminOutput, nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("tenants:*")).
+					Return(nil)
+			},
+			expected: expectedOutput{
+				tenant: tenantOutput,
+				admin:  adminOutput,
+				err:    nil,
+			},
+		},
+		{
+			// the admins of other tenants may grant themselves the route, not the right
+			desc:     "Fail_OtherTenant",
+			tenantID: 2,
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+			},
+			expected: expectedOutput{
+				err: models.ErrForbidden,
+			},
+		},
+		{
+			desc: "Fail_DuplicateData",
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+				tenantRepo.EXPECT().
+					CreateTenant(gomock.Any(), gomock.Eq(tenantInput)).
+					Return(nil, models.ErrConflictingData)
+			},
+			expected: expectedOutput{
+				err: models.ErrConflictingData,
+			},
+		},
+		{
+			desc: "Fail_AdminNotRegistered",
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+				tenantRepo.EXPECT().
+					CreateTenant(gomock.Any(), gomock.Eq(tenantInput)).
+					Return(tenantOutput, nil)
+				users.EXPECT().
+					Register(gomock.Any(), gomock.Any()).
+					Return(nil, models.ErrInternal)
+				tenantRepo.EXPECT().
+					DeleteTenant(gomock.Any(), gomock.Eq(tenantOutput.ID)).
+					Return(nil)
+			},
+			expected: expectedOutput{
+				err: models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenantRepo := mock2.NewMockTenantRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			users := mock2.NewMockUserService(ctrl)
+
+			tc.mocks(tenantRepo, cache, users)
+
+			tenantService := services.NewTenantService(tenantRepo, cache, users)
+
+			admin := &models.User{
+				Name:     adminOutput.Name,
+				Email:    adminOutput.Email,
+				Password: gofakeit.Password(true, true, true, true, false, 8),
+			}
+			reqCtx := ctx
+			if tc.tenantID != 0 {
+				reqCtx = _constant.WithTenantID(ctx, tc.tenantID)
+			}
+
+			tenant, admin, err := tenantService.CreateTenant(reqCtx, tenantInput, admin)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.tenant, tenant, "Tenant mismatch")
+			assert.Equal(t, tc.expected.admin, admin, "Admin mismatch")
+		})
+	}
+}
+
+func TestTenantService_GetTenant(t *testing.T) {
+	ctx := _constant.WithTenantID(context.Background(), models.DefaultTenantID)
+	tenantID := gofakeit.Uint64()
+	tenantOutput := &models.Tenant{
+		ID:        tenantID,
+		Name:      gofakeit.Company(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	cacheKey := util2.GenerateCacheKey("tenant", tenantID)
+	tenantSerialized, _ := util2.Serialize(tenantOutput)
+	ttl := time.Duration(0)
+
+	type expectedOutput struct {
+		tenant *models.Tenant
+		err    error
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			tenantRepo *mock2.MockTenantRepository,
+			cache *mock2.MockCacheRepository,
+			users *mock2.MockUserService,
+		)
+		tenantID uint64
+		expected expectedOutput
+	}{
+		{
+			desc: "Success_FromCache",
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(tenantSerialized, nil)
+			},
+			expected: expectedOutput{
+				tenant: tenantOutput,
+				err:    nil,
+			},
+		},
+		{
+			// tenants are shared by every tenant, so they are cached outside of any
+			desc: "Success_FromDB",
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+				cache.EXPECT().
+					Get(inTenant(0), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				tenantRepo.EXPECT().
+					GetTenantByID(gomock.Any(), gomock.Eq(tenantID)).
+					Return(tenantOutput, nil)
+				cache.EXPECT().
+					Set(inTenant(0), gomock.Eq(cacheKey), gomock.Eq(tenantSerialized), gomock.Eq(ttl)).
+					Return(nil)
+			},
+			expected: expectedOutput{
+				tenant: tenantOutput,
+				err:    nil,
+			},
+		},
+		{
+			desc: "Success_OwnTenant",
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(tenantSerialized, nil)
+			},
+			tenantID: tenantID,
+			expected: expectedOutput{
+				tenant: tenantOutput,
+				err:    nil,
+			},
+		},
+		{
+			// the users of the other tenants cannot read any but their own
+			desc: "Fail_OtherTenant",
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+			},
+			tenantID: tenantID + 1,
+			expected: expectedOutput{
+				tenant: nil,
+				err:    models.ErrForbidden,
+			},
+		},
+		{
+			desc: "Fail_NotFound",
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				tenantRepo.EXPECT().
+					GetTenantByID(gomock.Any(), gomock.Eq(tenantID)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			expected: expectedOutput{
+				tenant: nil,
+				err:    models.ErrDataNotFound,
+			},
+		},
+		{
+			desc: "Fail_InternalError",
+			mocks: func(
+				tenantRepo *mock2.MockTenantRepository,
+				cache *mock2.MockCacheRepository,
+				users *mock2.MockUserService,
+			) {
+				cache.EXPECT().
+					Get(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil, models.ErrDataNotFound)
+				tenantRepo.EXPECT().
+					GetTenantByID(gomock.Any(), gomock.Eq(tenantID)).
+					Return(nil, errors.New("connection refused"))
+			},
+			expected: expectedOutput{
+				tenant: nil,
+				err:    models.ErrInternal,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tenantRepo := mock2.NewMockTenantRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			users := mock2.NewMockUserService(ctrl)
+
+			tc.mocks(tenantRepo, cache, users)
+
+			tenantService := services.NewTenantService(tenantRepo, cache, users)
+
+			reqCtx := ctx
+			if tc.tenantID != 0 {
+				reqCtx = _constant.WithTenantID(ctx, tc.tenantID)
+			}
+
+			tenant, err := tenantService.GetTenant(reqCtx, tenantID)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			if tc.expected.tenant != nil {
+				assert.Equal(t, tc.expected.tenant.ID, tenant.ID, "Tenant mismatch")
+				assert.Equal(t, tc.expected.tenant.Name, tenant.Name, "Tenant mismatch")
+			} else {
+				assert.Nil(t, tenant, "Tenant mismatch")
+			}
+		})
+	}
+}
@@ -2,26 +2,43 @@ package services
 
 import (
 	"context"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/utils"
+	"net/http"
 )
 
 /**
  * UserService implements ports.UserService interface
- * and provides an access to the user repositories
- * and cache services
+ * and provides an access to the user and role repositories,
+ * cache, policy, email change and audit services
  */
 type UserService struct {
-	repo  ports.UserRepository
-	cache ports.CacheRepository
+	repo        ports.UserRepository
+	roleRepo    ports.RoleRepository
+	cache       ports.CacheRepository
+	policy      ports.PolicyService
+	emailChange ports.EmailChangeService
+	audit       ports.AuditService
 }
 
 // NewUserService creates a new user services instance
-func NewUserService(repo ports.UserRepository, cache ports.CacheRepository) *UserService {
+func NewUserService(
+	repo ports.UserRepository,
+	roleRepo ports.RoleRepository,
+	cache ports.CacheRepository,
+	policy ports.PolicyService,
+	emailChange ports.EmailChangeService,
+	audit ports.AuditService,
+) *UserService {
 	return &UserService{
 		repo,
+		roleRepo,
 		cache,
+		policy,
+		emailChange,
+		audit,
 	}
 }
 
@@ -36,7 +53,7 @@ func (us *UserService) Register(ctx context.Context, user *models.User) (*models
 
 	user, err = us.repo.CreateUser(ctx, user)
 	if err != nil {
-		if err == models.ErrConflictingData {
+		if err == models.ErrConflictingData || err == models.ErrInvalidRole {
 			return nil, err
 		}
 		return nil, models.ErrInternal
@@ -58,6 +75,16 @@ func (us *UserService) Register(ctx context.Context, user *models.User) (*models
 		return nil, models.ErrInternal
 	}
 
+	err = us.policy.AddUserRole(ctx, user.ID, user.Role)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = us.audit.RecordUserChange(ctx, models.AuditUserCreate, nil, user)
+	if err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
@@ -130,8 +157,10 @@ func (us *UserService) ListUsers(ctx context.Context, skip, limit uint64) ([]mod
 	return users, nil
 }
 
-// UpdateUser updates a user's name, email, and password
-func (us *UserService) UpdateUser(ctx context.Context, user *models.User) (*models.User, error) {
+// UpdateUser updates a user's name, password, and role if the given version is still current,
+// a new email is held pending until confirmed. On their own record, users only change the fields
+// the policy grants the owner, and their password only with the current one
+func (us *UserService) UpdateUser(ctx context.Context, user *models.User, currentPassword string) (*models.User, error) {
 	existingUser, err := us.repo.GetUserByID(ctx, user.ID)
 	if err != nil {
 		if err == models.ErrDataNotFound {
@@ -140,17 +169,70 @@ func (us *UserService) UpdateUser(ctx context.Context, user *models.User) (*mode
 		return nil, models.ErrInternal
 	}
 
+	if existingUser.Version != user.Version {
+		return nil, models.ErrVersionConflict
+	}
+
 	emptyData := user.Name == "" &&
 		user.Email == "" &&
 		user.Password == "" &&
 		user.Role == ""
-	sameData := existingUser.Name == user.Name &&
+	sameData := user.Password == "" &&
+		existingUser.Name == user.Name &&
 		existingUser.Email == user.Email &&
 		existingUser.Role == user.Role
 	if emptyData || sameData {
 		return nil, models.ErrNoUpdatedData
 	}
 
+	roleChanged := user.Role != "" && user.Role != existingUser.Role
+
+	var fields []models.UserField
+	if user.Name != "" && user.Name != existingUser.Name {
+		fields = append(fields, models.UserNameField)
+	}
+	if user.Email != "" && user.Email != existingUser.Email {
+		fields = append(fields, models.UserEmailField)
+	}
+	if user.Password != "" {
+		fields = append(fields, models.UserPasswordField)
+	}
+	if roleChanged {
+		fields = append(fields, models.UserRoleField)
+	}
+
+	err = us.checkOwnChanges(ctx, existingUser, fields, currentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	if roleChanged {
+		_, err := us.roleRepo.GetRoleByName(ctx, user.Role)
+		if err != nil {
+			if err == models.ErrDataNotFound {
+				return nil, models.ErrInvalidRole
+			}
+			return nil, models.ErrInternal
+		}
+	}
+
+	// a new email only takes effect once it is confirmed from that address
+	if user.Email != "" && user.Email != existingUser.Email {
+		_, err = us.emailChange.RequestEmailChange(ctx, existingUser, user.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		user.Email = ""
+
+		emailOnly := user.Password == "" &&
+			(user.Name == "" || user.Name == existingUser.Name) &&
+			!roleChanged
+		if emailOnly {
+			return existingUser, nil
+		}
+	}
+
 	var hashedPassword string
 
 	if user.Password != "" {
@@ -162,9 +244,134 @@ func (us *UserService) UpdateUser(ctx context.Context, user *models.User) (*mode
 
 	user.Password = hashedPassword
 
-	_, err = us.repo.UpdateUser(ctx, user)
+	user, err = us.repo.UpdateUser(ctx, user)
+	if err != nil {
+		if err == models.ErrConflictingData ||
+			err == models.ErrInvalidRole ||
+			err == models.ErrVersionConflict {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	cacheKey := utils.GenerateCacheKey("user", user.ID)
+
+	err = us.cache.Delete(ctx, cacheKey)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	userSerialized, err := utils.Serialize(user)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = us.cache.Set(ctx, cacheKey, userSerialized, 0)
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	err = us.cache.DeleteByPrefix(ctx, "users:*")
+	if err != nil {
+		return nil, models.ErrInternal
+	}
+
+	if roleChanged {
+		err = us.policy.UpdateUserRole(ctx, user.ID, existingUser.Role, user.Role)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+	}
+
+	err = us.audit.RecordUserChange(ctx, models.AuditUserUpdate, existingUser, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// PatchUser applies a merge patch to a user if the given version is still current,
+// only the fields that actually change are written. On their own record, users only change
+// the fields the policy grants the owner, and their password only with the current one
+func (us *UserService) PatchUser(ctx context.Context, patch *models.UserPatch) (*models.User, error) {
+	existingUser, err := us.repo.GetUserByID(ctx, patch.ID)
 	if err != nil {
-		if err == models.ErrConflictingData {
+		if err == models.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, models.ErrInternal
+	}
+
+	if existingUser.Version != patch.Version {
+		return nil, models.ErrVersionConflict
+	}
+
+	changes, err := userChanges(existingUser, patch)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, models.ErrNoUpdatedData
+	}
+
+	fields := make([]models.UserField, len(changes))
+	for i, change := range changes {
+		fields[i] = change.Field
+	}
+
+	err = us.checkOwnChanges(ctx, existingUser, fields, patch.CurrentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// a new email only takes effect once it is confirmed from that address
+	for i, change := range changes {
+		if change.Field != models.UserEmailField {
+			continue
+		}
+
+		_, err = us.emailChange.RequestEmailChange(ctx, existingUser, change.To.(string))
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes[:i], changes[i+1:]...)
+		if len(changes) == 0 {
+			return existingUser, nil
+		}
+		break
+	}
+
+	for i, change := range changes {
+		switch change.Field {
+		case models.UserRoleField:
+			if change.To == nil {
+				continue
+			}
+
+			_, err := us.roleRepo.GetRoleByName(ctx, change.To.(models.UserRole))
+			if err != nil {
+				if err == models.ErrDataNotFound {
+					return nil, models.ErrInvalidRole
+				}
+				return nil, models.ErrInternal
+			}
+		case models.UserPasswordField:
+			hashedPassword, err := utils.HashPassword(change.To.(string))
+			if err != nil {
+				return nil, models.ErrInternal
+			}
+
+			changes[i].To = hashedPassword
+		}
+	}
+
+	user, err := us.repo.PatchUser(ctx, patch.ID, patch.Version, changes)
+	if err != nil {
+		if err == models.ErrConflictingData ||
+			err == models.ErrInvalidRole ||
+			err == models.ErrVersionConflict {
 			return nil, err
 		}
 		return nil, models.ErrInternal
@@ -192,12 +399,74 @@ func (us *UserService) UpdateUser(ctx context.Context, user *models.User) (*mode
 		return nil, models.ErrInternal
 	}
 
+	if user.Role != existingUser.Role {
+		err = us.policy.UpdateUserRole(ctx, user.ID, existingUser.Role, user.Role)
+		if err != nil {
+			return nil, models.ErrInternal
+		}
+	}
+
+	err = us.audit.RecordUserChange(ctx, models.AuditUserUpdate, existingUser, user)
+	if err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
+// userChanges diffs a merge patch against the stored user into a field-level change set.
+// Members equal to the stored value are left out, while a password is always a change.
+// Null resets the role to its default and is refused for every other field.
+func userChanges(user *models.User, patch *models.UserPatch) ([]models.UserChange, error) {
+	var changes []models.UserChange
+
+	if patch.Name.Null || patch.Email.Null || patch.Password.Null {
+		return nil, models.ErrNotNullable
+	}
+
+	if patch.Name.Present && patch.Name.Value != user.Name {
+		changes = append(changes, models.UserChange{
+			Field: models.UserNameField,
+			From:  user.Name,
+			To:    patch.Name.Value,
+		})
+	}
+
+	if patch.Email.Present && patch.Email.Value != user.Email {
+		changes = append(changes, models.UserChange{
+			Field: models.UserEmailField,
+			From:  user.Email,
+			To:    patch.Email.Value,
+		})
+	}
+
+	// the stored hash is never carried in a change set
+	if patch.Password.Present {
+		changes = append(changes, models.UserChange{
+			Field: models.UserPasswordField,
+			To:    patch.Password.Value,
+		})
+	}
+
+	if patch.Role.Null {
+		changes = append(changes, models.UserChange{
+			Field: models.UserRoleField,
+			From:  user.Role,
+		})
+	} else if patch.Role.Present && patch.Role.Value != user.Role {
+		changes = append(changes, models.UserChange{
+			Field: models.UserRoleField,
+			From:  user.Role,
+			To:    patch.Role.Value,
+		})
+	}
+
+	return changes, nil
+}
+
 // DeleteUser deletes a user by ID
 func (us *UserService) DeleteUser(ctx context.Context, id uint64) error {
-	_, err := us.repo.GetUserByID(ctx, id)
+	existingUser, err := us.repo.GetUserByID(ctx, id)
 	if err != nil {
 		if err == models.ErrDataNotFound {
 			return err
@@ -217,5 +486,53 @@ func (us *UserService) DeleteUser(ctx context.Context, id uint64) error {
 		return models.ErrInternal
 	}
 
-	return us.repo.DeleteUser(ctx, id)
+	err = us.repo.DeleteUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = us.policy.DeleteUser(ctx, id)
+	if err != nil {
+		return models.ErrInternal
+	}
+
+	return us.audit.RecordUserChange(ctx, models.AuditUserDelete, existingUser, nil)
+}
+
+// checkOwnChanges refuses the changes users make to their own record that the policy does not grant
+// the owner, and a new password without the current one. The changes users make to other records
+// were allowed by the route already, and changes made by no user at all are refused
+func (us *UserService) checkOwnChanges(ctx context.Context, user *models.User, fields []models.UserField, currentPassword string) error {
+	payload, ok := _constant.Payload(ctx)
+	if !ok {
+		return models.ErrForbidden
+	}
+	if payload.UserID != user.ID {
+		return nil
+	}
+
+	for _, field := range fields {
+		decision, err := us.policy.Explain(&models.AuthzRequest{
+			Subject: models.OwnerSubject,
+			Domain:  models.TenantDomain(payload.TenantID),
+			Object:  models.UserFieldObject(user.ID, field),
+			Action:  http.MethodPut,
+			Owner:   models.OwnerSubject,
+		})
+		if err != nil {
+			return models.ErrInternal
+		}
+		if !decision.Allowed {
+			return models.ErrForbidden
+		}
+
+		if field == models.UserPasswordField {
+			err = utils.ComparePassword(currentPassword, user.Password)
+			if err != nil {
+				return models.ErrInvalidCurrentPassword
+			}
+		}
+	}
+
+	return nil
 }
@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"context"
+	_constant "github.com/bagashiz/go_hexagonal/internal/app/core/constant"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
 	mock2 "github.com/bagashiz/go_hexagonal/internal/app/core/ports/mock"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/services"
@@ -53,7 +54,11 @@ func TestUserService_Register(t *testing.T) {
 		desc  string
 		mocks func(
 			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
 			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+			emailChange *mock2.MockEmailChangeService,
+			audit *mock2.MockAuditService,
 		)
 		input    registerTestedInput
 		expected registerExpectedOutput
@@ -62,7 +67,11 @@ func TestUserService_Register(t *testing.T) {
 			desc: "Success",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					CreateUser(gomock.Any(), gomock.Eq(userInput)).
@@ -73,6 +82,12 @@ func TestUserService_Register(t *testing.T) {
 				cache.EXPECT().
 					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
 					Return(nil)
+				policy.EXPECT().
+					AddUserRole(gomock.Any(), gomock.Eq(userOutput.ID), gomock.Eq(userOutput.Role)).
+					Return(nil)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserCreate), gomock.Nil(), gomock.Any()).
+					Return(nil)
 			},
 			input: registerTestedInput{
 				user: userInput,
@@ -86,7 +101,11 @@ func TestUserService_Register(t *testing.T) {
 			desc: "Fail_InternalError",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					CreateUser(gomock.Any(), gomock.Eq(userInput)).
@@ -104,7 +123,11 @@ func TestUserService_Register(t *testing.T) {
 			desc: "Fail_DuplicateData",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					CreateUser(gomock.Any(), gomock.Eq(userInput)).
@@ -122,7 +145,11 @@ func TestUserService_Register(t *testing.T) {
 			desc: "Fail_SetCache",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					CreateUser(gomock.Any(), gomock.Eq(userInput)).
@@ -143,7 +170,39 @@ func TestUserService_Register(t *testing.T) {
 			desc: "Fail_DeleteCacheByPrefix",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					CreateUser(gomock.Any(), gomock.Eq(userInput)).
+					Return(userOutput, nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(userSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(models.ErrInternal)
+			},
+			input: registerTestedInput{
+				user: userInput,
+			},
+			expected: registerExpectedOutput{
+				user: nil,
+				err:  models.ErrInternal,
+			},
+		},
+		{
+			desc: "Fail_AddUserRole",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					CreateUser(gomock.Any(), gomock.Eq(userInput)).
@@ -153,6 +212,9 @@ func TestUserService_Register(t *testing.T) {
 					Return(nil)
 				cache.EXPECT().
 					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				policy.EXPECT().
+					AddUserRole(gomock.Any(), gomock.Eq(userOutput.ID), gomock.Eq(userOutput.Role)).
 					Return(models.ErrInternal)
 			},
 			input: registerTestedInput{
@@ -174,11 +236,15 @@ func TestUserService_Register(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
 			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+			emailChange := mock2.NewMockEmailChangeService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
 
-			tc.mocks(userRepo, cache)
+			tc.mocks(userRepo, roleRepo, cache, policy, emailChange, audit)
 
-			userService := services.NewUserService(userRepo, cache)
+			userService := services.NewUserService(userRepo, roleRepo, cache, policy, emailChange, audit)
 
 			user, err := userService.Register(ctx, tc.input.user)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -215,7 +281,11 @@ func TestUserService_GetUser(t *testing.T) {
 		desc  string
 		mocks func(
 			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
 			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+			emailChange *mock2.MockEmailChangeService,
+			audit *mock2.MockAuditService,
 		)
 		input    getUserTestedInput
 		expected getUserExpectedOutput
@@ -224,7 +294,11 @@ func TestUserService_GetUser(t *testing.T) {
 			desc: "Success_FromCache",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -242,7 +316,11 @@ func TestUserService_GetUser(t *testing.T) {
 			desc: "Success_FromDB",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -266,7 +344,11 @@ func TestUserService_GetUser(t *testing.T) {
 			desc: "Fail_NotFound",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -287,7 +369,11 @@ func TestUserService_GetUser(t *testing.T) {
 			desc: "Fail_InternalError",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -308,7 +394,11 @@ func TestUserService_GetUser(t *testing.T) {
 			desc: "Fail_SetCache",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -332,7 +422,11 @@ func TestUserService_GetUser(t *testing.T) {
 			desc: "Fail_Deserialize",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -356,11 +450,15 @@ func TestUserService_GetUser(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
 			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+			emailChange := mock2.NewMockEmailChangeService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
 
-			tc.mocks(userRepo, cache)
+			tc.mocks(userRepo, roleRepo, cache, policy, emailChange, audit)
 
-			userService := services.NewUserService(userRepo, cache)
+			userService := services.NewUserService(userRepo, roleRepo, cache, policy, emailChange, audit)
 
 			user, err := userService.GetUser(ctx, tc.input.id)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -408,7 +506,11 @@ func TestUserService_ListUsers(t *testing.T) {
 		desc  string
 		mocks func(
 			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
 			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+			emailChange *mock2.MockEmailChangeService,
+			audit *mock2.MockAuditService,
 		)
 		input    listUsersTestedInput
 		expected listUsersExpectedOutput
@@ -417,7 +519,11 @@ func TestUserService_ListUsers(t *testing.T) {
 			desc: "Success_FromCache",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -436,7 +542,11 @@ func TestUserService_ListUsers(t *testing.T) {
 			desc: "Success_FromDB",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -461,7 +571,11 @@ func TestUserService_ListUsers(t *testing.T) {
 			desc: "Fail_Deserialize",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -480,7 +594,11 @@ func TestUserService_ListUsers(t *testing.T) {
 			desc: "Fail_InternalError",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -502,7 +620,11 @@ func TestUserService_ListUsers(t *testing.T) {
 			desc: "Fail_SetCache",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				cache.EXPECT().
 					Get(gomock.Any(), gomock.Eq(cacheKey)).
@@ -533,11 +655,15 @@ func TestUserService_ListUsers(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
 			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+			emailChange := mock2.NewMockEmailChangeService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
 
-			tc.mocks(userRepo, cache)
+			tc.mocks(userRepo, roleRepo, cache, policy, emailChange, audit)
 
-			userService := services.NewUserService(userRepo, cache)
+			userService := services.NewUserService(userRepo, roleRepo, cache, policy, emailChange, audit)
 
 			users, err := userService.ListUsers(ctx, tc.input.skip, tc.input.limit)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
@@ -547,7 +673,8 @@ func TestUserService_ListUsers(t *testing.T) {
 }
 
 type updateUserTestedInput struct {
-	user *models.User
+	user            *models.User
+	currentPassword string
 }
 
 type updateUserExpectedOutput struct {
@@ -556,29 +683,34 @@ type updateUserExpectedOutput struct {
 }
 
 func TestUserService_UpdateUser(t *testing.T) {
-	ctx := context.Background()
 	userID := gofakeit.Uint64()
+	// the changes are made by an admin on the record of another user
+	ctx := _constant.WithPayload(context.Background(), &models.TokenPayload{UserID: userID + 1, Role: models.Admin, TenantID: 1})
 
 	// TODO: test with hashed password
 
+	existingUser := &models.User{
+		ID:      userID,
+		Name:    gofakeit.Name(),
+		Email:   gofakeit.Email(),
+		Role:    models.Admin,
+		Version: 3,
+	}
 	userInput := &models.User{
-		ID:    userID,
-		Name:  gofakeit.Name(),
-		Email: gofakeit.Email(),
-		Role:  models.Cashier,
+		ID:      userID,
+		Name:    gofakeit.Name(),
+		Email:   existingUser.Email,
+		Role:    models.Cashier,
+		Version: 3,
 	}
 	userOutput := &models.User{
-		ID:    userID,
-		Name:  userInput.Name,
-		Email: userInput.Email,
-		Role:  userInput.Role,
-	}
-	existingUser := &models.User{
-		ID:    userID,
-		Name:  gofakeit.Name(),
-		Email: gofakeit.Email(),
-		Role:  models.Admin,
+		ID:      userID,
+		Name:    userInput.Name,
+		Email:   userInput.Email,
+		Role:    userInput.Role,
+		Version: 4,
 	}
+	newEmail := gofakeit.Email()
 
 	cacheKey := util2.GenerateCacheKey("user", userID)
 	userSerialized, _ := util2.Serialize(userOutput)
@@ -588,7 +720,11 @@ func TestUserService_UpdateUser(t *testing.T) {
 		desc  string
 		mocks func(
 			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
 			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+			emailChange *mock2.MockEmailChangeService,
+			audit *mock2.MockAuditService,
 		)
 		input    updateUserTestedInput
 		expected updateUserExpectedOutput
@@ -597,11 +733,18 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Success",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
 					Return(existingUser, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
 				userRepo.EXPECT().
 					UpdateUser(gomock.Any(), gomock.Eq(userInput)).
 					Return(userOutput, nil)
@@ -614,6 +757,12 @@ func TestUserService_UpdateUser(t *testing.T) {
 				cache.EXPECT().
 					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
 					Return(nil)
+				policy.EXPECT().
+					UpdateUserRole(gomock.Any(), gomock.Eq(userID), gomock.Eq(models.Admin), gomock.Eq(models.Cashier)).
+					Return(nil)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserUpdate), gomock.Eq(existingUser), gomock.Eq(userOutput)).
+					Return(nil)
 			},
 			input: updateUserTestedInput{
 				user: userInput,
@@ -627,7 +776,11 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Fail_NotFound",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
@@ -645,7 +798,11 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Fail_InternalErrorGetByID",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
@@ -663,7 +820,11 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Fail_EmptyData",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
@@ -671,7 +832,8 @@ func TestUserService_UpdateUser(t *testing.T) {
 			},
 			input: updateUserTestedInput{
 				user: &models.User{
-					ID: userID,
+					ID:      userID,
+					Version: existingUser.Version,
 				},
 			},
 			expected: updateUserExpectedOutput{
@@ -683,7 +845,11 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Fail_SameData",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
@@ -701,11 +867,18 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Fail_DuplicateData",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
 					Return(existingUser, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
 				userRepo.EXPECT().
 					UpdateUser(gomock.Any(), gomock.Eq(userInput)).
 					Return(nil, models.ErrConflictingData)
@@ -722,11 +895,18 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Fail_InternalErrorUpdate",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
 					Return(existingUser, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
 				userRepo.EXPECT().
 					UpdateUser(gomock.Any(), gomock.Eq(userInput)).
 					Return(nil, models.ErrInternal)
@@ -743,11 +923,18 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Fail_DeleteCache",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
 					Return(existingUser, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
 				userRepo.EXPECT().
 					UpdateUser(gomock.Any(), gomock.Eq(userInput)).
 					Return(userOutput, nil)
@@ -767,11 +954,18 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Fail_SetCache",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
 					Return(existingUser, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
 				userRepo.EXPECT().
 					UpdateUser(gomock.Any(), gomock.Eq(userInput)).
 					Return(userOutput, nil)
@@ -794,11 +988,18 @@ func TestUserService_UpdateUser(t *testing.T) {
 			desc: "Fail_DeleteCacheByPrefix",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
 					Return(existingUser, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
 				userRepo.EXPECT().
 					UpdateUser(gomock.Any(), gomock.Eq(userInput)).
 					Return(userOutput, nil)
@@ -820,6 +1021,144 @@ func TestUserService_UpdateUser(t *testing.T) {
 				err:  models.ErrInternal,
 			},
 		},
+		{
+			desc: "Fail_InvalidRole",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: updateUserTestedInput{
+				user: userInput,
+			},
+			expected: updateUserExpectedOutput{
+				user: nil,
+				err:  models.ErrInvalidRole,
+			},
+		},
+		{
+			desc: "Fail_StaleVersion",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+			},
+			input: updateUserTestedInput{
+				user: &models.User{
+					ID:      userID,
+					Name:    userInput.Name,
+					Version: existingUser.Version - 1,
+				},
+			},
+			expected: updateUserExpectedOutput{
+				user: nil,
+				err:  models.ErrVersionConflict,
+			},
+		},
+		{
+			desc: "Fail_ConcurrentUpdate",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.Cashier)).
+					Return(&models.Role{Name: models.Cashier}, nil)
+				userRepo.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Eq(userInput)).
+					Return(nil, models.ErrVersionConflict)
+			},
+			input: updateUserTestedInput{
+				user: userInput,
+			},
+			expected: updateUserExpectedOutput{
+				user: nil,
+				err:  models.ErrVersionConflict,
+			},
+		},
+		{
+			desc: "Success_EmailChangePending",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				emailChange.EXPECT().
+					RequestEmailChange(gomock.Any(), gomock.Eq(existingUser), gomock.Eq(newEmail)).
+					Return(&models.EmailChange{UserID: userID, OldEmail: existingUser.Email, NewEmail: newEmail}, nil)
+			},
+			input: updateUserTestedInput{
+				user: &models.User{
+					ID:      userID,
+					Email:   newEmail,
+					Version: existingUser.Version,
+				},
+			},
+			expected: updateUserExpectedOutput{
+				user: existingUser,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_EmailTaken",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				emailChange.EXPECT().
+					RequestEmailChange(gomock.Any(), gomock.Eq(existingUser), gomock.Eq(newEmail)).
+					Return(nil, models.ErrConflictingData)
+			},
+			input: updateUserTestedInput{
+				user: &models.User{
+					ID:      userID,
+					Name:    gofakeit.Name(),
+					Email:   newEmail,
+					Version: existingUser.Version,
+				},
+			},
+			expected: updateUserExpectedOutput{
+				user: nil,
+				err:  models.ErrConflictingData,
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -831,19 +1170,613 @@ func TestUserService_UpdateUser(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
 			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+			emailChange := mock2.NewMockEmailChangeService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
 
-			tc.mocks(userRepo, cache)
+			tc.mocks(userRepo, roleRepo, cache, policy, emailChange, audit)
 
-			userService := services.NewUserService(userRepo, cache)
+			userService := services.NewUserService(userRepo, roleRepo, cache, policy, emailChange, audit)
 
-			user, err := userService.UpdateUser(ctx, tc.input.user)
+			user, err := userService.UpdateUser(ctx, tc.input.user, tc.input.currentPassword)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
 			assert.Equal(t, tc.expected.user, user, "User mismatch")
 		})
 	}
 }
 
+type patchUserTestedInput struct {
+	patch *models.UserPatch
+}
+
+type patchUserExpectedOutput struct {
+	user *models.User
+	err  error
+}
+
+func TestUserService_PatchUser(t *testing.T) {
+	userID := gofakeit.Uint64()
+	// the changes are made by an admin on the record of another user
+	ctx := _constant.WithPayload(context.Background(), &models.TokenPayload{UserID: userID + 1, Role: models.Admin, TenantID: 1})
+
+	existingUser := &models.User{
+		ID:      userID,
+		Name:    gofakeit.Name(),
+		Email:   gofakeit.Email(),
+		Role:    models.Admin,
+		Version: 7,
+	}
+	clearedUser := &models.User{
+		ID:      userID,
+		Email:   existingUser.Email,
+		Role:    models.Admin,
+		Version: 8,
+	}
+	resetUser := &models.User{
+		ID:      userID,
+		Name:    existingUser.Name,
+		Email:   existingUser.Email,
+		Role:    models.Cashier,
+		Version: 8,
+	}
+
+	cacheKey := util2.GenerateCacheKey("user", userID)
+	ttl := time.Duration(0)
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
+			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+			emailChange *mock2.MockEmailChangeService,
+			audit *mock2.MockAuditService,
+		)
+		input    patchUserTestedInput
+		expected patchUserExpectedOutput
+	}{
+		{
+			desc: "Success_ClearName",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userSerialized, _ := util2.Serialize(clearedUser)
+
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(userID), gomock.Eq(existingUser.Version), gomock.Eq([]models.UserChange{
+						{Field: models.UserNameField, From: existingUser.Name, To: ""},
+					})).
+					Return(clearedUser, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(userSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserUpdate), gomock.Eq(existingUser), gomock.Eq(clearedUser)).
+					Return(nil)
+			},
+			input: patchUserTestedInput{
+				patch: &models.UserPatch{
+					ID:      userID,
+					Version: existingUser.Version,
+					Name:    models.PatchField[string]{Present: true},
+					Email:   models.PatchField[string]{Present: true, Value: existingUser.Email},
+				},
+			},
+			expected: patchUserExpectedOutput{
+				user: clearedUser,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Success_PasswordOnly",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(userID), gomock.Eq(existingUser.Version), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ uint64, changes []models.UserChange) (*models.User, error) {
+						assert.Len(t, changes, 1)
+						assert.Equal(t, models.UserPasswordField, changes[0].Field)
+						assert.NoError(t, util2.ComparePassword("12345678", changes[0].To.(string)))
+						return existingUser, nil
+					})
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Any(), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserUpdate), gomock.Eq(existingUser), gomock.Any()).
+					Return(nil)
+			},
+			input: patchUserTestedInput{
+				patch: &models.UserPatch{
+					ID:       userID,
+					Version:  existingUser.Version,
+					Password: models.PatchField[string]{Present: true, Value: "12345678"},
+				},
+			},
+			expected: patchUserExpectedOutput{
+				user: existingUser,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Success_ResetRole",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userSerialized, _ := util2.Serialize(resetUser)
+
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(userID), gomock.Eq(existingUser.Version), gomock.Eq([]models.UserChange{
+						{Field: models.UserRoleField, From: models.Admin},
+					})).
+					Return(resetUser, nil)
+				cache.EXPECT().
+					Delete(gomock.Any(), gomock.Eq(cacheKey)).
+					Return(nil)
+				cache.EXPECT().
+					Set(gomock.Any(), gomock.Eq(cacheKey), gomock.Eq(userSerialized), gomock.Eq(ttl)).
+					Return(nil)
+				cache.EXPECT().
+					DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+					Return(nil)
+				policy.EXPECT().
+					UpdateUserRole(gomock.Any(), gomock.Eq(userID), gomock.Eq(models.Admin), gomock.Eq(models.Cashier)).
+					Return(nil)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserUpdate), gomock.Eq(existingUser), gomock.Eq(resetUser)).
+					Return(nil)
+			},
+			input: patchUserTestedInput{
+				patch: &models.UserPatch{
+					ID:      userID,
+					Version: existingUser.Version,
+					Role:    models.PatchField[models.UserRole]{Present: true, Null: true},
+				},
+			},
+			expected: patchUserExpectedOutput{
+				user: resetUser,
+				err:  nil,
+			},
+		},
+		{
+			desc: "Fail_NullName",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+			},
+			input: patchUserTestedInput{
+				patch: &models.UserPatch{
+					ID:      userID,
+					Version: existingUser.Version,
+					Name:    models.PatchField[string]{Present: true, Null: true},
+				},
+			},
+			expected: patchUserExpectedOutput{
+				user: nil,
+				err:  models.ErrNotNullable,
+			},
+		},
+		{
+			desc: "Fail_SameData",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+			},
+			input: patchUserTestedInput{
+				patch: &models.UserPatch{
+					ID:      userID,
+					Version: existingUser.Version,
+					Role:    models.PatchField[models.UserRole]{Present: true, Value: models.Admin},
+				},
+			},
+			expected: patchUserExpectedOutput{
+				user: nil,
+				err:  models.ErrNoUpdatedData,
+			},
+		},
+		{
+			desc: "Fail_InvalidRole",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				roleRepo.EXPECT().
+					GetRoleByName(gomock.Any(), gomock.Eq(models.UserRole("manager"))).
+					Return(nil, models.ErrDataNotFound)
+			},
+			input: patchUserTestedInput{
+				patch: &models.UserPatch{
+					ID:      userID,
+					Version: existingUser.Version,
+					Role:    models.PatchField[models.UserRole]{Present: true, Value: "manager"},
+				},
+			},
+			expected: patchUserExpectedOutput{
+				user: nil,
+				err:  models.ErrInvalidRole,
+			},
+		},
+		{
+			desc: "Fail_StaleVersion",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+			},
+			input: patchUserTestedInput{
+				patch: &models.UserPatch{
+					ID:      userID,
+					Version: existingUser.Version - 1,
+					Name:    models.PatchField[string]{Present: true},
+				},
+			},
+			expected: patchUserExpectedOutput{
+				user: nil,
+				err:  models.ErrVersionConflict,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+			emailChange := mock2.NewMockEmailChangeService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(userRepo, roleRepo, cache, policy, emailChange, audit)
+
+			userService := services.NewUserService(userRepo, roleRepo, cache, policy, emailChange, audit)
+
+			user, err := userService.PatchUser(ctx, tc.input.patch)
+			assert.Equal(t, tc.expected.err, err, "Error mismatch")
+			assert.Equal(t, tc.expected.user, user, "User mismatch")
+		})
+	}
+}
+
+func TestUserService_EditOwnUser(t *testing.T) {
+	userID := gofakeit.Uint64()
+	password := gofakeit.Password(true, true, true, false, false, 12)
+	hashedPassword, _ := util2.HashPassword(password)
+
+	// the user edits their own record, as its owner
+	ctx := _constant.WithPayload(context.Background(), &models.TokenPayload{UserID: userID, TenantID: 1})
+
+	existingUser := &models.User{
+		ID:       userID,
+		Name:     gofakeit.Name(),
+		Email:    gofakeit.Email(),
+		Password: hashedPassword,
+		Role:     models.Cashier,
+		Version:  3,
+	}
+	updatedUser := &models.User{
+		ID:      userID,
+		Name:    existingUser.Name,
+		Email:   existingUser.Email,
+		Role:    models.Cashier,
+		Version: 4,
+	}
+
+	// ownerField is the request the policy decides a change of a field of the own record with
+	ownerField := func(field models.UserField) *models.AuthzRequest {
+		return &models.AuthzRequest{
+			Subject: models.OwnerSubject,
+			Domain:  "tenant:1",
+			Object:  models.UserFieldObject(userID, field),
+			Action:  "PUT",
+			Owner:   models.OwnerSubject,
+		}
+	}
+	// updated expects the writes that follow a successful edit
+	updated := func(cache *mock2.MockCacheRepository, audit *mock2.MockAuditService) {
+		cache.EXPECT().
+			Delete(gomock.Any(), gomock.Any()).
+			Return(nil)
+		cache.EXPECT().
+			Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil)
+		cache.EXPECT().
+			DeleteByPrefix(gomock.Any(), gomock.Eq("users:*")).
+			Return(nil)
+		audit.EXPECT().
+			RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserUpdate), gomock.Any(), gomock.Any()).
+			Return(nil)
+	}
+
+	testCases := []struct {
+		desc  string
+		mocks func(
+			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
+			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+			emailChange *mock2.MockEmailChangeService,
+			audit *mock2.MockAuditService,
+		)
+		edit     func(userService *services.UserService) (*models.User, error)
+		expected error
+	}{
+		{
+			desc: "Success_Password",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				policy.EXPECT().
+					Explain(gomock.Eq(ownerField(models.UserPasswordField))).
+					Return(&models.AuthzDecision{Allowed: true}, nil)
+				userRepo.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Return(updatedUser, nil)
+				updated(cache, audit)
+			},
+			edit: func(userService *services.UserService) (*models.User, error) {
+				return userService.UpdateUser(ctx, &models.User{ID: userID, Password: "new-password", Version: 3}, password)
+			},
+			expected: nil,
+		},
+		{
+			desc: "Success_PatchPassword",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				policy.EXPECT().
+					Explain(gomock.Eq(ownerField(models.UserPasswordField))).
+					Return(&models.AuthzDecision{Allowed: true}, nil)
+				userRepo.EXPECT().
+					PatchUser(gomock.Any(), gomock.Eq(userID), gomock.Eq(uint64(3)), gomock.Any()).
+					Return(updatedUser, nil)
+				updated(cache, audit)
+			},
+			edit: func(userService *services.UserService) (*models.User, error) {
+				return userService.PatchUser(ctx, &models.UserPatch{
+					ID:              userID,
+					Version:         3,
+					Password:        models.PatchField[string]{Present: true, Value: "new-password"},
+					CurrentPassword: password,
+				})
+			},
+			expected: nil,
+		},
+		{
+			desc: "Fail_WrongCurrentPassword",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				policy.EXPECT().
+					Explain(gomock.Eq(ownerField(models.UserPasswordField))).
+					Return(&models.AuthzDecision{Allowed: true}, nil)
+			},
+			edit: func(userService *services.UserService) (*models.User, error) {
+				return userService.UpdateUser(ctx, &models.User{ID: userID, Password: "new-password", Version: 3}, "not-the-password")
+			},
+			expected: models.ErrInvalidCurrentPassword,
+		},
+		{
+			desc: "Fail_NoCurrentPassword",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				policy.EXPECT().
+					Explain(gomock.Eq(ownerField(models.UserPasswordField))).
+					Return(&models.AuthzDecision{Allowed: true}, nil)
+			},
+			edit: func(userService *services.UserService) (*models.User, error) {
+				return userService.PatchUser(ctx, &models.UserPatch{
+					ID:       userID,
+					Version:  3,
+					Password: models.PatchField[string]{Present: true, Value: "new-password"},
+				})
+			},
+			expected: models.ErrInvalidCurrentPassword,
+		},
+		{
+			// the owner is granted no role change
+			desc: "Fail_Role",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				policy.EXPECT().
+					Explain(gomock.Eq(ownerField(models.UserRoleField))).
+					Return(&models.AuthzDecision{Allowed: false}, nil)
+			},
+			edit: func(userService *services.UserService) (*models.User, error) {
+				return userService.UpdateUser(ctx, &models.User{
+					ID:      userID,
+					Name:    existingUser.Name,
+					Email:   existingUser.Email,
+					Role:    models.Admin,
+					Version: 3,
+				}, "")
+			},
+			expected: models.ErrForbidden,
+		},
+		{
+			desc: "Fail_PatchRole",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+				policy.EXPECT().
+					Explain(gomock.Eq(ownerField(models.UserRoleField))).
+					Return(&models.AuthzDecision{Allowed: false}, nil)
+			},
+			edit: func(userService *services.UserService) (*models.User, error) {
+				return userService.PatchUser(ctx, &models.UserPatch{
+					ID:      userID,
+					Version: 3,
+					Role:    models.PatchField[models.UserRole]{Present: true, Value: models.Admin},
+				})
+			},
+			expected: models.ErrForbidden,
+		},
+		{
+			// a context without a signed-in user, as of a caller outside the router, is refused
+			desc: "Fail_NoPayload",
+			mocks: func(
+				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
+				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
+			) {
+				userRepo.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(userID)).
+					Return(existingUser, nil)
+			},
+			edit: func(userService *services.UserService) (*models.User, error) {
+				return userService.UpdateUser(context.Background(), &models.User{ID: userID, Password: "new-password", Version: 3}, "")
+			},
+			expected: models.ErrForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
+			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+			emailChange := mock2.NewMockEmailChangeService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
+
+			tc.mocks(userRepo, roleRepo, cache, policy, emailChange, audit)
+
+			userService := services.NewUserService(userRepo, roleRepo, cache, policy, emailChange, audit)
+
+			_, err := tc.edit(userService)
+			assert.Equal(t, tc.expected, err, "Error mismatch")
+		})
+	}
+}
+
 type deleteUserTestedInput struct {
 	id uint64
 }
@@ -862,7 +1795,11 @@ func TestUserService_DeleteUser(t *testing.T) {
 		desc  string
 		mocks func(
 			userRepo *mock2.MockUserRepository,
+			roleRepo *mock2.MockRoleRepository,
 			cache *mock2.MockCacheRepository,
+			policy *mock2.MockPolicyService,
+			emailChange *mock2.MockEmailChangeService,
+			audit *mock2.MockAuditService,
 		)
 		input    deleteUserTestedInput
 		expected deleteUserExpectedOutput
@@ -871,7 +1808,11 @@ func TestUserService_DeleteUser(t *testing.T) {
 			desc: "Success",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
@@ -885,6 +1826,12 @@ func TestUserService_DeleteUser(t *testing.T) {
 				userRepo.EXPECT().
 					DeleteUser(gomock.Any(), gomock.Eq(userID)).
 					Return(nil)
+				policy.EXPECT().
+					DeleteUser(gomock.Any(), gomock.Eq(userID)).
+					Return(nil)
+				audit.EXPECT().
+					RecordUserChange(gomock.Any(), gomock.Eq(models.AuditUserDelete), gomock.Any(), gomock.Nil()).
+					Return(nil)
 			},
 			input: deleteUserTestedInput{
 				id: userID,
@@ -897,7 +1844,11 @@ func TestUserService_DeleteUser(t *testing.T) {
 			desc: "Fail_NotFound",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
@@ -914,7 +1865,11 @@ func TestUserService_DeleteUser(t *testing.T) {
 			desc: "Fail_InternalErrorGetByID",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
@@ -931,7 +1886,11 @@ func TestUserService_DeleteUser(t *testing.T) {
 			desc: "Fail_DeleteCache",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
@@ -951,7 +1910,11 @@ func TestUserService_DeleteUser(t *testing.T) {
 			desc: "Fail_DeleteCacheByPrefix",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				userRepo.EXPECT().
 					GetUserByID(gomock.Any(), gomock.Eq(userID)).
@@ -974,7 +1937,11 @@ func TestUserService_DeleteUser(t *testing.T) {
 			desc: "Fail_InternalErrorDelete",
 			mocks: func(
 				userRepo *mock2.MockUserRepository,
+				roleRepo *mock2.MockRoleRepository,
 				cache *mock2.MockCacheRepository,
+				policy *mock2.MockPolicyService,
+				emailChange *mock2.MockEmailChangeService,
+				audit *mock2.MockAuditService,
 			) {
 				user := &models.User{
 					ID: userID,
@@ -1009,11 +1976,15 @@ func TestUserService_DeleteUser(t *testing.T) {
 			defer ctrl.Finish()
 
 			userRepo := mock2.NewMockUserRepository(ctrl)
+			roleRepo := mock2.NewMockRoleRepository(ctrl)
 			cache := mock2.NewMockCacheRepository(ctrl)
+			policy := mock2.NewMockPolicyService(ctrl)
+			emailChange := mock2.NewMockEmailChangeService(ctrl)
+			audit := mock2.NewMockAuditService(ctrl)
 
-			tc.mocks(userRepo, cache)
+			tc.mocks(userRepo, roleRepo, cache, policy, emailChange, audit)
 
-			userService := services.NewUserService(userRepo, cache)
+			userService := services.NewUserService(userRepo, roleRepo, cache, policy, emailChange, audit)
 
 			err := userService.DeleteUser(ctx, tc.input.id)
 			assert.Equal(t, tc.expected.err, err, "Error mismatch")
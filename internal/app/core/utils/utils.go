@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/csv"
 	"errors"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/models"
 	"github.com/gin-gonic/gin"
@@ -55,22 +56,758 @@ func NewAuthResponse(token string) authResponse {
 
 // userResponse represents a user response body
 type UserResponse struct {
-	ID        uint64    `json:"id" example:"1"`
-	Name      string    `json:"name" example:"John Doe"`
-	Email     string    `json:"email" example:"test@example.com"`
-	CreatedAt time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
-	UpdatedAt time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+	ID        uint64          `json:"id" example:"1"`
+	Name      string          `json:"name" example:"John Doe"`
+	Email     string          `json:"email" example:"test@example.com"`
+	Role      models.UserRole `json:"role" example:"cashier"`
+	AvatarURL string          `json:"avatar_url" example:"http://127.0.0.1:8080/uploads/avatars/1/0b5f9d4e-2c1a-4f7e-9a64-1d2b3c4d5e6f/256.png"`
+	Avatars   map[int]string  `json:"avatars,omitempty"`
+	StoreID   *uint64         `json:"store_id" example:"1"`
+	CreatedAt time.Time       `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time       `json:"updated_at" example:"1970-01-01T00:00:00Z"`
 }
 
 // NewUserResponse is a helper function to create a response body for handling user data
 func NewUserResponse(user *models.User) UserResponse {
-	return UserResponse{
+	rsp := UserResponse{
 		ID:        user.ID,
 		Name:      user.Name,
 		Email:     user.Email,
+		Role:      user.Role,
+		StoreID:   user.StoreID,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
+
+	if user.Avatar != "" {
+		rsp.AvatarURL = user.AvatarURL(models.AvatarSizes[0])
+		rsp.Avatars = make(map[int]string, len(models.AvatarSizes))
+		for _, size := range models.AvatarSizes {
+			rsp.Avatars[size] = user.AvatarURL(size)
+		}
+	}
+
+	return rsp
+}
+
+// RoleResponse represents a role response body
+type RoleResponse struct {
+	ID          uint64          `json:"id" example:"1"`
+	Name        models.UserRole `json:"name" example:"cashier"`
+	Description string          `json:"description" example:"Rings up sales at the point of sale"`
+	CreatedAt   time.Time       `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt   time.Time       `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewRoleResponse is a helper function to create a response body for handling role data
+func NewRoleResponse(role *models.Role) RoleResponse {
+	return RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+// GroupResponse represents a group response body
+type GroupResponse struct {
+	ID          uint64    `json:"id" example:"1"`
+	Name        string    `json:"name" example:"Store 1 - Morning shift"`
+	Description string    `json:"description" example:"Cashiers working 06:00-14:00"`
+	ParentID    *uint64   `json:"parent_id" example:"1"`
+	CreatedAt   time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewGroupResponse is a helper function to create a response body for handling group data
+func NewGroupResponse(group *models.Group) GroupResponse {
+	return GroupResponse{
+		ID:          group.ID,
+		Name:        group.Name,
+		Description: group.Description,
+		ParentID:    group.ParentID,
+		CreatedAt:   group.CreatedAt,
+		UpdatedAt:   group.UpdatedAt,
+	}
+}
+
+// GroupMemberResponse represents a group membership response body
+type GroupMemberResponse struct {
+	GroupID   uint64    `json:"group_id" example:"1"`
+	UserID    uint64    `json:"user_id" example:"1"`
+	CreatedAt time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewGroupMemberResponse is a helper function to create a response body for handling group membership data
+func NewGroupMemberResponse(member *models.GroupMember) GroupMemberResponse {
+	return GroupMemberResponse{
+		GroupID:   member.GroupID,
+		UserID:    member.UserID,
+		CreatedAt: member.CreatedAt,
+	}
+}
+
+// InvitationResponse represents an invitation response body
+type InvitationResponse struct {
+	ID         uint64          `json:"id" example:"1"`
+	Email      string          `json:"email" example:"test@example.com"`
+	Role       models.UserRole `json:"role" example:"cashier"`
+	InvitedBy  uint64          `json:"invited_by" example:"1"`
+	ExpiresAt  time.Time       `json:"expires_at" example:"1970-01-01T00:00:00Z"`
+	AcceptedAt *time.Time      `json:"accepted_at" example:"1970-01-01T00:00:00Z"`
+	RevokedAt  *time.Time      `json:"revoked_at" example:"1970-01-01T00:00:00Z"`
+	CreatedAt  time.Time       `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewInvitationResponse is a helper function to create a response body for handling invitation data
+func NewInvitationResponse(invitation *models.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:         invitation.ID,
+		Email:      invitation.Email,
+		Role:       invitation.Role,
+		InvitedBy:  invitation.InvitedBy,
+		ExpiresAt:  invitation.ExpiresAt,
+		AcceptedAt: invitation.AcceptedAt,
+		RevokedAt:  invitation.RevokedAt,
+		CreatedAt:  invitation.CreatedAt,
+	}
+}
+
+// AuditRecordResponse represents an audit record response body
+type AuditRecordResponse struct {
+	ID        uint64               `json:"id" example:"1"`
+	ActorID   *uint64              `json:"actor_id" example:"1"`
+	TargetID  uint64               `json:"target_id" example:"2"`
+	Action    models.AuditAction   `json:"action" example:"user.update"`
+	Changes   []models.AuditChange `json:"changes"`
+	IP        string               `json:"ip" example:"127.0.0.1"`
+	RequestID string               `json:"request_id" example:"0b6a7d4e-8f4c-4f7e-9d0a-2c1f3b5e6a7d"`
+	CreatedAt time.Time            `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewAuditRecordResponse is a helper function to create a response body for handling audit record data
+func NewAuditRecordResponse(record *models.AuditRecord) AuditRecordResponse {
+	return AuditRecordResponse{
+		ID:        record.ID,
+		ActorID:   record.ActorID,
+		TargetID:  record.TargetID,
+		Action:    record.Action,
+		Changes:   record.Changes,
+		IP:        record.IP,
+		RequestID: record.RequestID,
+		CreatedAt: record.CreatedAt,
+	}
+}
+
+// CategoryResponse represents a category response body
+type CategoryResponse struct {
+	ID        uint64    `json:"id" example:"1"`
+	Name      string    `json:"name" example:"Beverages"`
+	CreatedAt time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewCategoryResponse is a helper function to create a response body for handling category data
+func NewCategoryResponse(category *models.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:        category.ID,
+		Name:      category.Name,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+// StoreResponse represents a store response body
+type StoreResponse struct {
+	ID          uint64    `json:"id" example:"1"`
+	Name        string    `json:"name" example:"Main store"`
+	Address     string    `json:"address" example:"Jl. Sudirman No. 1, Jakarta"`
+	Region      string    `json:"region" example:"ID-JK"`
+	TaxRounding string    `json:"tax_rounding" example:"line"`
+	CreatedAt   time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewStoreResponse is a helper function to create a response body for handling store data
+func NewStoreResponse(store *models.Store) StoreResponse {
+	return StoreResponse{
+		ID:          store.ID,
+		Name:        store.Name,
+		Address:     store.Address,
+		Region:      store.Region,
+		TaxRounding: string(store.TaxRounding),
+		CreatedAt:   store.CreatedAt,
+		UpdatedAt:   store.UpdatedAt,
+	}
+}
+
+// TenantResponse represents a tenant response body, the admin is only shown when the tenant is created
+type TenantResponse struct {
+	ID        uint64        `json:"id" example:"1"`
+	Name      string        `json:"name" example:"Acme"`
+	Admin     *UserResponse `json:"admin,omitempty"`
+	CreatedAt time.Time     `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time     `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewTenantResponse is a helper function to create a response body for handling tenant data
+func NewTenantResponse(tenant *models.Tenant, admin *models.User) TenantResponse {
+	rsp := TenantResponse{
+		ID:        tenant.ID,
+		Name:      tenant.Name,
+		CreatedAt: tenant.CreatedAt,
+		UpdatedAt: tenant.UpdatedAt,
+	}
+	if admin != nil {
+		adminRsp := NewUserResponse(admin)
+		rsp.Admin = &adminRsp
+	}
+
+	return rsp
+}
+
+// ProductResponse represents a product response body, the price is in minor currency units
+type ProductResponse struct {
+	ID                uint64    `json:"id" example:"1"`
+	CategoryID        uint64    `json:"category_id" example:"1"`
+	SKU               string    `json:"sku" example:"BEV-COLA-330"`
+	Name              string    `json:"name" example:"Cola 330ml"`
+	Image             string    `json:"image" example:"https://example.com/cola.png"`
+	Price             int64     `json:"price" example:"1500"`
+	Stock             int64     `json:"stock" example:"100"`
+	CreatedAt         time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt         time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+	LowStockThreshold int64     `json:"low_stock_threshold" example:"10"`
+	TaxClassID        *uint64   `json:"tax_class_id" example:"1"`
+}
+
+// NewProductResponse is a helper function to create a response body for handling product data
+func NewProductResponse(product *models.Product) ProductResponse {
+	return ProductResponse{
+		ID:                product.ID,
+		CategoryID:        product.CategoryID,
+		SKU:               product.SKU,
+		Name:              product.Name,
+		Image:             product.Image,
+		Price:             product.Price,
+		Stock:             product.Stock,
+		CreatedAt:         product.CreatedAt,
+		UpdatedAt:         product.UpdatedAt,
+		LowStockThreshold: product.LowStockThreshold,
+		TaxClassID:        product.TaxClassID,
+	}
+}
+
+// OrderItemResponse represents an order item response body, the prices are in minor currency units
+type OrderItemResponse struct {
+	ID         uint64 `json:"id" example:"1"`
+	ProductID  uint64 `json:"product_id" example:"1"`
+	SKU        string `json:"sku" example:"BEV-COLA-330"`
+	Name       string `json:"name" example:"Cola 330ml"`
+	Quantity   int64  `json:"quantity" example:"2"`
+	Price      int64  `json:"price" example:"1500"`
+	TotalPrice int64  `json:"total_price" example:"3000"`
+}
+
+// OrderDiscountResponse represents an order discount response body, the amount is in minor currency units
+type OrderDiscountResponse struct {
+	PromotionID uint64 `json:"promotion_id" example:"1"`
+	Name        string `json:"name" example:"Summer sale"`
+	CouponCode  string `json:"coupon_code" example:"SUMMER10"`
+	Amount      int64  `json:"amount" example:"300"`
+}
+
+// OrderTaxResponse represents an order tax response body, the rate is in millionths
+// and the amounts are in minor currency units
+type OrderTaxResponse struct {
+	TaxRateID     uint64 `json:"tax_rate_id" example:"1"`
+	Name          string `json:"name" example:"VAT"`
+	Rate          int64  `json:"rate" example:"110000"`
+	Inclusive     bool   `json:"inclusive" example:"false"`
+	TaxableAmount int64  `json:"taxable_amount" example:"2700"`
+	Amount        int64  `json:"amount" example:"297"`
+}
+
+// OrderResponse represents an order response body, the amounts are in minor currency units
+type OrderResponse struct {
+	ID             uint64                  `json:"id" example:"1"`
+	UserID         uint64                  `json:"user_id" example:"1"`
+	StoreID        uint64                  `json:"store_id" example:"1"`
+	Status         string                  `json:"status" example:"paid"`
+	PaymentMethod  string                  `json:"payment_method" example:"cash"`
+	TotalPrice     int64                   `json:"total_price" example:"2997"`
+	TotalPaid      int64                   `json:"total_paid" example:"5000"`
+	TotalChange    int64                   `json:"total_change" example:"2003"`
+	Items          []OrderItemResponse     `json:"items"`
+	CouponCode     string                  `json:"coupon_code" example:"SUMMER10"`
+	TotalDiscount  int64                   `json:"total_discount" example:"300"`
+	Discounts      []OrderDiscountResponse `json:"discounts"`
+	TotalTax       int64                   `json:"total_tax" example:"297"`
+	Taxes          []OrderTaxResponse      `json:"taxes"`
+	CustomerID     *uint64                 `json:"customer_id" example:"1"`
+	PointsEarned   int64                   `json:"points_earned" example:"29"`
+	PointsRedeemed int64                   `json:"points_redeemed" example:"0"`
+	CreatedAt      time.Time               `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt      time.Time               `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+	PaidAt         *time.Time              `json:"paid_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewOrderResponse is a helper function to create a response body for handling order data
+func NewOrderResponse(order *models.Order) OrderResponse {
+	items := make([]OrderItemResponse, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = OrderItemResponse{
+			ID:         item.ID,
+			ProductID:  item.ProductID,
+			SKU:        item.SKU,
+			Name:       item.Name,
+			Quantity:   item.Quantity,
+			Price:      item.Price,
+			TotalPrice: item.TotalPrice,
+		}
+	}
+
+	discounts := make([]OrderDiscountResponse, len(order.Discounts))
+	for i, discount := range order.Discounts {
+		discounts[i] = OrderDiscountResponse{
+			PromotionID: discount.PromotionID,
+			Name:        discount.Name,
+			CouponCode:  discount.CouponCode,
+			Amount:      discount.Amount,
+		}
+	}
+
+	taxes := make([]OrderTaxResponse, len(order.Taxes))
+	for i, tax := range order.Taxes {
+		taxes[i] = OrderTaxResponse{
+			TaxRateID:     tax.TaxRateID,
+			Name:          tax.Name,
+			Rate:          tax.Rate,
+			Inclusive:     tax.Inclusive,
+			TaxableAmount: tax.TaxableAmount,
+			Amount:        tax.Amount,
+		}
+	}
+
+	return OrderResponse{
+		ID:             order.ID,
+		UserID:         order.UserID,
+		StoreID:        order.StoreID,
+		Status:         string(order.Status),
+		PaymentMethod:  string(order.PaymentMethod),
+		TotalPrice:     order.TotalPrice,
+		TotalPaid:      order.TotalPaid,
+		TotalChange:    order.TotalChange,
+		Items:          items,
+		CouponCode:     order.CouponCode,
+		TotalDiscount:  order.TotalDiscount,
+		Discounts:      discounts,
+		TotalTax:       order.TotalTax,
+		Taxes:          taxes,
+		CustomerID:     order.CustomerID,
+		PointsEarned:   order.PointsEarned,
+		PointsRedeemed: order.PointsRedeemed,
+		CreatedAt:      order.CreatedAt,
+		UpdatedAt:      order.UpdatedAt,
+		PaidAt:         order.PaidAt,
+	}
+}
+
+// RefundItemResponse represents a refund item response body
+type RefundItemResponse struct {
+	OrderItemID uint64 `json:"order_item_id" example:"1"`
+	ProductID   uint64 `json:"product_id" example:"1"`
+	Quantity    int64  `json:"quantity" example:"1"`
+}
+
+// RefundResponse represents a refund response body, the amount is in minor currency units.
+// The points are the ones given back to and taken back from the customer of the order
+type RefundResponse struct {
+	ID             uint64               `json:"id" example:"1"`
+	OrderID        uint64               `json:"order_id" example:"1"`
+	UserID         uint64               `json:"user_id" example:"1"`
+	StoreID        uint64               `json:"store_id" example:"1"`
+	Type           string               `json:"type" example:"refund"`
+	PaymentMethod  string               `json:"payment_method" example:"cash"`
+	Amount         int64                `json:"amount" example:"1500"`
+	Reason         string               `json:"reason" example:"damaged packaging"`
+	Items          []RefundItemResponse `json:"items"`
+	PointsRefunded int64                `json:"points_refunded" example:"0"`
+	PointsReversed int64                `json:"points_reversed" example:"15"`
+	CreatedAt      time.Time            `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewRefundResponse is a helper function to create a response body for handling refund data
+func NewRefundResponse(refund *models.Refund) RefundResponse {
+	items := make([]RefundItemResponse, len(refund.Items))
+	for i, item := range refund.Items {
+		items[i] = RefundItemResponse{
+			OrderItemID: item.OrderItemID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	return RefundResponse{
+		ID:             refund.ID,
+		OrderID:        refund.OrderID,
+		UserID:         refund.UserID,
+		StoreID:        refund.StoreID,
+		Type:           string(refund.Type),
+		PaymentMethod:  string(refund.PaymentMethod),
+		Amount:         refund.Amount,
+		Reason:         refund.Reason,
+		Items:          items,
+		PointsRefunded: refund.PointsRefunded,
+		PointsReversed: refund.PointsReversed,
+		CreatedAt:      refund.CreatedAt,
+	}
+}
+
+// TaxClassResponse represents a tax class response body
+type TaxClassResponse struct {
+	ID        uint64    `json:"id" example:"1"`
+	Name      string    `json:"name" example:"Food"`
+	CreatedAt time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewTaxClassResponse is a helper function to create a response body for handling tax class data
+func NewTaxClassResponse(class *models.TaxClass) TaxClassResponse {
+	return TaxClassResponse{
+		ID:        class.ID,
+		Name:      class.Name,
+		CreatedAt: class.CreatedAt,
+		UpdatedAt: class.UpdatedAt,
+	}
+}
+
+// TaxRateResponse represents a tax rate response body, the rate is in millionths
+type TaxRateResponse struct {
+	ID         uint64    `json:"id" example:"1"`
+	TaxClassID uint64    `json:"tax_class_id" example:"1"`
+	Name       string    `json:"name" example:"VAT"`
+	Rate       int64     `json:"rate" example:"110000"`
+	Inclusive  bool      `json:"inclusive" example:"false"`
+	StoreID    *uint64   `json:"store_id" example:"1"`
+	Region     string    `json:"region" example:"ID-JK"`
+	CreatedAt  time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt  time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewTaxRateResponse is a helper function to create a response body for handling tax rate data
+func NewTaxRateResponse(rate *models.TaxRate) TaxRateResponse {
+	return TaxRateResponse{
+		ID:         rate.ID,
+		TaxClassID: rate.TaxClassID,
+		Name:       rate.Name,
+		Rate:       rate.Rate,
+		Inclusive:  rate.Inclusive,
+		StoreID:    rate.StoreID,
+		Region:     rate.Region,
+		CreatedAt:  rate.CreatedAt,
+		UpdatedAt:  rate.UpdatedAt,
+	}
+}
+
+// CustomerResponse represents a customer response body
+type CustomerResponse struct {
+	ID        uint64    `json:"id" example:"1"`
+	Name      string    `json:"name" example:"Jane Doe"`
+	Email     string    `json:"email" example:"jane@example.com"`
+	Phone     string    `json:"phone" example:"+6281234567890"`
+	Points    int64     `json:"points" example:"120"`
+	CreatedAt time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewCustomerResponse is a helper function to create a response body for handling customer data
+func NewCustomerResponse(customer *models.Customer) CustomerResponse {
+	return CustomerResponse{
+		ID:        customer.ID,
+		Name:      customer.Name,
+		Email:     customer.Email,
+		Phone:     customer.Phone,
+		Points:    customer.Points,
+		CreatedAt: customer.CreatedAt,
+		UpdatedAt: customer.UpdatedAt,
+	}
+}
+
+// LoyaltyEntryResponse represents a loyalty ledger entry response body, the points are signed
+type LoyaltyEntryResponse struct {
+	ID           uint64    `json:"id" example:"1"`
+	CustomerID   uint64    `json:"customer_id" example:"1"`
+	Type         string    `json:"type" example:"earn"`
+	Points       int64     `json:"points" example:"29"`
+	BalanceAfter int64     `json:"balance_after" example:"149"`
+	Reason       string    `json:"reason" example:"goodwill for a late delivery"`
+	OrderID      *uint64   `json:"order_id" example:"1"`
+	UserID       *uint64   `json:"user_id" example:"1"`
+	CreatedAt    time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewLoyaltyEntryResponse is a helper function to create a response body for handling loyalty ledger data
+func NewLoyaltyEntryResponse(entry *models.LoyaltyEntry) LoyaltyEntryResponse {
+	return LoyaltyEntryResponse{
+		ID:           entry.ID,
+		CustomerID:   entry.CustomerID,
+		Type:         string(entry.Type),
+		Points:       entry.Points,
+		BalanceAfter: entry.BalanceAfter,
+		Reason:       entry.Reason,
+		OrderID:      entry.OrderID,
+		UserID:       entry.UserID,
+		CreatedAt:    entry.CreatedAt,
+	}
+}
+
+// PolicyResponse represents a permission rule response body, a domain of "*" applies in every tenant
+type PolicyResponse struct {
+	Subject string `json:"subject" example:"manager"`
+	Domain  string `json:"domain" example:"tenant:1"`
+	Object  string `json:"object" example:"/v1/products/"`
+	Action  string `json:"action" example:"POST"`
+}
+
+// NewPolicyResponse is a helper function to create a response body for handling permission rule data
+func NewPolicyResponse(rule *models.PolicyRule) PolicyResponse {
+	return PolicyResponse{
+		Subject: rule.Subject,
+		Domain:  rule.Domain,
+		Object:  rule.Object,
+		Action:  rule.Action,
+	}
+}
+
+// GroupingResponse represents a grouping rule response body
+type GroupingResponse struct {
+	Subject string `json:"subject" example:"user:1"`
+	Role    string `json:"role" example:"manager"`
+	Domain  string `json:"domain" example:"tenant:1"`
+}
+
+// NewGroupingResponse is a helper function to create a response body for handling grouping rule data
+func NewGroupingResponse(rule *models.PolicyRule) GroupingResponse {
+	return GroupingResponse{
+		Subject: rule.Subject,
+		Role:    rule.Role,
+		Domain:  rule.Domain,
+	}
+}
+
+// SubjectRolesResponse represents the roles of a casbin subject response body
+type SubjectRolesResponse struct {
+	Subject       string   `json:"subject" example:"user:1"`
+	Domain        string   `json:"domain" example:"tenant:1"`
+	Roles         []string `json:"roles" example:"group:1"`
+	ImplicitRoles []string `json:"implicit_roles" example:"group:1,manager"`
+}
+
+// NewSubjectRolesResponse is a helper function to create a response body for handling subject roles data
+func NewSubjectRolesResponse(roles *models.SubjectRoles) SubjectRolesResponse {
+	return SubjectRolesResponse{
+		Subject:       roles.Subject,
+		Domain:        roles.Domain,
+		Roles:         roles.Roles,
+		ImplicitRoles: roles.ImplicitRoles,
+	}
+}
+
+// AuthzDecisionResponse represents the decision of the policy on a request response body, the rules
+// are the ones that allowed it and the roles the ones the subject has in the domain
+type AuthzDecisionResponse struct {
+	Allowed bool             `json:"allowed" example:"true"`
+	Subject string           `json:"subject" example:"user:1"`
+	Domain  string           `json:"domain" example:"tenant:1"`
+	Object  string           `json:"object" example:"/v1/products/42"`
+	Action  string           `json:"action" example:"PUT"`
+	Owner   string           `json:"owner" example:""`
+	Rules   []PolicyResponse `json:"rules"`
+	Roles   []string         `json:"roles" example:"manager"`
+}
+
+// NewAuthzDecisionResponse is a helper function to create a response body for handling authz decision data
+func NewAuthzDecisionResponse(decision *models.AuthzDecision) AuthzDecisionResponse {
+	rules := make([]PolicyResponse, 0, len(decision.Rules))
+	for _, rule := range decision.Rules {
+		rules = append(rules, NewPolicyResponse(&rule))
+	}
+
+	return AuthzDecisionResponse{
+		Allowed: decision.Allowed,
+		Subject: decision.Request.Subject,
+		Domain:  decision.Request.Domain,
+		Object:  decision.Request.Object,
+		Action:  decision.Request.Action,
+		Owner:   decision.Request.Owner,
+		Rules:   rules,
+		Roles:   decision.Roles,
+	}
+}
+
+// PromotionResponse represents a promotion response body, a fixed value is in minor currency units
+type PromotionResponse struct {
+	ID          uint64     `json:"id" example:"1"`
+	Name        string     `json:"name" example:"Summer sale"`
+	Type        string     `json:"type" example:"percentage"`
+	Value       int64      `json:"value" example:"10"`
+	ProductID   *uint64    `json:"product_id" example:"1"`
+	CategoryID  *uint64    `json:"category_id" example:"1"`
+	BuyQuantity int64      `json:"buy_quantity" example:"0"`
+	GetQuantity int64      `json:"get_quantity" example:"0"`
+	CouponCode  string     `json:"coupon_code" example:"SUMMER10"`
+	UsageLimit  int64      `json:"usage_limit" example:"100"`
+	UsageCount  int64      `json:"usage_count" example:"42"`
+	StartsAt    *time.Time `json:"starts_at" example:"1970-01-01T00:00:00Z"`
+	EndsAt      *time.Time `json:"ends_at" example:"1970-01-01T00:00:00Z"`
+	Active      bool       `json:"active" example:"true"`
+	CreatedAt   time.Time  `json:"created_at" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt   time.Time  `json:"updated_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewPromotionResponse is a helper function to create a response body for handling promotion data
+func NewPromotionResponse(promotion *models.Promotion) PromotionResponse {
+	return PromotionResponse{
+		ID:          promotion.ID,
+		Name:        promotion.Name,
+		Type:        string(promotion.Type),
+		Value:       promotion.Value,
+		ProductID:   promotion.ProductID,
+		CategoryID:  promotion.CategoryID,
+		BuyQuantity: promotion.BuyQuantity,
+		GetQuantity: promotion.GetQuantity,
+		CouponCode:  promotion.CouponCode,
+		UsageLimit:  promotion.UsageLimit,
+		UsageCount:  promotion.UsageCount,
+		StartsAt:    promotion.StartsAt,
+		EndsAt:      promotion.EndsAt,
+		Active:      promotion.Active,
+		CreatedAt:   promotion.CreatedAt,
+		UpdatedAt:   promotion.UpdatedAt,
+	}
+}
+
+// StockMovementResponse represents a stock movement response body
+type StockMovementResponse struct {
+	ID         uint64    `json:"id" example:"1"`
+	ProductID  uint64    `json:"product_id" example:"1"`
+	StoreID    uint64    `json:"store_id" example:"1"`
+	Type       string    `json:"type" example:"adjustment"`
+	Quantity   int64     `json:"quantity" example:"-2"`
+	StockAfter int64     `json:"stock_after" example:"98"`
+	Reason     string    `json:"reason" example:"damaged in storage"`
+	OrderID    *uint64   `json:"order_id" example:"1"`
+	UserID     *uint64   `json:"user_id" example:"1"`
+	CreatedAt  time.Time `json:"created_at" example:"1970-01-01T00:00:00Z"`
+}
+
+// NewStockMovementResponse is a helper function to create a response body for handling stock movement data
+func NewStockMovementResponse(movement *models.StockMovement) StockMovementResponse {
+	return StockMovementResponse{
+		ID:         movement.ID,
+		ProductID:  movement.ProductID,
+		StoreID:    movement.StoreID,
+		Type:       string(movement.Type),
+		Quantity:   movement.Quantity,
+		StockAfter: movement.StockAfter,
+		Reason:     movement.Reason,
+		OrderID:    movement.OrderID,
+		UserID:     movement.UserID,
+		CreatedAt:  movement.CreatedAt,
+	}
+}
+
+// RevenueReportResponse represents a revenue report row response body, the revenue is in minor currency units
+type RevenueReportResponse struct {
+	Start   string `json:"start" example:"2024-01-01"`
+	Orders  int64  `json:"orders" example:"42"`
+	Revenue int64  `json:"revenue" example:"315000"`
+}
+
+// NewRevenueReportResponse is a helper function to create a response body for handling revenue report data,
+// the start of the period is the local date it begins on
+func NewRevenueReportResponse(row *models.RevenueReport) RevenueReportResponse {
+	return RevenueReportResponse{
+		Start:   row.Start.Format(time.DateOnly),
+		Orders:  row.Orders,
+		Revenue: row.Revenue,
+	}
+}
+
+// ProductSalesReportResponse represents a product sales report row response body, the revenue is in minor currency units
+type ProductSalesReportResponse struct {
+	ProductID uint64 `json:"product_id" example:"1"`
+	SKU       string `json:"sku" example:"BEV-COLA-330"`
+	Name      string `json:"name" example:"Cola 330ml"`
+	Quantity  int64  `json:"quantity" example:"120"`
+	Revenue   int64  `json:"revenue" example:"180000"`
+}
+
+// NewProductSalesReportResponse is a helper function to create a response body for handling product sales report data
+func NewProductSalesReportResponse(row *models.ProductSalesReport) ProductSalesReportResponse {
+	return ProductSalesReportResponse{
+		ProductID: row.ProductID,
+		SKU:       row.SKU,
+		Name:      row.Name,
+		Quantity:  row.Quantity,
+		Revenue:   row.Revenue,
+	}
+}
+
+// CashierSalesReportResponse represents a cashier sales report row response body, the revenue is in minor currency units
+type CashierSalesReportResponse struct {
+	UserID  uint64 `json:"user_id" example:"1"`
+	Name    string `json:"name" example:"John Doe"`
+	Orders  int64  `json:"orders" example:"42"`
+	Revenue int64  `json:"revenue" example:"315000"`
+}
+
+// NewCashierSalesReportResponse is a helper function to create a response body for handling cashier sales report data
+func NewCashierSalesReportResponse(row *models.CashierSalesReport) CashierSalesReportResponse {
+	return CashierSalesReportResponse{
+		UserID:  row.UserID,
+		Name:    row.Name,
+		Orders:  row.Orders,
+		Revenue: row.Revenue,
+	}
+}
+
+// PaymentMethodReportResponse represents a payment method report row response body, the revenue is in minor currency units
+type PaymentMethodReportResponse struct {
+	PaymentMethod string `json:"payment_method" example:"cash"`
+	Orders        int64  `json:"orders" example:"42"`
+	Revenue       int64  `json:"revenue" example:"315000"`
+}
+
+// NewPaymentMethodReportResponse is a helper function to create a response body for handling payment method report data
+func NewPaymentMethodReportResponse(row *models.PaymentMethodReport) PaymentMethodReportResponse {
+	return PaymentMethodReportResponse{
+		PaymentMethod: string(row.PaymentMethod),
+		Orders:        row.Orders,
+		Revenue:       row.Revenue,
+	}
+}
+
+// DiscountReportResponse represents a discount report row response body, the amount is in minor currency units
+type DiscountReportResponse struct {
+	PromotionID uint64 `json:"promotion_id" example:"1"`
+	Name        string `json:"name" example:"Summer sale"`
+	CouponCode  string `json:"coupon_code" example:"SUMMER10"`
+	Orders      int64  `json:"orders" example:"42"`
+	Amount      int64  `json:"amount" example:"12600"`
+}
+
+// NewDiscountReportResponse is a helper function to create a response body for handling discount report data
+func NewDiscountReportResponse(row *models.DiscountReport) DiscountReportResponse {
+	return DiscountReportResponse{
+		PromotionID: row.PromotionID,
+		Name:        row.Name,
+		CouponCode:  row.CouponCode,
+		Orders:      row.Orders,
+		Amount:      row.Amount,
+	}
 }
 
 // errorStatusMap is a map of defined error messages and their corresponding http status codes
@@ -86,9 +823,50 @@ var errorStatusMap = map[error]int{
 	models.ErrInvalidToken:               http.StatusUnauthorized,
 	models.ErrExpiredToken:               http.StatusUnauthorized,
 	models.ErrForbidden:                  http.StatusForbidden,
+	models.ErrInvalidCurrentPassword:     http.StatusForbidden,
 	models.ErrNoUpdatedData:              http.StatusBadRequest,
 	models.ErrInsufficientStock:          http.StatusBadRequest,
 	models.ErrInsufficientPayment:        http.StatusBadRequest,
+	models.ErrInvalidRole:                http.StatusBadRequest,
+	models.ErrRoleInUse:                  http.StatusConflict,
+	models.ErrGroupCycle:                 http.StatusBadRequest,
+	models.ErrVersionConflict:            http.StatusPreconditionFailed,
+	models.ErrPreconditionRequired:       http.StatusPreconditionRequired,
+	models.ErrNotNullable:                http.StatusBadRequest,
+	models.ErrUnsupportedMediaType:       http.StatusUnsupportedMediaType,
+	models.ErrFileTooLarge:               http.StatusRequestEntityTooLarge,
+	models.ErrNotAcceptable:              http.StatusNotAcceptable,
+	models.ErrUnknownPreference:          http.StatusBadRequest,
+	models.ErrInvalidPreference:          http.StatusBadRequest,
+	models.ErrInvalidCategory:            http.StatusBadRequest,
+	models.ErrCategoryInUse:              http.StatusConflict,
+	models.ErrInvalidProduct:             http.StatusBadRequest,
+	models.ErrInvalidStockMovement:       http.StatusBadRequest,
+	models.ErrInvalidReport:              http.StatusBadRequest,
+	models.ErrInvalidPromotion:           http.StatusBadRequest,
+	models.ErrInvalidCoupon:              http.StatusBadRequest,
+	models.ErrPromotionExhausted:         http.StatusConflict,
+	models.ErrInvalidRefund:              http.StatusBadRequest,
+	models.ErrInvalidPayment:             http.StatusBadRequest,
+	models.ErrOrderNotOpen:               http.StatusConflict,
+	models.ErrInvalidTaxClass:            http.StatusBadRequest,
+	models.ErrTaxClassInUse:              http.StatusConflict,
+	models.ErrInvalidTaxRate:             http.StatusBadRequest,
+	models.ErrInvalidStore:               http.StatusBadRequest,
+	models.ErrStoreRequired:              http.StatusBadRequest,
+	models.ErrStoreInUse:                 http.StatusConflict,
+	models.ErrInvalidCustomer:            http.StatusBadRequest,
+	models.ErrCustomerRequired:           http.StatusBadRequest,
+	models.ErrCustomerInUse:              http.StatusConflict,
+	models.ErrInsufficientPoints:         http.StatusBadRequest,
+	models.ErrInvalidLoyaltyEntry:        http.StatusBadRequest,
+	models.ErrInvalidPolicy:              http.StatusBadRequest,
+	models.ErrProtectedPolicy:            http.StatusConflict,
+	models.ErrRegistrationClosed:         http.StatusForbidden,
+	models.ErrInvalidLink:                http.StatusBadRequest,
+	models.ErrExpiredLink:                http.StatusGone,
+	models.ErrInvalidTenant:              http.StatusBadRequest,
+	models.ErrTenantMismatch:             http.StatusForbidden,
 }
 
 // ValidationError sends an error response for some specific request validation error
@@ -156,3 +934,21 @@ func HandleSuccess(ctx *gin.Context, data any) {
 	rsp := NewResponse(true, "Success", data)
 	ctx.JSON(http.StatusOK, rsp)
 }
+
+// HandleCSV sends the records as a CSV attachment with the given file name, the first record being the header
+func HandleCSV(ctx *gin.Context, filename string, records [][]string) {
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	ctx.Status(http.StatusOK)
+
+	w := csv.NewWriter(ctx.Writer)
+	_ = w.WriteAll(records)
+}
+
+// HandleDocument sends a rendered document, as an attachment with the given file name unless it is empty
+func HandleDocument(ctx *gin.Context, contentType, filename string, data []byte) {
+	if filename != "" {
+		ctx.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	}
+	ctx.Data(http.StatusOK, contentType, data)
+}
@@ -7,19 +7,27 @@ import (
 	"github.com/joho/godotenv"
 )
 
-// Container contains environment variables for the application, database, cache, token, and http server
+// Container contains environment variables for the application, database, cache, token, http server, links, mail, file storage, inventory, receipts, loyalty points, and the authorization policy
 type (
 	Container struct {
-		App   *App
-		Token *Token
-		Redis *Redis
-		DB    *DB
-		HTTP  *HTTP
+		App       *App
+		Token     *Token
+		Redis     *Redis
+		DB        *DB
+		HTTP      *HTTP
+		Link      *Link
+		Mail      *Mail
+		Storage   *Storage
+		Inventory *Inventory
+		Receipt   *Receipt
+		Loyalty   *Loyalty
+		Policy    *Policy
 	}
 	// App contains all the environment variables for the application
 	App struct {
-		Name string
-		Env  string
+		Name             string
+		Env              string
+		OpenRegistration bool
 	}
 	// Token contains all the environment variables for the token services
 	Token struct {
@@ -48,6 +56,53 @@ type (
 		Port           string
 		AllowedOrigins string
 	}
+	// Link contains all the environment variables for the signed links sent to users
+	Link struct {
+		Secret   string
+		BaseURL  string
+		Duration string
+	}
+	// Mail contains all the environment variables for the mail server
+	Mail struct {
+		Host     string
+		Port     string
+		User     string
+		Password string
+		From     string
+	}
+	// Storage contains all the environment variables for the file storage
+	Storage struct {
+		Driver    string
+		Path      string
+		PublicURL string
+		Endpoint  string
+		Region    string
+		Bucket    string
+		AccessKey string
+		SecretKey string
+	}
+	// Inventory contains all the environment variables for the inventory alerts
+	Inventory struct {
+		AlertRecipient string
+	}
+	// Receipt contains all the environment variables for the branding and layout of receipts
+	Receipt struct {
+		Brand      string
+		Header     string
+		Footer     string
+		Currency   string
+		Decimals   string
+		PaperWidth string
+	}
+	// Loyalty contains all the environment variables for earning and redeeming loyalty points
+	Loyalty struct {
+		EarnAmount string
+		PointValue string
+	}
+	// Policy contains all the environment variables for keeping the authorization policy in sync between replicas
+	Policy struct {
+		ReloadInterval string
+	}
 )
 
 // NewContainer creates a new container instance
@@ -60,8 +115,9 @@ func NewContainer() (*Container, error) {
 	}
 
 	app := &App{
-		Name: os.Getenv("APP_NAME"),
-		Env:  os.Getenv("APP_ENV"),
+		Name:             os.Getenv("APP_NAME"),
+		Env:              os.Getenv("APP_ENV"),
+		OpenRegistration: os.Getenv("APP_OPEN_REGISTRATION") != "false",
 	}
 
 	token := &Token{
@@ -91,12 +147,66 @@ func NewContainer() (*Container, error) {
 		AllowedOrigins: os.Getenv("HTTP_ALLOWED_ORIGINS"),
 	}
 
+	link := &Link{
+		Secret:   os.Getenv("LINK_SECRET"),
+		BaseURL:  os.Getenv("LINK_BASE_URL"),
+		Duration: os.Getenv("LINK_DURATION"),
+	}
+
+	mail := &Mail{
+		Host:     os.Getenv("MAIL_HOST"),
+		Port:     os.Getenv("MAIL_PORT"),
+		User:     os.Getenv("MAIL_USER"),
+		Password: os.Getenv("MAIL_PASSWORD"),
+		From:     os.Getenv("MAIL_FROM"),
+	}
+
+	storage := &Storage{
+		Driver:    os.Getenv("STORAGE_DRIVER"),
+		Path:      os.Getenv("STORAGE_PATH"),
+		PublicURL: os.Getenv("STORAGE_PUBLIC_URL"),
+		Endpoint:  os.Getenv("STORAGE_ENDPOINT"),
+		Region:    os.Getenv("STORAGE_REGION"),
+		Bucket:    os.Getenv("STORAGE_BUCKET"),
+		AccessKey: os.Getenv("STORAGE_ACCESS_KEY"),
+		SecretKey: os.Getenv("STORAGE_SECRET_KEY"),
+	}
+
+	inventory := &Inventory{
+		AlertRecipient: os.Getenv("INVENTORY_ALERT_RECIPIENT"),
+	}
+
+	receipt := &Receipt{
+		Brand:      os.Getenv("RECEIPT_BRAND"),
+		Header:     os.Getenv("RECEIPT_HEADER"),
+		Footer:     os.Getenv("RECEIPT_FOOTER"),
+		Currency:   os.Getenv("RECEIPT_CURRENCY"),
+		Decimals:   os.Getenv("RECEIPT_DECIMALS"),
+		PaperWidth: os.Getenv("RECEIPT_PAPER_WIDTH"),
+	}
+
+	loyalty := &Loyalty{
+		EarnAmount: os.Getenv("LOYALTY_EARN_AMOUNT"),
+		PointValue: os.Getenv("LOYALTY_POINT_VALUE"),
+	}
+
+	policy := &Policy{
+		ReloadInterval: os.Getenv("POLICY_RELOAD_INTERVAL"),
+	}
+
 	return &Container{
 		app,
 		token,
 		redis,
 		db,
 		http,
+		link,
+		mail,
+		storage,
+		inventory,
+		receipt,
+		loyalty,
+		policy,
 	}, nil
 }
 
@@ -112,6 +222,34 @@ func ProvideRedis(container *Container) *Redis {
 	return container.Redis
 }
 
+func ProvideLink(container *Container) *Link {
+	return container.Link
+}
+
+func ProvideMail(container *Container) *Mail {
+	return container.Mail
+}
+
+func ProvideStorage(container *Container) *Storage {
+	return container.Storage
+}
+
+func ProvideInventory(container *Container) *Inventory {
+	return container.Inventory
+}
+
+func ProvideReceipt(container *Container) *Receipt {
+	return container.Receipt
+}
+
+func ProvideLoyalty(container *Container) *Loyalty {
+	return container.Loyalty
+}
+
+func ProvidePolicy(container *Container) *Policy {
+	return container.Policy
+}
+
 var Module = fx.Module(
 	"configs-module",
 	fx.Provide(
@@ -119,5 +257,12 @@ var Module = fx.Module(
 		ProvideToken,
 		ProvideDB,
 		ProvideRedis,
+		ProvideLink,
+		ProvideMail,
+		ProvideStorage,
+		ProvideInventory,
+		ProvideReceipt,
+		ProvideLoyalty,
+		ProvidePolicy,
 	),
 )
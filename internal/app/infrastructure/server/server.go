@@ -1,22 +1,31 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/handlers"
 	"github.com/bagashiz/go_hexagonal/internal/app/adapters/storages/db/postgres"
 	"github.com/bagashiz/go_hexagonal/internal/app/core/ports"
 	"github.com/bagashiz/go_hexagonal/internal/app/infrastructure/configs"
 	"go.elastic.co/ecszap"
+	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"log/slog"
+	"net"
+	"net/http"
 	"os"
 )
 
-// Serve starts the HTTP server
+// Serve starts the HTTP server with the application, after the other start hooks, and shuts it
+// down first when the application stops. It takes the schema so that the database is migrated
+// even when nothing else reads it
 func Serve(
+	lc fx.Lifecycle,
 	router *handlers.RouterHandler,
 	config *configs.Container,
 	db *postgres.DB,
+	_ *postgres.Schema,
 	cache ports.CacheRepository,
 ) error {
 
@@ -28,18 +37,36 @@ func Serve(
 		zap.Int("times", 1),
 	)
 
-	// Migrate database
-	var err = db.Migrate()
-	if err != nil {
-		slog.Error("Error migrating database", "error", err)
-		os.Exit(1)
+	listenAddr := fmt.Sprintf("%s:%s", config.HTTP.URL, config.HTTP.Port)
+	server := &http.Server{
+		Addr:    listenAddr,
+		Handler: router.Handler(),
 	}
 
-	defer cache.Close()
-	defer db.Close()
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", listenAddr)
+			if err != nil {
+				return err
+			}
 
-	listenAddr := fmt.Sprintf("%s:%s", config.HTTP.URL, config.HTTP.Port)
-	slog.Info("Starting the HTTP server", "listen_address", listenAddr)
-	return router.Run(listenAddr)
+			slog.Info("Starting the HTTP server", "listen_address", listenAddr)
+			go func() {
+				err := server.Serve(listener)
+				if err != nil && !errors.Is(err, http.ErrServerClosed) {
+					slog.Error("Error serving HTTP", "error", err)
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			err := server.Shutdown(ctx)
+			cache.Close()
+			db.Close()
+			return err
+		},
+	})
 
+	return nil
 }